@@ -31,6 +31,7 @@ import (
 	"github.com/bufbuild/buf/private/buf/bufwire"
 	"github.com/bufbuild/buf/private/bufpkg/bufanalysis"
 	"github.com/bufbuild/buf/private/bufpkg/bufapimodule"
+	"github.com/bufbuild/buf/private/bufpkg/bufcheck"
 	"github.com/bufbuild/buf/private/bufpkg/bufcheck/buflint"
 	"github.com/bufbuild/buf/private/bufpkg/bufconfig"
 	"github.com/bufbuild/buf/private/bufpkg/bufconnect"
@@ -164,6 +165,11 @@ var (
 	// This directory replaces the use of v1CacheModuleDataRelDirPath, v1CacheModuleLockRelDirPath, and
 	// v1CacheModuleSumRelDirPath with a cache implementation using content addressable storage.
 	v2CacheModuleRelDirPath = normalpath.Join("v2", "module")
+	// v1CacheCheckRelDirPath is the relative path to the cache directory where the results of
+	// lint and breaking change detection are stored.
+	//
+	// Normalized.
+	v1CacheCheckRelDirPath = normalpath.Join("v1", "check")
 
 	// allVisibiltyStrings are the possible options that a user can set the visibility flag with.
 	allVisibiltyStrings = []string{
@@ -569,6 +575,22 @@ func newModuleReaderAndCreateCacheDirs(
 	return moduleReader, nil
 }
 
+// NewCheckCacheReadWriteBucketAndCreateCacheDirs returns a new ReadWriteBucket for the
+// cache of the results of lint and breaking change detection while creating the required
+// cache directories.
+func NewCheckCacheReadWriteBucketAndCreateCacheDirs(
+	container appflag.Container,
+) (storage.ReadWriteBucket, error) {
+	cacheCheckDirPath := normalpath.Join(container.CacheDirPath(), v1CacheCheckRelDirPath)
+	if err := checkExistingCacheDirs(container.CacheDirPath(), cacheCheckDirPath); err != nil {
+		return nil, err
+	}
+	if err := createCacheDirs(cacheCheckDirPath); err != nil {
+		return nil, err
+	}
+	return storageos.NewProvider().NewReadWriteBucket(cacheCheckDirPath)
+}
+
 // NewConfig creates a new Config.
 func NewConfig(container appflag.Container) (*bufapp.Config, error) {
 	externalConfig := bufapp.ExternalConfig{}
@@ -887,6 +909,53 @@ func ValidateErrorFormatFlagLint(errorFormatString string, errorFormatFlagName s
 	return validateErrorFormatFlag(buflint.AllFormatStrings, errorFormatString, errorFormatFlagName)
 }
 
+// PrintRuleExplanation prints the explanation of the rule with the ID to the writer.
+//
+// Returns an invalid argument error for the flag if no rule has the ID.
+func PrintRuleExplanation(writer io.Writer, rules []bufcheck.Rule, ruleID string, flagName string) error {
+	for _, rule := range rules {
+		if rule.ID() == ruleID {
+			return bufcheck.PrintRuleExplanation(writer, rule)
+		}
+	}
+	return appcmd.NewInvalidArgumentErrorf("--%s: unknown rule %q", flagName, ruleID)
+}
+
+// RuleInfosForImageConfigs returns the rules returned by rulesForImageConfig for all
+// of the ImageConfigs, deduplicated by ID.
+//
+// This is used to add rule metadata to printed FileAnnotations.
+func RuleInfosForImageConfigs(
+	imageConfigs []bufwire.ImageConfig,
+	rulesForImageConfig func(bufwire.ImageConfig) ([]bufcheck.Rule, error),
+) ([]bufanalysis.RuleInfo, error) {
+	var ruleInfos []bufanalysis.RuleInfo
+	seenIDs := make(map[string]struct{})
+	for _, imageConfig := range imageConfigs {
+		rules, err := rulesForImageConfig(imageConfig)
+		if err != nil {
+			return nil, err
+		}
+		for _, rule := range rules {
+			if _, ok := seenIDs[rule.ID()]; ok {
+				continue
+			}
+			seenIDs[rule.ID()] = struct{}{}
+			ruleInfos = append(ruleInfos, rule)
+		}
+	}
+	return ruleInfos, nil
+}
+
+// IsSARIFErrorFormat returns true if the error format is SARIF.
+//
+// Unlike the other formats, SARIF is printed even if there are no FileAnnotations,
+// as consumers of SARIF expect a document for every run.
+func IsSARIFErrorFormat(errorFormatString string) bool {
+	format, err := bufanalysis.ParseFormat(errorFormatString)
+	return err == nil && format == bufanalysis.FormatSARIF
+}
+
 // PackageVersionShortDescription returns the long description for the <package>-version command.
 func PackageVersionShortDescription(name string) string {
 	return fmt.Sprintf("Resolve module and %s plugin reference to a specific Generated SDK version", name)
@@ -16,6 +16,8 @@ package buffetch
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
 
@@ -224,6 +226,59 @@ func NewSourceOrModuleRefParser(logger *zap.Logger) SourceOrModuleRefParser {
 	return newSourceOrModuleRefParser(logger)
 }
 
+// IsLocalSourceRef returns true if the Ref is a SourceRef for a local directory or a
+// local .proto file, that is, a SourceRef whose files can be rewritten in place.
+func IsLocalSourceRef(ref Ref) bool {
+	sourceRef, ok := ref.(SourceRef)
+	if !ok {
+		return false
+	}
+	switch sourceRef.internalBucketRef().(type) {
+	case internal.DirRef, internal.ProtoFileRef:
+		return true
+	default:
+		return false
+	}
+}
+
+// GetLocalGitPathAndSubDirPath returns the path of the local git repository and the
+// path of the subdirectory within the repository if the Ref is a SourceRef for a local
+// git repository that does not specify a branch, tag, or ref.
+//
+// The path of the subdirectory is empty if the Ref does not specify a subdirectory.
+func GetLocalGitPathAndSubDirPath(ref Ref) (string, string, bool) {
+	gitRef, ok := getParsedGitRef(ref)
+	if !ok || gitRef.GitScheme() != internal.GitSchemeLocal || gitRef.GitName() != nil {
+		return "", "", false
+	}
+	return gitRef.Path(), gitRef.SubDirPath(), true
+}
+
+// NewSourceRefForGitTag returns a new SourceRef that is the same as the Ref, except
+// that it checks out the given tag.
+//
+// The Ref must be a SourceRef for a git repository that does not specify a branch, tag, or ref.
+func NewSourceRefForGitTag(ref Ref, tag string) (SourceRef, error) {
+	gitRef, ok := getParsedGitRef(ref)
+	if !ok {
+		return nil, fmt.Errorf("%T is not a git reference", ref)
+	}
+	if gitRef.GitName() != nil {
+		return nil, errors.New("git reference already specifies a branch, tag, or ref")
+	}
+	return newSourceRef(
+		internal.NewDirectParsedGitRef(
+			gitRef.Format(),
+			gitRef.Path(),
+			gitRef.GitScheme(),
+			git.NewTagName(tag),
+			gitRef.RecurseSubmodules(),
+			gitRef.Depth(),
+			gitRef.SubDirPath(),
+		),
+	), nil
+}
+
 // ReadBucketCloser is a bucket returned from GetBucket.
 // We need to surface the internal.ReadBucketCloser
 // interface to other packages, so we use a type
@@ -385,3 +440,12 @@ func NewWriter(
 type getSourceBucketOptions struct {
 	workspacesDisabled bool
 }
+
+func getParsedGitRef(ref Ref) (internal.ParsedGitRef, bool) {
+	sourceRef, ok := ref.(SourceRef)
+	if !ok {
+		return nil, false
+	}
+	gitRef, ok := sourceRef.internalBucketRef().(internal.ParsedGitRef)
+	return gitRef, ok
+}
@@ -16,8 +16,10 @@ package bufformat
 
 import (
 	"context"
+	"errors"
 	"io"
 
+	"github.com/bufbuild/buf/private/bufpkg/bufformatconfig"
 	"github.com/bufbuild/buf/private/bufpkg/bufmodule"
 	"github.com/bufbuild/buf/private/pkg/storage"
 	"github.com/bufbuild/buf/private/pkg/storage/storagemem"
@@ -29,7 +31,7 @@ import (
 )
 
 // FormatModule formats and writes the target module files into a read bucket.
-func FormatModule(ctx context.Context, module bufmodule.Module) (_ storage.ReadBucket, retErr error) {
+func FormatModule(ctx context.Context, module bufmodule.Module, options ...FormatOption) (_ storage.ReadBucket, retErr error) {
 	fileInfos, err := module.TargetFileInfos(ctx)
 	if err != nil {
 		return nil, err
@@ -57,7 +59,7 @@ func FormatModule(ctx context.Context, module bufmodule.Module) (_ storage.ReadB
 			defer func() {
 				retErr = multierr.Append(retErr, writeObjectCloser.Close())
 			}()
-			if err := FormatFileNode(writeObjectCloser, fileNode); err != nil {
+			if err := FormatFileNode(writeObjectCloser, fileNode, options...); err != nil {
 				return err
 			}
 			return writeObjectCloser.SetExternalPath(moduleFile.ExternalPath())
@@ -70,7 +72,131 @@ func FormatModule(ctx context.Context, module bufmodule.Module) (_ storage.ReadB
 }
 
 // FormatFileNode formats the given file node and writ the result to dest.
-func FormatFileNode(dest io.Writer, fileNode *ast.FileNode) error {
-	formatter := newFormatter(dest, fileNode)
+//
+// If a range is set with FormatWithRange, the content outside of the range is written as-is.
+func FormatFileNode(dest io.Writer, fileNode *ast.FileNode, options ...FormatOption) error {
+	formatOptions := newFormatOptions()
+	for _, option := range options {
+		option(formatOptions)
+	}
+	if formatOptions.hasRange {
+		if formatOptions.sort {
+			return errors.New("a range cannot be formatted with sorting")
+		}
+		edits, err := formatRange(fileNode, formatOptions)
+		if err != nil {
+			return err
+		}
+		_, err = dest.Write(applyEdits(fileNodeContent(fileNode), edits))
+		return err
+	}
+	if formatOptions.sort {
+		return formatAndVerifySorted(dest, fileNode, formatOptions)
+	}
+	formatter := newFormatter(dest, fileNode, formatOptions)
 	return formatter.Run()
 }
+
+// FormatFileNodeEdits returns the edits that format the given file node.
+//
+// The edits are sorted by offset, and do not overlap. Each edit only spans the text
+// that changes. No edits are returned if the file node is already formatted.
+func FormatFileNodeEdits(fileNode *ast.FileNode, options ...FormatOption) ([]Edit, error) {
+	formatOptions := newFormatOptions()
+	for _, option := range options {
+		option(formatOptions)
+	}
+	if formatOptions.sort {
+		return nil, errors.New("edits cannot be computed with sorting")
+	}
+	return formatRange(fileNode, formatOptions)
+}
+
+// Edit is an edit that replaces the text between two positions of a file.
+type Edit struct {
+	// StartOffset is the zero-based byte offset at which the edit starts.
+	StartOffset int `json:"start_offset"`
+	// EndOffset is the zero-based byte offset at which the edit ends, exclusive.
+	EndOffset int `json:"end_offset"`
+	// StartLine is the one-based line at which the edit starts.
+	StartLine int `json:"start_line"`
+	// StartColumn is the one-based byte column at which the edit starts.
+	StartColumn int `json:"start_column"`
+	// EndLine is the one-based line at which the edit ends.
+	EndLine int `json:"end_line"`
+	// EndColumn is the one-based byte column at which the edit ends, exclusive.
+	EndColumn int `json:"end_column"`
+	// NewText is the text that replaces the text between the positions.
+	NewText string `json:"new_text"`
+}
+
+// FormatOption is an option for formatting.
+type FormatOption func(*formatOptions)
+
+// FormatWithConfig returns a new FormatOption that formats files
+// with the style of the given config.
+//
+// The default style is used if this option is not set, or if the config is nil.
+func FormatWithConfig(config *bufformatconfig.Config) FormatOption {
+	return func(formatOptions *formatOptions) {
+		formatOptions.config = config
+	}
+}
+
+// FormatWithSort returns a new FormatOption that sorts the declarations
+// of files into a canonical order.
+//
+// The file options, and the options of each declaration, are sorted by name.
+// The services, messages, enums, and extensions of a file, and the fields, nested
+// types, and other declarations of a message, are sorted by kind. Comments stay
+// attached to the declarations they describe.
+//
+// The descriptor of each sorted file is verified to be unchanged except for its
+// source info and the order of its declarations. If it would change, an error is
+// returned instead.
+func FormatWithSort() FormatOption {
+	return func(formatOptions *formatOptions) {
+		formatOptions.sort = true
+	}
+}
+
+// FormatWithSortFieldsByNumber returns a new FormatOption that sorts the fields
+// of messages and extensions by number.
+//
+// This has no effect unless FormatWithSort is also set. Enum values are never
+// sorted, as the first value of an enum is its default value.
+func FormatWithSortFieldsByNumber() FormatOption {
+	return func(formatOptions *formatOptions) {
+		formatOptions.sortFieldsByNumber = true
+	}
+}
+
+// FormatWithRange returns a new FormatOption that only formats the nodes that
+// overlap the lines from startLine to endLine, inclusive. The lines are one-based.
+//
+// If the range is within the body of a declaration, only the declarations of the
+// body that overlap the range are formatted. If the range overlaps the syntax,
+// package, imports, or options of the file, the entire file is formatted, as these
+// are sorted together. The blank lines around formatted nodes are left as-is.
+//
+// This cannot be used with FormatWithSort.
+func FormatWithRange(startLine int, endLine int) FormatOption {
+	return func(formatOptions *formatOptions) {
+		formatOptions.hasRange = true
+		formatOptions.rangeStartLine = startLine
+		formatOptions.rangeEndLine = endLine
+	}
+}
+
+type formatOptions struct {
+	config             *bufformatconfig.Config
+	sort               bool
+	sortFieldsByNumber bool
+	hasRange           bool
+	rangeStartLine     int
+	rangeEndLine       int
+}
+
+func newFormatOptions() *formatOptions {
+	return &formatOptions{}
+}
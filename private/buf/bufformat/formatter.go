@@ -15,6 +15,7 @@
 package bufformat
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io"
@@ -24,6 +25,7 @@ import (
 	"unicode"
 	"unicode/utf8"
 
+	"github.com/bufbuild/buf/private/bufpkg/bufformatconfig"
 	"github.com/bufbuild/protocompile/ast"
 	"go.uber.org/multierr"
 )
@@ -33,10 +35,31 @@ type formatter struct {
 	writer   io.Writer
 	fileNode *ast.FileNode
 
+	// The number of spaces written for each level of indentation.
+	indentWidth int
+	// The maximum length of a line, or zero if there is no maximum.
+	maxLineLength int
+	// If true, imports are separated into groups by the first component of their path.
+	groupImports bool
+	// If true, declarations are written in a canonical order.
+	sort bool
+	// If true, fields are sorted by number. Only used if sort is true.
+	sortFields bool
+	// The width of the label, type, and name of the fields that are aligned,
+	// i.e. the width that each of these fields is padded to before its '='.
+	// Only set if fields are aligned.
+	fieldNodeToAlignedPrefixWidth map[ast.Node]int
+	// The field whose prefix width is measured, and its measured width. Only set
+	// for the formatters used to measure the fields that are aligned.
+	measuredFieldNode        ast.Node
+	measuredFieldPrefixWidth int
+
 	// Current level of indentation.
 	indent int
 	// The last character written to writer.
 	lastWritten rune
+	// The number of characters written to writer since the last newline.
+	column int
 
 	// The last node written. This must be updated from all functions
 	// that write comments with a node. This flag informs how the next
@@ -68,14 +91,32 @@ type formatter struct {
 }
 
 // newFormatter returns a new formatter for the given file.
+//
+// If the config of the options is nil, the default style is used.
 func newFormatter(
 	writer io.Writer,
 	fileNode *ast.FileNode,
+	formatOptions *formatOptions,
 ) *formatter {
-	return &formatter{
-		writer:   writer,
-		fileNode: fileNode,
+	formatter := &formatter{
+		writer:      writer,
+		fileNode:    fileNode,
+		indentWidth: bufformatconfig.DefaultIndentWidth,
+		sort:        formatOptions.sort,
+		sortFields:  formatOptions.sort && formatOptions.sortFieldsByNumber,
 	}
+	if config := formatOptions.config; config != nil {
+		if config.IndentWidth > 0 {
+			formatter.indentWidth = config.IndentWidth
+		}
+		formatter.maxLineLength = config.MaxLineLength
+		formatter.groupImports = config.GroupImports
+		if config.AlignFields {
+			formatter.fieldNodeToAlignedPrefixWidth = make(map[ast.Node]int)
+			formatter.alignFields(nodesForElements(formatter.sortedFileElements(fileNode.Decls)), 0)
+		}
+	}
+	return formatter
 }
 
 // Run runs the formatter and writes the file's content to the formatter's writer.
@@ -144,7 +185,7 @@ func (f *formatter) Indent(nextNode ast.Node) {
 			indent--
 		}
 	}
-	f.WriteString(strings.Repeat("  ", indent))
+	f.WriteString(strings.Repeat(" ", indent*f.indentWidth))
 }
 
 // WriteString writes the given element to the generated output.
@@ -178,12 +219,18 @@ func (f *formatter) WriteString(elem string) {
 				f.err = multierr.Append(f.err, err)
 				return
 			}
+			f.column++
 		}
 	}
 	if len(elem) == 0 {
 		return
 	}
 	f.lastWritten, _ = utf8.DecodeLastRuneInString(elem)
+	if index := strings.LastIndexByte(elem, '\n'); index >= 0 {
+		f.column = utf8.RuneCountInString(elem[index+1:])
+	} else {
+		f.column += utf8.RuneCountInString(elem)
+	}
 	if _, err := f.writer.Write([]byte(elem)); err != nil {
 		f.err = multierr.Append(f.err, err)
 	}
@@ -256,6 +303,14 @@ func (f *formatter) writeFileHeader() {
 	sort.Slice(importNodes, func(i, j int) bool {
 		iName := importNodes[i].Name.AsString()
 		jName := importNodes[j].Name.AsString()
+		if f.groupImports {
+			// Sort by group first so that each group is contiguous.
+			iGroup := importGroup(iName)
+			jGroup := importGroup(jName)
+			if iGroup != jGroup {
+				return iGroup < jGroup
+			}
+		}
 		// sort by public > None > weak
 		iOrder := importSortOrder(importNodes[i])
 		jOrder := importSortOrder(importNodes[j])
@@ -288,24 +343,14 @@ func (f *formatter) writeFileHeader() {
 			continue
 		}
 
+		if f.groupImports && i > 0 && importGroup(importNode.Name.AsString()) != importGroup(importNodes[i-1].Name.AsString()) {
+			// Separate each group of imports with a blank line.
+			f.P("")
+		}
 		f.writeImport(importNode, i > 0)
 	}
 	sort.Slice(optionNodes, func(i, j int) bool {
-		// The default options (e.g. cc_enable_arenas) should always
-		// be sorted above custom options (which are identified by a
-		// leading '(').
-		left := stringForOptionName(optionNodes[i].Name)
-		right := stringForOptionName(optionNodes[j].Name)
-		if strings.HasPrefix(left, "(") && !strings.HasPrefix(right, "(") {
-			// Prefer the default option on the right.
-			return false
-		}
-		if !strings.HasPrefix(left, "(") && strings.HasPrefix(right, "(") {
-			// Prefer the default option on the left.
-			return true
-		}
-		// Both options are custom, so we defer to the standard sorting.
-		return left < right
+		return optionNodeLess(optionNodes[i], optionNodes[j])
 	})
 	for i, optionNode := range optionNodes {
 		if i == 0 && f.previousNode != nil && !f.leadingCommentsContainBlankLine(optionNode) {
@@ -318,7 +363,7 @@ func (f *formatter) writeFileHeader() {
 // writeFileTypes writes the types defined in a .proto file. This includes the messages, enums,
 // services, etc. All other elements are ignored since they are handled by f.writeFileHeader.
 func (f *formatter) writeFileTypes() {
-	for i, fileElement := range f.fileNode.Decls {
+	for i, fileElement := range f.sortedFileElements(f.fileNode.Decls) {
 		switch node := fileElement.(type) {
 		case *ast.PackageNode, *ast.OptionNode, *ast.ImportNode, *ast.EmptyDeclNode:
 			// These elements have already been written by f.writeFileHeader.
@@ -532,7 +577,7 @@ func (f *formatter) writeMessage(messageNode *ast.MessageNode) {
 	var elementWriterFunc func()
 	if len(messageNode.Decls) != 0 {
 		elementWriterFunc = func() {
-			for _, decl := range messageNode.Decls {
+			for _, decl := range f.sortedMessageElements(messageNode.Decls) {
 				f.writeNode(decl)
 			}
 		}
@@ -738,7 +783,7 @@ func (f *formatter) writeEnum(enumNode *ast.EnumNode) {
 	var elementWriterFunc func()
 	if len(enumNode.Decls) > 0 {
 		elementWriterFunc = func() {
-			for _, decl := range enumNode.Decls {
+			for _, decl := range f.sortedEnumElements(enumNode.Decls) {
 				f.writeNode(decl)
 			}
 		}
@@ -801,8 +846,18 @@ func (f *formatter) writeField(fieldNode *ast.FieldNode) {
 			f.writeStart(fieldNode.FldType)
 		}
 	}
+	wrapped := f.maybeWrapFieldName(
+		fieldNode.FldType,
+		fieldNode.Name,
+		fieldNode.Equals,
+		fieldNode.Tag,
+		fieldNode.Options != nil,
+	)
 	f.Space()
 	f.writeInline(fieldNode.Name)
+	if !wrapped {
+		f.writeFieldAlignment(fieldNode)
+	}
 	f.Space()
 	f.writeInline(fieldNode.Equals)
 	f.Space()
@@ -812,13 +867,27 @@ func (f *formatter) writeField(fieldNode *ast.FieldNode) {
 		f.writeNode(fieldNode.Options)
 	}
 	f.writeLineEnd(fieldNode.Semicolon)
+	if wrapped {
+		f.Out()
+		f.Out()
+	}
 }
 
 // writeMapField writes a map field (e.g. 'map<string, string> pairs = 1;').
 func (f *formatter) writeMapField(mapFieldNode *ast.MapFieldNode) {
 	f.writeNode(mapFieldNode.MapType)
+	wrapped := f.maybeWrapFieldName(
+		mapFieldNode.MapType,
+		mapFieldNode.Name,
+		mapFieldNode.Equals,
+		mapFieldNode.Tag,
+		mapFieldNode.Options != nil,
+	)
 	f.Space()
 	f.writeInline(mapFieldNode.Name)
+	if !wrapped {
+		f.writeFieldAlignment(mapFieldNode)
+	}
 	f.Space()
 	f.writeInline(mapFieldNode.Equals)
 	f.Space()
@@ -828,6 +897,140 @@ func (f *formatter) writeMapField(mapFieldNode *ast.MapFieldNode) {
 		f.writeNode(mapFieldNode.Options)
 	}
 	f.writeLineEnd(mapFieldNode.Semicolon)
+	if wrapped {
+		f.Out()
+		f.Out()
+	}
+}
+
+// maybeWrapFieldName starts a new line for the name of a field if there is a maximum
+// line length and the field declaration does not fit on the current line, up to and
+// including its '[' or ';'. The new line is indented by two additional levels of
+// indentation, which the caller must remove after the end of the field is written.
+// For example:
+//
+//	map<string, google.protobuf.Timestamp>
+//	    last_seen_time_by_device_name = 1;
+//
+// The field is not wrapped if there are comments between its type and its tag, and
+// fields that are wrapped are not aligned with the other fields of their block.
+//
+// Returns true if the field name is wrapped.
+func (f *formatter) maybeWrapFieldName(
+	typeNode ast.Node,
+	nameNode *ast.IdentNode,
+	equalsNode *ast.RuneNode,
+	tagNode *ast.UintLiteralNode,
+	hasOptions bool,
+) bool {
+	if f.maxLineLength <= 0 {
+		return false
+	}
+	// The width of ' name = 1;' or ' name = 1 ['.
+	width := 1 + utf8.RuneCountInString(nameNode.Val) + 3 + utf8.RuneCountInString(f.fileNode.NodeInfo(tagNode).RawText()) + 1
+	if hasOptions {
+		width += 1
+	}
+	if f.column+width <= f.maxLineLength {
+		return false
+	}
+	if (f.indent+2)*f.indentWidth >= f.column || f.hasInteriorComments(typeNode, nameNode, equalsNode, tagNode) {
+		return false
+	}
+	f.P("")
+	f.In()
+	f.In()
+	f.Indent(nil)
+	return true
+}
+
+// alignFields records the aligned prefix widths of the consecutive fields within
+// the given elements, and recursively within the nested messages, oneofs, extend
+// blocks, and groups of the elements.
+//
+// Fields are consecutive if they are not separated by a blank line or another element.
+// Fields that are wrapped because they exceed the maximum line length are not aligned,
+// and separate blocks like any other element.
+//
+// The depth is the level of indentation of the elements.
+func (f *formatter) alignFields(elements []ast.Node, depth int) {
+	var (
+		blockFieldNodes  []ast.Node
+		blockPrefixWidth int
+	)
+	flushBlock := func() {
+		if len(blockFieldNodes) > 1 {
+			for _, fieldNode := range blockFieldNodes {
+				f.fieldNodeToAlignedPrefixWidth[fieldNode] = blockPrefixWidth
+			}
+		}
+		blockFieldNodes = nil
+		blockPrefixWidth = 0
+	}
+	for _, element := range elements {
+		switch node := element.(type) {
+		case *ast.FieldNode, *ast.MapFieldNode:
+			prefixWidth, wrapped := f.fieldPrefixWidth(node, depth)
+			if wrapped {
+				flushBlock()
+				continue
+			}
+			if len(blockFieldNodes) > 0 && f.leadingCommentsContainBlankLine(node) {
+				flushBlock()
+			}
+			blockFieldNodes = append(blockFieldNodes, node)
+			if prefixWidth > blockPrefixWidth {
+				blockPrefixWidth = prefixWidth
+			}
+			continue
+		}
+		flushBlock()
+		switch node := element.(type) {
+		case *ast.MessageNode:
+			f.alignFields(nodesForElements(f.sortedMessageElements(node.Decls)), depth+1)
+		case *ast.GroupNode:
+			f.alignFields(nodesForElements(f.sortedMessageElements(node.Decls)), depth+1)
+		case *ast.OneofNode:
+			f.alignFields(nodesForElements(f.sortedOneofElements(node.Decls)), depth+1)
+		case *ast.ExtendNode:
+			f.alignFields(nodesForElements(f.sortedExtendElements(node.Decls)), depth+1)
+		}
+	}
+	flushBlock()
+}
+
+// fieldPrefixWidth returns the width of the label, type, and name of the
+// field node when written at the given depth, including any comments written
+// in-line, and true if the field is wrapped instead.
+func (f *formatter) fieldPrefixWidth(fieldNode ast.Node, depth int) (int, bool) {
+	measurer := newFormatter(io.Discard, f.fileNode, newFormatOptions())
+	measurer.indentWidth = f.indentWidth
+	measurer.maxLineLength = f.maxLineLength
+	measurer.indent = depth
+	// Start at the beginning of a line, so that the field is indented.
+	measurer.lastWritten = '\n'
+	measurer.measuredFieldNode = fieldNode
+	measurer.writeNode(fieldNode)
+	// The width is only measured if the field is not wrapped.
+	return measurer.measuredFieldPrefixWidth, measurer.measuredFieldPrefixWidth == 0
+}
+
+// writeFieldAlignment writes the padding after the name of an aligned field,
+// so that its '=' is aligned with the other fields of its block.
+func (f *formatter) writeFieldAlignment(fieldNode ast.Node) {
+	if f.measuredFieldNode == fieldNode {
+		f.measuredFieldPrefixWidth = f.column - f.indent*f.indentWidth
+		return
+	}
+	alignedPrefixWidth, ok := f.fieldNodeToAlignedPrefixWidth[fieldNode]
+	if !ok {
+		return
+	}
+	if padding := alignedPrefixWidth - (f.column - f.indent*f.indentWidth); padding > 0 {
+		// The padding includes the space that is otherwise written before the '=',
+		// as a pending space is not written after a space.
+		f.WriteString(strings.Repeat(" ", padding+1))
+	}
 }
 
 // writeMapType writes a map type (e.g. 'map<string, string>').
@@ -863,7 +1066,7 @@ func (f *formatter) writeExtend(extendNode *ast.ExtendNode) {
 	var elementWriterFunc func()
 	if len(extendNode.Decls) > 0 {
 		elementWriterFunc = func() {
-			for _, decl := range extendNode.Decls {
+			for _, decl := range f.sortedExtendElements(extendNode.Decls) {
 				f.writeNode(decl)
 			}
 		}
@@ -891,7 +1094,7 @@ func (f *formatter) writeService(serviceNode *ast.ServiceNode) {
 	var elementWriterFunc func()
 	if len(serviceNode.Decls) > 0 {
 		elementWriterFunc = func() {
-			for _, decl := range serviceNode.Decls {
+			for _, decl := range f.sortedServiceElements(serviceNode.Decls) {
 				f.writeNode(decl)
 			}
 		}
@@ -919,7 +1122,7 @@ func (f *formatter) writeRPC(rpcNode *ast.RPCNode) {
 	var elementWriterFunc func()
 	if len(rpcNode.Decls) > 0 {
 		elementWriterFunc = func() {
-			for _, decl := range rpcNode.Decls {
+			for _, decl := range f.sortedRPCElements(rpcNode.Decls) {
 				f.writeNode(decl)
 			}
 		}
@@ -974,7 +1177,7 @@ func (f *formatter) writeOneOf(oneOfNode *ast.OneofNode) {
 	var elementWriterFunc func()
 	if len(oneOfNode.Decls) > 0 {
 		elementWriterFunc = func() {
-			for _, decl := range oneOfNode.Decls {
+			for _, decl := range f.sortedOneofElements(oneOfNode.Decls) {
 				f.writeNode(decl)
 			}
 		}
@@ -1005,7 +1208,7 @@ func (f *formatter) writeGroup(groupNode *ast.GroupNode) {
 	var elementWriterFunc func()
 	if len(groupNode.Decls) > 0 {
 		elementWriterFunc = func() {
-			for _, decl := range groupNode.Decls {
+			for _, decl := range f.sortedMessageElements(groupNode.Decls) {
 				f.writeNode(decl)
 			}
 		}
@@ -1127,6 +1330,20 @@ func (f *formatter) writeCompactOptions(compactOptionsNode *ast.CompactOptionsNo
 	defer func() {
 		f.inCompactOptions = false
 	}()
+	if f.maxLineLength > 0 && f.canWriteCompactOptionsInline(compactOptionsNode) {
+		// If there is a maximum line length, the options are written in-line
+		// if they fit on the line, including the trailing ';', and wrapped with
+		// one option per line otherwise. For example:
+		//
+		//  string name = 1 [deprecated = true, json_name = "name"];
+		//
+		if f.column+1+f.compactOptionsInlineWidth(compactOptionsNode)+1 <= f.maxLineLength {
+			f.writeCompactOptionsInline(compactOptionsNode)
+			return
+		}
+		f.writeCompactOptionsMultiline(compactOptionsNode)
+		return
+	}
 	if len(compactOptionsNode.Options) == 1 &&
 		!f.hasInteriorComments(compactOptionsNode.OpenBracket, compactOptionsNode.Options[0].Name) {
 		// If there's only a single compact scalar option without comments, we can write it
@@ -1160,6 +1377,19 @@ func (f *formatter) writeCompactOptions(compactOptionsNode *ast.CompactOptionsNo
 		f.writeInline(compactOptionsNode.CloseBracket)
 		return
 	}
+	f.writeCompactOptionsMultiline(compactOptionsNode)
+}
+
+// writeCompactOptionsMultiline writes a compact options node
+// with one option per line.
+//
+// For example,
+//
+//	[
+//	  deprecated = true,
+//	  json_name = "something"
+//	]
+func (f *formatter) writeCompactOptionsMultiline(compactOptionsNode *ast.CompactOptionsNode) {
 	var elementWriterFunc func()
 	if len(compactOptionsNode.Options) > 0 {
 		elementWriterFunc = func() {
@@ -1181,6 +1411,51 @@ func (f *formatter) writeCompactOptions(compactOptionsNode *ast.CompactOptionsNo
 	)
 }
 
+// writeCompactOptionsInline writes a compact options node in-line. This must
+// only be called if f.canWriteCompactOptionsInline returns true.
+//
+// For example,
+//
+//	[deprecated = true, json_name = "something"]
+func (f *formatter) writeCompactOptionsInline(compactOptionsNode *ast.CompactOptionsNode) {
+	f.writeInline(compactOptionsNode.OpenBracket)
+	for i, optionNode := range compactOptionsNode.Options {
+		if i > 0 {
+			f.writeInline(compactOptionsNode.Commas[i-1])
+			f.Space()
+		}
+		f.writeInline(optionNode.Name)
+		f.Space()
+		f.writeInline(optionNode.Equals)
+		f.Space()
+		f.writeInline(optionNode.Val)
+	}
+	f.writeInline(compactOptionsNode.CloseBracket)
+}
+
+// canWriteCompactOptionsInline returns true if the compact options node can be written
+// in-line, i.e. none of its tokens have comments, and all of its values are scalars.
+func (f *formatter) canWriteCompactOptionsInline(compactOptionsNode *ast.CompactOptionsNode) bool {
+	for _, optionNode := range compactOptionsNode.Options {
+		switch optionNode.Val.(type) {
+		case *ast.MessageLiteralNode, *ast.ArrayLiteralNode, *ast.CompoundStringLiteralNode:
+			return false
+		}
+	}
+	return !f.nodeOrDescendantHasComment(compactOptionsNode)
+}
+
+// compactOptionsInlineWidth returns the width of the compact options node
+// when written in-line.
+func (f *formatter) compactOptionsInlineWidth(compactOptionsNode *ast.CompactOptionsNode) int {
+	// The node has no comments, so writing it with a new formatter
+	// writes exactly the same characters.
+	buffer := bytes.NewBuffer(nil)
+	measurer := newFormatter(buffer, f.fileNode, newFormatOptions())
+	measurer.writeCompactOptionsInline(compactOptionsNode)
+	return utf8.RuneCount(buffer.Bytes())
+}
+
 func (f *formatter) hasInteriorComments(nodes ...ast.Node) bool {
 	for i, n := range nodes {
 		// interior comments mean we ignore leading comments on first
@@ -2245,6 +2520,20 @@ func (f *formatter) importHasComment(importNode *ast.ImportNode) bool {
 		f.nodeHasComment(importNode.Weak)
 }
 
+// nodeOrDescendantHasComment returns true if the node, or any of the
+// nodes within it, has leading or trailing comments.
+func (f *formatter) nodeOrDescendantHasComment(node ast.Node) bool {
+	if compositeNode, ok := node.(ast.CompositeNode); ok {
+		for _, child := range compositeNode.Children() {
+			if f.nodeOrDescendantHasComment(child) {
+				return true
+			}
+		}
+		return false
+	}
+	return f.nodeHasComment(node)
+}
+
 func (f *formatter) nodeHasComment(node ast.Node) bool {
 	// when node != nil, node's value could be nil, see: https://go.dev/doc/faq#nil_error
 	if node == nil || reflect.ValueOf(node).IsNil() {
@@ -2269,6 +2558,25 @@ func importSortOrder(node *ast.ImportNode) int {
 	}
 }
 
+// nodesForElements returns the elements as a slice of ast.Nodes.
+func nodesForElements[T ast.Node](elements []T) []ast.Node {
+	nodes := make([]ast.Node, len(elements))
+	for i, element := range elements {
+		nodes[i] = element
+	}
+	return nodes
+}
+
+// importGroup returns the group of the import with the given name, which
+// is the first component of its path, or the empty string for imports
+// without a directory.
+func importGroup(name string) string {
+	if index := strings.IndexByte(name, '/'); index >= 0 {
+		return name[:index]
+	}
+	return ""
+}
+
 // stringForOptionName returns the string representation of the given option name node.
 // This is used for sorting file-level options.
 func stringForOptionName(optionNameNode *ast.OptionNameNode) string {
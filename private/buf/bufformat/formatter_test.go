@@ -15,16 +15,20 @@
 package bufformat
 
 import (
+	"bytes"
 	"context"
 	"io"
 	"strings"
 	"testing"
 
+	"github.com/bufbuild/buf/private/bufpkg/bufformatconfig"
 	"github.com/bufbuild/buf/private/bufpkg/bufmodule"
 	"github.com/bufbuild/buf/private/pkg/command"
 	"github.com/bufbuild/buf/private/pkg/diff"
 	"github.com/bufbuild/buf/private/pkg/storage"
 	"github.com/bufbuild/buf/private/pkg/storage/storageos"
+	"github.com/bufbuild/protocompile/parser"
+	"github.com/bufbuild/protocompile/reporter"
 	"github.com/stretchr/testify/require"
 )
 
@@ -33,6 +37,177 @@ func TestFormatter(t *testing.T) {
 	testFormatCustomOptions(t)
 	testFormatProto2(t)
 	testFormatProto3(t)
+	testFormatConfig(t)
+	testFormatSort(t)
+}
+
+func TestFormatSortDescriptorChanged(t *testing.T) {
+	t.Parallel()
+	// The duplicate imports are merged by the formatter, which changes the
+	// public and weak dependencies of the file.
+	fileNode, err := parser.Parse(
+		"a.proto",
+		strings.NewReader(`syntax = "proto3";
+
+import public "b.proto";
+import weak "b.proto";
+`),
+		reporter.NewHandler(nil),
+	)
+	require.NoError(t, err)
+	buffer := bytes.NewBuffer(nil)
+	err = FormatFileNode(buffer, fileNode, FormatWithSort())
+	require.EqualError(t, err, "sorting a.proto would change its descriptor, so it was not sorted")
+	require.Zero(t, buffer.Len())
+}
+
+func TestFormatFileNodeRange(t *testing.T) {
+	t.Parallel()
+	const content = `syntax = "proto3";
+
+package acme.v1;
+
+// Object is an object.
+message   Object {
+    string key=1;   // The key.
+  // Nested is nested.
+     message Nested {
+  int32 id=1;
+     }
+
+    int32   value  = 2;
+}
+
+enum  Kind {   KIND_UNSPECIFIED=0; }
+`
+	testFormatFileNodeRange(
+		t,
+		"field",
+		content,
+		7,
+		7,
+		strings.Replace(content, "    string key=1;   // The key.", "  string key = 1; // The key.", 1),
+		[]Edit{
+			{
+				StartOffset: 83,
+				EndOffset:   100,
+				StartLine:   7,
+				StartColumn: 3,
+				EndLine:     7,
+				EndColumn:   20,
+				NewText:     "string key = 1;",
+			},
+		},
+	)
+	testFormatFileNodeRange(
+		t,
+		"nested message",
+		content,
+		9,
+		9,
+		strings.Replace(content, "     message Nested {\n  int32 id=1;\n     }", "  message Nested {\n    int32 id = 1;\n  }", 1),
+		nil,
+	)
+	testFormatFileNodeRange(
+		t,
+		"message and enum",
+		content,
+		6,
+		16,
+		`syntax = "proto3";
+
+package acme.v1;
+
+// Object is an object.
+message Object {
+  string key = 1; // The key.
+  // Nested is nested.
+  message Nested {
+    int32 id = 1;
+  }
+
+  int32 value = 2;
+}
+
+enum Kind {
+  KIND_UNSPECIFIED = 0;
+}
+`,
+		nil,
+	)
+	testFormatFileNodeRange(
+		t,
+		"nested field",
+		content,
+		10,
+		10,
+		strings.Replace(content, "  int32 id=1;", "    int32 id = 1;", 1),
+		nil,
+	)
+	testFormatFileNodeRange(
+		t,
+		"blank line",
+		content,
+		15,
+		15,
+		content,
+		[]Edit{},
+	)
+	testFormatFileNodeRange(
+		t,
+		"header",
+		content,
+		3,
+		3,
+		`syntax = "proto3";
+
+package acme.v1;
+
+// Object is an object.
+message Object {
+  string key = 1; // The key.
+  // Nested is nested.
+  message Nested {
+    int32 id = 1;
+  }
+
+  int32 value = 2;
+}
+
+enum Kind {
+  KIND_UNSPECIFIED = 0;
+}
+`,
+		nil,
+	)
+}
+
+func testFormatFileNodeRange(
+	t *testing.T,
+	name string,
+	content string,
+	startLine int,
+	endLine int,
+	expectedContent string,
+	// If nil, the edits are not checked.
+	expectedEdits []Edit,
+) {
+	t.Run(name, func(t *testing.T) {
+		t.Parallel()
+		fileNode, err := parser.Parse("a.proto", strings.NewReader(content), reporter.NewHandler(nil))
+		require.NoError(t, err)
+		buffer := bytes.NewBuffer(nil)
+		require.NoError(t, FormatFileNode(buffer, fileNode, FormatWithRange(startLine, endLine)))
+		require.Equal(t, expectedContent, buffer.String())
+		edits, err := FormatFileNodeEdits(fileNode, FormatWithRange(startLine, endLine))
+		require.NoError(t, err)
+		if expectedEdits != nil {
+			require.Equal(t, len(expectedEdits), len(edits))
+			for i, expectedEdit := range expectedEdits {
+				require.Equal(t, expectedEdit, edits[i])
+			}
+		}
+	})
 }
 
 func testFormatCustomOptions(t *testing.T) {
@@ -63,7 +238,20 @@ func testFormatProto3(t *testing.T) {
 	testFormatNoDiff(t, "testdata/proto3/block/v1")
 }
 
-func testFormatNoDiff(t *testing.T, path string) {
+func testFormatConfig(t *testing.T) {
+	testFormatNoDiff(t, "testdata/config/align_fields", FormatWithConfig(&bufformatconfig.Config{AlignFields: true}))
+	testFormatNoDiff(t, "testdata/config/align_fields_max_line_length", FormatWithConfig(&bufformatconfig.Config{AlignFields: true, MaxLineLength: 60}))
+	testFormatNoDiff(t, "testdata/config/group_imports", FormatWithConfig(&bufformatconfig.Config{GroupImports: true}))
+	testFormatNoDiff(t, "testdata/config/indent", FormatWithConfig(&bufformatconfig.Config{IndentWidth: 4}))
+	testFormatNoDiff(t, "testdata/config/max_line_length", FormatWithConfig(&bufformatconfig.Config{MaxLineLength: 60}))
+}
+
+func testFormatSort(t *testing.T) {
+	testFormatNoDiff(t, "testdata/sort/default", FormatWithSort())
+	testFormatNoDiff(t, "testdata/sort/fields", FormatWithSort(), FormatWithSortFieldsByNumber())
+}
+
+func testFormatNoDiff(t *testing.T, path string, options ...FormatOption) {
 	t.Run(path, func(t *testing.T) {
 		ctx := context.Background()
 		runner := command.NewRunner()
@@ -71,7 +259,7 @@ func testFormatNoDiff(t *testing.T, path string) {
 		require.NoError(t, err)
 		module, err := bufmodule.NewModuleForBucket(ctx, moduleBucket)
 		require.NoError(t, err)
-		readBucket, err := FormatModule(ctx, module)
+		readBucket, err := FormatModule(ctx, module, options...)
 		require.NoError(t, err)
 		require.NoError(
 			t,
@@ -0,0 +1,306 @@
+// Copyright 2020-2023 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bufformat
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/bufbuild/protocompile/ast"
+)
+
+// formatRange returns the edits that format the file node. If a range is set on
+// the options, only the nodes that overlap the range are formatted.
+func formatRange(fileNode *ast.FileNode, formatOptions *formatOptions) ([]Edit, error) {
+	content := fileNodeContent(fileNode)
+	rangeFormatter := &rangeFormatter{
+		fileNode:      fileNode,
+		formatOptions: formatOptions,
+		content:       content,
+		lineOffsets:   lineOffsets(content),
+	}
+	return rangeFormatter.run()
+}
+
+type rangeFormatter struct {
+	fileNode      *ast.FileNode
+	formatOptions *formatOptions
+	content       []byte
+	// The zero-based byte offset at which each line of content begins.
+	lineOffsets []int
+}
+
+// nodeSpan is the span of a node in the content of a file, including its leading
+// and trailing comments, and the indentation of its first line.
+type nodeSpan struct {
+	node  ast.Node
+	depth int
+	// The zero-based byte offsets of the span, the end is exclusive.
+	startOffset int
+	endOffset   int
+	// The one-based lines of the span, inclusive.
+	startLine int
+	endLine   int
+	// If false, the span shares a line with other nodes.
+	isolated bool
+}
+
+func (r *rangeFormatter) run() ([]Edit, error) {
+	if !r.formatOptions.hasRange {
+		return r.formatFile()
+	}
+	var spans []*nodeSpan
+	if r.fileNode.Syntax != nil {
+		spans = append(spans, r.nodeSpan(r.fileNode.Syntax, 0))
+	}
+	if r.fileNode.Edition != nil {
+		spans = append(spans, r.nodeSpan(r.fileNode.Edition, 0))
+	}
+	for _, decl := range r.fileNode.Decls {
+		switch decl.(type) {
+		case *ast.PackageNode, *ast.ImportNode, *ast.OptionNode:
+			spans = append(spans, r.nodeSpan(decl, 0))
+		}
+	}
+	for _, span := range spans {
+		if r.overlapsRange(span) {
+			// The header is sorted as a whole, so it can only be formatted
+			// along with the rest of the file.
+			return r.formatFile()
+		}
+	}
+	selectedSpans, ok := r.selectSpans(nodesForElements(r.fileNode.Decls), 0)
+	if !ok {
+		return r.formatFile()
+	}
+	var edits []Edit
+	for _, span := range selectedSpans {
+		formatted, err := r.formatNode(span.node, span.depth)
+		if err != nil {
+			return nil, err
+		}
+		if edit, ok := r.newEdit(span.startOffset, span.endOffset, formatted); ok {
+			edits = append(edits, edit)
+		}
+	}
+	return edits, nil
+}
+
+// formatFile returns the edit that formats the entire file, if any.
+func (r *rangeFormatter) formatFile() ([]Edit, error) {
+	buffer := bytes.NewBuffer(nil)
+	if err := newFormatter(buffer, r.fileNode, r.formatOptions).Run(); err != nil {
+		return nil, err
+	}
+	if edit, ok := r.newEdit(0, len(r.content), buffer.String()); ok {
+		return []Edit{edit}, nil
+	}
+	return nil, nil
+}
+
+// selectSpans returns the spans of the nodes that overlap the range. If the range is
+// within the body of a node, the nodes of its body are selected instead.
+//
+// Returns false if a node that overlaps the range shares a line with another node, in
+// which case the parent of the nodes must be formatted instead.
+func (r *rangeFormatter) selectSpans(nodes []ast.Node, depth int) ([]*nodeSpan, bool) {
+	var selectedSpans []*nodeSpan
+	for _, node := range nodes {
+		if _, ok := node.(*ast.EmptyDeclNode); ok {
+			continue
+		}
+		span := r.nodeSpan(node, depth)
+		if !r.overlapsRange(span) {
+			continue
+		}
+		if !span.isolated {
+			return nil, false
+		}
+		if openBrace, closeBrace, children := bodyOfNode(node); openBrace != nil &&
+			r.formatOptions.rangeStartLine > r.fileNode.NodeInfo(openBrace).Start().Line &&
+			r.formatOptions.rangeEndLine < r.fileNode.NodeInfo(closeBrace).Start().Line {
+			childSpans, ok := r.selectSpans(children, depth+1)
+			if ok {
+				selectedSpans = append(selectedSpans, childSpans...)
+				continue
+			}
+		}
+		selectedSpans = append(selectedSpans, span)
+	}
+	return selectedSpans, true
+}
+
+// formatNode returns the formatted node, indented to the given depth.
+func (r *rangeFormatter) formatNode(node ast.Node, depth int) (string, error) {
+	buffer := bytes.NewBuffer(nil)
+	formatter := newFormatter(buffer, r.fileNode, r.formatOptions)
+	formatter.indent = depth
+	// The node is written at the beginning of a line, so that its first line is indented.
+	formatter.lastWritten = '\n'
+	formatter.writeNode(node)
+	if formatter.err != nil {
+		return "", formatter.err
+	}
+	// The blank lines around the node are left as-is.
+	return strings.TrimRight(strings.TrimLeft(buffer.String(), "\n"), "\n"), nil
+}
+
+func (r *rangeFormatter) nodeSpan(node ast.Node, depth int) *nodeSpan {
+	info := r.fileNode.NodeInfo(node)
+	startOffset := info.Start().Offset
+	if leadingComments := info.LeadingComments(); leadingComments.Len() > 0 {
+		startOffset = leadingComments.Index(0).Start().Offset
+	}
+	endOffset := info.Start().Offset + len(info.RawText())
+	if trailingComments := info.TrailingComments(); trailingComments.Len() > 0 {
+		lastComment := trailingComments.Index(trailingComments.Len() - 1)
+		endOffset = lastComment.Start().Offset + len(lastComment.RawText())
+	}
+	startLine := r.lineForOffset(startOffset)
+	endLine := r.lineForOffset(endOffset - 1)
+	lineStartOffset := r.lineOffsets[startLine-1]
+	lineEndOffset := len(r.content)
+	if endLine < len(r.lineOffsets) {
+		lineEndOffset = r.lineOffsets[endLine] - 1
+	}
+	isolated := len(bytes.TrimSpace(r.content[lineStartOffset:startOffset])) == 0 &&
+		len(bytes.TrimSpace(r.content[endOffset:lineEndOffset])) == 0
+	return &nodeSpan{
+		node:  node,
+		depth: depth,
+		// The span includes the indentation of its first line, as the
+		// formatted node is indented.
+		startOffset: lineStartOffset,
+		endOffset:   endOffset,
+		startLine:   startLine,
+		endLine:     endLine,
+		isolated:    isolated,
+	}
+}
+
+func (r *rangeFormatter) overlapsRange(span *nodeSpan) bool {
+	return span.startLine <= r.formatOptions.rangeEndLine && span.endLine >= r.formatOptions.rangeStartLine
+}
+
+// newEdit returns the edit that replaces the content between the offsets with the
+// given text, trimmed to the text that differs.
+//
+// Returns false if the text is the same as the content.
+func (r *rangeFormatter) newEdit(startOffset int, endOffset int, text string) (Edit, bool) {
+	original := string(r.content[startOffset:endOffset])
+	if original == text {
+		return Edit{}, false
+	}
+	prefixLength := 0
+	for prefixLength < len(original) && prefixLength < len(text) && original[prefixLength] == text[prefixLength] {
+		prefixLength++
+	}
+	for prefixLength > 0 && prefixLength < len(original) && !utf8.RuneStart(original[prefixLength]) {
+		prefixLength--
+	}
+	suffixLength := 0
+	for suffixLength < len(original)-prefixLength && suffixLength < len(text)-prefixLength &&
+		original[len(original)-1-suffixLength] == text[len(text)-1-suffixLength] {
+		suffixLength++
+	}
+	for suffixLength > 0 && !utf8.RuneStart(original[len(original)-suffixLength]) {
+		suffixLength--
+	}
+	startOffset += prefixLength
+	endOffset -= suffixLength
+	startLine := r.lineForOffset(startOffset)
+	endLine := r.lineForOffset(endOffset)
+	return Edit{
+		StartOffset: startOffset,
+		EndOffset:   endOffset,
+		StartLine:   startLine,
+		StartColumn: startOffset - r.lineOffsets[startLine-1] + 1,
+		EndLine:     endLine,
+		EndColumn:   endOffset - r.lineOffsets[endLine-1] + 1,
+		NewText:     text[prefixLength : len(text)-suffixLength],
+	}, true
+}
+
+// lineForOffset returns the one-based line of the offset.
+func (r *rangeFormatter) lineForOffset(offset int) int {
+	return sort.Search(len(r.lineOffsets), func(i int) bool {
+		return r.lineOffsets[i] > offset
+	})
+}
+
+// bodyOfNode returns the braces and elements of the body of the node, if any.
+func bodyOfNode(node ast.Node) (*ast.RuneNode, *ast.RuneNode, []ast.Node) {
+	switch node := node.(type) {
+	case *ast.MessageNode:
+		return node.OpenBrace, node.CloseBrace, nodesForElements(node.Decls)
+	case *ast.GroupNode:
+		return node.OpenBrace, node.CloseBrace, nodesForElements(node.Decls)
+	case *ast.EnumNode:
+		return node.OpenBrace, node.CloseBrace, nodesForElements(node.Decls)
+	case *ast.ServiceNode:
+		return node.OpenBrace, node.CloseBrace, nodesForElements(node.Decls)
+	case *ast.RPCNode:
+		return node.OpenBrace, node.CloseBrace, nodesForElements(node.Decls)
+	case *ast.OneofNode:
+		return node.OpenBrace, node.CloseBrace, nodesForElements(node.Decls)
+	case *ast.ExtendNode:
+		return node.OpenBrace, node.CloseBrace, nodesForElements(node.Decls)
+	default:
+		return nil, nil, nil
+	}
+}
+
+// applyEdits returns the content with the edits applied. The edits must not overlap.
+func applyEdits(content []byte, edits []Edit) []byte {
+	sortedEdits := make([]Edit, len(edits))
+	copy(sortedEdits, edits)
+	sort.Slice(sortedEdits, func(i, j int) bool {
+		return sortedEdits[i].StartOffset < sortedEdits[j].StartOffset
+	})
+	result := bytes.NewBuffer(nil)
+	offset := 0
+	for _, edit := range sortedEdits {
+		result.Write(content[offset:edit.StartOffset])
+		result.WriteString(edit.NewText)
+		offset = edit.EndOffset
+	}
+	result.Write(content[offset:])
+	return result.Bytes()
+}
+
+// fileNodeContent returns the original content of the file node.
+func fileNodeContent(fileNode *ast.FileNode) []byte {
+	content := bytes.NewBuffer(nil)
+	items := fileNode.Items()
+	for item, ok := items.First(); ok; item, ok = items.Next(item) {
+		itemInfo := fileNode.ItemInfo(item)
+		content.WriteString(itemInfo.LeadingWhitespace())
+		content.WriteString(itemInfo.RawText())
+	}
+	return content.Bytes()
+}
+
+// lineOffsets returns the zero-based byte offset at which each line of the content begins.
+func lineOffsets(content []byte) []int {
+	offsets := []int{0}
+	for i, b := range content {
+		if b == '\n' {
+			offsets = append(offsets, i+1)
+		}
+	}
+	return offsets
+}
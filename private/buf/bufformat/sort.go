@@ -0,0 +1,438 @@
+// Copyright 2020-2023 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bufformat
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/bufbuild/buf/private/pkg/slicesext"
+	"github.com/bufbuild/protocompile/ast"
+	"github.com/bufbuild/protocompile/parser"
+	"github.com/bufbuild/protocompile/reporter"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// The canonical order of the declarations written with f.sortedFileElements.
+// The header (package, imports, and options) is always written first.
+const (
+	fileElementRankService = iota
+	fileElementRankMessage
+	fileElementRankEnum
+	fileElementRankExtend
+	fileElementRankOther
+)
+
+// The canonical order of the declarations written with f.sortedMessageElements.
+const (
+	messageElementRankOption = iota
+	messageElementRankField
+	messageElementRankExtensionRange
+	messageElementRankReserved
+	messageElementRankMessage
+	messageElementRankEnum
+	messageElementRankExtend
+	messageElementRankOther
+)
+
+// formatAndVerifySorted formats the file node with the sorter enabled, and verifies
+// that the descriptor of the result is the same as the descriptor of the file node,
+// except for source info and the order of declarations.
+//
+// Nothing is written to dest if the descriptors differ.
+func formatAndVerifySorted(dest io.Writer, fileNode *ast.FileNode, formatOptions *formatOptions) error {
+	buffer := bytes.NewBuffer(nil)
+	if err := newFormatter(buffer, fileNode, formatOptions).Run(); err != nil {
+		return err
+	}
+	sortedFileNode, err := parser.Parse(fileNode.Name(), bytes.NewReader(buffer.Bytes()), reporter.NewHandler(nil))
+	if err != nil {
+		return fmt.Errorf("could not parse the sorted content of %s: %w", fileNode.Name(), err)
+	}
+	fileDescriptorProto, err := canonicalFileDescriptorProtoForFileNode(fileNode)
+	if err != nil {
+		return err
+	}
+	sortedFileDescriptorProto, err := canonicalFileDescriptorProtoForFileNode(sortedFileNode)
+	if err != nil {
+		return err
+	}
+	if !proto.Equal(fileDescriptorProto, sortedFileDescriptorProto) {
+		return fmt.Errorf("sorting %s would change its descriptor, so it was not sorted", fileNode.Name())
+	}
+	_, err = dest.Write(buffer.Bytes())
+	return err
+}
+
+// sortedFileElements returns the file elements in the order they should be written.
+//
+// The elements are returned as-is unless the sorter is enabled.
+func (f *formatter) sortedFileElements(elements []ast.FileElement) []ast.FileElement {
+	if !f.sort {
+		return elements
+	}
+	return sortedElements(elements, func(element ast.FileElement) (int, uint64) {
+		switch element.(type) {
+		case *ast.ServiceNode:
+			return fileElementRankService, 0
+		case *ast.MessageNode:
+			return fileElementRankMessage, 0
+		case *ast.EnumNode:
+			return fileElementRankEnum, 0
+		case *ast.ExtendNode:
+			return fileElementRankExtend, 0
+		default:
+			return fileElementRankOther, 0
+		}
+	}, nil)
+}
+
+// sortedMessageElements returns the message elements in the order they should be written.
+//
+// The elements are returned as-is unless the sorter is enabled.
+func (f *formatter) sortedMessageElements(elements []ast.MessageElement) []ast.MessageElement {
+	if !f.sort {
+		return elements
+	}
+	return sortedElements(elements, func(element ast.MessageElement) (int, uint64) {
+		switch node := element.(type) {
+		case *ast.OptionNode:
+			return messageElementRankOption, 0
+		case *ast.FieldNode, *ast.MapFieldNode, *ast.GroupNode, *ast.OneofNode:
+			return messageElementRankField, f.fieldSortNumber(node)
+		case *ast.ExtensionRangeNode:
+			return messageElementRankExtensionRange, 0
+		case *ast.ReservedNode:
+			return messageElementRankReserved, 0
+		case *ast.MessageNode:
+			return messageElementRankMessage, 0
+		case *ast.EnumNode:
+			return messageElementRankEnum, 0
+		case *ast.ExtendNode:
+			return messageElementRankExtend, 0
+		default:
+			return messageElementRankOther, 0
+		}
+	}, optionNodeLess)
+}
+
+// sortedEnumElements returns the enum elements in the order they should be written.
+//
+// The values are never reordered because the first value is the default value.
+func (f *formatter) sortedEnumElements(elements []ast.EnumElement) []ast.EnumElement {
+	if !f.sort {
+		return elements
+	}
+	return sortedElements(elements, func(element ast.EnumElement) (int, uint64) {
+		switch element.(type) {
+		case *ast.OptionNode:
+			return 0, 0
+		case *ast.EnumValueNode:
+			return 1, 0
+		case *ast.ReservedNode:
+			return 2, 0
+		default:
+			return 3, 0
+		}
+	}, optionNodeLess)
+}
+
+// sortedServiceElements returns the service elements in the order they should be written.
+func (f *formatter) sortedServiceElements(elements []ast.ServiceElement) []ast.ServiceElement {
+	if !f.sort {
+		return elements
+	}
+	return sortedElements(elements, func(element ast.ServiceElement) (int, uint64) {
+		switch element.(type) {
+		case *ast.OptionNode:
+			return 0, 0
+		case *ast.RPCNode:
+			return 1, 0
+		default:
+			return 2, 0
+		}
+	}, optionNodeLess)
+}
+
+// sortedRPCElements returns the RPC elements in the order they should be written.
+func (f *formatter) sortedRPCElements(elements []ast.RPCElement) []ast.RPCElement {
+	if !f.sort {
+		return elements
+	}
+	return sortedElements(elements, func(element ast.RPCElement) (int, uint64) {
+		if _, ok := element.(*ast.OptionNode); ok {
+			return 0, 0
+		}
+		return 1, 0
+	}, optionNodeLess)
+}
+
+// sortedOneofElements returns the oneof elements in the order they should be written.
+func (f *formatter) sortedOneofElements(elements []ast.OneofElement) []ast.OneofElement {
+	if !f.sort {
+		return elements
+	}
+	return sortedElements(elements, func(element ast.OneofElement) (int, uint64) {
+		switch node := element.(type) {
+		case *ast.OptionNode:
+			return 0, 0
+		case *ast.FieldNode, *ast.GroupNode:
+			return 1, f.fieldSortNumber(node)
+		default:
+			return 2, 0
+		}
+	}, optionNodeLess)
+}
+
+// sortedExtendElements returns the extend elements in the order they should be written.
+func (f *formatter) sortedExtendElements(elements []ast.ExtendElement) []ast.ExtendElement {
+	if !f.sort {
+		return elements
+	}
+	return sortedElements(elements, func(element ast.ExtendElement) (int, uint64) {
+		switch node := element.(type) {
+		case *ast.FieldNode, *ast.GroupNode:
+			return 0, f.fieldSortNumber(node)
+		default:
+			return 1, 0
+		}
+	}, nil)
+}
+
+// fieldSortNumber returns the number that the field is sorted by within its rank.
+//
+// A oneof is sorted by the lowest number of its fields. Zero is returned for all
+// fields if fields are not sorted by number, which preserves their relative order.
+func (f *formatter) fieldSortNumber(node ast.Node) uint64 {
+	if !f.sortFields {
+		return 0
+	}
+	switch node := node.(type) {
+	case *ast.FieldNode:
+		if node.Tag != nil {
+			return node.Tag.Val
+		}
+	case *ast.MapFieldNode:
+		if node.Tag != nil {
+			return node.Tag.Val
+		}
+	case *ast.GroupNode:
+		if node.Tag != nil {
+			return node.Tag.Val
+		}
+	case *ast.OneofNode:
+		var number uint64 = math.MaxUint64
+		for _, decl := range node.Decls {
+			switch decl.(type) {
+			case *ast.FieldNode, *ast.GroupNode:
+				if declNumber := f.fieldSortNumber(decl); declNumber < number {
+					number = declNumber
+				}
+			}
+		}
+		return number
+	}
+	return math.MaxUint64
+}
+
+// sortedElements returns a copy of the elements stably sorted by the rank and
+// number returned by rankFunc, in that order.
+//
+// If optionLessFunc is not nil, the options are sorted with it within their rank.
+func sortedElements[T ast.Node](
+	elements []T,
+	rankFunc func(T) (int, uint64),
+	optionLessFunc func(*ast.OptionNode, *ast.OptionNode) bool,
+) []T {
+	sorted := make([]T, len(elements))
+	copy(sorted, elements)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		iRank, iNumber := rankFunc(sorted[i])
+		jRank, jNumber := rankFunc(sorted[j])
+		if iRank != jRank {
+			return iRank < jRank
+		}
+		if iNumber != jNumber {
+			return iNumber < jNumber
+		}
+		if optionLessFunc != nil {
+			iOptionNode, iOK := ast.Node(sorted[i]).(*ast.OptionNode)
+			jOptionNode, jOK := ast.Node(sorted[j]).(*ast.OptionNode)
+			if iOK && jOK {
+				return optionLessFunc(iOptionNode, jOptionNode)
+			}
+		}
+		return false
+	})
+	return sorted
+}
+
+// optionNodeLess reports whether the left option is sorted before the right option.
+//
+// The default options (e.g. cc_enable_arenas) are always sorted above custom
+// options (which are identified by a leading '(').
+func optionNodeLess(left *ast.OptionNode, right *ast.OptionNode) bool {
+	leftName := stringForOptionName(left.Name)
+	rightName := stringForOptionName(right.Name)
+	if strings.HasPrefix(leftName, "(") && !strings.HasPrefix(rightName, "(") {
+		// Prefer the default option on the right.
+		return false
+	}
+	if !strings.HasPrefix(leftName, "(") && strings.HasPrefix(rightName, "(") {
+		// Prefer the default option on the left.
+		return true
+	}
+	// Both options are of the same kind, so we defer to the standard sorting.
+	return leftName < rightName
+}
+
+// canonicalFileDescriptorProtoForFileNode returns the descriptor of the file node
+// without source info, and with all declarations that can be reordered by the
+// sorter in a canonical order.
+func canonicalFileDescriptorProtoForFileNode(fileNode *ast.FileNode) (*descriptorpb.FileDescriptorProto, error) {
+	result, err := parser.ResultFromAST(fileNode, false, reporter.NewHandler(nil))
+	if err != nil {
+		return nil, err
+	}
+	fileDescriptorProto, ok := proto.Clone(result.FileDescriptorProto()).(*descriptorpb.FileDescriptorProto)
+	if !ok {
+		// Unreachable - the clone of a FileDescriptorProto is always a FileDescriptorProto.
+		return nil, fmt.Errorf("unexpected descriptor type %T", result.FileDescriptorProto())
+	}
+	fileDescriptorProto.SourceCodeInfo = nil
+	canonicalizeDependencies(fileDescriptorProto)
+	canonicalizeUninterpretedOptions(fileDescriptorProto.GetOptions().GetUninterpretedOption())
+	sortByName(fileDescriptorProto.MessageType)
+	sortByName(fileDescriptorProto.EnumType)
+	sortByName(fileDescriptorProto.Service)
+	sortByName(fileDescriptorProto.Extension)
+	for _, descriptorProto := range fileDescriptorProto.MessageType {
+		canonicalizeDescriptorProto(descriptorProto)
+	}
+	for _, enumDescriptorProto := range fileDescriptorProto.EnumType {
+		canonicalizeEnumDescriptorProto(enumDescriptorProto)
+	}
+	for _, serviceDescriptorProto := range fileDescriptorProto.Service {
+		canonicalizeUninterpretedOptions(serviceDescriptorProto.GetOptions().GetUninterpretedOption())
+		for _, methodDescriptorProto := range serviceDescriptorProto.Method {
+			canonicalizeUninterpretedOptions(methodDescriptorProto.GetOptions().GetUninterpretedOption())
+		}
+	}
+	return fileDescriptorProto, nil
+}
+
+func canonicalizeDescriptorProto(descriptorProto *descriptorpb.DescriptorProto) {
+	canonicalizeUninterpretedOptions(descriptorProto.GetOptions().GetUninterpretedOption())
+	// The oneofs are referenced by index, so the fields are updated to
+	// reference the new index of their oneof.
+	oneofNameToIndex := make(map[string]int32, len(descriptorProto.OneofDecl))
+	oneofNames := make([]string, len(descriptorProto.OneofDecl))
+	for i, oneofDescriptorProto := range descriptorProto.OneofDecl {
+		oneofNames[i] = oneofDescriptorProto.GetName()
+	}
+	sortByName(descriptorProto.OneofDecl)
+	for i, oneofDescriptorProto := range descriptorProto.OneofDecl {
+		oneofNameToIndex[oneofDescriptorProto.GetName()] = int32(i)
+		canonicalizeUninterpretedOptions(oneofDescriptorProto.GetOptions().GetUninterpretedOption())
+	}
+	for _, fieldDescriptorProto := range descriptorProto.Field {
+		if fieldDescriptorProto.OneofIndex != nil {
+			fieldDescriptorProto.OneofIndex = proto.Int32(oneofNameToIndex[oneofNames[fieldDescriptorProto.GetOneofIndex()]])
+		}
+	}
+	sortByName(descriptorProto.Field)
+	sortByName(descriptorProto.Extension)
+	sortByName(descriptorProto.NestedType)
+	sortByName(descriptorProto.EnumType)
+	sort.SliceStable(descriptorProto.ExtensionRange, func(i, j int) bool {
+		return descriptorProto.ExtensionRange[i].GetStart() < descriptorProto.ExtensionRange[j].GetStart()
+	})
+	sort.SliceStable(descriptorProto.ReservedRange, func(i, j int) bool {
+		return descriptorProto.ReservedRange[i].GetStart() < descriptorProto.ReservedRange[j].GetStart()
+	})
+	sort.Strings(descriptorProto.ReservedName)
+	for _, nestedDescriptorProto := range descriptorProto.NestedType {
+		canonicalizeDescriptorProto(nestedDescriptorProto)
+	}
+	for _, enumDescriptorProto := range descriptorProto.EnumType {
+		canonicalizeEnumDescriptorProto(enumDescriptorProto)
+	}
+}
+
+func canonicalizeEnumDescriptorProto(enumDescriptorProto *descriptorpb.EnumDescriptorProto) {
+	canonicalizeUninterpretedOptions(enumDescriptorProto.GetOptions().GetUninterpretedOption())
+	sort.SliceStable(enumDescriptorProto.ReservedRange, func(i, j int) bool {
+		return enumDescriptorProto.ReservedRange[i].GetStart() < enumDescriptorProto.ReservedRange[j].GetStart()
+	})
+	sort.Strings(enumDescriptorProto.ReservedName)
+}
+
+// canonicalizeDependencies sorts and deduplicates the dependencies by name, and updates the
+// indexes of the public and weak dependencies accordingly.
+//
+// The imports are always sorted by the formatter, whether or not the sorter is enabled.
+func canonicalizeDependencies(fileDescriptorProto *descriptorpb.FileDescriptorProto) {
+	publicDependencies := make(map[string]struct{}, len(fileDescriptorProto.PublicDependency))
+	for _, index := range fileDescriptorProto.PublicDependency {
+		publicDependencies[fileDescriptorProto.Dependency[index]] = struct{}{}
+	}
+	weakDependencies := make(map[string]struct{}, len(fileDescriptorProto.WeakDependency))
+	for _, index := range fileDescriptorProto.WeakDependency {
+		weakDependencies[fileDescriptorProto.Dependency[index]] = struct{}{}
+	}
+	// The formatter also removes duplicate imports.
+	fileDescriptorProto.Dependency = slicesext.ToUniqueSorted(fileDescriptorProto.Dependency)
+	fileDescriptorProto.PublicDependency = nil
+	fileDescriptorProto.WeakDependency = nil
+	for i, dependency := range fileDescriptorProto.Dependency {
+		if _, ok := publicDependencies[dependency]; ok {
+			fileDescriptorProto.PublicDependency = append(fileDescriptorProto.PublicDependency, int32(i))
+		}
+		if _, ok := weakDependencies[dependency]; ok {
+			fileDescriptorProto.WeakDependency = append(fileDescriptorProto.WeakDependency, int32(i))
+		}
+	}
+}
+
+// canonicalizeUninterpretedOptions stably sorts the options by name, so that the
+// values of a repeated option stay in the same order.
+func canonicalizeUninterpretedOptions(uninterpretedOptions []*descriptorpb.UninterpretedOption) {
+	sort.SliceStable(uninterpretedOptions, func(i, j int) bool {
+		return stringForUninterpretedOptionName(uninterpretedOptions[i]) < stringForUninterpretedOptionName(uninterpretedOptions[j])
+	})
+}
+
+func stringForUninterpretedOptionName(uninterpretedOption *descriptorpb.UninterpretedOption) string {
+	nameParts := make([]string, len(uninterpretedOption.GetName()))
+	for i, namePart := range uninterpretedOption.GetName() {
+		if namePart.GetIsExtension() {
+			nameParts[i] = "(" + namePart.GetNamePart() + ")"
+			continue
+		}
+		nameParts[i] = namePart.GetNamePart()
+	}
+	return strings.Join(nameParts, ".")
+}
+
+func sortByName[T interface{ GetName() string }](values []T) {
+	sort.SliceStable(values, func(i, j int) bool {
+		return values[i].GetName() < values[j].GetName()
+	})
+}
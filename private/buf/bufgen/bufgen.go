@@ -161,6 +161,27 @@ func GenerateWithIncludeWellKnownTypes() GenerateOption {
 	}
 }
 
+// GenerateWithClean says to remove the files previously generated to the out
+// directories of all plugins that are no longer generated.
+//
+// This is in addition to the plugins that set Clean.
+func GenerateWithClean() GenerateOption {
+	return func(generateOptions *generateOptions) {
+		generateOptions.clean = true
+	}
+}
+
+// GenerateWithPartialInput says that the image is restricted to only part of the
+// input, such as with paths or types.
+//
+// The files previously generated to the out directories that are cleaned are then
+// not removed, as they may still be generated from the rest of the input.
+func GenerateWithPartialInput() GenerateOption {
+	return func(generateOptions *generateOptions) {
+		generateOptions.partialInput = true
+	}
+}
+
 // GenerateWithWASMEnabled says to enable WASM support.
 func GenerateWithWASMEnabled() GenerateOption {
 	return func(generateOptions *generateOptions) {
@@ -196,6 +217,19 @@ type PluginConfig struct {
 	Strategy Strategy
 	// Optional
 	ProtocPath string
+	// Optional, restricts the files generated by this plugin to these paths
+	Paths []string
+	// Optional, excludes these paths from the files generated by this plugin
+	ExcludePaths []string
+	// Optional, restricts the image given to this plugin to these types
+	Types []string
+	// Optional, includes imports in the files generated by this plugin
+	IncludeImports bool
+	// Optional, includes well-known types in the files generated by this plugin,
+	// requires IncludeImports
+	IncludeWKT bool
+	// Optional, removes the files previously generated to Out that are no longer generated
+	Clean bool
 }
 
 // PluginName returns this PluginConfig's plugin name.
@@ -355,15 +389,21 @@ type ExternalConfigV1 struct {
 
 // ExternalPluginConfigV1 is an external plugin configuration.
 type ExternalPluginConfigV1 struct {
-	Plugin     string      `json:"plugin,omitempty" yaml:"plugin,omitempty"`
-	Revision   int         `json:"revision,omitempty" yaml:"revision,omitempty"`
-	Name       string      `json:"name,omitempty" yaml:"name,omitempty"`
-	Remote     string      `json:"remote,omitempty" yaml:"remote,omitempty"`
-	Out        string      `json:"out,omitempty" yaml:"out,omitempty"`
-	Opt        interface{} `json:"opt,omitempty" yaml:"opt,omitempty"`
-	Path       interface{} `json:"path,omitempty" yaml:"path,omitempty"`
-	ProtocPath string      `json:"protoc_path,omitempty" yaml:"protoc_path,omitempty"`
-	Strategy   string      `json:"strategy,omitempty" yaml:"strategy,omitempty"`
+	Plugin         string      `json:"plugin,omitempty" yaml:"plugin,omitempty"`
+	Revision       int         `json:"revision,omitempty" yaml:"revision,omitempty"`
+	Name           string      `json:"name,omitempty" yaml:"name,omitempty"`
+	Remote         string      `json:"remote,omitempty" yaml:"remote,omitempty"`
+	Out            string      `json:"out,omitempty" yaml:"out,omitempty"`
+	Opt            interface{} `json:"opt,omitempty" yaml:"opt,omitempty"`
+	Path           interface{} `json:"path,omitempty" yaml:"path,omitempty"`
+	ProtocPath     string      `json:"protoc_path,omitempty" yaml:"protoc_path,omitempty"`
+	Strategy       string      `json:"strategy,omitempty" yaml:"strategy,omitempty"`
+	Paths          []string    `json:"paths,omitempty" yaml:"paths,omitempty"`
+	ExcludePaths   []string    `json:"exclude_paths,omitempty" yaml:"exclude_paths,omitempty"`
+	Types          []string    `json:"types,omitempty" yaml:"types,omitempty"`
+	IncludeImports bool        `json:"include_imports,omitempty" yaml:"include_imports,omitempty"`
+	IncludeWKT     bool        `json:"include_wkt,omitempty" yaml:"include_wkt,omitempty"`
+	Clean          bool        `json:"clean,omitempty" yaml:"clean,omitempty"`
 }
 
 // ExternalManagedConfigV1 is an external managed mode configuration.
@@ -26,6 +26,7 @@ import (
 	"github.com/bufbuild/buf/private/bufpkg/bufremoteplugin"
 	"github.com/bufbuild/buf/private/pkg/encoding"
 	"github.com/bufbuild/buf/private/pkg/normalpath"
+	"github.com/bufbuild/buf/private/pkg/slicesext"
 	"github.com/bufbuild/buf/private/pkg/storage"
 	"go.uber.org/zap"
 	"google.golang.org/protobuf/types/descriptorpb"
@@ -147,16 +148,30 @@ func newConfigV1(logger *zap.Logger, externalConfig ExternalConfigV1, id string)
 		if err != nil {
 			return nil, err
 		}
+		paths, err := normalizeAndValidatePluginPaths(plugin.Paths, "paths")
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", id, err)
+		}
+		excludePaths, err := normalizeAndValidatePluginPaths(plugin.ExcludePaths, "exclude_paths")
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", id, err)
+		}
 		pluginConfig := &PluginConfig{
-			Plugin:     plugin.Plugin,
-			Revision:   plugin.Revision,
-			Name:       plugin.Name,
-			Remote:     plugin.Remote,
-			Out:        plugin.Out,
-			Opt:        opt,
-			Path:       path,
-			ProtocPath: plugin.ProtocPath,
-			Strategy:   strategy,
+			Plugin:         plugin.Plugin,
+			Revision:       plugin.Revision,
+			Name:           plugin.Name,
+			Remote:         plugin.Remote,
+			Out:            plugin.Out,
+			Opt:            opt,
+			Path:           path,
+			ProtocPath:     plugin.ProtocPath,
+			Strategy:       strategy,
+			Paths:          paths,
+			ExcludePaths:   excludePaths,
+			Types:          plugin.Types,
+			IncludeImports: plugin.IncludeImports,
+			IncludeWKT:     plugin.IncludeWKT,
+			Clean:          plugin.Clean,
 		}
 		if pluginConfig.IsRemote() {
 			// Always use StrategyAll for remote plugins
@@ -195,6 +210,12 @@ func validateExternalConfigV1(externalConfig ExternalConfigV1, id string) error
 		if plugin.Out == "" {
 			return fmt.Errorf("%s: plugin %s out is required", id, pluginIdentifier)
 		}
+		if plugin.IncludeWKT && !plugin.IncludeImports {
+			return fmt.Errorf("%s: plugin %s cannot set include_wkt without include_imports", id, pluginIdentifier)
+		}
+		if err := checkPathsAndExcludePathsDisjoint(plugin.Paths, plugin.ExcludePaths); err != nil {
+			return fmt.Errorf("%s: plugin %s %w", id, pluginIdentifier, err)
+		}
 		switch {
 		case plugin.Plugin != "":
 			if bufpluginref.IsPluginReferenceOrIdentity(pluginIdentifier) {
@@ -232,6 +253,40 @@ func validateExternalConfigV1(externalConfig ExternalConfigV1, id string) error
 	return nil
 }
 
+// checkPathsAndExcludePathsDisjoint checks that no path is set in both paths and exclude_paths.
+func checkPathsAndExcludePathsDisjoint(paths []string, excludePaths []string) error {
+	excludePathMap := make(map[string]struct{}, len(excludePaths))
+	for _, excludePath := range excludePaths {
+		excludePathMap[normalpath.Normalize(excludePath)] = struct{}{}
+	}
+	for _, path := range paths {
+		if _, ok := excludePathMap[normalpath.Normalize(path)]; ok {
+			return fmt.Errorf("cannot set the same path for both paths and exclude_paths: %s", path)
+		}
+	}
+	return nil
+}
+
+// normalizeAndValidatePluginPaths normalizes and validates the paths or exclude_paths
+// of a plugin, and returns them sorted and deduplicated.
+func normalizeAndValidatePluginPaths(paths []string, fieldName string) ([]string, error) {
+	if len(paths) == 0 {
+		return nil, nil
+	}
+	normalizedPaths := make([]string, 0, len(paths))
+	for _, path := range paths {
+		normalizedPath, err := normalpath.NormalizeAndValidate(path)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value in %s: %w", fieldName, err)
+		}
+		if normalizedPath == "." {
+			return nil, fmt.Errorf("invalid value in %s: %q is not a valid path value", fieldName, path)
+		}
+		normalizedPaths = append(normalizedPaths, normalizedPath)
+	}
+	return slicesext.ToUniqueSorted(normalizedPaths), nil
+}
+
 func checkPathAndStrategyUnset(id string, plugin ExternalPluginConfigV1, pluginIdentifier string) error {
 	if plugin.Path != nil {
 		return fmt.Errorf("%s: remote plugin %s cannot specify a path", id, pluginIdentifier)
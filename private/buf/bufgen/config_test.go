@@ -369,6 +369,36 @@ func TestReadConfigV1(t *testing.T) {
 			},
 		},
 	}
+	successConfig10 := &Config{
+		PluginConfigs: []*PluginConfig{
+			{
+				Plugin:         "go",
+				Out:            "gen/go",
+				Strategy:       StrategyDirectory,
+				Paths:          []string{"proto/bar", "proto/foo"},
+				ExcludePaths:   []string{"proto/foo/internal"},
+				Types:          []string{"foo.v1.User"},
+				IncludeImports: true,
+				IncludeWKT:     true,
+			},
+			{
+				Plugin:   "someremote.com/owner/myplugin",
+				Out:      "gen/remote",
+				Strategy: StrategyAll,
+				Paths:    []string{"proto/bar"},
+			},
+		},
+	}
+	successConfig11 := &Config{
+		PluginConfigs: []*PluginConfig{
+			{
+				Plugin:   "go",
+				Out:      "gen/go",
+				Strategy: StrategyDirectory,
+				Clean:    true,
+			},
+		},
+	}
 
 	ctx := context.Background()
 	nopLogger := zap.NewNop()
@@ -588,6 +618,23 @@ func TestReadConfigV1(t *testing.T) {
 	config, err = ReadConfig(ctx, nopLogger, provider, readBucket, ReadConfigWithOverride(string(data)))
 	require.NoError(t, err)
 	assertConfigsWithEqualOptimizeFor(t, successConfig9, config)
+	config, err = ReadConfig(ctx, nopLogger, provider, readBucket, ReadConfigWithOverride(filepath.Join("testdata", "v1", "gen_success10.yaml")))
+	require.NoError(t, err)
+	require.Equal(t, successConfig10, config)
+	config, err = ReadConfig(ctx, nopLogger, provider, readBucket, ReadConfigWithOverride(filepath.Join("testdata", "v1", "gen_success10.json")))
+	require.NoError(t, err)
+	require.Equal(t, successConfig10, config)
+	data, err = os.ReadFile(filepath.Join("testdata", "v1", "gen_success10.json"))
+	require.NoError(t, err)
+	config, err = ReadConfig(ctx, nopLogger, provider, readBucket, ReadConfigWithOverride(string(data)))
+	require.NoError(t, err)
+	require.Equal(t, successConfig10, config)
+	config, err = ReadConfig(ctx, nopLogger, provider, readBucket, ReadConfigWithOverride(filepath.Join("testdata", "v1", "gen_success11.yaml")))
+	require.NoError(t, err)
+	require.Equal(t, successConfig11, config)
+	config, err = ReadConfig(ctx, nopLogger, provider, readBucket, ReadConfigWithOverride(filepath.Join("testdata", "v1", "gen_success11.json")))
+	require.NoError(t, err)
+	require.Equal(t, successConfig11, config)
 
 	testReadConfigError(t, nopLogger, provider, readBucket, filepath.Join("testdata", "v1", "gen_error1.yaml"))
 	testReadConfigError(t, nopLogger, provider, readBucket, filepath.Join("testdata", "v1", "gen_error2.yaml"))
@@ -605,6 +652,9 @@ func TestReadConfigV1(t *testing.T) {
 	testReadConfigError(t, nopLogger, provider, readBucket, filepath.Join("testdata", "v1", "gen_error14.yaml"))
 	testReadConfigError(t, nopLogger, provider, readBucket, filepath.Join("testdata", "v1", "gen_error15.yaml"))
 	assertContainsReadConfigError(t, nopLogger, provider, readBucket, filepath.Join("testdata", "v1", "gen_error15.yaml"), "the remote field no longer works")
+	assertContainsReadConfigError(t, nopLogger, provider, readBucket, filepath.Join("testdata", "v1", "gen_error16.yaml"), "cannot set include_wkt without include_imports")
+	assertContainsReadConfigError(t, nopLogger, provider, readBucket, filepath.Join("testdata", "v1", "gen_error17.yaml"), "cannot set the same path for both paths and exclude_paths")
+	assertContainsReadConfigError(t, nopLogger, provider, readBucket, filepath.Join("testdata", "v1", "gen_error18.yaml"), "invalid value in paths")
 
 	successConfig = &Config{
 		PluginConfigs: []*PluginConfig{
@@ -23,6 +23,7 @@ import (
 	connect "connectrpc.com/connect"
 	"github.com/bufbuild/buf/private/bufpkg/bufimage"
 	"github.com/bufbuild/buf/private/bufpkg/bufimage/bufimagemodify"
+	"github.com/bufbuild/buf/private/bufpkg/bufimage/bufimageutil"
 	"github.com/bufbuild/buf/private/bufpkg/bufmodule/bufmoduleref"
 	"github.com/bufbuild/buf/private/bufpkg/bufplugin"
 	"github.com/bufbuild/buf/private/bufpkg/bufplugin/bufpluginref"
@@ -35,6 +36,8 @@ import (
 	"github.com/bufbuild/buf/private/pkg/app/appproto/appprotoos"
 	"github.com/bufbuild/buf/private/pkg/command"
 	"github.com/bufbuild/buf/private/pkg/connectclient"
+	"github.com/bufbuild/buf/private/pkg/normalpath"
+	"github.com/bufbuild/buf/private/pkg/slicesext"
 	"github.com/bufbuild/buf/private/pkg/storage/storageos"
 	"github.com/bufbuild/buf/private/pkg/thread"
 	"go.uber.org/multierr"
@@ -101,6 +104,8 @@ func (g *generator) Generate(
 		generateOptions.includeImports,
 		generateOptions.includeWellKnownTypes,
 		generateOptions.wasmEnabled,
+		generateOptions.clean,
+		generateOptions.partialInput,
 	)
 }
 
@@ -113,6 +118,8 @@ func (g *generator) generate(
 	includeImports bool,
 	includeWellKnownTypes bool,
 	wasmEnabled bool,
+	clean bool,
+	partialInput bool,
 ) error {
 	if err := modifyImage(ctx, g.logger, config, image); err != nil {
 		return err
@@ -135,6 +142,9 @@ func (g *generator) generate(
 		g.storageosProvider,
 		appprotoos.ResponseWriterWithCreateOutDirIfNotExists(),
 	)
+	cleanOuts := make(map[string]struct{})
+	partialOuts := make(map[string]struct{})
+	var orderedPartialOuts []string
 	for i, pluginConfig := range config.PluginConfigs {
 		out := pluginConfig.Out
 		if baseOutDirPath != "" && baseOutDirPath != "." {
@@ -144,14 +154,36 @@ func (g *generator) generate(
 		if response == nil {
 			return fmt.Errorf("failed to get plugin response for %s", pluginConfig.PluginName())
 		}
+		var addResponseOptions []appprotoos.AddResponseOption
+		if clean || pluginConfig.Clean {
+			addResponseOptions = append(addResponseOptions, appprotoos.AddResponseWithClean())
+			cleanOuts[out] = struct{}{}
+		}
+		if partialInput || hasPluginInputFilter(pluginConfig) {
+			addResponseOptions = append(addResponseOptions, appprotoos.AddResponseWithPartial())
+			if _, ok := partialOuts[out]; !ok {
+				partialOuts[out] = struct{}{}
+				orderedPartialOuts = append(orderedPartialOuts, out)
+			}
+		}
 		if err := responseWriter.AddResponse(
 			ctx,
 			response,
 			out,
+			addResponseOptions...,
 		); err != nil {
 			return fmt.Errorf("plugin %s: %v", pluginConfig.PluginName(), err)
 		}
 	}
+	for _, out := range orderedPartialOuts {
+		if _, ok := cleanOuts[out]; ok {
+			g.logger.Sugar().Warnf(
+				"Not removing the files previously generated to %s that are no longer generated, as it was generated from only part of the input. "+
+					"Generate without --path, --exclude-path, --type, or the paths, exclude_paths, or types of plugins to remove them.",
+				out,
+			)
+		}
+	}
 	if err := responseWriter.Close(); err != nil {
 		return err
 	}
@@ -176,24 +208,88 @@ func (g *generator) execPlugins(
 	for i, pluginConfig := range config.PluginConfigs {
 		index := i
 		currentPluginConfig := pluginConfig
+		if !hasPluginInputConfig(currentPluginConfig) {
+			remote := currentPluginConfig.GetRemoteHostname()
+			if remote != "" {
+				remotePluginConfigTable[remote] = append(
+					remotePluginConfigTable[remote],
+					&remotePluginExecArgs{
+						Index:        index,
+						PluginConfig: currentPluginConfig,
+					},
+				)
+			} else {
+				jobs = append(jobs, func(ctx context.Context) error {
+					response, err := g.execLocalPlugin(
+						ctx,
+						container,
+						imageProvider,
+						currentPluginConfig,
+						includeImports,
+						includeWellKnownTypes,
+						wasmEnabled,
+					)
+					if err != nil {
+						return err
+					}
+					responses[index] = response
+					return nil
+				})
+			}
+			continue
+		}
+		// The plugin has its own input configuration, so it is given its own image.
+		pluginImage, err := imageForPluginConfig(image, currentPluginConfig)
+		if err != nil {
+			return nil, fmt.Errorf("plugin %s: %w", currentPluginConfig.PluginName(), err)
+		}
+		if pluginImage == nil {
+			g.logger.Debug(
+				"no files to generate for plugin",
+				zap.String("plugin", currentPluginConfig.PluginName()),
+			)
+			responses[index] = &pluginpb.CodeGeneratorResponse{}
+			continue
+		}
+		pluginIncludeImports := includeImports || currentPluginConfig.IncludeImports
+		pluginIncludeWellKnownTypes := includeWellKnownTypes || currentPluginConfig.IncludeWKT
 		remote := currentPluginConfig.GetRemoteHostname()
 		if remote != "" {
-			remotePluginConfigTable[remote] = append(
-				remotePluginConfigTable[remote],
-				&remotePluginExecArgs{
-					Index:        index,
-					PluginConfig: currentPluginConfig,
-				},
-			)
+			if currentPluginConfig.Remote != "" {
+				return nil, fmt.Errorf("invalid plugin reference: %s", currentPluginConfig.Remote)
+			}
+			jobs = append(jobs, func(ctx context.Context) error {
+				results, err := g.execRemotePluginsV2(
+					ctx,
+					container,
+					pluginImage,
+					remote,
+					[]*remotePluginExecArgs{
+						{
+							Index:        index,
+							PluginConfig: currentPluginConfig,
+						},
+					},
+					pluginIncludeImports,
+					pluginIncludeWellKnownTypes,
+				)
+				if err != nil {
+					return err
+				}
+				for _, result := range results {
+					responses[result.Index] = result.CodeGeneratorResponse
+				}
+				return nil
+			})
 		} else {
 			jobs = append(jobs, func(ctx context.Context) error {
 				response, err := g.execLocalPlugin(
 					ctx,
 					container,
-					imageProvider,
+					newImageProvider(pluginImage),
 					currentPluginConfig,
-					includeImports,
-					includeWellKnownTypes,
+					pluginIncludeImports,
+					pluginIncludeWellKnownTypes,
 					wasmEnabled,
 				)
 				if err != nil {
@@ -267,6 +363,61 @@ func (g *generator) execPlugins(
 	return responses, nil
 }
 
+// hasPluginInputConfig returns true if the PluginConfig changes the input
+// given to the plugin.
+func hasPluginInputConfig(pluginConfig *PluginConfig) bool {
+	return hasPluginInputFilter(pluginConfig) ||
+		pluginConfig.IncludeImports ||
+		pluginConfig.IncludeWKT
+}
+
+// hasPluginInputFilter returns true if the PluginConfig restricts the input
+// given to the plugin to part of the image.
+func hasPluginInputFilter(pluginConfig *PluginConfig) bool {
+	return len(pluginConfig.Paths) > 0 ||
+		len(pluginConfig.ExcludePaths) > 0 ||
+		len(pluginConfig.Types) > 0
+}
+
+// imageForPluginConfig returns the image filtered by the paths, exclude paths,
+// and types of the PluginConfig.
+//
+// Only the non-import files of the image are matched against the paths. Returns
+// nil if none of them match, in which case the plugin has nothing to generate.
+func imageForPluginConfig(image bufimage.Image, pluginConfig *PluginConfig) (bufimage.Image, error) {
+	if len(pluginConfig.Paths) > 0 || len(pluginConfig.ExcludePaths) > 0 {
+		pathMap := slicesext.ToStructMap(pluginConfig.Paths)
+		excludePathMap := slicesext.ToStructMap(pluginConfig.ExcludePaths)
+		var targetPaths []string
+		for _, imageFile := range image.Files() {
+			if imageFile.IsImport() {
+				continue
+			}
+			if len(pathMap) > 0 && !normalpath.MapHasEqualOrContainingPath(pathMap, imageFile.Path(), normalpath.Relative) {
+				continue
+			}
+			if normalpath.MapHasEqualOrContainingPath(excludePathMap, imageFile.Path(), normalpath.Relative) {
+				continue
+			}
+			targetPaths = append(targetPaths, imageFile.Path())
+		}
+		if len(targetPaths) == 0 {
+			return nil, nil
+		}
+		// The target paths are the paths of the files themselves, so that
+		// imports within the paths are not turned into targets.
+		var err error
+		image, err = bufimage.ImageWithOnlyPaths(image, targetPaths, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(pluginConfig.Types) > 0 {
+		return bufimageutil.ImageFilteredByTypes(image, pluginConfig.Types...)
+	}
+	return image, nil
+}
+
 func (g *generator) execLocalPlugin(
 	ctx context.Context,
 	container app.EnvStdioContainer,
@@ -618,6 +769,8 @@ type generateOptions struct {
 	includeImports        bool
 	includeWellKnownTypes bool
 	wasmEnabled           bool
+	clean                 bool
+	partialInput          bool
 }
 
 func newGenerateOptions() *generateOptions {
@@ -0,0 +1,103 @@
+// Copyright 2020-2023 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bufgen
+
+import (
+	"testing"
+
+	"github.com/bufbuild/buf/private/bufpkg/bufimage"
+	"github.com/bufbuild/buf/private/bufpkg/bufimage/bufimagetesting"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestImageForPluginConfig(t *testing.T) {
+	t.Parallel()
+	protoImageFileB := bufimagetesting.NewProtoImageFile(t, "b/v1/b.proto")
+	protoImageFileB.Package = proto.String("b.v1")
+	protoImageFileB.MessageType = []*descriptorpb.DescriptorProto{
+		{Name: proto.String("B")},
+		{Name: proto.String("C")},
+	}
+	image, err := bufimage.NewImage(
+		[]bufimage.ImageFile{
+			bufimagetesting.NewImageFile(t, bufimagetesting.NewProtoImageFileIsImport(t, "a/v1/dep/dep.proto"), nil, "", "", true, false, nil),
+			bufimagetesting.NewImageFile(t, bufimagetesting.NewProtoImageFile(t, "a/v1/a.proto", "a/v1/dep/dep.proto"), nil, "", "", false, false, nil),
+			bufimagetesting.NewImageFile(t, bufimagetesting.NewProtoImageFile(t, "a/v1/internal/internal.proto"), nil, "", "", false, false, nil),
+			bufimagetesting.NewImageFile(t, protoImageFileB, nil, "", "", false, false, nil),
+		},
+	)
+	require.NoError(t, err)
+	assertImageFiles := func(pluginConfig *PluginConfig, expectedPaths []string, expectedImportPaths []string) {
+		t.Helper()
+		pluginImage, err := imageForPluginConfig(image, pluginConfig)
+		require.NoError(t, err)
+		require.NotNil(t, pluginImage)
+		var paths []string
+		var importPaths []string
+		for _, imageFile := range pluginImage.Files() {
+			if imageFile.IsImport() {
+				importPaths = append(importPaths, imageFile.Path())
+			} else {
+				paths = append(paths, imageFile.Path())
+			}
+		}
+		assert.Equal(t, expectedPaths, paths)
+		assert.Equal(t, expectedImportPaths, importPaths)
+	}
+	assertImageFiles(
+		&PluginConfig{},
+		[]string{"a/v1/a.proto", "a/v1/internal/internal.proto", "b/v1/b.proto"},
+		[]string{"a/v1/dep/dep.proto"},
+	)
+	// Imports within the paths are not generated.
+	assertImageFiles(
+		&PluginConfig{Paths: []string{"a/v1"}},
+		[]string{"a/v1/a.proto", "a/v1/internal/internal.proto"},
+		[]string{"a/v1/dep/dep.proto"},
+	)
+	assertImageFiles(
+		&PluginConfig{Paths: []string{"a/v1"}, ExcludePaths: []string{"a/v1/internal"}},
+		[]string{"a/v1/a.proto"},
+		[]string{"a/v1/dep/dep.proto"},
+	)
+	assertImageFiles(
+		&PluginConfig{ExcludePaths: []string{"a"}},
+		[]string{"b/v1/b.proto"},
+		nil,
+	)
+	assertImageFiles(
+		&PluginConfig{Types: []string{"b.v1.B"}},
+		[]string{"b/v1/b.proto"},
+		nil,
+	)
+	pluginImage, err := imageForPluginConfig(image, &PluginConfig{Types: []string{"b.v1.B"}})
+	require.NoError(t, err)
+	require.Len(t, pluginImage.Files(), 1)
+	messageTypes := pluginImage.Files()[0].FileDescriptorProto().GetMessageType()
+	require.Len(t, messageTypes, 1)
+	assert.Equal(t, "B", messageTypes[0].GetName())
+	// No files match, so there is nothing to generate.
+	pluginImage, err = imageForPluginConfig(image, &PluginConfig{Paths: []string{"c"}})
+	require.NoError(t, err)
+	assert.Nil(t, pluginImage)
+	pluginImage, err = imageForPluginConfig(image, &PluginConfig{Paths: []string{"a/v1/dep"}})
+	require.NoError(t, err)
+	assert.Nil(t, pluginImage)
+	_, err = imageForPluginConfig(image, &PluginConfig{Types: []string{"b.v1.D"}})
+	require.Error(t, err)
+}
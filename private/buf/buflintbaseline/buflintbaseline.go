@@ -0,0 +1,350 @@
+// Copyright 2020-2023 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package buflintbaseline records existing lint violations in a baseline, so
+// that only new violations are reported.
+//
+// Violations are keyed by rule ID, file path, and the fully-qualified name of
+// the element that they are on, as opposed to line numbers, so that the
+// baseline stays valid when unrelated parts of a file are edited.
+package buflintbaseline
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/bufbuild/buf/private/bufpkg/bufanalysis"
+	"github.com/bufbuild/buf/private/bufpkg/bufimage"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+const (
+	// V1Version is the only supported version of the baseline format.
+	V1Version = "v1"
+
+	// https://github.com/protocolbuffers/protobuf/blob/main/src/google/protobuf/descriptor.proto
+	messageTypesFieldNumberInFile   = 4
+	enumTypesFieldNumberInFile      = 5
+	servicesFieldNumberInFile       = 6
+	extensionsFieldNumberInFile     = 7
+	fieldsFieldNumberInMessage      = 2
+	nestedTypesFieldNumberInMessage = 3
+	enumTypesFieldNumberInMessage   = 4
+	extensionsFieldNumberInMessage  = 6
+	oneofsFieldNumberInMessage      = 8
+	valuesFieldNumberInEnum         = 2
+	methodsFieldNumberInService     = 2
+)
+
+// Baseline is a set of lint violations that are not reported.
+type Baseline struct {
+	// Version is the version of the baseline format.
+	Version string `json:"version"`
+	// Entries are the entries of the baseline.
+	//
+	// Sorted by path, element, and then rule.
+	Entries []*Entry `json:"entries"`
+}
+
+// Entry is a baseline entry.
+type Entry struct {
+	// Rule is the ID of the rule that was violated.
+	Rule string `json:"rule"`
+	// Path is the path of the file within the module.
+	Path string `json:"path"`
+	// Element is the fully-qualified name of the element that the violations are on.
+	//
+	// Empty if the violations are not on a named element, such as violations on
+	// the package declaration or imports of a file.
+	Element string `json:"element,omitempty"`
+	// Count is the number of violations of the rule on the element.
+	Count int `json:"count"`
+}
+
+// NewBaseline returns a new Baseline that contains the FileAnnotations.
+//
+// The FileAnnotations must be the result of linting the Image.
+func NewBaseline(image bufimage.Image, fileAnnotations []bufanalysis.FileAnnotation) *Baseline {
+	keyToEntry := make(map[entryKey]*Entry)
+	for _, fileAnnotation := range fileAnnotations {
+		key := newEntryKey(image, fileAnnotation)
+		entry, ok := keyToEntry[key]
+		if !ok {
+			entry = &Entry{
+				Rule:    key.rule,
+				Path:    key.path,
+				Element: key.element,
+			}
+			keyToEntry[key] = entry
+		}
+		entry.Count++
+	}
+	entries := make([]*Entry, 0, len(keyToEntry))
+	for _, entry := range keyToEntry {
+		entries = append(entries, entry)
+	}
+	sortEntries(entries)
+	return &Baseline{
+		Version: V1Version,
+		Entries: entries,
+	}
+}
+
+// ReadBaseline reads a Baseline in JSON form.
+func ReadBaseline(reader io.Reader) (*Baseline, error) {
+	baseline := &Baseline{}
+	if err := json.NewDecoder(reader).Decode(baseline); err != nil {
+		return nil, fmt.Errorf("could not read baseline: %w", err)
+	}
+	if baseline.Version != V1Version {
+		return nil, fmt.Errorf("unknown baseline version: %q", baseline.Version)
+	}
+	return baseline, nil
+}
+
+// WriteBaseline writes the Baseline in JSON form.
+func WriteBaseline(writer io.Writer, baseline *Baseline) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(baseline)
+}
+
+// Filter filters the FileAnnotations that are in the Baseline.
+//
+// Returns the FileAnnotations that are not in the Baseline, and the Entries of the
+// Baseline that have been fixed. The Count of a returned Entry is the number of
+// violations that have been fixed. Only the Entries of the non-import files of the
+// Image are returned, as the other files were not linted, for example if the Image
+// was built with only some of the paths of the module.
+//
+// If there are more violations for an Entry than the Entry's Count, the violations
+// that are sorted last are returned, as these are most likely the new violations.
+//
+// The FileAnnotations must be the result of linting the Image.
+func Filter(
+	image bufimage.Image,
+	baseline *Baseline,
+	fileAnnotations []bufanalysis.FileAnnotation,
+) ([]bufanalysis.FileAnnotation, []*Entry) {
+	keyToRemainingCount := make(map[entryKey]int, len(baseline.Entries))
+	for _, entry := range baseline.Entries {
+		keyToRemainingCount[entryKey{rule: entry.Rule, path: entry.Path, element: entry.Element}] += entry.Count
+	}
+	var newFileAnnotations []bufanalysis.FileAnnotation
+	for _, fileAnnotation := range bufanalysis.DeduplicateAndSortFileAnnotations(fileAnnotations) {
+		key := newEntryKey(image, fileAnnotation)
+		if keyToRemainingCount[key] > 0 {
+			keyToRemainingCount[key]--
+			continue
+		}
+		newFileAnnotations = append(newFileAnnotations, fileAnnotation)
+	}
+	var fixedEntries []*Entry
+	for key, remainingCount := range keyToRemainingCount {
+		if remainingCount <= 0 {
+			continue
+		}
+		if key.path != "" {
+			if imageFile := image.GetFile(key.path); imageFile == nil || imageFile.IsImport() {
+				continue
+			}
+		}
+		fixedEntries = append(
+			fixedEntries,
+			&Entry{
+				Rule:    key.rule,
+				Path:    key.path,
+				Element: key.element,
+				Count:   remainingCount,
+			},
+		)
+	}
+	sortEntries(fixedEntries)
+	return newFileAnnotations, fixedEntries
+}
+
+type entryKey struct {
+	rule    string
+	path    string
+	element string
+}
+
+func newEntryKey(image bufimage.Image, fileAnnotation bufanalysis.FileAnnotation) entryKey {
+	key := entryKey{
+		rule: fileAnnotation.Type(),
+	}
+	fileInfo := fileAnnotation.FileInfo()
+	if fileInfo == nil {
+		return key
+	}
+	key.path = fileInfo.Path()
+	if imageFile := image.GetFile(fileInfo.Path()); imageFile != nil {
+		key.element = elementForPosition(imageFile.FileDescriptorProto(), fileAnnotation.StartLine(), fileAnnotation.StartColumn())
+	}
+	return key
+}
+
+// elementForPosition returns the fully-qualified name of the most specific element
+// whose source location contains the 1-based line and column.
+//
+// Returns the empty string if the position is not within a named element.
+func elementForPosition(fileDescriptorProto *descriptorpb.FileDescriptorProto, line int, column int) string {
+	if line <= 0 {
+		return ""
+	}
+	// Source code info spans are 0-based.
+	line--
+	if column > 0 {
+		column--
+	}
+	var element string
+	var elementPathLen int
+	for _, location := range fileDescriptorProto.GetSourceCodeInfo().GetLocation() {
+		if !spanContains(location.GetSpan(), line, column) {
+			continue
+		}
+		name, pathLen := elementForPath(fileDescriptorProto, location.GetPath())
+		if pathLen > elementPathLen {
+			element = name
+			elementPathLen = pathLen
+		}
+	}
+	return element
+}
+
+// elementForPath returns the fully-qualified name of the deepest element that the
+// path is within, and the length of the path to that element.
+func elementForPath(fileDescriptorProto *descriptorpb.FileDescriptorProto, path []int32) (string, int) {
+	prefix := fileDescriptorProto.GetPackage()
+	if prefix != "" {
+		prefix += "."
+	}
+	if len(path) < 2 {
+		return "", 0
+	}
+	index := int(path[1])
+	switch path[0] {
+	case messageTypesFieldNumberInFile:
+		if index < len(fileDescriptorProto.GetMessageType()) {
+			return elementForMessagePath(fileDescriptorProto.GetMessageType()[index], prefix, path[2:], 2)
+		}
+	case enumTypesFieldNumberInFile:
+		if index < len(fileDescriptorProto.GetEnumType()) {
+			return elementForEnumPath(fileDescriptorProto.GetEnumType()[index], prefix, path[2:], 2)
+		}
+	case servicesFieldNumberInFile:
+		if index < len(fileDescriptorProto.GetService()) {
+			service := fileDescriptorProto.GetService()[index]
+			name := prefix + service.GetName()
+			if len(path) >= 4 && path[2] == methodsFieldNumberInService && int(path[3]) < len(service.GetMethod()) {
+				return name + "." + service.GetMethod()[path[3]].GetName(), 4
+			}
+			return name, 2
+		}
+	case extensionsFieldNumberInFile:
+		if index < len(fileDescriptorProto.GetExtension()) {
+			return prefix + fileDescriptorProto.GetExtension()[index].GetName(), 2
+		}
+	}
+	return "", 0
+}
+
+func elementForMessagePath(
+	message *descriptorpb.DescriptorProto,
+	prefix string,
+	path []int32,
+	pathLen int,
+) (string, int) {
+	name := prefix + message.GetName()
+	if len(path) < 2 {
+		return name, pathLen
+	}
+	index := int(path[1])
+	switch path[0] {
+	case fieldsFieldNumberInMessage:
+		if index < len(message.GetField()) {
+			return name + "." + message.GetField()[index].GetName(), pathLen + 2
+		}
+	case nestedTypesFieldNumberInMessage:
+		if index < len(message.GetNestedType()) {
+			return elementForMessagePath(message.GetNestedType()[index], name+".", path[2:], pathLen+2)
+		}
+	case enumTypesFieldNumberInMessage:
+		if index < len(message.GetEnumType()) {
+			return elementForEnumPath(message.GetEnumType()[index], name+".", path[2:], pathLen+2)
+		}
+	case extensionsFieldNumberInMessage:
+		if index < len(message.GetExtension()) {
+			return name + "." + message.GetExtension()[index].GetName(), pathLen + 2
+		}
+	case oneofsFieldNumberInMessage:
+		if index < len(message.GetOneofDecl()) {
+			return name + "." + message.GetOneofDecl()[index].GetName(), pathLen + 2
+		}
+	}
+	return name, pathLen
+}
+
+func elementForEnumPath(
+	enum *descriptorpb.EnumDescriptorProto,
+	prefix string,
+	path []int32,
+	pathLen int,
+) (string, int) {
+	name := prefix + enum.GetName()
+	if len(path) >= 2 && path[0] == valuesFieldNumberInEnum && int(path[1]) < len(enum.GetValue()) {
+		return name + "." + enum.GetValue()[path[1]].GetName(), pathLen + 2
+	}
+	return name, pathLen
+}
+
+// spanContains returns true if the span contains the 0-based line and column.
+//
+// A span is either [startLine, startColumn, endLine, endColumn] or
+// [startLine, startColumn, endColumn] if the start and end lines are the same.
+func spanContains(span []int32, line int, column int) bool {
+	var startLine, startColumn, endLine, endColumn int
+	switch len(span) {
+	case 3:
+		startLine, startColumn, endLine, endColumn = int(span[0]), int(span[1]), int(span[0]), int(span[2])
+	case 4:
+		startLine, startColumn, endLine, endColumn = int(span[0]), int(span[1]), int(span[2]), int(span[3])
+	default:
+		return false
+	}
+	if line < startLine || (line == startLine && column < startColumn) {
+		return false
+	}
+	if line > endLine || (line == endLine && column >= endColumn) {
+		return false
+	}
+	return true
+}
+
+func sortEntries(entries []*Entry) {
+	sort.Slice(
+		entries,
+		func(i int, j int) bool {
+			if entries[i].Path != entries[j].Path {
+				return entries[i].Path < entries[j].Path
+			}
+			if entries[i].Element != entries[j].Element {
+				return entries[i].Element < entries[j].Element
+			}
+			return entries[i].Rule < entries[j].Rule
+		},
+	)
+}
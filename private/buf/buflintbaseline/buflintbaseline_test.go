@@ -0,0 +1,122 @@
+// Copyright 2020-2023 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package buflintbaseline
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bufbuild/buf/private/bufpkg/bufanalysis"
+	"github.com/bufbuild/buf/private/bufpkg/bufcheck/buflint"
+	"github.com/bufbuild/buf/private/bufpkg/bufconfig"
+	"github.com/bufbuild/buf/private/bufpkg/bufimage"
+	"github.com/bufbuild/buf/private/bufpkg/bufimage/bufimagebuild"
+	"github.com/bufbuild/buf/private/bufpkg/bufmodule"
+	"github.com/bufbuild/buf/private/bufpkg/bufmodule/bufmodulebuild"
+	"github.com/bufbuild/buf/private/pkg/command"
+	"github.com/bufbuild/buf/private/pkg/storage/storageos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestBaseline(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	previousImage, previousFileAnnotations := testLint(t, ctx, filepath.Join("testdata", "previous"))
+	baseline := NewBaseline(previousImage, previousFileAnnotations)
+	assert.Equal(
+		t,
+		[]*Entry{
+			{Rule: "PACKAGE_VERSION_SUFFIX", Path: "acme/a.proto", Count: 1},
+			{Rule: "ENUM_VALUE_PREFIX", Path: "acme/a.proto", Element: "acme.Color.RED", Count: 1},
+			{Rule: "ENUM_ZERO_VALUE_SUFFIX", Path: "acme/a.proto", Element: "acme.Color.RED", Count: 1},
+			{Rule: "FIELD_LOWER_SNAKE_CASE", Path: "acme/a.proto", Element: "acme.Foo.Nested.nestedName", Count: 1},
+			{Rule: "FIELD_LOWER_SNAKE_CASE", Path: "acme/a.proto", Element: "acme.Foo.barBaz", Count: 1},
+			{Rule: "FIELD_LOWER_SNAKE_CASE", Path: "acme/a.proto", Element: "acme.Foo.fooBar", Count: 1},
+		},
+		baseline.Entries,
+	)
+
+	buffer := bytes.NewBuffer(nil)
+	require.NoError(t, WriteBaseline(buffer, baseline))
+	readBaseline, err := ReadBaseline(buffer)
+	require.NoError(t, err)
+	assert.Equal(t, baseline, readBaseline)
+
+	// All previous violations are in the baseline.
+	newFileAnnotations, fixedEntries := Filter(previousImage, baseline, previousFileAnnotations)
+	assert.Empty(t, newFileAnnotations)
+	assert.Empty(t, fixedEntries)
+
+	// The violations in the baseline are matched even though their lines moved.
+	image, fileAnnotations := testLint(t, ctx, filepath.Join("testdata", "current"))
+	newFileAnnotations, fixedEntries = Filter(image, baseline, fileAnnotations)
+	require.Len(t, newFileAnnotations, 1)
+	assert.Equal(t, "FIELD_LOWER_SNAKE_CASE", newFileAnnotations[0].Type())
+	assert.Equal(t, 7, newFileAnnotations[0].StartLine())
+	assert.Equal(
+		t,
+		[]*Entry{
+			{Rule: "FIELD_LOWER_SNAKE_CASE", Path: "acme/a.proto", Element: "acme.Foo.barBaz", Count: 1},
+		},
+		fixedEntries,
+	)
+
+	// The violations of files that are not in the image are not fixed, as these
+	// files were not linted, for example if only some paths were linted.
+	baseline.Entries = append(
+		baseline.Entries,
+		&Entry{Rule: "FIELD_LOWER_SNAKE_CASE", Path: "acme/b.proto", Element: "acme.Bar.bazQux", Count: 1},
+	)
+	_, fixedEntries = Filter(image, baseline, fileAnnotations)
+	assert.Equal(
+		t,
+		[]*Entry{
+			{Rule: "FIELD_LOWER_SNAKE_CASE", Path: "acme/a.proto", Element: "acme.Foo.barBaz", Count: 1},
+		},
+		fixedEntries,
+	)
+}
+
+func TestReadBaselineUnknownVersion(t *testing.T) {
+	t.Parallel()
+	_, err := ReadBaseline(bytes.NewBufferString(`{"version":"v2","entries":[]}`))
+	assert.Error(t, err)
+}
+
+func testLint(
+	t *testing.T,
+	ctx context.Context,
+	dirPath string,
+) (bufimage.Image, []bufanalysis.FileAnnotation) {
+	readBucket, err := storageos.NewProvider().NewReadWriteBucket(dirPath)
+	require.NoError(t, err)
+	config, err := bufconfig.GetConfigForBucket(ctx, readBucket)
+	require.NoError(t, err)
+	module, err := bufmodulebuild.NewModuleBucketBuilder().BuildForBucket(ctx, readBucket, config.Build)
+	require.NoError(t, err)
+	image, buildFileAnnotations, err := bufimagebuild.NewBuilder(zap.NewNop(), bufmodule.NewNopModuleReader()).Build(ctx, module)
+	require.NoError(t, err)
+	require.Empty(t, buildFileAnnotations)
+	fileAnnotations, _, err := buflint.NewHandler(zap.NewNop(), command.NewRunner()).Check(ctx, config.Lint, image)
+	require.NoError(t, err)
+	return image, fileAnnotations
+}
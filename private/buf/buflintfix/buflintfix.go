@@ -0,0 +1,86 @@
+// Copyright 2020-2023 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package buflintfix applies mechanical fixes for lint violations.
+//
+// Fixes are applied to the source of the files, and the fixed files are
+// rewritten with the bufformat printer.
+package buflintfix
+
+import (
+	"context"
+
+	"github.com/bufbuild/buf/private/bufpkg/bufanalysis"
+	"github.com/bufbuild/buf/private/bufpkg/bufcheck/buflint/buflintconfig"
+	"github.com/bufbuild/buf/private/bufpkg/bufformatconfig"
+	"github.com/bufbuild/buf/private/bufpkg/bufimage"
+	"github.com/bufbuild/buf/private/pkg/storage"
+)
+
+// FixableRuleIDs are the IDs of the lint rules that have a mechanical fix.
+var FixableRuleIDs = []string{
+	"COMMENT_ENUM",
+	"COMMENT_ENUM_VALUE",
+	"COMMENT_FIELD",
+	"COMMENT_MESSAGE",
+	"COMMENT_ONEOF",
+	"COMMENT_RPC",
+	"COMMENT_SERVICE",
+	"ENUM_VALUE_PREFIX",
+	"ENUM_ZERO_VALUE_SUFFIX",
+	"FIELD_LOWER_SNAKE_CASE",
+	"IMPORT_USED",
+	"RPC_REQUEST_STANDARD_NAME",
+	"RPC_RESPONSE_STANDARD_NAME",
+	"SERVICE_SUFFIX",
+}
+
+// Result is the result of fixing lint violations.
+type Result struct {
+	// ReadBucket contains the files that were rewritten.
+	//
+	// Files are at their path within the Image, and have their external path set.
+	ReadBucket storage.ReadBucket
+	// FixedFileAnnotations are the FileAnnotations that were fixed.
+	FixedFileAnnotations []bufanalysis.FileAnnotation
+	// UnfixedFileAnnotations are the FileAnnotations that were not fixed.
+	//
+	// These either have no mechanical fix, or could not be fixed safely, and
+	// need to be fixed by a human.
+	UnfixedFileAnnotations []bufanalysis.FileAnnotation
+}
+
+// Fix fixes the FileAnnotations produced by linting the Image with the Config.
+//
+// The rewritten files are formatted with the style of the format config, which may be nil.
+//
+// The readBucket must contain the source of the files that can be rewritten, at
+// their path within the Image. Elements are only renamed if all references to them
+// are within files that can be rewritten.
+//
+// The Image must have source code info.
+func Fix(
+	ctx context.Context,
+	config *buflintconfig.Config,
+	formatConfig *bufformatconfig.Config,
+	image bufimage.Image,
+	readBucket storage.ReadBucket,
+	fileAnnotations []bufanalysis.FileAnnotation,
+) (*Result, error) {
+	fixer, err := newFixer(ctx, config, formatConfig, image, readBucket)
+	if err != nil {
+		return nil, err
+	}
+	return fixer.Fix(ctx, fileAnnotations)
+}
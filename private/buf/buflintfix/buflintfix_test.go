@@ -0,0 +1,146 @@
+// Copyright 2020-2023 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package buflintfix
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bufbuild/buf/private/bufpkg/bufanalysis"
+	"github.com/bufbuild/buf/private/bufpkg/bufcheck/buflint"
+	"github.com/bufbuild/buf/private/bufpkg/bufconfig"
+	"github.com/bufbuild/buf/private/bufpkg/bufimage"
+	"github.com/bufbuild/buf/private/bufpkg/bufimage/bufimagebuild"
+	"github.com/bufbuild/buf/private/bufpkg/bufmodule"
+	"github.com/bufbuild/buf/private/bufpkg/bufmodule/bufmodulebuild"
+	"github.com/bufbuild/buf/private/pkg/command"
+	"github.com/bufbuild/buf/private/pkg/storage"
+	"github.com/bufbuild/buf/private/pkg/storage/storagemem"
+	"github.com/bufbuild/buf/private/pkg/storage/storageos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestFix(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	storageosProvider := storageos.NewProvider()
+	inputReadBucket, err := storageosProvider.NewReadWriteBucket(filepath.Join("testdata", "fix", "input"))
+	require.NoError(t, err)
+	outputReadBucket, err := storageosProvider.NewReadWriteBucket(filepath.Join("testdata", "fix", "output"))
+	require.NoError(t, err)
+	config, err := bufconfig.GetConfigForBucket(ctx, inputReadBucket)
+	require.NoError(t, err)
+
+	fileAnnotations := testLint(t, ctx, config, inputReadBucket)
+	image := testBuild(t, ctx, config, inputReadBucket)
+	result, err := Fix(ctx, config.Lint, config.Format, image, inputReadBucket, fileAnnotations)
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		[]string{
+			"IMPORT_USED",
+			"FIELD_LOWER_SNAKE_CASE",
+			"COMMENT_FIELD",
+			"COMMENT_ONEOF",
+			"COMMENT_ENUM_VALUE",
+			"ENUM_VALUE_PREFIX",
+			"ENUM_ZERO_VALUE_SUFFIX",
+			"ENUM_VALUE_PREFIX",
+			"SERVICE_SUFFIX",
+			"RPC_REQUEST_STANDARD_NAME",
+			"RPC_RESPONSE_STANDARD_NAME",
+			"RPC_REQUEST_STANDARD_NAME",
+			"RPC_RESPONSE_STANDARD_NAME",
+		},
+		testFileAnnotationTypes(result.FixedFileAnnotations),
+	)
+	assert.Equal(
+		t,
+		[]string{
+			"RPC_REQUEST_RESPONSE_UNIQUE",
+			"RPC_RESPONSE_STANDARD_NAME",
+		},
+		testFileAnnotationTypes(result.UnfixedFileAnnotations),
+	)
+
+	var fixedPaths []string
+	require.NoError(
+		t,
+		storage.WalkReadObjects(
+			ctx,
+			result.ReadBucket,
+			"",
+			func(readObject storage.ReadObject) error {
+				fixedPaths = append(fixedPaths, readObject.Path())
+				assert.Equal(t, filepath.Join("testdata", "fix", "input", readObject.Path()), readObject.ExternalPath())
+				fixedData, err := storage.ReadPath(ctx, result.ReadBucket, readObject.Path())
+				require.NoError(t, err)
+				expectedData, err := storage.ReadPath(ctx, outputReadBucket, readObject.Path())
+				require.NoError(t, err)
+				assert.Equal(t, string(expectedData), string(fixedData), readObject.Path())
+				return nil
+			},
+		),
+	)
+	assert.Equal(t, []string{"acme/foo/v1/foo.proto", "acme/foo/v1/thing.proto"}, fixedPaths)
+
+	// The fixed files must compile, and only the unfixed violations should remain.
+	fixedReadWriteBucket := storagemem.NewReadWriteBucket()
+	_, err = storage.Copy(ctx, inputReadBucket, fixedReadWriteBucket)
+	require.NoError(t, err)
+	_, err = storage.Copy(ctx, result.ReadBucket, fixedReadWriteBucket)
+	require.NoError(t, err)
+	fileAnnotations = testLint(t, ctx, config, fixedReadWriteBucket)
+	assert.Equal(t, testFileAnnotationTypes(result.UnfixedFileAnnotations), testFileAnnotationTypes(fileAnnotations))
+}
+
+func testLint(
+	t *testing.T,
+	ctx context.Context,
+	config *bufconfig.Config,
+	readBucket storage.ReadBucket,
+) []bufanalysis.FileAnnotation {
+	image := testBuild(t, ctx, config, readBucket)
+	fileAnnotations, _, err := buflint.NewHandler(zap.NewNop(), command.NewRunner()).Check(ctx, config.Lint, image)
+	require.NoError(t, err)
+	return fileAnnotations
+}
+
+func testBuild(
+	t *testing.T,
+	ctx context.Context,
+	config *bufconfig.Config,
+	readBucket storage.ReadBucket,
+) bufimage.Image {
+	module, err := bufmodulebuild.NewModuleBucketBuilder().BuildForBucket(ctx, readBucket, config.Build)
+	require.NoError(t, err)
+	image, fileAnnotations, err := bufimagebuild.NewBuilder(zap.NewNop(), bufmodule.NewNopModuleReader()).Build(ctx, module)
+	require.NoError(t, err)
+	require.Empty(t, fileAnnotations)
+	return image
+}
+
+func testFileAnnotationTypes(fileAnnotations []bufanalysis.FileAnnotation) []string {
+	types := make([]string, len(fileAnnotations))
+	for i, fileAnnotation := range fileAnnotations {
+		types[i] = fileAnnotation.Type()
+	}
+	return types
+}
@@ -0,0 +1,975 @@
+// Copyright 2020-2023 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package buflintfix
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bufbuild/buf/private/buf/bufformat"
+	"github.com/bufbuild/buf/private/bufpkg/bufanalysis"
+	"github.com/bufbuild/buf/private/bufpkg/bufcheck/buflint"
+	"github.com/bufbuild/buf/private/bufpkg/bufcheck/buflint/buflintconfig"
+	"github.com/bufbuild/buf/private/bufpkg/bufformatconfig"
+	"github.com/bufbuild/buf/private/bufpkg/bufimage"
+	"github.com/bufbuild/buf/private/bufpkg/bufimage/bufimageutil"
+	"github.com/bufbuild/buf/private/pkg/protosource"
+	"github.com/bufbuild/buf/private/pkg/storage"
+	"github.com/bufbuild/buf/private/pkg/storage/storagemem"
+	"github.com/bufbuild/buf/private/pkg/stringutil"
+	"github.com/bufbuild/protocompile/ast"
+	"github.com/bufbuild/protocompile/parser"
+	"github.com/bufbuild/protocompile/reporter"
+	"go.uber.org/multierr"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+const (
+	// commentStubFormat is the format of the comment added for the COMMENT_* rules.
+	commentStubFormat = "// TODO: document %s."
+)
+
+var (
+	// fixOrder is the order in which FileAnnotations are fixed.
+	//
+	// ENUM_ZERO_VALUE_SUFFIX is fixed before ENUM_VALUE_PREFIX, as the zero value is
+	// renamed to a name that also has the enum value prefix.
+	fixOrder = []string{
+		"IMPORT_USED",
+		"FIELD_LOWER_SNAKE_CASE",
+		"ENUM_ZERO_VALUE_SUFFIX",
+		"ENUM_VALUE_PREFIX",
+		"SERVICE_SUFFIX",
+		"RPC_REQUEST_STANDARD_NAME",
+		"RPC_RESPONSE_STANDARD_NAME",
+		"COMMENT_ENUM",
+		"COMMENT_ENUM_VALUE",
+		"COMMENT_FIELD",
+		"COMMENT_MESSAGE",
+		"COMMENT_ONEOF",
+		"COMMENT_RPC",
+		"COMMENT_SERVICE",
+	}
+	ruleIDToFixFunc = map[string]fixFunc{
+		"COMMENT_ENUM":               newCommentFixFunc(getEnums),
+		"COMMENT_ENUM_VALUE":         newCommentFixFunc(getEnumValues),
+		"COMMENT_FIELD":              newCommentFixFunc(getFields),
+		"COMMENT_MESSAGE":            newCommentFixFunc(getMessages),
+		"COMMENT_ONEOF":              newCommentFixFunc(getOneofs),
+		"COMMENT_RPC":                newCommentFixFunc(getMethods),
+		"COMMENT_SERVICE":            newCommentFixFunc(getServices),
+		"ENUM_VALUE_PREFIX":          fixEnumValuePrefix,
+		"ENUM_ZERO_VALUE_SUFFIX":     fixEnumZeroValueSuffix,
+		"FIELD_LOWER_SNAKE_CASE":     fixFieldLowerSnakeCase,
+		"IMPORT_USED":                fixImportUsed,
+		"RPC_REQUEST_STANDARD_NAME":  newRPCStandardNameFixFunc(true),
+		"RPC_RESPONSE_STANDARD_NAME": newRPCStandardNameFixFunc(false),
+		"SERVICE_SUFFIX":             fixServiceSuffix,
+	}
+)
+
+// fixFunc fixes the FileAnnotation for the File, and returns true if
+// the FileAnnotation was fixed.
+type fixFunc func(*fixer, protosource.File, bufanalysis.FileAnnotation) bool
+
+type fixer struct {
+	config       *buflintconfig.Config
+	formatConfig *bufformatconfig.Config
+	files        []protosource.File
+	// pathToSourceFile only contains the files that can be rewritten.
+	pathToSourceFile  map[string]*sourceFile
+	fullNameToMessage map[string]protosource.Message
+	// fullNames contains the full names of all elements, and is used to
+	// check that renamed elements do not collide with existing elements.
+	fullNames map[string]struct{}
+	// optionTypeFullNames contains the full names of the messages and enums that
+	// are used in custom options. Elements of these types may be referenced by name
+	// in option values, so they are never renamed.
+	optionTypeFullNames map[string]struct{}
+	// messageFullNameToRPCUses is the number of times each message is used as
+	// a request or response type.
+	messageFullNameToRPCUses map[string]int
+	typeReferences           []*typeReference
+	// nameKeyToNewName contains the new names of renamed elements.
+	nameKeyToNewName map[nameKey]string
+}
+
+func newFixer(
+	ctx context.Context,
+	config *buflintconfig.Config,
+	formatConfig *bufformatconfig.Config,
+	image bufimage.Image,
+	readBucket storage.ReadBucket,
+) (*fixer, error) {
+	files, err := protosource.NewFilesUnstable(ctx, bufimageutil.NewInputFiles(image.Files())...)
+	if err != nil {
+		return nil, err
+	}
+	fixer := &fixer{
+		config:                   config,
+		formatConfig:             formatConfig,
+		files:                    files,
+		pathToSourceFile:         make(map[string]*sourceFile),
+		fullNameToMessage:        make(map[string]protosource.Message),
+		fullNames:                make(map[string]struct{}),
+		optionTypeFullNames:      make(map[string]struct{}),
+		messageFullNameToRPCUses: make(map[string]int),
+		nameKeyToNewName:         make(map[nameKey]string),
+	}
+	for _, imageFile := range image.Files() {
+		data, err := storage.ReadPath(ctx, readBucket, imageFile.Path())
+		if err != nil {
+			if storage.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		sourceFile, err := newSourceFile(imageFile.Path(), imageFile.ExternalPath(), data)
+		if err != nil {
+			return nil, err
+		}
+		fixer.pathToSourceFile[imageFile.Path()] = sourceFile
+	}
+	for _, file := range files {
+		fixer.addFile(file)
+	}
+	fixer.addOptionTypeFullNames()
+	return fixer, nil
+}
+
+// Fix fixes the FileAnnotations.
+func (f *fixer) Fix(ctx context.Context, fileAnnotations []bufanalysis.FileAnnotation) (*Result, error) {
+	ruleIDToFileAnnotations := make(map[string][]bufanalysis.FileAnnotation)
+	var fixedFileAnnotations []bufanalysis.FileAnnotation
+	var unfixedFileAnnotations []bufanalysis.FileAnnotation
+	for _, fileAnnotation := range fileAnnotations {
+		if _, ok := ruleIDToFixFunc[fileAnnotation.Type()]; !ok {
+			unfixedFileAnnotations = append(unfixedFileAnnotations, fileAnnotation)
+			continue
+		}
+		ruleIDToFileAnnotations[fileAnnotation.Type()] = append(
+			ruleIDToFileAnnotations[fileAnnotation.Type()],
+			fileAnnotation,
+		)
+	}
+	for _, ruleID := range fixOrder {
+		for _, fileAnnotation := range ruleIDToFileAnnotations[ruleID] {
+			if f.fixFileAnnotation(ruleIDToFixFunc[ruleID], fileAnnotation) {
+				fixedFileAnnotations = append(fixedFileAnnotations, fileAnnotation)
+			} else {
+				unfixedFileAnnotations = append(unfixedFileAnnotations, fileAnnotation)
+			}
+		}
+	}
+	readBucket, err := f.write(ctx)
+	if err != nil {
+		return nil, err
+	}
+	bufanalysis.SortFileAnnotations(fixedFileAnnotations)
+	bufanalysis.SortFileAnnotations(unfixedFileAnnotations)
+	return &Result{
+		ReadBucket:             readBucket,
+		FixedFileAnnotations:   fixedFileAnnotations,
+		UnfixedFileAnnotations: unfixedFileAnnotations,
+	}, nil
+}
+
+func (f *fixer) fixFileAnnotation(fixFunc fixFunc, fileAnnotation bufanalysis.FileAnnotation) bool {
+	fileInfo := fileAnnotation.FileInfo()
+	if fileInfo == nil || fileAnnotation.StartLine() == 0 {
+		return false
+	}
+	if _, ok := f.pathToSourceFile[fileInfo.Path()]; !ok {
+		return false
+	}
+	for _, file := range f.files {
+		if file.Path() == fileInfo.Path() {
+			return fixFunc(f, file, fileAnnotation)
+		}
+	}
+	return false
+}
+
+func (f *fixer) addFile(file protosource.File) {
+	for _, message := range getAllMessages(file) {
+		f.fullNameToMessage[message.FullName()] = message
+		f.fullNames[message.FullName()] = struct{}{}
+		for _, oneof := range message.Oneofs() {
+			f.fullNames[oneof.FullName()] = struct{}{}
+		}
+	}
+	for _, enum := range getEnums(file) {
+		f.fullNames[enum.FullName()] = struct{}{}
+	}
+	for _, enumValue := range getEnumValues(file) {
+		f.fullNames[joinFullName(getScope(enumValue.(protosource.EnumValue).Enum()), enumValue.Name())] = struct{}{}
+	}
+	for _, field := range getAllFields(file) {
+		f.fullNames[field.FullName()] = struct{}{}
+	}
+	for _, service := range file.Services() {
+		f.fullNames[service.FullName()] = struct{}{}
+		for _, method := range service.Methods() {
+			f.fullNames[method.FullName()] = struct{}{}
+			f.messageFullNameToRPCUses[method.InputTypeName()]++
+			f.messageFullNameToRPCUses[method.OutputTypeName()]++
+			f.typeReferences = append(
+				f.typeReferences,
+				&typeReference{
+					file:     file,
+					fullName: method.InputTypeName(),
+					location: method.InputTypeLocation(),
+				},
+				&typeReference{
+					file:     file,
+					fullName: method.OutputTypeName(),
+					location: method.OutputTypeLocation(),
+				},
+			)
+		}
+	}
+	for _, field := range getAllFields(file) {
+		if field.ParentMessage() != nil && field.ParentMessage().IsMapEntry() {
+			// Map entry fields do not have locations, these references are
+			// added for the map field itself.
+			continue
+		}
+		f.addFieldTypeReferences(file, field)
+	}
+}
+
+func (f *fixer) addFieldTypeReferences(file protosource.File, field protosource.Field) {
+	if field.Extendee() != "" {
+		f.typeReferences = append(
+			f.typeReferences,
+			&typeReference{
+				file:     file,
+				fullName: field.Extendee(),
+				location: field.ExtendeeLocation(),
+			},
+		)
+	}
+	if field.TypeName() == "" {
+		return
+	}
+	if message, ok := f.fullNameToMessage[field.TypeName()]; ok && message.IsMapEntry() {
+		for _, entryField := range message.Fields() {
+			// The value field of a map entry always has number 2.
+			if entryField.Number() == 2 && entryField.TypeName() != "" {
+				f.typeReferences = append(
+					f.typeReferences,
+					&typeReference{
+						file:       file,
+						fullName:   entryField.TypeName(),
+						location:   field.TypeNameLocation(),
+						isMapValue: true,
+					},
+				)
+			}
+		}
+		return
+	}
+	f.typeReferences = append(
+		f.typeReferences,
+		&typeReference{
+			file:     file,
+			fullName: field.TypeName(),
+			location: field.TypeNameLocation(),
+			isGroup:  field.Type() == descriptorpb.FieldDescriptorProto_TYPE_GROUP,
+		},
+	)
+}
+
+// addOptionTypeFullNames adds the full names of all messages and enums that are
+// used by custom options, including through the fields of other messages.
+func (f *fixer) addOptionTypeFullNames() {
+	var fullNames []string
+	for _, file := range f.files {
+		for _, field := range getAllFields(file) {
+			extendee := field.Extendee()
+			if strings.HasPrefix(extendee, "google.protobuf.") && strings.HasSuffix(extendee, "Options") && field.TypeName() != "" {
+				fullNames = append(fullNames, field.TypeName())
+			}
+		}
+	}
+	for len(fullNames) > 0 {
+		fullName := fullNames[0]
+		fullNames = fullNames[1:]
+		if _, ok := f.optionTypeFullNames[fullName]; ok {
+			continue
+		}
+		f.optionTypeFullNames[fullName] = struct{}{}
+		if message, ok := f.fullNameToMessage[fullName]; ok {
+			for _, field := range message.Fields() {
+				if field.TypeName() != "" {
+					fullNames = append(fullNames, field.TypeName())
+				}
+			}
+		}
+	}
+}
+
+// rename renames the element with the name at the Location to newName.
+//
+// The extraEdits are committed along with the rename, and are typically used to
+// update references to the element.
+func (f *fixer) rename(
+	file protosource.File,
+	nameLocation protosource.Location,
+	scope string,
+	newName string,
+	extraEdits []*edit,
+) bool {
+	newFullName := joinFullName(scope, newName)
+	if _, ok := f.fullNames[newFullName]; ok {
+		return false
+	}
+	sourceFile := f.pathToSourceFile[file.Path()]
+	if sourceFile == nil {
+		return false
+	}
+	identNode, ok := sourceFile.positionToIdentNode[newPosition(nameLocation)]
+	if !ok {
+		return false
+	}
+	if !f.commit(append(extraEdits, sourceFile.newNodeEdit(identNode, newName))) {
+		return false
+	}
+	f.fullNames[newFullName] = struct{}{}
+	f.nameKeyToNewName[newNameKey(file, nameLocation)] = newName
+	return true
+}
+
+// renameMessage renames the Message to newName, and updates all references to the
+// Message and any of its nested types.
+func (f *fixer) renameMessage(message protosource.Message, newName string) bool {
+	if _, ok := f.optionTypeFullNames[message.FullName()]; ok {
+		return false
+	}
+	componentIndex := len(strings.Split(message.FullName(), ".")) - 1
+	var edits []*edit
+	for _, typeReference := range f.typeReferences {
+		if typeReference.fullName != message.FullName() && !strings.HasPrefix(typeReference.fullName, message.FullName()+".") {
+			continue
+		}
+		if typeReference.isGroup {
+			return false
+		}
+		sourceFile, ok := f.pathToSourceFile[typeReference.file.Path()]
+		if !ok {
+			// The reference is in a file that cannot be rewritten.
+			return false
+		}
+		edit, ok := sourceFile.newTypeReferenceEdit(typeReference, componentIndex, newName)
+		if !ok {
+			return false
+		}
+		if edit != nil {
+			edits = append(edits, edit)
+		}
+	}
+	return f.rename(message.File(), message.NameLocation(), getScope(message), newName, edits)
+}
+
+// renameEnumValue renames the EnumValue to newName, and updates all field defaults
+// that reference the EnumValue.
+func (f *fixer) renameEnumValue(enumValue protosource.EnumValue, newName string) bool {
+	enum := enumValue.Enum()
+	if _, ok := f.optionTypeFullNames[enum.FullName()]; ok {
+		return false
+	}
+	var edits []*edit
+	for _, file := range f.files {
+		for _, field := range getAllFields(file) {
+			if field.TypeName() != enum.FullName() {
+				continue
+			}
+			sourceFile, ok := f.pathToSourceFile[file.Path()]
+			if !ok {
+				// Only proto3 files cannot have defaults.
+				if file.Syntax() != protosource.SyntaxProto3 {
+					return false
+				}
+				continue
+			}
+			if edit := sourceFile.newDefaultEdit(field, enumValue.Name(), newName); edit != nil {
+				edits = append(edits, edit)
+			}
+		}
+	}
+	return f.rename(enumValue.File(), enumValue.NameLocation(), getScope(enum), newName, edits)
+}
+
+// commit adds the edits to their source files.
+//
+// If any of the edits overlap with an existing edit, no edits are added and false
+// is returned. Edits that are equal to an existing edit are skipped.
+func (f *fixer) commit(edits []*edit) bool {
+	var newEdits []*edit
+	for i, edit := range edits {
+		isDuplicate := false
+		for _, existingEdit := range append(edit.sourceFile.edits, edits[:i]...) {
+			if existingEdit.sourceFile != edit.sourceFile {
+				continue
+			}
+			if existingEdit.equal(edit) {
+				isDuplicate = true
+				break
+			}
+			if existingEdit.overlaps(edit) {
+				return false
+			}
+		}
+		if !isDuplicate {
+			newEdits = append(newEdits, edit)
+		}
+	}
+	for _, edit := range newEdits {
+		edit.sourceFile.edits = append(edit.sourceFile.edits, edit)
+	}
+	return true
+}
+
+// write applies the edits to the source files, and formats and writes the files that
+// have edits to a ReadBucket.
+func (f *fixer) write(ctx context.Context) (storage.ReadBucket, error) {
+	readWriteBucket := storagemem.NewReadWriteBucket()
+	for _, sourceFile := range f.pathToSourceFile {
+		if len(sourceFile.edits) == 0 {
+			continue
+		}
+		if err := sourceFile.write(ctx, readWriteBucket, f.formatConfig); err != nil {
+			return nil, err
+		}
+	}
+	return readWriteBucket, nil
+}
+
+func fixFieldLowerSnakeCase(f *fixer, file protosource.File, fileAnnotation bufanalysis.FileAnnotation) bool {
+	for _, message := range getAllMessages(file) {
+		for _, field := range message.Fields() {
+			if !locationMatches(field.NameLocation(), fileAnnotation) {
+				continue
+			}
+			if _, ok := f.optionTypeFullNames[message.FullName()]; ok {
+				return false
+			}
+			return f.rename(file, field.NameLocation(), message.FullName(), stringutil.ToLowerSnakeCase(field.Name()), nil)
+		}
+	}
+	return false
+}
+
+func fixEnumZeroValueSuffix(f *fixer, file protosource.File, fileAnnotation bufanalysis.FileAnnotation) bool {
+	enumValue := findEnumValue(file, fileAnnotation)
+	if enumValue == nil || enumValue.Number() != 0 {
+		return false
+	}
+	suffix := buflint.EnumZeroValueSuffixForConfig(f.config)
+	newName := stringutil.ToUpperSnakeCase(enumValue.Enum().Name())
+	if !strings.HasPrefix(suffix, "_") {
+		newName += "_"
+	}
+	return f.renameEnumValue(enumValue, newName+suffix)
+}
+
+func fixEnumValuePrefix(f *fixer, file protosource.File, fileAnnotation bufanalysis.FileAnnotation) bool {
+	enumValue := findEnumValue(file, fileAnnotation)
+	if enumValue == nil {
+		return false
+	}
+	expectedPrefix := stringutil.ToUpperSnakeCase(enumValue.Enum().Name()) + "_"
+	if newName, ok := f.nameKeyToNewName[newNameKey(file, enumValue.NameLocation())]; ok {
+		// Already renamed by another fix, such as ENUM_ZERO_VALUE_SUFFIX.
+		return strings.HasPrefix(newName, expectedPrefix)
+	}
+	return f.renameEnumValue(enumValue, expectedPrefix+enumValue.Name())
+}
+
+func fixServiceSuffix(f *fixer, file protosource.File, fileAnnotation bufanalysis.FileAnnotation) bool {
+	suffix := buflint.ServiceSuffixForConfig(f.config)
+	for _, service := range file.Services() {
+		if locationMatches(service.NameLocation(), fileAnnotation) {
+			return f.rename(file, service.NameLocation(), getScope(service), service.Name()+suffix, nil)
+		}
+	}
+	return false
+}
+
+func newRPCStandardNameFixFunc(request bool) fixFunc {
+	return func(f *fixer, file protosource.File, fileAnnotation bufanalysis.FileAnnotation) bool {
+		for _, service := range file.Services() {
+			for _, method := range service.Methods() {
+				location, messageFullName, suffix := method.OutputTypeLocation(), method.OutputTypeName(), "Response"
+				if request {
+					location, messageFullName, suffix = method.InputTypeLocation(), method.InputTypeName(), "Request"
+				}
+				if !locationMatches(location, fileAnnotation) {
+					continue
+				}
+				// Renaming a message that is used by multiple RPCs would only move the
+				// violation to another RPC.
+				if f.messageFullNameToRPCUses[messageFullName] != 1 {
+					return false
+				}
+				message, ok := f.fullNameToMessage[messageFullName]
+				if !ok {
+					return false
+				}
+				expectedName := stringutil.ToPascalCase(method.Name()) + suffix
+				if f.renameMessage(message, expectedName) {
+					return true
+				}
+				return f.renameMessage(message, stringutil.ToPascalCase(service.Name())+expectedName)
+			}
+		}
+		return false
+	}
+}
+
+func fixImportUsed(f *fixer, file protosource.File, fileAnnotation bufanalysis.FileAnnotation) bool {
+	sourceFile := f.pathToSourceFile[file.Path()]
+	for _, fileImport := range file.FileImports() {
+		if !locationMatches(fileImport.Location(), fileAnnotation) {
+			continue
+		}
+		importNode, ok := sourceFile.positionToNode[newPosition(fileImport.Location())].(*ast.ImportNode)
+		if !ok {
+			return false
+		}
+		return f.commit([]*edit{sourceFile.newLineDeleteEdit(importNode)})
+	}
+	return false
+}
+
+func newCommentFixFunc(getNamedDescriptors func(protosource.File) []protosource.NamedDescriptor) fixFunc {
+	return func(f *fixer, file protosource.File, fileAnnotation bufanalysis.FileAnnotation) bool {
+		sourceFile := f.pathToSourceFile[file.Path()]
+		for _, namedDescriptor := range getNamedDescriptors(file) {
+			if !locationMatches(namedDescriptor.Location(), fileAnnotation) {
+				continue
+			}
+			node, ok := sourceFile.positionToNode[newPosition(namedDescriptor.Location())]
+			if !ok {
+				return false
+			}
+			name := namedDescriptor.Name()
+			if newName, ok := f.nameKeyToNewName[newNameKey(file, namedDescriptor.NameLocation())]; ok {
+				name = newName
+			}
+			return f.commit([]*edit{sourceFile.newLineInsertEdit(node, fmt.Sprintf(commentStubFormat, name))})
+		}
+		return false
+	}
+}
+
+type sourceFile struct {
+	path         string
+	externalPath string
+	data         []byte
+	fileNode     *ast.FileNode
+	// positionToNode contains the outermost node at each position.
+	positionToNode              map[position]ast.Node
+	positionToIdentNode         map[position]*ast.IdentNode
+	positionToCompoundIdentNode map[position]*ast.CompoundIdentNode
+	edits                       []*edit
+}
+
+func newSourceFile(path string, externalPath string, data []byte) (*sourceFile, error) {
+	fileNode, err := parser.Parse(externalPath, bytes.NewReader(data), reporter.NewHandler(nil))
+	if err != nil {
+		return nil, err
+	}
+	sourceFile := &sourceFile{
+		path:                        path,
+		externalPath:                externalPath,
+		data:                        data,
+		fileNode:                    fileNode,
+		positionToNode:              make(map[position]ast.Node),
+		positionToIdentNode:         make(map[position]*ast.IdentNode),
+		positionToCompoundIdentNode: make(map[position]*ast.CompoundIdentNode),
+	}
+	if err := ast.Walk(
+		fileNode,
+		&ast.SimpleVisitor{},
+		ast.WithBefore(
+			func(node ast.Node) error {
+				if _, ok := node.(*ast.FileNode); ok {
+					return nil
+				}
+				start := fileNode.NodeInfo(node).Start()
+				position := position{line: start.Line, column: start.Col}
+				if _, ok := sourceFile.positionToNode[position]; !ok {
+					sourceFile.positionToNode[position] = node
+				}
+				switch node := node.(type) {
+				case *ast.IdentNode:
+					sourceFile.positionToIdentNode[position] = node
+				case *ast.CompoundIdentNode:
+					sourceFile.positionToCompoundIdentNode[position] = node
+				}
+				return nil
+			},
+		),
+	); err != nil {
+		return nil, err
+	}
+	return sourceFile, nil
+}
+
+// newNodeEdit returns an edit that replaces the node with the text.
+func (s *sourceFile) newNodeEdit(node ast.Node, text string) *edit {
+	nodeInfo := s.fileNode.NodeInfo(node)
+	return &edit{
+		sourceFile: s,
+		start:      nodeInfo.Start().Offset,
+		end:        nodeInfo.End().Offset + 1,
+		text:       text,
+	}
+}
+
+// newLineDeleteEdit returns an edit that deletes the node, along with the rest of
+// the line if it only contains whitespace.
+func (s *sourceFile) newLineDeleteEdit(node ast.Node) *edit {
+	edit := s.newNodeEdit(node, "")
+	start := edit.start
+	for start > 0 && (s.data[start-1] == ' ' || s.data[start-1] == '\t') {
+		start--
+	}
+	end := edit.end
+	for end < len(s.data) && (s.data[end] == ' ' || s.data[end] == '\t' || s.data[end] == '\r') {
+		end++
+	}
+	if (start == 0 || s.data[start-1] == '\n') && (end == len(s.data) || s.data[end] == '\n') {
+		edit.start = start
+		edit.end = end
+		if edit.end < len(s.data) {
+			edit.end++
+		}
+	}
+	return edit
+}
+
+// newLineInsertEdit returns an edit that inserts the line before the line of the node.
+func (s *sourceFile) newLineInsertEdit(node ast.Node, line string) *edit {
+	offset := s.fileNode.NodeInfo(node).Start().Offset
+	lineStart := offset
+	for lineStart > 0 && (s.data[lineStart-1] == ' ' || s.data[lineStart-1] == '\t') {
+		lineStart--
+	}
+	if lineStart > 0 && s.data[lineStart-1] != '\n' {
+		// The node is not at the start of a line, so we start a new line.
+		return &edit{
+			sourceFile: s,
+			start:      offset,
+			end:        offset,
+			text:       "\n" + line + "\n",
+		}
+	}
+	return &edit{
+		sourceFile: s,
+		start:      lineStart,
+		end:        lineStart,
+		text:       string(s.data[lineStart:offset]) + line + "\n",
+	}
+}
+
+// newTypeReferenceEdit returns an edit that renames the component at componentIndex
+// in the full name of the typeReference to newName.
+//
+// If the component is not part of the text of the reference, nil and true are returned.
+func (s *sourceFile) newTypeReferenceEdit(typeReference *typeReference, componentIndex int, newName string) (*edit, bool) {
+	if typeReference.location == nil {
+		return nil, false
+	}
+	position := newPosition(typeReference.location)
+	var components []*ast.IdentNode
+	if typeReference.isMapValue {
+		mapFieldNode, ok := s.positionToNode[position].(*ast.MapFieldNode)
+		if !ok {
+			return nil, false
+		}
+		switch valueType := mapFieldNode.MapType.ValueType.(type) {
+		case *ast.IdentNode:
+			components = []*ast.IdentNode{valueType}
+		case *ast.CompoundIdentNode:
+			components = valueType.Components
+		}
+	} else if compoundIdentNode, ok := s.positionToCompoundIdentNode[position]; ok {
+		components = compoundIdentNode.Components
+	} else if identNode, ok := s.positionToIdentNode[position]; ok {
+		components = []*ast.IdentNode{identNode}
+	}
+	if len(components) == 0 {
+		return nil, false
+	}
+	// The text of a reference is always a suffix of the full name it resolves to.
+	textIndex := componentIndex - (len(strings.Split(typeReference.fullName, ".")) - len(components))
+	if textIndex < 0 {
+		return nil, true
+	}
+	if textIndex >= len(components) {
+		return nil, false
+	}
+	return s.newNodeEdit(components[textIndex], newName), true
+}
+
+// newDefaultEdit returns an edit that updates the default value of the field
+// from oldValue to newValue, if the field has this default value.
+func (s *sourceFile) newDefaultEdit(field protosource.Field, oldValue string, newValue string) *edit {
+	if field.Location() == nil {
+		return nil
+	}
+	fieldNode, ok := s.positionToNode[newPosition(field.Location())].(*ast.FieldNode)
+	if !ok || fieldNode.Options == nil {
+		return nil
+	}
+	for _, optionNode := range fieldNode.Options.Options {
+		parts := optionNode.Name.Parts
+		if len(parts) != 1 || parts[0].IsExtension() || parts[0].Name.AsIdentifier() != "default" {
+			continue
+		}
+		if identNode, ok := optionNode.Val.(*ast.IdentNode); ok && identNode.Val == oldValue {
+			return s.newNodeEdit(identNode, newValue)
+		}
+	}
+	return nil
+}
+
+func (s *sourceFile) write(
+	ctx context.Context,
+	readWriteBucket storage.ReadWriteBucket,
+	formatConfig *bufformatconfig.Config,
+) (retErr error) {
+	edits := make([]*edit, len(s.edits))
+	copy(edits, s.edits)
+	// Apply the edits from the end of the file, so that the offsets of the remaining
+	// edits are still valid. If an insertion and a replacement start at the same offset,
+	// the replacement is applied first so that the insertion ends up before it.
+	sort.Slice(
+		edits,
+		func(i int, j int) bool {
+			if edits[i].start != edits[j].start {
+				return edits[i].start > edits[j].start
+			}
+			return edits[i].end > edits[j].end
+		},
+	)
+	data := make([]byte, len(s.data))
+	copy(data, s.data)
+	for _, edit := range edits {
+		data = append(data[:edit.start], append([]byte(edit.text), data[edit.end:]...)...)
+	}
+	fileNode, err := parser.Parse(s.externalPath, bytes.NewReader(data), reporter.NewHandler(nil))
+	if err != nil {
+		return fmt.Errorf("could not parse %s after applying fixes: %w", s.externalPath, err)
+	}
+	writeObjectCloser, err := readWriteBucket.Put(ctx, s.path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		retErr = multierr.Append(retErr, writeObjectCloser.Close())
+	}()
+	if err := bufformat.FormatFileNode(writeObjectCloser, fileNode, bufformat.FormatWithConfig(formatConfig)); err != nil {
+		return err
+	}
+	return writeObjectCloser.SetExternalPath(s.externalPath)
+}
+
+// edit replaces the bytes in [start, end) with text.
+type edit struct {
+	sourceFile *sourceFile
+	start      int
+	end        int
+	text       string
+}
+
+func (e *edit) equal(other *edit) bool {
+	return e.start == other.start && e.end == other.end && e.text == other.text
+}
+
+func (e *edit) overlaps(other *edit) bool {
+	if e.start == e.end && other.start == other.end {
+		// Two insertions at the same offset.
+		return e.start == other.start
+	}
+	return e.start < other.end && other.start < e.end
+}
+
+// typeReference is a reference to a type by its full name.
+type typeReference struct {
+	file     protosource.File
+	fullName string
+	// location is the location of the text of the reference. If isMapValue
+	// is true, this is the location of the map type.
+	location   protosource.Location
+	isMapValue bool
+	isGroup    bool
+}
+
+// position is a 1-indexed line and column.
+//
+// Columns follow the same tab expansion rules as source code info, so that
+// positions from source code info locations and AST nodes match.
+type position struct {
+	line   int
+	column int
+}
+
+func newPosition(location protosource.Location) position {
+	if location == nil {
+		return position{}
+	}
+	return position{
+		line:   location.StartLine(),
+		column: location.StartColumn(),
+	}
+}
+
+// nameKey identifies the name of an element.
+type nameKey struct {
+	path     string
+	position position
+}
+
+func newNameKey(file protosource.File, nameLocation protosource.Location) nameKey {
+	return nameKey{
+		path:     file.Path(),
+		position: newPosition(nameLocation),
+	}
+}
+
+func locationMatches(location protosource.Location, fileAnnotation bufanalysis.FileAnnotation) bool {
+	return location != nil &&
+		location.StartLine() == fileAnnotation.StartLine() &&
+		location.StartColumn() == fileAnnotation.StartColumn()
+}
+
+func findEnumValue(file protosource.File, fileAnnotation bufanalysis.FileAnnotation) protosource.EnumValue {
+	for _, enumValue := range getEnumValues(file) {
+		if locationMatches(enumValue.NameLocation(), fileAnnotation) {
+			return enumValue.(protosource.EnumValue)
+		}
+	}
+	return nil
+}
+
+// getScope returns the full name of the scope that the descriptor is defined in.
+func getScope(namedDescriptor protosource.NamedDescriptor) string {
+	return strings.TrimSuffix(strings.TrimSuffix(namedDescriptor.FullName(), namedDescriptor.Name()), ".")
+}
+
+func joinFullName(scope string, name string) string {
+	if scope == "" {
+		return name
+	}
+	return scope + "." + name
+}
+
+func getAllMessages(file protosource.File) []protosource.Message {
+	var messages []protosource.Message
+	var addMessages func([]protosource.Message)
+	addMessages = func(nestedMessages []protosource.Message) {
+		for _, message := range nestedMessages {
+			messages = append(messages, message)
+			addMessages(message.Messages())
+		}
+	}
+	addMessages(file.Messages())
+	return messages
+}
+
+func getAllFields(file protosource.File) []protosource.Field {
+	var fields []protosource.Field
+	fields = append(fields, file.Extensions()...)
+	for _, message := range getAllMessages(file) {
+		fields = append(fields, message.Fields()...)
+		fields = append(fields, message.Extensions()...)
+	}
+	return fields
+}
+
+func getMessages(file protosource.File) []protosource.NamedDescriptor {
+	var namedDescriptors []protosource.NamedDescriptor
+	for _, message := range getAllMessages(file) {
+		if !message.IsMapEntry() {
+			namedDescriptors = append(namedDescriptors, message)
+		}
+	}
+	return namedDescriptors
+}
+
+func getEnums(file protosource.File) []protosource.NamedDescriptor {
+	var namedDescriptors []protosource.NamedDescriptor
+	for _, enum := range file.Enums() {
+		namedDescriptors = append(namedDescriptors, enum)
+	}
+	for _, message := range getAllMessages(file) {
+		for _, enum := range message.Enums() {
+			namedDescriptors = append(namedDescriptors, enum)
+		}
+	}
+	return namedDescriptors
+}
+
+func getEnumValues(file protosource.File) []protosource.NamedDescriptor {
+	var namedDescriptors []protosource.NamedDescriptor
+	for _, enum := range getEnums(file) {
+		for _, enumValue := range enum.(protosource.Enum).Values() {
+			namedDescriptors = append(namedDescriptors, enumValue)
+		}
+	}
+	return namedDescriptors
+}
+
+func getFields(file protosource.File) []protosource.NamedDescriptor {
+	var namedDescriptors []protosource.NamedDescriptor
+	for _, field := range getAllFields(file) {
+		namedDescriptors = append(namedDescriptors, field)
+	}
+	return namedDescriptors
+}
+
+func getOneofs(file protosource.File) []protosource.NamedDescriptor {
+	var namedDescriptors []protosource.NamedDescriptor
+	for _, message := range getAllMessages(file) {
+		for _, oneof := range message.Oneofs() {
+			namedDescriptors = append(namedDescriptors, oneof)
+		}
+	}
+	return namedDescriptors
+}
+
+func getServices(file protosource.File) []protosource.NamedDescriptor {
+	var namedDescriptors []protosource.NamedDescriptor
+	for _, service := range file.Services() {
+		namedDescriptors = append(namedDescriptors, service)
+	}
+	return namedDescriptors
+}
+
+func getMethods(file protosource.File) []protosource.NamedDescriptor {
+	var namedDescriptors []protosource.NamedDescriptor
+	for _, service := range file.Services() {
+		for _, method := range service.Methods() {
+			namedDescriptors = append(namedDescriptors, method)
+		}
+	}
+	return namedDescriptors
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/bufbuild/buf/private/bufpkg/bufmodule/bufmodulebuild"
 	"github.com/bufbuild/buf/private/bufpkg/bufmodule/bufmoduleref"
 	"github.com/bufbuild/buf/private/pkg/app"
+	"github.com/bufbuild/buf/private/pkg/storage"
 	"github.com/bufbuild/buf/private/pkg/storage/storageos"
 	"go.uber.org/zap"
 	"google.golang.org/protobuf/proto"
@@ -38,6 +39,15 @@ import (
 type ImageConfig interface {
 	Image() bufimage.Image
 	Config() *bufconfig.Config
+	// Module is the Module that the Image was built from.
+	//
+	// Optional. May be nil if the Image was read from an image input.
+	Module() bufmodule.Module
+	// SourceReadBucket is the bucket of the directory of the Module, which
+	// contains the configuration file of the Module.
+	//
+	// Optional. May be nil if the Module was not read from a source input.
+	SourceReadBucket() storage.ReadBucket
 }
 
 // ImageConfigReader is an ImageConfig reader.
@@ -78,6 +88,11 @@ func NewImageConfigReader(
 type ModuleConfig interface {
 	Module() bufmodule.Module
 	Config() *bufconfig.Config
+	// SourceReadBucket is the bucket of the directory of the Module, which
+	// contains the configuration file of the Module.
+	//
+	// Optional. May be nil if the Module was not read from a source input.
+	SourceReadBucket() storage.ReadBucket
 }
 
 // ModuleConfigSet is a set of ModuleConfigs with a potentially associated Workspace.
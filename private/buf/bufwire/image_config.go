@@ -17,17 +17,28 @@ package bufwire
 import (
 	"github.com/bufbuild/buf/private/bufpkg/bufconfig"
 	"github.com/bufbuild/buf/private/bufpkg/bufimage"
+	"github.com/bufbuild/buf/private/bufpkg/bufmodule"
+	"github.com/bufbuild/buf/private/pkg/storage"
 )
 
 type imageConfig struct {
-	image  bufimage.Image
-	config *bufconfig.Config
+	image            bufimage.Image
+	config           *bufconfig.Config
+	module           bufmodule.Module
+	sourceReadBucket storage.ReadBucket
 }
 
-func newImageConfig(image bufimage.Image, config *bufconfig.Config) *imageConfig {
+func newImageConfig(
+	image bufimage.Image,
+	config *bufconfig.Config,
+	module bufmodule.Module,
+	sourceReadBucket storage.ReadBucket,
+) *imageConfig {
 	return &imageConfig{
-		image:  image,
-		config: config,
+		image:            image,
+		config:           config,
+		module:           module,
+		sourceReadBucket: sourceReadBucket,
 	}
 }
 
@@ -38,3 +49,11 @@ func (i *imageConfig) Image() bufimage.Image {
 func (i *imageConfig) Config() *bufconfig.Config {
 	return i.config
 }
+
+func (i *imageConfig) Module() bufmodule.Module {
+	return i.module
+}
+
+func (i *imageConfig) SourceReadBucket() storage.ReadBucket {
+	return i.sourceReadBucket
+}
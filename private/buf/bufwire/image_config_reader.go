@@ -27,6 +27,7 @@ import (
 	"github.com/bufbuild/buf/private/bufpkg/bufmodule"
 	"github.com/bufbuild/buf/private/bufpkg/bufmodule/bufmodulebuild"
 	"github.com/bufbuild/buf/private/pkg/app"
+	"github.com/bufbuild/buf/private/pkg/storage"
 	"github.com/bufbuild/buf/private/pkg/storage/storageos"
 	"go.uber.org/zap"
 )
@@ -161,8 +162,7 @@ func (i *imageConfigReader) getSourceOrModuleImageConfigs(
 		}
 		imageConfig, fileAnnotations, err := i.buildModule(
 			ctx,
-			moduleConfig.Config(),
-			moduleConfig.Module(),
+			moduleConfig,
 			buildOpts...,
 		)
 		if err != nil {
@@ -227,18 +227,17 @@ func (i *imageConfigReader) getImageImageConfig(
 	if err != nil {
 		return nil, err
 	}
-	return newImageConfig(image, config), nil
+	return newImageConfig(image, config, nil, nil), nil
 }
 
 func (i *imageConfigReader) buildModule(
 	ctx context.Context,
-	config *bufconfig.Config,
-	module bufmodule.Module,
+	moduleConfig ModuleConfig,
 	buildOpts ...bufimagebuild.BuildOption,
 ) (ImageConfig, []bufanalysis.FileAnnotation, error) {
 	image, fileAnnotations, err := i.imageBuilder.Build(
 		ctx,
-		module,
+		moduleConfig.Module(),
 		buildOpts...,
 	)
 	if err != nil {
@@ -247,7 +246,12 @@ func (i *imageConfigReader) buildModule(
 	if len(fileAnnotations) > 0 {
 		return nil, fileAnnotations, nil
 	}
-	return newImageConfig(image, config), nil, nil
+	return newImageConfig(
+		image,
+		moduleConfig.Config(),
+		moduleConfig.Module(),
+		moduleConfig.SourceReadBucket(),
+	), nil, nil
 }
 
 // filterImageConfigs takes in image configs and filters them based on the proto file ref.
@@ -270,6 +274,8 @@ func filterImageConfigs(imageConfigs []ImageConfig, protoFileRef buffetch.ProtoF
 	var pkg string
 	var path string
 	var config *bufconfig.Config
+	var module bufmodule.Module
+	var sourceReadBucket storage.ReadBucket
 	var images []bufimage.Image
 	for _, imageConfig := range imageConfigs {
 		for _, imageFile := range imageConfig.Image().Files() {
@@ -281,6 +287,8 @@ func filterImageConfigs(imageConfigs []ImageConfig, protoFileRef buffetch.ProtoF
 				pkg = imageFile.FileDescriptorProto().GetPackage()
 				path = imageFile.Path()
 				config = imageConfig.Config()
+				module = imageConfig.Module()
+				sourceReadBucket = imageConfig.SourceReadBucket()
 				break
 			}
 		}
@@ -309,5 +317,5 @@ func filterImageConfigs(imageConfigs []ImageConfig, protoFileRef buffetch.ProtoF
 	if err != nil {
 		return nil, err
 	}
-	return []ImageConfig{newImageConfig(prunedImage, config)}, nil
+	return []ImageConfig{newImageConfig(prunedImage, config, module, sourceReadBucket)}, nil
 }
@@ -17,17 +17,24 @@ package bufwire
 import (
 	"github.com/bufbuild/buf/private/bufpkg/bufconfig"
 	"github.com/bufbuild/buf/private/bufpkg/bufmodule"
+	"github.com/bufbuild/buf/private/pkg/storage"
 )
 
 type moduleConfig struct {
-	module bufmodule.Module
-	config *bufconfig.Config
+	module           bufmodule.Module
+	config           *bufconfig.Config
+	sourceReadBucket storage.ReadBucket
 }
 
-func newModuleConfig(module bufmodule.Module, config *bufconfig.Config) *moduleConfig {
+func newModuleConfig(
+	module bufmodule.Module,
+	config *bufconfig.Config,
+	sourceReadBucket storage.ReadBucket,
+) *moduleConfig {
 	return &moduleConfig{
-		module: module,
-		config: config,
+		module:           module,
+		config:           config,
+		sourceReadBucket: sourceReadBucket,
 	}
 }
 
@@ -38,3 +45,7 @@ func (m *moduleConfig) Module() bufmodule.Module {
 func (m *moduleConfig) Config() *bufconfig.Config {
 	return m.config
 }
+
+func (m *moduleConfig) SourceReadBucket() storage.ReadBucket {
+	return m.sourceReadBucket
+}
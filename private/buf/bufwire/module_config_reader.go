@@ -251,7 +251,7 @@ func (m *moduleConfigReader) getModuleModuleConfig(
 	if err != nil {
 		return nil, err
 	}
-	return newModuleConfig(module, config), nil
+	return newModuleConfig(module, config, nil), nil
 }
 
 func (m *moduleConfigReader) getProtoFileModuleSourceConfigSet(
@@ -513,7 +513,7 @@ func (m *moduleConfigReader) getSourceModuleConfig(
 				}
 			}
 		}
-		return newModuleConfig(module, moduleConfig), nil
+		return newModuleConfig(module, moduleConfig, mappedReadBucket), nil
 	}
 	moduleConfig, err := bufconfig.ReadConfigOS(
 		ctx,
@@ -648,7 +648,7 @@ func (m *moduleConfigReader) getSourceModuleConfig(
 		}
 		m.logger.Warn(builder.String())
 	}
-	return newModuleConfig(module, moduleConfig), nil
+	return newModuleConfig(module, moduleConfig, mappedReadBucket), nil
 }
 
 func workspaceDirectoryEqualsOrContainsSubDirPath(workspaceConfig *bufwork.Config, subDirPath string) bool {
@@ -17,6 +17,7 @@ package buf
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
@@ -384,6 +385,110 @@ lint:
 	)
 }
 
+func TestFailSARIF(t *testing.T) {
+	t.Parallel()
+	stdout := bytes.NewBuffer(nil)
+	testRun(
+		t,
+		bufcli.ExitCodeFileAnnotation,
+		nil,
+		stdout,
+		"lint",
+		filepath.Join("testdata", "fail"),
+		"--error-format",
+		"sarif",
+	)
+	var sarifLog struct {
+		Version string `json:"version"`
+		Runs    []struct {
+			Tool struct {
+				Driver struct {
+					Rules []struct {
+						ID               string `json:"id"`
+						ShortDescription struct {
+							Text string `json:"text"`
+						} `json:"shortDescription"`
+						Properties struct {
+							Categories []string `json:"categories"`
+						} `json:"properties"`
+					} `json:"rules"`
+				} `json:"driver"`
+			} `json:"tool"`
+			Results []struct {
+				RuleID    string `json:"ruleId"`
+				RuleIndex int    `json:"ruleIndex"`
+				Locations []struct {
+					PhysicalLocation struct {
+						ArtifactLocation struct {
+							URI string `json:"uri"`
+						} `json:"artifactLocation"`
+						Region struct {
+							StartLine   int `json:"startLine"`
+							StartColumn int `json:"startColumn"`
+						} `json:"region"`
+					} `json:"physicalLocation"`
+				} `json:"locations"`
+				Fingerprints map[string]string `json:"fingerprints"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &sarifLog))
+	assert.Equal(t, "2.1.0", sarifLog.Version)
+	require.Len(t, sarifLog.Runs, 1)
+	run := sarifLog.Runs[0]
+	require.Len(t, run.Tool.Driver.Rules, 2)
+	assert.Equal(t, "FIELD_LOWER_SNAKE_CASE", run.Tool.Driver.Rules[0].ID)
+	assert.Equal(t, "Checks that field names are lower_snake_case.", run.Tool.Driver.Rules[0].ShortDescription.Text)
+	assert.Equal(t, []string{"BASIC", "DEFAULT"}, run.Tool.Driver.Rules[0].Properties.Categories)
+	assert.Equal(t, "PACKAGE_DIRECTORY_MATCH", run.Tool.Driver.Rules[1].ID)
+	require.Len(t, run.Results, 2)
+	assert.Equal(t, "PACKAGE_DIRECTORY_MATCH", run.Results[0].RuleID)
+	assert.Equal(t, 1, run.Results[0].RuleIndex)
+	require.Len(t, run.Results[0].Locations, 1)
+	assert.Equal(t, "testdata/fail/buf/buf.proto", run.Results[0].Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	assert.Equal(t, 3, run.Results[0].Locations[0].PhysicalLocation.Region.StartLine)
+	assert.Equal(t, 1, run.Results[0].Locations[0].PhysicalLocation.Region.StartColumn)
+	assert.NotEmpty(t, run.Results[0].Fingerprints["buf/v1"])
+	assert.Equal(t, "FIELD_LOWER_SNAKE_CASE", run.Results[1].RuleID)
+	assert.Equal(t, 0, run.Results[1].RuleIndex)
+}
+
+func TestSuccessSARIF(t *testing.T) {
+	t.Parallel()
+	// SARIF is printed even if there are no violations.
+	for _, args := range [][]string{
+		{"lint", filepath.Join("testdata", "success")},
+		{"breaking", filepath.Join("testdata", "success"), "--against", filepath.Join("testdata", "success")},
+	} {
+		stdout := bytes.NewBuffer(nil)
+		testRun(
+			t,
+			0,
+			nil,
+			stdout,
+			append(args, "--error-format", "sarif")...,
+		)
+		var sarifLog struct {
+			Version string `json:"version"`
+			Runs    []struct {
+				Tool struct {
+					Driver struct {
+						Rules []json.RawMessage `json:"rules"`
+					} `json:"driver"`
+				} `json:"tool"`
+				Results []json.RawMessage `json:"results"`
+			} `json:"runs"`
+		}
+		require.NoError(t, json.Unmarshal(stdout.Bytes(), &sarifLog))
+		assert.Equal(t, "2.1.0", sarifLog.Version)
+		require.Len(t, sarifLog.Runs, 1)
+		assert.NotNil(t, sarifLog.Runs[0].Tool.Driver.Rules)
+		assert.Empty(t, sarifLog.Runs[0].Tool.Driver.Rules)
+		assert.NotNil(t, sarifLog.Runs[0].Results)
+		assert.Empty(t, sarifLog.Runs[0].Results)
+	}
+}
+
 func TestFail13(t *testing.T) {
 	t.Parallel()
 	// this tests that we still use buf.mod if it exists
@@ -420,6 +525,53 @@ func TestFailCheckBreaking1(t *testing.T) {
 	)
 }
 
+func TestCheckBreakingSummary(t *testing.T) {
+	t.Parallel()
+	testRunStdout(
+		t,
+		nil,
+		0,
+		filepath.FromSlash(`
+		example: major
+		  wire-breaking: testdata/protofileref/breaking/a/bar.proto:5:1:Previously present field "2" with name "value" on message "Bar" was deleted without reserving the number "2". (FIELD_NO_DELETE_UNLESS_NUMBER_RESERVED)
+		  wire-breaking: testdata/protofileref/breaking/a/foo.proto:7:3:Field "2" on message "Foo" changed type from "int32" to "string". See https://developers.google.com/protocol-buffers/docs/proto3#updating for wire compatibility rules. (FIELD_WIRE_COMPATIBLE_TYPE)
+		`),
+		"breaking",
+		filepath.Join("testdata", "protofileref", "breaking", "a"),
+		"--against",
+		filepath.Join("testdata", "protofileref", "breaking", "b"),
+		"--summary",
+	)
+	testRunStdout(
+		t,
+		nil,
+		0,
+		`{"bump":"none","packages":[{"package":"example","bump":"none"}]}`,
+		"breaking",
+		filepath.Join("testdata", "protofileref", "breaking", "a"),
+		"--against",
+		filepath.Join("testdata", "protofileref", "breaking", "a"),
+		"--summary",
+		"--summary-format",
+		"json",
+	)
+}
+
+func TestBetaChangelog(t *testing.T) {
+	t.Parallel()
+	testRunStdout(
+		t,
+		nil,
+		0,
+		"# Changelog\n\n## `example`\n\n### Changed\n\n- Field `example.Foo.world`: type changed from \"int32\" to \"string\".\n\n### Removed\n\n- Field `example.Bar.value`",
+		"beta",
+		"changelog",
+		filepath.Join("testdata", "protofileref", "breaking", "a"),
+		"--against",
+		filepath.Join("testdata", "protofileref", "breaking", "b"),
+	)
+}
+
 func TestFailCheckBreaking2(t *testing.T) {
 	t.Parallel()
 	testRunStdout(
@@ -532,7 +684,28 @@ COMMENT_RPC                       COMMENTS                 Checks that RPCs have
 COMMENT_SERVICE                   COMMENTS                 Checks that services have non-empty comments.
 RPC_NO_CLIENT_STREAMING           UNARY_RPC                Checks that RPCs are not client streaming.
 RPC_NO_SERVER_STREAMING           UNARY_RPC                Checks that RPCs are not server streaming.
+AIP_FIELD_BEHAVIOR                AIP                      Checks that fields do not have conflicting google.api.field_behavior annotations, and that the resource name, parent and resource fields of standard method requests are annotated as REQUIRED.
+AIP_PAGINATION                    AIP                      Checks that List requests have page_size and page_token fields, and List responses have a next_page_token field and a repeated field.
+AIP_RESOURCE                      AIP                      Checks that the resources of standard Get methods are annotated with google.api.resource, and that resource annotations have a valid type, a pattern, and a name field.
+AIP_STANDARD_METHOD               AIP                      Checks that standard Get, List, Create, Update and Delete methods have the standard request and response types and request fields.
+DEPRECATION_COMMENT               DEPRECATION              Checks that deprecated elements have a leading comment that explains why or when they will be removed.
+ENUM_NAMING                       NAMING                   Checks that enum names match the naming rules (naming rules are configurable).
+ENUM_VALUE_NAMING                 NAMING                   Checks that enum value names match the naming rules (naming rules are configurable).
+FIELD_NAMING                      NAMING                   Checks that field names match the naming rules (naming rules are configurable).
+FILE_NAMING                       NAMING                   Checks that file paths match the naming rules (naming rules are configurable).
+MESSAGE_NAMING                    NAMING                   Checks that message names match the naming rules (naming rules are configurable).
+ONEOF_NAMING                      NAMING                   Checks that oneof names match the naming rules (naming rules are configurable).
+PACKAGE_NAMING                    NAMING                   Checks that package names match the naming rules (naming rules are configurable).
+RPC_NAMING                        NAMING                   Checks that RPC names match the naming rules (naming rules are configurable).
+SERVICE_NAMING                    NAMING                   Checks that service names match the naming rules (naming rules are configurable).
+ENUM_VALUE_NUMBER_CONTIGUOUS      NUMBERING                Checks that enum value numbers are contiguous, or the gaps are reserved.
+FIELD_NUMBER_CONTIGUOUS           NUMBERING                Checks that field numbers are contiguous from 1, or the gaps are reserved.
+FIELD_NUMBER_LOW_FIRST            NUMBERING                Checks that field numbers 1 to 15, which are encoded with a one-byte tag, are used or reserved before higher field numbers in the messages listed in field_number_low_first_messages.
+RESERVED_NAME_WITH_NUMBER         NUMBERING                Checks that reserved names are accompanied by reserved numbers.
+ENUM_USED                         TYPE_USED                Checks that enums are referenced by an RPC, field, or extension (roots are configurable).
+MESSAGE_USED                      TYPE_USED                Checks that messages are referenced by an RPC, field, or extension (roots are configurable).
 PACKAGE_NO_IMPORT_CYCLE                                    Checks that packages do not have import cycles.
+PROTOVALIDATE_STRICT                                       Checks that protovalidate required rules are only on fields with presence, and duration and timestamp bounds are not inverted.
 		`
 	testRunStdout(
 		t,
@@ -624,64 +797,71 @@ ENUM_FIRST_VALUE_ZERO             OTHER                                       Ch
 func TestCheckLsBreakingRules1(t *testing.T) {
 	t.Parallel()
 	expectedStdout := `
-ID                                              CATEGORIES                      PURPOSE
-ENUM_NO_DELETE                                  FILE                            Checks that enums are not deleted from a given file.
-FILE_NO_DELETE                                  FILE                            Checks that files are not deleted.
-MESSAGE_NO_DELETE                               FILE                            Checks that messages are not deleted from a given file.
-SERVICE_NO_DELETE                               FILE                            Checks that services are not deleted from a given file.
-ENUM_VALUE_NO_DELETE                            FILE, PACKAGE                   Checks that enum values are not deleted from a given enum.
-EXTENSION_MESSAGE_NO_DELETE                     FILE, PACKAGE                   Checks that extension ranges are not deleted from a given message.
-FIELD_NO_DELETE                                 FILE, PACKAGE                   Checks that fields are not deleted from a given message.
-FIELD_SAME_CTYPE                                FILE, PACKAGE                   Checks that fields have the same value for the ctype option.
-FIELD_SAME_JSTYPE                               FILE, PACKAGE                   Checks that fields have the same value for the jstype option.
-FIELD_SAME_TYPE                                 FILE, PACKAGE                   Checks that fields have the same types in a given message.
-FILE_SAME_CC_ENABLE_ARENAS                      FILE, PACKAGE                   Checks that files have the same value for the cc_enable_arenas option.
-FILE_SAME_CC_GENERIC_SERVICES                   FILE, PACKAGE                   Checks that files have the same value for the cc_generic_services option.
-FILE_SAME_CSHARP_NAMESPACE                      FILE, PACKAGE                   Checks that files have the same value for the csharp_namespace option.
-FILE_SAME_GO_PACKAGE                            FILE, PACKAGE                   Checks that files have the same value for the go_package option.
-FILE_SAME_JAVA_GENERIC_SERVICES                 FILE, PACKAGE                   Checks that files have the same value for the java_generic_services option.
-FILE_SAME_JAVA_MULTIPLE_FILES                   FILE, PACKAGE                   Checks that files have the same value for the java_multiple_files option.
-FILE_SAME_JAVA_OUTER_CLASSNAME                  FILE, PACKAGE                   Checks that files have the same value for the java_outer_classname option.
-FILE_SAME_JAVA_PACKAGE                          FILE, PACKAGE                   Checks that files have the same value for the java_package option.
-FILE_SAME_JAVA_STRING_CHECK_UTF8                FILE, PACKAGE                   Checks that files have the same value for the java_string_check_utf8 option.
-FILE_SAME_OBJC_CLASS_PREFIX                     FILE, PACKAGE                   Checks that files have the same value for the objc_class_prefix option.
-FILE_SAME_OPTIMIZE_FOR                          FILE, PACKAGE                   Checks that files have the same value for the optimize_for option.
-FILE_SAME_PHP_CLASS_PREFIX                      FILE, PACKAGE                   Checks that files have the same value for the php_class_prefix option.
-FILE_SAME_PHP_GENERIC_SERVICES                  FILE, PACKAGE                   Checks that files have the same value for the php_generic_services option.
-FILE_SAME_PHP_METADATA_NAMESPACE                FILE, PACKAGE                   Checks that files have the same value for the php_metadata_namespace option.
-FILE_SAME_PHP_NAMESPACE                         FILE, PACKAGE                   Checks that files have the same value for the php_namespace option.
-FILE_SAME_PY_GENERIC_SERVICES                   FILE, PACKAGE                   Checks that files have the same value for the py_generic_services option.
-FILE_SAME_RUBY_PACKAGE                          FILE, PACKAGE                   Checks that files have the same value for the ruby_package option.
-FILE_SAME_SWIFT_PREFIX                          FILE, PACKAGE                   Checks that files have the same value for the swift_prefix option.
-FILE_SAME_SYNTAX                                FILE, PACKAGE                   Checks that files have the same syntax.
-MESSAGE_NO_REMOVE_STANDARD_DESCRIPTOR_ACCESSOR  FILE, PACKAGE                   Checks that messages do not change the no_standard_descriptor_accessor option from false or unset to true.
-ONEOF_NO_DELETE                                 FILE, PACKAGE                   Checks that oneofs are not deleted from a given message.
-RPC_NO_DELETE                                   FILE, PACKAGE                   Checks that rpcs are not deleted from a given service.
-ENUM_VALUE_SAME_NAME                            FILE, PACKAGE, WIRE_JSON        Checks that enum values have the same name.
-FIELD_SAME_JSON_NAME                            FILE, PACKAGE, WIRE_JSON        Checks that fields have the same value for the json_name option.
-FIELD_SAME_NAME                                 FILE, PACKAGE, WIRE_JSON        Checks that fields have the same names in a given message.
-FIELD_SAME_LABEL                                FILE, PACKAGE, WIRE_JSON, WIRE  Checks that fields have the same labels in a given message.
-FIELD_SAME_ONEOF                                FILE, PACKAGE, WIRE_JSON, WIRE  Checks that fields have the same oneofs in a given message.
-FILE_SAME_PACKAGE                               FILE, PACKAGE, WIRE_JSON, WIRE  Checks that files have the same package.
-MESSAGE_SAME_MESSAGE_SET_WIRE_FORMAT            FILE, PACKAGE, WIRE_JSON, WIRE  Checks that messages have the same value for the message_set_wire_format option.
-MESSAGE_SAME_REQUIRED_FIELDS                    FILE, PACKAGE, WIRE_JSON, WIRE  Checks that messages have no added or deleted required fields.
-RESERVED_ENUM_NO_DELETE                         FILE, PACKAGE, WIRE_JSON, WIRE  Checks that reserved ranges and names are not deleted from a given enum.
-RESERVED_MESSAGE_NO_DELETE                      FILE, PACKAGE, WIRE_JSON, WIRE  Checks that reserved ranges and names are not deleted from a given message.
-RPC_SAME_CLIENT_STREAMING                       FILE, PACKAGE, WIRE_JSON, WIRE  Checks that rpcs have the same client streaming value.
-RPC_SAME_IDEMPOTENCY_LEVEL                      FILE, PACKAGE, WIRE_JSON, WIRE  Checks that rpcs have the same value for the idempotency_level option.
-RPC_SAME_REQUEST_TYPE                           FILE, PACKAGE, WIRE_JSON, WIRE  Checks that rpcs are have the same request type.
-RPC_SAME_RESPONSE_TYPE                          FILE, PACKAGE, WIRE_JSON, WIRE  Checks that rpcs are have the same response type.
-RPC_SAME_SERVER_STREAMING                       FILE, PACKAGE, WIRE_JSON, WIRE  Checks that rpcs have the same server streaming value.
-PACKAGE_ENUM_NO_DELETE                          PACKAGE                         Checks that enums are not deleted from a given package.
-PACKAGE_MESSAGE_NO_DELETE                       PACKAGE                         Checks that messages are not deleted from a given package.
-PACKAGE_NO_DELETE                               PACKAGE                         Checks that packages are not deleted.
-PACKAGE_SERVICE_NO_DELETE                       PACKAGE                         Checks that services are not deleted from a given package.
-ENUM_VALUE_NO_DELETE_UNLESS_NAME_RESERVED       WIRE_JSON                       Checks that enum values are not deleted from a given enum unless the name is reserved.
-FIELD_NO_DELETE_UNLESS_NAME_RESERVED            WIRE_JSON                       Checks that fields are not deleted from a given message unless the name is reserved.
-FIELD_WIRE_JSON_COMPATIBLE_TYPE                 WIRE_JSON                       Checks that fields have wire and JSON compatible types in a given message.
-ENUM_VALUE_NO_DELETE_UNLESS_NUMBER_RESERVED     WIRE_JSON, WIRE                 Checks that enum values are not deleted from a given enum unless the number is reserved.
-FIELD_NO_DELETE_UNLESS_NUMBER_RESERVED          WIRE_JSON, WIRE                 Checks that fields are not deleted from a given message unless the number is reserved.
-FIELD_WIRE_COMPATIBLE_TYPE                      WIRE                            Checks that fields have wire-compatible types in a given message.
+ID                                              CATEGORIES                              PURPOSE
+ENUM_NO_DELETE                                  FILE                                    Checks that enums are not deleted from a given file.
+FILE_NO_DELETE                                  FILE                                    Checks that files are not deleted.
+MESSAGE_NO_DELETE                               FILE                                    Checks that messages are not deleted from a given file.
+SERVICE_NO_DELETE                               FILE                                    Checks that services are not deleted from a given file.
+CUSTOM_OPTION_SAME_VALUE                        FILE, PACKAGE                           Checks that custom options are not added, changed, or removed (custom options are configurable).
+ENUM_VALUE_NO_DELETE                            FILE, PACKAGE                           Checks that enum values are not deleted from a given enum.
+EXTENSION_MESSAGE_NO_DELETE                     FILE, PACKAGE                           Checks that extension ranges are not deleted from a given message.
+FIELD_NO_DELETE                                 FILE, PACKAGE                           Checks that fields are not deleted from a given message.
+FIELD_SAME_CTYPE                                FILE, PACKAGE                           Checks that fields have the same value for the ctype option.
+FIELD_SAME_JSTYPE                               FILE, PACKAGE                           Checks that fields have the same value for the jstype option.
+FIELD_SAME_TYPE                                 FILE, PACKAGE                           Checks that fields have the same types in a given message.
+FILE_SAME_CC_ENABLE_ARENAS                      FILE, PACKAGE                           Checks that files have the same value for the cc_enable_arenas option.
+FILE_SAME_CC_GENERIC_SERVICES                   FILE, PACKAGE                           Checks that files have the same value for the cc_generic_services option.
+FILE_SAME_CSHARP_NAMESPACE                      FILE, PACKAGE                           Checks that files have the same value for the csharp_namespace option.
+FILE_SAME_GO_PACKAGE                            FILE, PACKAGE                           Checks that files have the same value for the go_package option.
+FILE_SAME_JAVA_GENERIC_SERVICES                 FILE, PACKAGE                           Checks that files have the same value for the java_generic_services option.
+FILE_SAME_JAVA_MULTIPLE_FILES                   FILE, PACKAGE                           Checks that files have the same value for the java_multiple_files option.
+FILE_SAME_JAVA_OUTER_CLASSNAME                  FILE, PACKAGE                           Checks that files have the same value for the java_outer_classname option.
+FILE_SAME_JAVA_PACKAGE                          FILE, PACKAGE                           Checks that files have the same value for the java_package option.
+FILE_SAME_JAVA_STRING_CHECK_UTF8                FILE, PACKAGE                           Checks that files have the same value for the java_string_check_utf8 option.
+FILE_SAME_OBJC_CLASS_PREFIX                     FILE, PACKAGE                           Checks that files have the same value for the objc_class_prefix option.
+FILE_SAME_OPTIMIZE_FOR                          FILE, PACKAGE                           Checks that files have the same value for the optimize_for option.
+FILE_SAME_PHP_CLASS_PREFIX                      FILE, PACKAGE                           Checks that files have the same value for the php_class_prefix option.
+FILE_SAME_PHP_GENERIC_SERVICES                  FILE, PACKAGE                           Checks that files have the same value for the php_generic_services option.
+FILE_SAME_PHP_METADATA_NAMESPACE                FILE, PACKAGE                           Checks that files have the same value for the php_metadata_namespace option.
+FILE_SAME_PHP_NAMESPACE                         FILE, PACKAGE                           Checks that files have the same value for the php_namespace option.
+FILE_SAME_PY_GENERIC_SERVICES                   FILE, PACKAGE                           Checks that files have the same value for the py_generic_services option.
+FILE_SAME_RUBY_PACKAGE                          FILE, PACKAGE                           Checks that files have the same value for the ruby_package option.
+FILE_SAME_SWIFT_PREFIX                          FILE, PACKAGE                           Checks that files have the same value for the swift_prefix option.
+FILE_SAME_SYNTAX                                FILE, PACKAGE                           Checks that files have the same syntax.
+MESSAGE_NO_REMOVE_STANDARD_DESCRIPTOR_ACCESSOR  FILE, PACKAGE                           Checks that messages do not change the no_standard_descriptor_accessor option from false or unset to true.
+ONEOF_NO_DELETE                                 FILE, PACKAGE                           Checks that oneofs are not deleted from a given message.
+RPC_NO_DELETE                                   FILE, PACKAGE                           Checks that rpcs are not deleted from a given service.
+ENUM_VALUE_SAME_NAME                            FILE, PACKAGE, WIRE_JSON                Checks that enum values have the same name.
+FIELD_SAME_JSON_NAME                            FILE, PACKAGE, WIRE_JSON                Checks that fields have the same value for the json_name option.
+FIELD_SAME_NAME                                 FILE, PACKAGE, WIRE_JSON                Checks that fields have the same names in a given message.
+FIELD_SAME_LABEL                                FILE, PACKAGE, WIRE_JSON, WIRE          Checks that fields have the same labels in a given message.
+FILE_SAME_PACKAGE                               FILE, PACKAGE, WIRE_JSON, WIRE          Checks that files have the same package.
+MESSAGE_SAME_MESSAGE_SET_WIRE_FORMAT            FILE, PACKAGE, WIRE_JSON, WIRE          Checks that messages have the same value for the message_set_wire_format option.
+MESSAGE_SAME_REQUIRED_FIELDS                    FILE, PACKAGE, WIRE_JSON, WIRE          Checks that messages have no added or deleted required fields.
+RESERVED_ENUM_NO_DELETE                         FILE, PACKAGE, WIRE_JSON, WIRE          Checks that reserved ranges and names are not deleted from a given enum.
+RESERVED_MESSAGE_NO_DELETE                      FILE, PACKAGE, WIRE_JSON, WIRE          Checks that reserved ranges and names are not deleted from a given message.
+RPC_SAME_CLIENT_STREAMING                       FILE, PACKAGE, WIRE_JSON, WIRE          Checks that rpcs have the same client streaming value.
+RPC_SAME_IDEMPOTENCY_LEVEL                      FILE, PACKAGE, WIRE_JSON, WIRE          Checks that rpcs have the same value for the idempotency_level option.
+RPC_SAME_REQUEST_TYPE                           FILE, PACKAGE, WIRE_JSON, WIRE          Checks that rpcs are have the same request type.
+RPC_SAME_RESPONSE_TYPE                          FILE, PACKAGE, WIRE_JSON, WIRE          Checks that rpcs are have the same response type.
+RPC_SAME_SERVER_STREAMING                       FILE, PACKAGE, WIRE_JSON, WIRE          Checks that rpcs have the same server streaming value.
+FIELD_SAME_ONEOF                                FILE, PACKAGE, WIRE_JSON, WIRE, SOURCE  Checks that fields have the same oneofs in a given message.
+PACKAGE_ENUM_NO_DELETE                          PACKAGE                                 Checks that enums are not deleted from a given package.
+PACKAGE_MESSAGE_NO_DELETE                       PACKAGE                                 Checks that messages are not deleted from a given package.
+PACKAGE_NO_DELETE                               PACKAGE                                 Checks that packages are not deleted.
+PACKAGE_SERVICE_NO_DELETE                       PACKAGE                                 Checks that services are not deleted from a given package.
+ENUM_VALUE_NO_DELETE_UNLESS_NAME_RESERVED       WIRE_JSON                               Checks that enum values are not deleted from a given enum unless the name is reserved.
+FIELD_NO_DELETE_UNLESS_NAME_RESERVED            WIRE_JSON                               Checks that fields are not deleted from a given message unless the name is reserved.
+FIELD_WIRE_JSON_COMPATIBLE_TYPE                 WIRE_JSON                               Checks that fields have wire and JSON compatible types in a given message.
+ENUM_VALUE_NO_DELETE_UNLESS_NUMBER_RESERVED     WIRE_JSON, WIRE                         Checks that enum values are not deleted from a given enum unless the number is reserved.
+FIELD_NO_DELETE_UNLESS_NUMBER_RESERVED          WIRE_JSON, WIRE                         Checks that fields are not deleted from a given message unless the number is reserved.
+FIELD_WIRE_COMPATIBLE_TYPE                      WIRE                                    Checks that fields have wire-compatible types in a given message.
+DEPRECATE_BEFORE_DELETE                         DEPRECATION                             Checks that fields are deprecated in the previous version before they are deleted.
+DEPRECATION_RPC_REFERENCE                       DEPRECATION                             Checks that RPCs that are added and not deprecated do not use deprecated messages, or messages with deprecated fields, as their request or response.
+PROTOVALIDATE_NO_TIGHTENING                     PROTOVALIDATE                           Checks that protovalidate constraints are not tightened.
+FIELD_SAME_GO_NAME                              SOURCE                                  Checks that fields have the same names in code generated for Go by protoc-gen-go.
+FIELD_SAME_JAVA_NAME                            SOURCE                                  Checks that fields have the same accessor names in code generated for Java by protoc.
+MESSAGE_CPP_CLASS_NO_DELETE                     SOURCE                                  Checks that messages are not moved or deleted in a way that deletes the classes generated for C++ by protoc.
 		`
 	testRunStdout(
 		t,
@@ -804,6 +984,87 @@ FIELD_NO_DELETE_UNLESS_NUMBER_RESERVED          WIRE_JSON, WIRE
 	)
 }
 
+func TestLintExplain(t *testing.T) {
+	t.Parallel()
+	testRunStdout(
+		t,
+		nil,
+		0,
+		`
+		PACKAGE_DEFINED
+
+		Categories: MINIMAL, BASIC, DEFAULT
+		Checks that all files have a package defined.
+
+		Rationale:
+		  Files without a package put their types in the global namespace, where they can conflict with the types of any other file.
+
+		Bad:
+		  syntax = "proto3";
+
+		  message Order {}
+
+		Good:
+		  syntax = "proto3";
+
+		  package acme.v1;
+
+		  message Order {}
+
+		Fix:
+		  Add a package declaration to the file, and move the file to the directory that matches the package.
+		`,
+		"lint",
+		"--explain",
+		"PACKAGE_DEFINED",
+	)
+	testRun(
+		t,
+		1,
+		nil,
+		nil,
+		"lint",
+		"--explain",
+		"PACKAGE_UNDEFINED",
+	)
+}
+
+func TestBreakingExplain(t *testing.T) {
+	t.Parallel()
+	testRunStdout(
+		t,
+		nil,
+		0,
+		`
+		FILE_SAME_PACKAGE
+
+		Categories: FILE, PACKAGE, WIRE_JSON, WIRE
+		Checks that files have the same package.
+
+		Rationale:
+		  The package is part of the fully-qualified name of every type of the file. Changing it changes the names used by the JSON form of google.protobuf.Any, by gRPC for service paths, and by generated code.
+
+		Bad:
+		  // Previous
+		  package acme.v1;
+		  // Current
+		  package acme.orders.v1;
+
+		Good:
+		  // Previous
+		  package acme.v1;
+		  // Current
+		  package acme.v1;
+
+		Fix:
+		  Restore the previous package. To move types to a new package, copy them to a new file in the new package, and deprecate the previous types.
+		`,
+		"breaking",
+		"--explain",
+		"FILE_SAME_PACKAGE",
+	)
+}
+
 func TestLsFiles(t *testing.T) {
 	t.Parallel()
 	testRunStdout(
@@ -1506,6 +1767,148 @@ testdata/paths/a/v3/foo/foo.proto:3:1:Package name "a.v3.foo" should be suffixed
 	)
 }
 
+func TestLintFix(t *testing.T) {
+	t.Parallel()
+	tempDir := t.TempDir()
+	require.NoError(
+		t,
+		os.WriteFile(
+			filepath.Join(tempDir, "buf.yaml"),
+			[]byte(`version: v1
+lint:
+  use:
+    - FIELD_LOWER_SNAKE_CASE
+    - MESSAGE_PASCAL_CASE
+`),
+			0600,
+		),
+	)
+	require.NoError(
+		t,
+		os.WriteFile(
+			filepath.Join(tempDir, "a.proto"),
+			[]byte(`syntax = "proto3";
+
+package a;
+
+message Foo {
+    int32 fooBar = 1;
+}
+
+message bar {}
+`),
+			0600,
+		),
+	)
+	testRunStdoutStderrNoWarn(
+		t,
+		nil,
+		bufcli.ExitCodeFileAnnotation,
+		filepath.Join(tempDir, "a.proto")+`:9:9:Message name "bar" should be PascalCase, such as "Bar".`,
+		"fixed: "+filepath.Join(tempDir, "a.proto")+`:6:11:Field name "fooBar" should be lower_snake_case, such as "foo_bar".`,
+		"lint",
+		tempDir,
+		"--fix",
+	)
+	data, err := os.ReadFile(filepath.Join(tempDir, "a.proto"))
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		`syntax = "proto3";
+
+package a;
+
+message Foo {
+  int32 foo_bar = 1;
+}
+
+message bar {}
+`,
+		string(data),
+	)
+	// The mode of the rewritten file is preserved.
+	fileInfo, err := os.Stat(filepath.Join(tempDir, "a.proto"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), fileInfo.Mode().Perm())
+	testRunStdoutStderrNoWarn(
+		t,
+		nil,
+		1,
+		"",
+		"Failure: --fix can only be used with local directory or .proto file inputs",
+		"lint",
+		filepath.Join(tempDir, "a.proto")+"#format=bin",
+		"--fix",
+	)
+}
+
+func TestLintBaselineSymlink(t *testing.T) {
+	t.Parallel()
+	tempDir := t.TempDir()
+	baselineDir := t.TempDir()
+	require.NoError(
+		t,
+		os.WriteFile(
+			filepath.Join(tempDir, "buf.yaml"),
+			[]byte(`version: v1
+lint:
+  use:
+    - MESSAGE_PASCAL_CASE
+  baseline: lint-baseline.json
+`),
+			0600,
+		),
+	)
+	require.NoError(
+		t,
+		os.WriteFile(
+			filepath.Join(tempDir, "a.proto"),
+			[]byte(`syntax = "proto3";
+
+package a;
+
+message bar {}
+`),
+			0600,
+		),
+	)
+	require.NoError(
+		t,
+		os.WriteFile(
+			filepath.Join(baselineDir, "lint-baseline.json"),
+			[]byte(`{"version":"v1","entries":[{"rule":"MESSAGE_PASCAL_CASE","path":"a.proto","element":"a.bar","count":1}]}`),
+			0600,
+		),
+	)
+	require.NoError(
+		t,
+		os.Symlink(
+			filepath.Join(baselineDir, "lint-baseline.json"),
+			filepath.Join(tempDir, "lint-baseline.json"),
+		),
+	)
+	testRunStdoutStderrNoWarn(
+		t,
+		nil,
+		0,
+		"",
+		"",
+		"lint",
+		tempDir,
+	)
+	// The baseline is read from the module, so symlinks are not followed.
+	testRunStdoutStderrNoWarn(
+		t,
+		nil,
+		1,
+		"",
+		"Failure: could not open lint.baseline: stat lint-baseline.json: file does not exist",
+		"lint",
+		tempDir,
+		"--disable-symlinks",
+	)
+}
+
 func TestBreakingWithPaths(t *testing.T) {
 	t.Parallel()
 	tempDir := t.TempDir()
@@ -1539,6 +1942,135 @@ a/v3/a.proto:7:10:Field "2" on message "Foo" changed name from "value" to "Value
 	)
 }
 
+func TestBreakingAgainstTags(t *testing.T) {
+	t.Parallel()
+	tempDir := testBreakingGitRepository(t)
+	input := filepath.Join(tempDir, "proto")
+	inputFilePath := filepath.Join(input, "a.proto")
+	against := filepath.Join(tempDir, ".git") + "#subdir=proto"
+	// Checking against the latest tag alone does not catch the reuse of field 2.
+	testRunStdout(
+		t,
+		nil,
+		bufcli.ExitCodeFileAnnotation,
+		fmt.Sprintf(`%s:3:1:Previously present reserved range "[2]" on message "A" was deleted.`, inputFilePath),
+		"breaking",
+		input,
+		"--against",
+		filepath.Join(tempDir, ".git")+"#subdir=proto,tag=v1.1.0",
+	)
+	testRunStdout(
+		t,
+		nil,
+		bufcli.ExitCodeFileAnnotation,
+		fmt.Sprintf(
+			`%s:3:1:Previously present reserved range "[2]" on message "A" was deleted (against v1.1.0).
+%s:5:3:Field "2" with name "baz" on message "A" changed option "json_name" from "bar" to "baz" (against v1.0.0).
+%s:5:3:Field "2" on message "A" changed type from "string" to "int32". See https://developers.google.com/protocol-buffers/docs/proto3#updating for wire compatibility rules and https://developers.google.com/protocol-buffers/docs/proto3#json for JSON compatibility rules (against v1.0.0).
+%s:5:9:Field "2" on message "A" changed name from "bar" to "baz" (against v1.0.0).`,
+			inputFilePath,
+			inputFilePath,
+			inputFilePath,
+			inputFilePath,
+		),
+		"breaking",
+		input,
+		"--against",
+		against,
+		"--against-tags",
+		"v*",
+	)
+	testRunStdout(
+		t,
+		nil,
+		0,
+		``,
+		"breaking",
+		input,
+		"--against",
+		against,
+		"--against-tags",
+		"none*",
+	)
+}
+
+func TestBreakingAgainstHistory(t *testing.T) {
+	t.Parallel()
+	tempDir := testBreakingGitRepository(t)
+	input := filepath.Join(tempDir, "proto")
+	commitHash := strings.TrimSpace(testRunGit(t, tempDir, "rev-parse", "v1.0.0"))
+	testRunStdout(
+		t,
+		nil,
+		bufcli.ExitCodeFileAnnotation,
+		fmt.Sprintf(
+			`%s:5:15:Field "2" with name "baz" on message "A" reuses the number of field "bar" of type "string" that was added in commit %s.`,
+			filepath.Join(input, "a.proto"),
+			commitHash,
+		),
+		"breaking",
+		input,
+		"--against",
+		filepath.Join(tempDir, ".git")+"#subdir=proto",
+		"--against-history",
+	)
+}
+
+// testBreakingGitRepository creates a git repository with a module in the proto directory.
+//
+// Field 2 of message A is added in v1.0.0 and deleted in v1.1.0, and the working tree reuses
+// field 2 with a different name and type.
+func testBreakingGitRepository(t *testing.T) string {
+	tempDir := t.TempDir()
+	runGit := func(args ...string) {
+		testRunGit(t, tempDir, args...)
+	}
+	writeProto := func(fields string) {
+		require.NoError(
+			t,
+			os.WriteFile(
+				filepath.Join(tempDir, "proto", "a.proto"),
+				[]byte("syntax = \"proto3\";\npackage a;\nmessage A {\n  string foo = 1;\n"+fields+"}\n"),
+				0600,
+			),
+		)
+	}
+	require.NoError(t, os.Mkdir(filepath.Join(tempDir, "proto"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "proto", "buf.yaml"), []byte("version: v1\nbreaking:\n  use:\n    - WIRE_JSON\n"), 0600))
+	runGit("init")
+	runGit("config", "user.name", "Buf TestBot")
+	runGit("config", "user.email", "testbot@buf.build")
+	writeProto("  string bar = 2;\n")
+	runGit("add", ".")
+	runGit("commit", "-m", "v1.0.0")
+	runGit("tag", "v1.0.0")
+	writeProto("  reserved 2;\n")
+	runGit("commit", "-am", "v1.1.0")
+	runGit("tag", "v1.1.0")
+	runGit("tag", "other")
+	writeProto("  int32 baz = 2;\n")
+	return tempDir
+}
+
+// testRunGit runs git in the directory and returns its stdout.
+func testRunGit(t *testing.T, dirPath string, args ...string) string {
+	stdout := bytes.NewBuffer(nil)
+	stderr := bytes.NewBuffer(nil)
+	require.NoError(
+		t,
+		command.NewRunner().Run(
+			context.Background(),
+			"git",
+			command.RunWithArgs(args...),
+			command.RunWithDir(dirPath),
+			command.RunWithStdout(stdout),
+			command.RunWithStderr(stderr),
+		),
+		stderr.String(),
+	)
+	return stdout.String()
+}
+
 func TestVersion(t *testing.T) {
 	t.Parallel()
 	testRunStdout(t, nil, 0, bufcli.Version, "--version")
@@ -2236,6 +2768,55 @@ func TestFormatExitCode(t *testing.T) {
 	assert.NotEmpty(t, stdout.String())
 }
 
+func TestFormatStdin(t *testing.T) {
+	t.Parallel()
+	data, err := os.ReadFile(filepath.Join("testdata", "format", "diff", "diff.proto"))
+	require.NoError(t, err)
+	testRunStdout(
+		t,
+		bytes.NewReader(data),
+		0,
+		`
+syntax = "proto3";
+
+package diff;
+
+message Diff {
+  string content = 1;
+}
+		`,
+		"format",
+		"--stdin-filename",
+		filepath.Join("testdata", "format", "diff", "diff.proto"),
+	)
+	testRunStdout(
+		t,
+		bytes.NewReader(data),
+		bufcli.ExitCodeFileAnnotation,
+		`{"start_offset":47,"end_offset":83,"start_line":9,"start_column":9,"end_line":13,"end_column":3,"new_text":"Diff {\n  string content = 1;\n"}`,
+		"format",
+		"--stdin-filename",
+		filepath.Join("testdata", "format", "diff", "diff.proto"),
+		"--range",
+		"9:9",
+		"--edits",
+		"--exit-code",
+	)
+	testRunStdout(
+		t,
+		bytes.NewReader(data),
+		0,
+		``,
+		"format",
+		"--stdin-filename",
+		filepath.Join("testdata", "format", "diff", "diff.proto"),
+		"--range",
+		"7:8",
+		"--edits",
+		"--exit-code",
+	)
+}
+
 // Tests if the image produced by the formatted result is
 // equivalent to the original result.
 func TestFormatEquivalence(t *testing.T) {
@@ -18,20 +18,38 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/bufbuild/buf/private/buf/bufcli"
 	"github.com/bufbuild/buf/private/buf/buffetch"
 	"github.com/bufbuild/buf/private/buf/bufwire"
 	"github.com/bufbuild/buf/private/bufpkg/bufanalysis"
+	"github.com/bufbuild/buf/private/bufpkg/bufcheck"
 	"github.com/bufbuild/buf/private/bufpkg/bufcheck/bufbreaking"
+	"github.com/bufbuild/buf/private/bufpkg/bufcheck/bufbreaking/bufbreakingledger"
+	"github.com/bufbuild/buf/private/bufpkg/bufcheck/bufbreaking/bufbreakingsummary"
+	"github.com/bufbuild/buf/private/bufpkg/bufcheck/bufcheckcache"
+	"github.com/bufbuild/buf/private/bufpkg/bufconfig"
 	"github.com/bufbuild/buf/private/bufpkg/bufimage"
+	"github.com/bufbuild/buf/private/bufpkg/bufimage/bufimagebuild"
+	"github.com/bufbuild/buf/private/bufpkg/bufmodule/bufmodulebuild"
 	"github.com/bufbuild/buf/private/pkg/app/appcmd"
 	"github.com/bufbuild/buf/private/pkg/app/appflag"
 	"github.com/bufbuild/buf/private/pkg/command"
+	"github.com/bufbuild/buf/private/pkg/connectclient"
+	"github.com/bufbuild/buf/private/pkg/git"
+	"github.com/bufbuild/buf/private/pkg/normalpath"
 	"github.com/bufbuild/buf/private/pkg/slicesext"
+	"github.com/bufbuild/buf/private/pkg/storage"
+	"github.com/bufbuild/buf/private/pkg/storage/storagegit"
 	"github.com/bufbuild/buf/private/pkg/stringutil"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
+	"golang.org/x/mod/semver"
 )
 
 const (
@@ -42,8 +60,14 @@ const (
 	configFlagName            = "config"
 	againstFlagName           = "against"
 	againstConfigFlagName     = "against-config"
+	againstTagsFlagName       = "against-tags"
+	againstHistoryFlagName    = "against-history"
 	excludePathsFlagName      = "exclude-path"
 	disableSymlinksFlagName   = "disable-symlinks"
+	summaryFlagName           = "summary"
+	summaryFormatFlagName     = "summary-format"
+	disableCacheFlagName      = "disable-cache"
+	explainFlagName           = "explain"
 )
 
 // NewCommand returns a new Command.
@@ -76,8 +100,14 @@ type flags struct {
 	Config            string
 	Against           string
 	AgainstConfig     string
+	AgainstTags       string
+	AgainstHistory    bool
 	ExcludePaths      []string
 	DisableSymlinks   bool
+	Summary           bool
+	SummaryFormat     string
+	DisableCache      bool
+	Explain           string
 	// special
 	InputHashtag string
 }
@@ -138,6 +168,61 @@ Overrides --%s`,
 		"",
 		`The buf.yaml file or data to use to configure the against source, module, or image`,
 	)
+	flagSet.StringVar(
+		&f.AgainstTags,
+		againstTagsFlagName,
+		"",
+		fmt.Sprintf(
+			`Check against every tag of the --%s git repository that matches this glob pattern, such as "v*"
+Each breaking change is reported with the tags that it breaks
+--%s must be a local git repository that does not specify a branch, tag, or ref`,
+			againstFlagName,
+			againstFlagName,
+		),
+	)
+	flagSet.BoolVar(
+		&f.AgainstHistory,
+		againstHistoryFlagName,
+		false,
+		fmt.Sprintf(
+			`Check that no field of the input reuses a number that a field with a different name or type used in any commit reachable from the checked-out commit of the --%s git repository
+This catches numbers of deleted fields that were not reserved, even if the field was deleted many commits ago
+A field that is renamed between adjacent commits without changing its type is the same field
+--%s must be a local git repository that does not specify a branch, tag, or ref, and the subdirectory must contain a module`,
+			againstFlagName,
+			againstFlagName,
+		),
+	)
+	flagSet.BoolVar(
+		&f.Summary,
+		summaryFlagName,
+		false,
+		`Print a summary of the changes for each package instead of the breaking changes
+The summary classifies the changes as additions, deprecations, and wire-, JSON-, or source-breaking changes, and recommends a major, minor, or patch version bump for each package
+Exits with a non-zero exit code only if the summary could not be produced`,
+	)
+	flagSet.StringVar(
+		&f.SummaryFormat,
+		summaryFormatFlagName,
+		"text",
+		fmt.Sprintf(
+			"The format for the summary printed to stdout if --%s is set. Must be one of %s",
+			summaryFlagName,
+			stringutil.SliceToString(bufbreakingsummary.AllFormatStrings),
+		),
+	)
+	flagSet.BoolVar(
+		&f.DisableCache,
+		disableCacheFlagName,
+		false,
+		`Do not read or write the cache of breaking change detection results. Results are cached for each module, and are reused if the files of the module and the against module, the breaking configuration, and the version of buf are unchanged`,
+	)
+	flagSet.StringVar(
+		&f.Explain,
+		explainFlagName,
+		"",
+		`Print the explanation of the given rule ID instead of checking for breaking changes, including its rationale, examples of breaking and non-breaking changes, the buf.yaml keys that configure it, and how to fix its violations`,
+	)
 }
 
 func run(
@@ -145,12 +230,39 @@ func run(
 	container appflag.Container,
 	flags *flags,
 ) error {
+	if flags.Explain != "" {
+		rules, err := bufbreaking.GetAllRulesV1()
+		if err != nil {
+			return err
+		}
+		return bufcli.PrintRuleExplanation(container.Stdout(), rules, flags.Explain, explainFlagName)
+	}
 	if flags.Against == "" {
 		return appcmd.NewInvalidArgumentErrorf("required flag %q not set", againstFlagName)
 	}
 	if err := bufcli.ValidateErrorFormatFlag(flags.ErrorFormat, errorFormatFlagName); err != nil {
 		return err
 	}
+	if flags.AgainstHistory {
+		if flags.Summary {
+			return appcmd.NewInvalidArgumentErrorf("--%s cannot be set if --%s is set", summaryFlagName, againstHistoryFlagName)
+		}
+		if flags.AgainstTags != "" {
+			return appcmd.NewInvalidArgumentErrorf("--%s cannot be set if --%s is set", againstTagsFlagName, againstHistoryFlagName)
+		}
+	}
+	if flags.AgainstTags != "" {
+		if flags.Summary {
+			return appcmd.NewInvalidArgumentErrorf("--%s cannot be set if --%s is set", summaryFlagName, againstTagsFlagName)
+		}
+		if _, err := path.Match(flags.AgainstTags, ""); err != nil {
+			return appcmd.NewInvalidArgumentErrorf("--%s: invalid pattern %q: %v", againstTagsFlagName, flags.AgainstTags, err)
+		}
+	}
+	summaryFormat, err := bufbreakingsummary.ParseFormat(flags.SummaryFormat)
+	if err != nil {
+		return appcmd.NewInvalidArgumentErrorf("--%s: %v", summaryFormatFlagName, err)
+	}
 	input, err := bufcli.GetInputValue(container, flags.InputHashtag, ".")
 	if err != nil {
 		return err
@@ -210,6 +322,377 @@ func run(
 	if err != nil {
 		return err
 	}
+	if flags.AgainstHistory {
+		return runAgainstHistory(
+			ctx,
+			container,
+			flags,
+			runner,
+			clientConfig,
+			imageConfigs,
+			againstRef,
+		)
+	}
+	if flags.AgainstTags != "" {
+		return runAgainstTags(
+			ctx,
+			container,
+			flags,
+			imageConfigReader,
+			runner,
+			imageConfigs,
+			againstRef,
+			externalPaths,
+		)
+	}
+	againstImageConfigs, err := getAgainstImageConfigs(
+		ctx,
+		container,
+		flags,
+		imageConfigReader,
+		imageConfigs,
+		againstRef,
+		externalPaths,
+	)
+	if err != nil {
+		return err
+	}
+	if flags.Summary {
+		summaries := make([]*bufbreakingsummary.Summary, len(imageConfigs))
+		for i, imageConfig := range imageConfigs {
+			summaries[i], err = summaryForImage(
+				ctx,
+				container,
+				imageConfig,
+				againstImageConfigs[i],
+				flags.ExcludeImports,
+			)
+			if err != nil {
+				return err
+			}
+		}
+		return bufbreakingsummary.PrintSummary(
+			container.Stdout(),
+			bufbreakingsummary.MergeSummaries(summaries...),
+			summaryFormat,
+		)
+	}
+	handler, err := newHandler(container, flags)
+	if err != nil {
+		return err
+	}
+	allFileAnnotations, err := breakingForImages(
+		ctx,
+		handler,
+		imageConfigs,
+		againstImageConfigs,
+		flags.ExcludeImports,
+	)
+	if err != nil {
+		return err
+	}
+	return printBreakingFileAnnotations(container, imageConfigs, allFileAnnotations, flags.ErrorFormat)
+}
+
+// runAgainstTags checks the input against every tag of the against git repository
+// that matches the --against-tags pattern.
+//
+// Each breaking change is reported once, along with the tags that it breaks.
+func runAgainstTags(
+	ctx context.Context,
+	container appflag.Container,
+	flags *flags,
+	imageConfigReader bufwire.ImageConfigReader,
+	runner command.Runner,
+	imageConfigs []bufwire.ImageConfig,
+	againstRef buffetch.Ref,
+	externalPaths []string,
+) error {
+	tags, err := getGitTags(ctx, runner, againstRef, flags.AgainstTags)
+	if err != nil {
+		return err
+	}
+	if len(tags) == 0 {
+		container.Logger().Sugar().Warnf("no tags of %q match %q", flags.Against, flags.AgainstTags)
+		return nil
+	}
+	handler, err := newHandler(container, flags)
+	if err != nil {
+		return err
+	}
+	var keys []fileAnnotationKey
+	keyToFileAnnotation := make(map[fileAnnotationKey]bufanalysis.FileAnnotation)
+	keyToTags := make(map[fileAnnotationKey][]string)
+	for _, tag := range tags {
+		tagRef, err := buffetch.NewSourceRefForGitTag(againstRef, tag)
+		if err != nil {
+			return err
+		}
+		againstImageConfigs, err := getAgainstImageConfigs(
+			ctx,
+			container,
+			flags,
+			imageConfigReader,
+			imageConfigs,
+			tagRef,
+			externalPaths,
+		)
+		if err != nil {
+			return fmt.Errorf("tag %q: %w", tag, err)
+		}
+		fileAnnotations, err := breakingForImages(
+			ctx,
+			handler,
+			imageConfigs,
+			againstImageConfigs,
+			flags.ExcludeImports,
+		)
+		if err != nil {
+			return fmt.Errorf("tag %q: %w", tag, err)
+		}
+		for _, fileAnnotation := range bufanalysis.DeduplicateAndSortFileAnnotations(fileAnnotations) {
+			key := newFileAnnotationKey(fileAnnotation)
+			if _, ok := keyToFileAnnotation[key]; !ok {
+				keys = append(keys, key)
+				keyToFileAnnotation[key] = fileAnnotation
+			}
+			keyToTags[key] = append(keyToTags[key], tag)
+		}
+	}
+	allFileAnnotations := make([]bufanalysis.FileAnnotation, len(keys))
+	for i, key := range keys {
+		fileAnnotation := keyToFileAnnotation[key]
+		allFileAnnotations[i] = bufanalysis.NewFileAnnotation(
+			fileAnnotation.FileInfo(),
+			fileAnnotation.StartLine(),
+			fileAnnotation.StartColumn(),
+			fileAnnotation.EndLine(),
+			fileAnnotation.EndColumn(),
+			fileAnnotation.Type(),
+			fmt.Sprintf(
+				"%s (against %s).",
+				strings.TrimSuffix(fileAnnotation.Message(), "."),
+				strings.Join(keyToTags[key], ", "),
+			),
+		)
+	}
+	return printBreakingFileAnnotations(container, imageConfigs, allFileAnnotations, flags.ErrorFormat)
+}
+
+// runAgainstHistory checks that no field of the input reuses a number that was used
+// by a field with a different name or type in any commit of the checked-out branch
+// of the against git repository.
+func runAgainstHistory(
+	ctx context.Context,
+	container appflag.Container,
+	flags *flags,
+	runner command.Runner,
+	clientConfig *connectclient.Config,
+	imageConfigs []bufwire.ImageConfig,
+	againstRef buffetch.Ref,
+) error {
+	repository, subDirPath, err := openLocalGitRepository(ctx, runner, againstRef, againstHistoryFlagName)
+	if err != nil {
+		return err
+	}
+	defer repository.Close()
+	// Resolve HEAD to a hash instead of a branch, so that detached checkouts,
+	// as done by most CI systems, are supported.
+	headHash, err := repository.CheckedOutHash()
+	if err != nil {
+		return fmt.Errorf("could not determine the checked-out commit of %q: %w", flags.Against, err)
+	}
+	var commits []git.Commit
+	if err := repository.ForEachCommit(
+		func(commit git.Commit) error {
+			commits = append(commits, commit)
+			return nil
+		},
+		git.ForEachCommitWithHashStartPoint(headHash.Hex()),
+	); err != nil {
+		return err
+	}
+	moduleReader, err := bufcli.NewModuleReaderAndCreateCacheDirs(container, clientConfig)
+	if err != nil {
+		return err
+	}
+	storageProvider := storagegit.NewProvider(repository.Objects(), storagegit.ProviderWithSymlinks())
+	imageBuilder := bufimagebuild.NewBuilder(container.Logger(), moduleReader)
+	ledger := bufbreakingledger.NewLedger()
+	// Add the oldest commit first, so that the ledger records the commit that added each field.
+	for i := len(commits) - 1; i >= 0; i-- {
+		commit := commits[i]
+		image, err := buildImageForCommit(ctx, storageProvider, imageBuilder, commit, subDirPath)
+		if err != nil {
+			// Commits that do not build do not contribute to the ledger. This is logged
+			// as a warning, as fields added or deleted in these commits are not checked.
+			container.Logger().Sugar().Warnf("skipping commit %s that does not build: %v", commit.Hash().Hex(), err)
+			continue
+		}
+		if image == nil {
+			container.Logger().Sugar().Debugf("skipping commit %s that does not contain the module", commit.Hash().Hex())
+			continue
+		}
+		if err := ledger.Add(ctx, image, "commit "+commit.Hash().Hex()); err != nil {
+			return err
+		}
+	}
+	var allFileAnnotations []bufanalysis.FileAnnotation
+	for _, imageConfig := range imageConfigs {
+		fileAnnotations, err := bufbreakingledger.Check(ctx, ledger, imageConfig.Image())
+		if err != nil {
+			return err
+		}
+		allFileAnnotations = append(allFileAnnotations, fileAnnotations...)
+	}
+	return printBreakingFileAnnotations(container, imageConfigs, allFileAnnotations, flags.ErrorFormat)
+}
+
+// buildImageForCommit builds the module at subDirPath of the commit.
+//
+// A nil Image is returned if the commit does not contain the module, or the module does
+// not contain any .proto files.
+func buildImageForCommit(
+	ctx context.Context,
+	storageProvider storagegit.Provider,
+	imageBuilder bufimagebuild.Builder,
+	commit git.Commit,
+	subDirPath string,
+) (bufimage.Image, error) {
+	readBucket, err := storageProvider.NewReadBucket(commit.Tree(), storagegit.ReadBucketWithSymlinksIfSupported())
+	if err != nil {
+		return nil, err
+	}
+	if subDirPath != "" {
+		readBucket = storage.MapReadBucket(readBucket, storage.MapOnPrefix(subDirPath))
+	}
+	isEmpty, err := storage.IsEmpty(ctx, readBucket, "")
+	if err != nil {
+		if storage.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if isEmpty {
+		return nil, nil
+	}
+	config, err := bufconfig.GetConfigForBucket(ctx, readBucket)
+	if err != nil {
+		return nil, err
+	}
+	builtModule, err := bufmodulebuild.NewModuleBucketBuilder().BuildForBucket(ctx, readBucket, config.Build)
+	if err != nil {
+		return nil, err
+	}
+	targetFileInfos, err := builtModule.TargetFileInfos(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(targetFileInfos) == 0 {
+		return nil, nil
+	}
+	image, fileAnnotations, err := imageBuilder.Build(
+		ctx,
+		builtModule,
+		bufimagebuild.WithExcludeSourceCodeInfo(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(fileAnnotations) > 0 {
+		return nil, fmt.Errorf("build failed: %v", fileAnnotations[0])
+	}
+	return image, nil
+}
+
+// getGitTags returns the tags of the local git repository of the Ref that match
+// the pattern, sorted by semantic version if possible.
+func getGitTags(
+	ctx context.Context,
+	runner command.Runner,
+	ref buffetch.Ref,
+	pattern string,
+) ([]string, error) {
+	repository, _, err := openLocalGitRepository(ctx, runner, ref, againstTagsFlagName)
+	if err != nil {
+		return nil, err
+	}
+	defer repository.Close()
+	var tags []string
+	if err := repository.ForEachTag(
+		func(tag string, _ git.Hash) error {
+			matched, err := path.Match(pattern, tag)
+			if err != nil {
+				return err
+			}
+			if matched {
+				tags = append(tags, tag)
+			}
+			return nil
+		},
+	); err != nil {
+		return nil, err
+	}
+	sort.Slice(
+		tags,
+		func(i int, j int) bool {
+			if semver.IsValid(tags[i]) && semver.IsValid(tags[j]) {
+				if compare := semver.Compare(tags[i], tags[j]); compare != 0 {
+					return compare < 0
+				}
+			}
+			return tags[i] < tags[j]
+		},
+	)
+	return tags, nil
+}
+
+// openLocalGitRepository opens the local git repository of the Ref, and returns
+// it along with the path of the subdirectory of the Ref within the repository.
+//
+// The caller must close the repository.
+func openLocalGitRepository(
+	ctx context.Context,
+	runner command.Runner,
+	ref buffetch.Ref,
+	flagName string,
+) (git.Repository, string, error) {
+	gitPath, subDirPath, ok := buffetch.GetLocalGitPathAndSubDirPath(ref)
+	if !ok {
+		return nil, "", appcmd.NewInvalidArgumentErrorf(
+			"--%s must be a local git repository that does not specify a branch, tag, or ref when --%s is set",
+			againstFlagName,
+			flagName,
+		)
+	}
+	gitDirPath := normalpath.Unnormalize(gitPath)
+	// Allow the path of the working tree as well as the path of the .git directory.
+	if fileInfo, err := os.Stat(filepath.Join(gitDirPath, git.DotGitDir)); err == nil && fileInfo.IsDir() {
+		gitDirPath = filepath.Join(gitDirPath, git.DotGitDir)
+	}
+	repository, err := git.OpenRepository(
+		ctx,
+		gitDirPath,
+		runner,
+		// The default branch is not used, and detecting it fails for repositories
+		// that have not been pushed.
+		git.OpenRepositoryWithDefaultBranch("HEAD"),
+	)
+	if err != nil {
+		return nil, "", err
+	}
+	return repository, subDirPath, nil
+}
+
+func getAgainstImageConfigs(
+	ctx context.Context,
+	container appflag.Container,
+	flags *flags,
+	imageConfigReader bufwire.ImageConfigReader,
+	imageConfigs []bufwire.ImageConfig,
+	againstRef buffetch.Ref,
+	externalPaths []string,
+) ([]bufwire.ImageConfig, error) {
 	againstImageConfigs, fileAnnotations, err := imageConfigReader.GetImageConfigs(
 		ctx,
 		container,
@@ -221,7 +704,7 @@ func run(
 		true,               // no need to include source info for against
 	)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if len(fileAnnotations) > 0 {
 		if err := bufanalysis.PrintFileAnnotations(
@@ -229,9 +712,9 @@ func run(
 			fileAnnotations,
 			flags.ErrorFormat,
 		); err != nil {
-			return err
+			return nil, err
 		}
-		return bufcli.ErrFileAnnotation
+		return nil, bufcli.ErrFileAnnotation
 	}
 	if len(imageConfigs) != len(againstImageConfigs) {
 		// If workspaces are being used as input, the number
@@ -240,43 +723,113 @@ func run(
 		//
 		// And similar to the note above, if the roots change,
 		// we're torched.
-		return fmt.Errorf("input contained %d images, whereas against contained %d images", len(imageConfigs), len(againstImageConfigs))
+		return nil, fmt.Errorf("input contained %d images, whereas against contained %d images", len(imageConfigs), len(againstImageConfigs))
+	}
+	return againstImageConfigs, nil
+}
+
+// newHandler returns a new bufbreaking.Handler that caches results unless
+// --disable-cache is set.
+func newHandler(container appflag.Container, flags *flags) (bufbreaking.Handler, error) {
+	handler := bufbreaking.NewHandler(container.Logger())
+	if flags.DisableCache {
+		return handler, nil
 	}
+	readWriteBucket, err := bufcli.NewCheckCacheReadWriteBucketAndCreateCacheDirs(container)
+	if err != nil {
+		return nil, err
+	}
+	return bufcheckcache.NewBreakingHandler(container.Logger(), handler, readWriteBucket, bufcli.Version), nil
+}
+
+func breakingForImages(
+	ctx context.Context,
+	handler bufbreaking.Handler,
+	imageConfigs []bufwire.ImageConfig,
+	againstImageConfigs []bufwire.ImageConfig,
+	excludeImports bool,
+) ([]bufanalysis.FileAnnotation, error) {
 	var allFileAnnotations []bufanalysis.FileAnnotation
 	for i, imageConfig := range imageConfigs {
 		fileAnnotations, err := breakingForImage(
 			ctx,
-			container,
+			handler,
 			imageConfig,
 			againstImageConfigs[i],
-			flags.ExcludeImports,
-			flags.ErrorFormat,
+			excludeImports,
 		)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		allFileAnnotations = append(allFileAnnotations, fileAnnotations...)
 	}
-	if len(allFileAnnotations) > 0 {
-		if err := bufanalysis.PrintFileAnnotations(
-			container.Stdout(),
-			bufanalysis.DeduplicateAndSortFileAnnotations(allFileAnnotations),
-			flags.ErrorFormat,
-		); err != nil {
-			return err
-		}
+	return allFileAnnotations, nil
+}
+
+func printBreakingFileAnnotations(
+	container appflag.Container,
+	imageConfigs []bufwire.ImageConfig,
+	fileAnnotations []bufanalysis.FileAnnotation,
+	errorFormat string,
+) error {
+	if len(fileAnnotations) == 0 && !bufcli.IsSARIFErrorFormat(errorFormat) {
+		return nil
+	}
+	ruleInfos, err := bufcli.RuleInfosForImageConfigs(
+		imageConfigs,
+		func(imageConfig bufwire.ImageConfig) ([]bufcheck.Rule, error) {
+			return bufbreaking.RulesForConfig(imageConfig.Config().Breaking)
+		},
+	)
+	if err != nil {
+		return err
+	}
+	if err := bufanalysis.PrintFileAnnotations(
+		container.Stdout(),
+		bufanalysis.DeduplicateAndSortFileAnnotations(fileAnnotations),
+		errorFormat,
+		bufanalysis.PrintFileAnnotationsWithRuleInfos(ruleInfos...),
+	); err != nil {
+		return err
+	}
+	if len(fileAnnotations) > 0 {
 		return bufcli.ErrFileAnnotation
 	}
 	return nil
 }
 
+type fileAnnotationKey struct {
+	path        string
+	startLine   int
+	startColumn int
+	endLine     int
+	endColumn   int
+	typeString  string
+	message     string
+}
+
+func newFileAnnotationKey(fileAnnotation bufanalysis.FileAnnotation) fileAnnotationKey {
+	var filePath string
+	if fileInfo := fileAnnotation.FileInfo(); fileInfo != nil {
+		filePath = fileInfo.Path()
+	}
+	return fileAnnotationKey{
+		path:        filePath,
+		startLine:   fileAnnotation.StartLine(),
+		startColumn: fileAnnotation.StartColumn(),
+		endLine:     fileAnnotation.EndLine(),
+		endColumn:   fileAnnotation.EndColumn(),
+		typeString:  fileAnnotation.Type(),
+		message:     fileAnnotation.Message(),
+	}
+}
+
 func breakingForImage(
 	ctx context.Context,
-	container appflag.Container,
+	handler bufbreaking.Handler,
 	imageConfig bufwire.ImageConfig,
 	againstImageConfig bufwire.ImageConfig,
 	excludeImports bool,
-	errorFormat string,
 ) ([]bufanalysis.FileAnnotation, error) {
 	image := imageConfig.Image()
 	if excludeImports {
@@ -286,8 +839,32 @@ func breakingForImage(
 	if excludeImports {
 		againstImage = bufimage.ImageWithoutImports(againstImage)
 	}
-	return bufbreaking.NewHandler(container.Logger()).Check(
+	return handler.Check(
+		ctx,
+		imageConfig.Config().Breaking,
+		againstImage,
+		image,
+	)
+}
+
+func summaryForImage(
+	ctx context.Context,
+	container appflag.Container,
+	imageConfig bufwire.ImageConfig,
+	againstImageConfig bufwire.ImageConfig,
+	excludeImports bool,
+) (*bufbreakingsummary.Summary, error) {
+	image := imageConfig.Image()
+	if excludeImports {
+		image = bufimage.ImageWithoutImports(image)
+	}
+	againstImage := againstImageConfig.Image()
+	if excludeImports {
+		againstImage = bufimage.ImageWithoutImports(againstImage)
+	}
+	return bufbreakingsummary.Summarize(
 		ctx,
+		bufbreaking.NewHandler(container.Logger()),
 		imageConfig.Config().Breaking,
 		againstImage,
 		image,
@@ -17,17 +17,22 @@ package format
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 
 	"github.com/bufbuild/buf/private/buf/bufcli"
 	"github.com/bufbuild/buf/private/buf/buffetch"
 	"github.com/bufbuild/buf/private/buf/bufformat"
 	"github.com/bufbuild/buf/private/buf/bufwork"
 	"github.com/bufbuild/buf/private/bufpkg/bufanalysis"
+	"github.com/bufbuild/buf/private/bufpkg/bufconfig"
+	"github.com/bufbuild/buf/private/bufpkg/bufformatconfig"
 	"github.com/bufbuild/buf/private/bufpkg/bufmodule"
 	"github.com/bufbuild/buf/private/pkg/app/appcmd"
 	"github.com/bufbuild/buf/private/pkg/app/appflag"
@@ -36,6 +41,8 @@ import (
 	"github.com/bufbuild/buf/private/pkg/storage/storagemem"
 	"github.com/bufbuild/buf/private/pkg/storage/storageos"
 	"github.com/bufbuild/buf/private/pkg/stringutil"
+	"github.com/bufbuild/protocompile/parser"
+	"github.com/bufbuild/protocompile/reporter"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"go.uber.org/multierr"
@@ -46,12 +53,17 @@ const (
 	diffFlagName            = "diff"
 	diffFlagShortName       = "d"
 	disableSymlinksFlagName = "disable-symlinks"
+	editsFlagName           = "edits"
 	errorFormatFlagName     = "error-format"
 	excludePathsFlagName    = "exclude-path"
 	exitCodeFlagName        = "exit-code"
 	outputFlagName          = "output"
 	outputFlagShortName     = "o"
 	pathsFlagName           = "path"
+	rangeFlagName           = "range"
+	sortFlagName            = "sort"
+	sortFieldsFlagName      = "sort-fields"
+	stdinFilenameFlagName   = "stdin-filename"
 	writeFlagName           = "write"
 	writeFlagShortName      = "w"
 )
@@ -160,6 +172,33 @@ Write a diff and rewrite the file(s) in-place:
     ...
 
 The -w and -o flags cannot be used together in a single invocation.
+
+Sort the declarations of the file(s) into a canonical order with --sort, and also
+sort the fields of each message by number with --sort-fields:
+
+    $ buf format -w --sort
+    $ buf format -w --sort --sort-fields
+
+With --sort, imports and options are sorted by name, the declarations of a file are
+ordered services, messages, enums, then extensions, and the declarations of a message
+are ordered options, fields, extension ranges, reserved ranges and names, nested messages,
+nested enums, then extensions. Comments stay attached to the declarations they describe.
+A file is not sorted if this would change its descriptor in any way except source info.
+
+Editors can format unsaved content by writing it to stdin with --stdin-filename. The
+configuration is resolved from the buf.yaml closest to the given path, which does not
+need to exist:
+
+    $ buf format --stdin-filename simple/simple.proto < simple/simple.proto
+
+Format only the declarations that overlap a range of lines with --range, and write the
+edits that format the content as JSON instead of the formatted content with --edits:
+
+    $ buf format --stdin-filename simple/simple.proto --range 7:7 --edits < simple/simple.proto
+    {"start_offset":57,"end_offset":59,"start_line":7,"start_column":3,"end_line":7,"end_column":5,"new_text":""}
+
+The lines of --range are one-based and inclusive. If the range overlaps the syntax, package,
+imports, or options of the file, the entire file is formatted.
 `,
 		Args: cobra.MaximumNArgs(1),
 		Run: builder.NewRunFunc(
@@ -176,11 +215,16 @@ type flags struct {
 	Config          string
 	Diff            bool
 	DisableSymlinks bool
+	Edits           bool
 	ErrorFormat     string
 	ExcludePaths    []string
 	ExitCode        bool
 	Paths           []string
 	Output          string
+	Range           string
+	Sort            bool
+	SortFields      bool
+	StdinFilename   string
 	Write           bool
 	// special
 	InputHashtag string
@@ -215,6 +259,42 @@ func (f *flags) Bind(flagSet *pflag.FlagSet) {
 		false,
 		"Rewrite files in-place",
 	)
+	flagSet.BoolVar(
+		&f.Sort,
+		sortFlagName,
+		false,
+		"Sort the declarations of files into a canonical order",
+	)
+	flagSet.BoolVar(
+		&f.SortFields,
+		sortFieldsFlagName,
+		false,
+		fmt.Sprintf("Sort the fields of messages by number. Requires --%s", sortFlagName),
+	)
+	flagSet.StringVar(
+		&f.StdinFilename,
+		stdinFilenameFlagName,
+		"",
+		"Format the content read from stdin as the file at this path. The configuration is resolved from this path",
+	)
+	flagSet.StringVar(
+		&f.Range,
+		rangeFlagName,
+		"",
+		fmt.Sprintf(
+			"Only format the declarations that overlap the lines start:end, inclusive. Requires --%s",
+			stdinFilenameFlagName,
+		),
+	)
+	flagSet.BoolVar(
+		&f.Edits,
+		editsFlagName,
+		false,
+		fmt.Sprintf(
+			"Write the edits that format the content as JSON, one per line, instead of the formatted content. Requires --%s",
+			stdinFilenameFlagName,
+		),
+	)
 	flagSet.StringVar(
 		&f.ErrorFormat,
 		errorFormatFlagName,
@@ -253,6 +333,18 @@ func run(
 	if flags.Output != "-" && flags.Write {
 		return fmt.Errorf("--%s cannot be used with --%s", outputFlagName, writeFlagName)
 	}
+	if flags.SortFields && !flags.Sort {
+		return fmt.Errorf("--%s requires --%s", sortFieldsFlagName, sortFlagName)
+	}
+	if flags.StdinFilename != "" {
+		return formatStdin(ctx, container, flags)
+	}
+	if flags.Range != "" {
+		return fmt.Errorf("--%s requires --%s", rangeFlagName, stdinFilenameFlagName)
+	}
+	if flags.Edits {
+		return fmt.Errorf("--%s requires --%s", editsFlagName, stdinFilenameFlagName)
+	}
 	source, err := bufcli.GetInputValue(container, flags.InputHashtag, ".")
 	if err != nil {
 		return err
@@ -355,7 +447,8 @@ func run(
 			// (potentially including a debug log).
 			return errors.New("this command does not support including package files")
 		}
-		module := moduleConfigs[0].Module()
+		moduleConfig := moduleConfigs[0]
+		module := moduleConfig.Module()
 		fileInfos, err := module.TargetFileInfos(ctx)
 		if err != nil {
 			return err
@@ -410,6 +503,7 @@ func run(
 			runner,
 			storageosProvider,
 			module,
+			newFormatOptions(moduleConfig.Config().Format, flags),
 			outputDirectory,
 			singleFileOutputFilename,
 			flags.ErrorFormat,
@@ -431,6 +525,7 @@ func run(
 			runner,
 			storageosProvider,
 			moduleConfig.Module(),
+			newFormatOptions(moduleConfig.Config().Format, flags),
 			outputDirectory,
 			singleFileOutputFilename,
 			flags.ErrorFormat,
@@ -458,6 +553,7 @@ func formatModule(
 	runner command.Runner,
 	storageosProvider storageos.Provider,
 	module bufmodule.Module,
+	formatOptions []bufformat.FormatOption,
 	outputDirectory string,
 	singleFileOutputFilename string,
 	errorFormat string,
@@ -473,7 +569,7 @@ func formatModule(
 		return false, err
 	}
 	// Note that external paths are set properly for the files in this read bucket.
-	formattedReadBucket, err := bufformat.FormatModule(ctx, module)
+	formattedReadBucket, err := bufformat.FormatModule(ctx, module, formatOptions...)
 	if err != nil {
 		return false, err
 	}
@@ -619,3 +715,170 @@ func formatModule(
 	}
 	return diffPresent, nil
 }
+
+// newFormatOptions returns the options to format a module with the given config
+// and flags.
+func newFormatOptions(formatConfig *bufformatconfig.Config, flags *flags) []bufformat.FormatOption {
+	formatOptions := []bufformat.FormatOption{
+		bufformat.FormatWithConfig(formatConfig),
+	}
+	if flags.Sort {
+		formatOptions = append(formatOptions, bufformat.FormatWithSort())
+	}
+	if flags.SortFields {
+		formatOptions = append(formatOptions, bufformat.FormatWithSortFieldsByNumber())
+	}
+	return formatOptions
+}
+
+// formatStdin formats the content read from stdin as the file at flags.StdinFilename,
+// and writes the result or its edits to stdout.
+func formatStdin(
+	ctx context.Context,
+	container appflag.Container,
+	flags *flags,
+) error {
+	if container.NumArgs() > 0 {
+		return fmt.Errorf("--%s cannot be used with a source input", stdinFilenameFlagName)
+	}
+	if flags.Write || flags.Diff || flags.Output != "-" {
+		return fmt.Errorf(
+			"--%s cannot be used with --%s, --%s, or --%s",
+			stdinFilenameFlagName,
+			writeFlagName,
+			diffFlagName,
+			outputFlagName,
+		)
+	}
+	if flags.Range != "" && flags.Sort {
+		return fmt.Errorf("--%s cannot be used with --%s", rangeFlagName, sortFlagName)
+	}
+	data, err := io.ReadAll(container.Stdin())
+	if err != nil {
+		return err
+	}
+	formatConfig, err := readFormatConfigForPath(
+		ctx,
+		bufcli.NewStorageosProvider(flags.DisableSymlinks),
+		flags.StdinFilename,
+		flags.Config,
+	)
+	if err != nil {
+		return err
+	}
+	formatOptions := newFormatOptions(formatConfig, flags)
+	if flags.Range != "" {
+		startLine, endLine, err := parseRange(flags.Range)
+		if err != nil {
+			return err
+		}
+		formatOptions = append(formatOptions, bufformat.FormatWithRange(startLine, endLine))
+	}
+	fileNode, err := parser.Parse(flags.StdinFilename, bytes.NewReader(data), reporter.NewHandler(nil))
+	if err != nil {
+		return err
+	}
+	var diffPresent bool
+	if flags.Edits {
+		edits, err := bufformat.FormatFileNodeEdits(fileNode, formatOptions...)
+		if err != nil {
+			return err
+		}
+		for _, edit := range edits {
+			editData, err := json.Marshal(edit)
+			if err != nil {
+				return err
+			}
+			if _, err := fmt.Fprintln(container.Stdout(), string(editData)); err != nil {
+				return err
+			}
+		}
+		diffPresent = len(edits) > 0
+	} else {
+		buffer := bytes.NewBuffer(nil)
+		if err := bufformat.FormatFileNode(buffer, fileNode, formatOptions...); err != nil {
+			return err
+		}
+		diffPresent = !bytes.Equal(data, buffer.Bytes())
+		if _, err := container.Stdout().Write(buffer.Bytes()); err != nil {
+			return err
+		}
+	}
+	if flags.ExitCode && diffPresent {
+		return bufcli.ErrFileAnnotation
+	}
+	return nil
+}
+
+// readFormatConfigForPath returns the format config of the buf.yaml closest to the
+// given path, or of the config override if set.
+//
+// Returns nil if there is no such configuration file.
+func readFormatConfigForPath(
+	ctx context.Context,
+	storageosProvider storageos.Provider,
+	path string,
+	configOverride string,
+) (*bufformatconfig.Config, error) {
+	if configOverride != "" {
+		config, err := bufconfig.ReadConfigOS(
+			ctx,
+			storagemem.NewReadWriteBucket(),
+			bufconfig.ReadConfigOSWithOverride(configOverride),
+		)
+		if err != nil {
+			return nil, err
+		}
+		return config.Format, nil
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	for dirPath := filepath.Dir(absPath); ; dirPath = filepath.Dir(dirPath) {
+		// The path does not need to exist, as the content may not have been saved yet.
+		if fileInfo, err := os.Stat(dirPath); err == nil && fileInfo.IsDir() {
+			readBucket, err := storageosProvider.NewReadWriteBucket(
+				dirPath,
+				storageos.ReadWriteBucketWithSymlinksIfSupported(),
+			)
+			if err != nil {
+				return nil, err
+			}
+			configFilePath, err := bufconfig.ExistingConfigFilePath(ctx, readBucket)
+			if err != nil {
+				return nil, err
+			}
+			if configFilePath != "" {
+				config, err := bufconfig.ReadConfigOS(ctx, readBucket)
+				if err != nil {
+					return nil, err
+				}
+				return config.Format, nil
+			}
+		}
+		if parentDirPath := filepath.Dir(dirPath); parentDirPath == dirPath {
+			return nil, nil
+		}
+	}
+}
+
+// parseRange parses a range of lines of the form start:end.
+func parseRange(value string) (int, int, error) {
+	start, end, ok := strings.Cut(value, ":")
+	if !ok {
+		return 0, 0, fmt.Errorf("--%s must be of the form start:end: %q", rangeFlagName, value)
+	}
+	startLine, err := strconv.Atoi(start)
+	if err != nil {
+		return 0, 0, fmt.Errorf("--%s must be of the form start:end: %q", rangeFlagName, value)
+	}
+	endLine, err := strconv.Atoi(end)
+	if err != nil {
+		return 0, 0, fmt.Errorf("--%s must be of the form start:end: %q", rangeFlagName, value)
+	}
+	if startLine < 1 || endLine < startLine {
+		return 0, 0, fmt.Errorf("--%s must have a start line of at least 1 that is not after its end line: %q", rangeFlagName, value)
+	}
+	return startLine, endLine, nil
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/bufbuild/buf/private/bufpkg/bufwasm"
 	"github.com/bufbuild/buf/private/pkg/app/appcmd"
 	"github.com/bufbuild/buf/private/pkg/app/appflag"
+	"github.com/bufbuild/buf/private/pkg/app/appproto/appprotoos"
 	"github.com/bufbuild/buf/private/pkg/command"
 	"github.com/bufbuild/buf/private/pkg/storage/storageos"
 	"github.com/bufbuild/buf/private/pkg/stringutil"
@@ -48,6 +49,7 @@ const (
 	disableSymlinksFlagName     = "disable-symlinks"
 	typeFlagName                = "type"
 	typeDeprecatedFlagName      = "include-types"
+	cleanFlagName               = "clean"
 )
 
 // NewCommand returns a new Command.
@@ -109,6 +111,38 @@ func NewCommand(
         # If omitted, "directory" is used. Most users should not need to set this option.
         # Optional.
         strategy: directory
+        # Only generate for the files in these paths, relative to the root of the module.
+        # This is applied in addition to the --path flag.
+        # Optional.
+        paths:
+          - proto/foo
+        # Do not generate for the files in these paths, relative to the root of the module.
+        # This is applied in addition to the --exclude-path flag.
+        # Optional.
+        exclude_paths:
+          - proto/foo/internal
+        # Only generate for these fully-qualified types and the types they depend on.
+        # This is applied in addition to the --type flag.
+        # Optional.
+        types:
+          - foo.v1.User
+        # Also generate for the imports of the input files, except for the well-known types.
+        # This is applied in addition to the --include-imports flag.
+        # Optional.
+        include_imports: false
+        # Also generate for the well-known types. Requires "include_imports".
+        # This is applied in addition to the --include-wkt flag.
+        # Optional.
+        include_wkt: false
+        # Remove the files previously generated to the out directory that are no longer
+        # generated. The generated files are listed in a .buf.gen.manifest file written
+        # to the out directory, and only these files are ever removed, so other files in
+        # the directory are left as-is. This has no effect if out is a .jar or .zip file.
+        # No files are removed if only part of the input is generated, such as with --path,
+        # --exclude-path, --type, or the paths, exclude_paths, or types of the plugin.
+        # This is applied in addition to the --clean flag.
+        # Optional.
+        clean: false
       - plugin: java
         out: gen/java
         # Use the plugin hosted at buf.build/protocolbuffers/python at version v21.9.
@@ -209,6 +243,7 @@ type flags struct {
 	// want to find out what will break if we do.
 	Types           []string
 	TypesDeprecated []string
+	Clean           bool
 	// special
 	InputHashtag string
 }
@@ -237,6 +272,18 @@ func (f *flags) Bind(flagSet *pflag.FlagSet) {
 			includeImportsFlagName,
 		),
 	)
+	flagSet.BoolVar(
+		&f.Clean,
+		cleanFlagName,
+		false,
+		fmt.Sprintf(
+			"Remove the files previously generated to each out directory that are no longer generated. Only the files listed in the %s file of the out directory are removed, and none are removed if only part of the input is generated, such as with --%s, --%s, or --%s",
+			appprotoos.ManifestFileName,
+			pathsFlagName,
+			excludePathsFlagName,
+			typeFlagName,
+		),
+	)
 	flagSet.StringVar(
 		&f.Template,
 		templateFlagName,
@@ -379,6 +426,12 @@ func run(
 			bufgen.GenerateWithIncludeWellKnownTypes(),
 		)
 	}
+	if flags.Clean {
+		generateOptions = append(
+			generateOptions,
+			bufgen.GenerateWithClean(),
+		)
+	}
 	wasmEnabled, err := bufcli.IsAlphaWASMEnabled(container)
 	if err != nil {
 		return err
@@ -402,6 +455,12 @@ func run(
 			return err
 		}
 	}
+	if _, isProtoFileRef := ref.(buffetch.ProtoFileRef); isProtoFileRef || len(flags.Paths) > 0 || len(flags.ExcludePaths) > 0 || len(includedTypes) > 0 {
+		generateOptions = append(
+			generateOptions,
+			bufgen.GenerateWithPartialInput(),
+		)
+	}
 	wasmPluginExecutor, err := bufwasm.NewPluginExecutor(
 		filepath.Join(container.CacheDirPath(), bufcli.WASMCompilationCacheDir))
 	if err != nil {
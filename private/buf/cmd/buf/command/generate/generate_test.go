@@ -30,6 +30,7 @@ import (
 	"github.com/bufbuild/buf/private/pkg/app/appcmd"
 	"github.com/bufbuild/buf/private/pkg/app/appcmd/appcmdtesting"
 	"github.com/bufbuild/buf/private/pkg/app/appflag"
+	"github.com/bufbuild/buf/private/pkg/app/appproto/appprotoos"
 	"github.com/bufbuild/buf/private/pkg/command"
 	"github.com/bufbuild/buf/private/pkg/storage"
 	"github.com/bufbuild/buf/private/pkg/storage/storagearchive"
@@ -206,6 +207,64 @@ func TestOutputWithPathEqualToExclude(t *testing.T) {
 	)
 }
 
+func TestGenerateCleanWithPath(t *testing.T) {
+	t.Parallel()
+	tempDirPath := t.TempDir()
+	runGenerate := func(args ...string) {
+		t.Helper()
+		testRunSuccess(
+			t,
+			append(
+				[]string{
+					filepath.Join("testdata", "paths"),
+					"--output",
+					tempDirPath,
+					"--template",
+					filepath.Join("testdata", "paths", "buf.gen.yaml"),
+					"--clean",
+				},
+				args...,
+			)...,
+		)
+	}
+	runGenerate()
+	_, err := os.Stat(filepath.Join(tempDirPath, "java", "a", "v1", "A.java"))
+	require.NoError(t, err)
+	_, err = os.Stat(filepath.Join(tempDirPath, "java", "b", "v1", "B.java"))
+	require.NoError(t, err)
+	// Simulate a file generated from a proto file that was since deleted.
+	staleFilePath := filepath.Join(tempDirPath, "java", "c", "v1", "C.java")
+	require.NoError(t, os.MkdirAll(filepath.Dir(staleFilePath), 0755))
+	require.NoError(t, os.WriteFile(staleFilePath, nil, 0600))
+	manifestFilePath := filepath.Join(tempDirPath, "java", appprotoos.ManifestFileName)
+	manifestData, err := os.ReadFile(manifestFilePath)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(manifestFilePath, append(manifestData, []byte("c/v1/C.java\n")...), 0600))
+
+	// Only part of the input is generated, so no files are removed.
+	runGenerate("--path", filepath.Join("testdata", "paths", "a", "v1"))
+	_, err = os.Stat(filepath.Join(tempDirPath, "java", "a", "v1", "A.java"))
+	require.NoError(t, err)
+	_, err = os.Stat(filepath.Join(tempDirPath, "java", "b", "v1", "B.java"))
+	require.NoError(t, err)
+	_, err = os.Stat(staleFilePath)
+	require.NoError(t, err)
+	manifestData, err = os.ReadFile(manifestFilePath)
+	require.NoError(t, err)
+	assert.Contains(t, string(manifestData), "b/v1/B.java\n")
+	assert.Contains(t, string(manifestData), "c/v1/C.java\n")
+
+	// The entire input is generated, so the files no longer generated are removed.
+	runGenerate()
+	_, err = os.Stat(filepath.Join(tempDirPath, "java", "b", "v1", "B.java"))
+	require.NoError(t, err)
+	_, err = os.Stat(staleFilePath)
+	require.True(t, os.IsNotExist(err))
+	manifestData, err = os.ReadFile(manifestFilePath)
+	require.NoError(t, err)
+	assert.NotContains(t, string(manifestData), "c/v1/C.java")
+}
+
 func TestGenerateInsertionPoint(t *testing.T) {
 	t.Parallel()
 	runner := command.NewRunner()
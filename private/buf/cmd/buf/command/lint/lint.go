@@ -17,18 +17,29 @@ package lint
 import (
 	"context"
 	"fmt"
+	"os"
 
 	"github.com/bufbuild/buf/private/buf/bufcli"
 	"github.com/bufbuild/buf/private/buf/buffetch"
+	"github.com/bufbuild/buf/private/buf/buflintbaseline"
+	"github.com/bufbuild/buf/private/buf/buflintfix"
+	"github.com/bufbuild/buf/private/buf/bufwire"
 	"github.com/bufbuild/buf/private/bufpkg/bufanalysis"
+	"github.com/bufbuild/buf/private/bufpkg/bufcheck"
+	"github.com/bufbuild/buf/private/bufpkg/bufcheck/bufcheckcache"
 	"github.com/bufbuild/buf/private/bufpkg/bufcheck/buflint"
 	"github.com/bufbuild/buf/private/bufpkg/bufcheck/buflint/buflintconfig"
+	"github.com/bufbuild/buf/private/bufpkg/bufmodule"
 	"github.com/bufbuild/buf/private/pkg/app/appcmd"
 	"github.com/bufbuild/buf/private/pkg/app/appflag"
 	"github.com/bufbuild/buf/private/pkg/command"
+	"github.com/bufbuild/buf/private/pkg/normalpath"
+	"github.com/bufbuild/buf/private/pkg/storage"
+	"github.com/bufbuild/buf/private/pkg/storage/storagemem"
 	"github.com/bufbuild/buf/private/pkg/stringutil"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
+	"go.uber.org/multierr"
 )
 
 const (
@@ -37,6 +48,10 @@ const (
 	pathsFlagName           = "path"
 	excludePathsFlagName    = "exclude-path"
 	disableSymlinksFlagName = "disable-symlinks"
+	fixFlagName             = "fix"
+	writeBaselineFlagName   = "write-baseline"
+	disableCacheFlagName    = "disable-cache"
+	explainFlagName         = "explain"
 )
 
 // NewCommand returns a new Command.
@@ -66,6 +81,10 @@ type flags struct {
 	Paths           []string
 	ExcludePaths    []string
 	DisableSymlinks bool
+	Fix             bool
+	WriteBaseline   string
+	DisableCache    bool
+	Explain         string
 	// special
 	InputHashtag string
 }
@@ -94,6 +113,33 @@ func (f *flags) Bind(flagSet *pflag.FlagSet) {
 		"",
 		`The buf.yaml file or data to use for configuration`,
 	)
+	flagSet.BoolVar(
+		&f.Fix,
+		fixFlagName,
+		false,
+		fmt.Sprintf(
+			`Fix the violations that have a mechanical fix and rewrite the files in-place. Renamed elements are updated in all files of the input. The fixed violations are printed to stderr, and the remaining violations are printed as usual. Only violations of %s can be fixed. Can only be used with local directory or .proto file inputs`,
+			stringutil.SliceToHumanString(buflintfix.FixableRuleIDs),
+		),
+	)
+	flagSet.StringVar(
+		&f.WriteBaseline,
+		writeBaselineFlagName,
+		"",
+		`Write the current violations to the given baseline file instead of printing them, and exit successfully. Violations are recorded by rule, file, and element, and not by line. When the baseline is set as lint.baseline in buf.yaml, the recorded violations are no longer reported, and fixed violations are reported as warnings. Any existing baseline is ignored when writing the baseline`,
+	)
+	flagSet.BoolVar(
+		&f.DisableCache,
+		disableCacheFlagName,
+		false,
+		`Do not read or write the cache of lint results. Results are cached for each module, and are reused if the files of the module, the lint configuration, and the version of buf are unchanged`,
+	)
+	flagSet.StringVar(
+		&f.Explain,
+		explainFlagName,
+		"",
+		`Print the explanation of the given rule ID instead of linting, including its rationale, examples of violating and conforming code, the buf.yaml keys that configure it, and how to fix its violations`,
+	)
 }
 
 func run(
@@ -101,6 +147,13 @@ func run(
 	container appflag.Container,
 	flags *flags,
 ) (retErr error) {
+	if flags.Explain != "" {
+		rules, err := buflint.GetAllRulesV1()
+		if err != nil {
+			return err
+		}
+		return bufcli.PrintRuleExplanation(container.Stdout(), rules, flags.Explain, explainFlagName)
+	}
 	if err := bufcli.ValidateErrorFormatFlagLint(flags.ErrorFormat, errorFormatFlagName); err != nil {
 		return err
 	}
@@ -112,6 +165,12 @@ func run(
 	if err != nil {
 		return err
 	}
+	if flags.Fix && !buffetch.IsLocalSourceRef(ref) {
+		return fmt.Errorf("--%s can only be used with local directory or .proto file inputs", fixFlagName)
+	}
+	if flags.Fix && flags.WriteBaseline != "" {
+		return appcmd.NewInvalidArgumentErrorf("--%s and --%s cannot be used together", fixFlagName, writeBaselineFlagName)
+	}
 	storageosProvider := bufcli.NewStorageosProvider(flags.DisableSymlinks)
 	runner := command.NewRunner()
 	clientConfig, err := bufcli.NewConnectClientConfig(container)
@@ -127,6 +186,84 @@ func run(
 	if err != nil {
 		return err
 	}
+	handler := buflint.NewHandler(container.Logger(), runner)
+	if !flags.DisableCache {
+		readWriteBucket, err := bufcli.NewCheckCacheReadWriteBucketAndCreateCacheDirs(container)
+		if err != nil {
+			return err
+		}
+		handler = bufcheckcache.NewLintHandler(container.Logger(), handler, readWriteBucket, bufcli.Version)
+	}
+	imageConfigs, allFileAnnotations, imageConfigToRules, err := lintInput(ctx, container, handler, imageConfigReader, ref, flags)
+	if err != nil {
+		return err
+	}
+	if flags.Fix && len(allFileAnnotations) > 0 {
+		fixed, err := fix(ctx, container, imageConfigs, allFileAnnotations)
+		if err != nil {
+			return err
+		}
+		if fixed {
+			// Lint again, so that the remaining violations have the locations
+			// of the rewritten files.
+			imageConfigs, allFileAnnotations, imageConfigToRules, err = lintInput(ctx, container, handler, imageConfigReader, ref, flags)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	if flags.WriteBaseline != "" {
+		return writeBaseline(imageConfigs, allFileAnnotations, flags.WriteBaseline)
+	}
+	isSARIF := bufcli.IsSARIFErrorFormat(flags.ErrorFormat)
+	if len(allFileAnnotations) == 0 && !isSARIF {
+		return nil
+	}
+	var printFileAnnotationsOptions []bufanalysis.PrintFileAnnotationsOption
+	if isSARIF && len(allFileAnnotations) > 0 {
+		// Rule metadata is only printed for SARIF.
+		ruleInfos, err := bufcli.RuleInfosForImageConfigs(
+			imageConfigs,
+			func(imageConfig bufwire.ImageConfig) ([]bufcheck.Rule, error) {
+				return imageConfigToRules[imageConfig], nil
+			},
+		)
+		if err != nil {
+			return err
+		}
+		printFileAnnotationsOptions = append(
+			printFileAnnotationsOptions,
+			bufanalysis.PrintFileAnnotationsWithRuleInfos(ruleInfos...),
+		)
+	}
+	if err := buflintconfig.PrintFileAnnotations(
+		container.Stdout(),
+		bufanalysis.DeduplicateAndSortFileAnnotations(allFileAnnotations),
+		flags.ErrorFormat,
+		printFileAnnotationsOptions...,
+	); err != nil {
+		return err
+	}
+	if len(allFileAnnotations) > 0 {
+		return bufcli.ErrFileAnnotation
+	}
+	return nil
+}
+
+// lintInput builds and lints the input.
+//
+// The rules that were checked for each ImageConfig are also returned.
+//
+// If the input does not build, the build errors are printed and bufcli.ErrFileAnnotation
+// is returned.
+func lintInput(
+	ctx context.Context,
+	container appflag.Container,
+	handler buflint.Handler,
+	imageConfigReader bufwire.ImageConfigReader,
+	ref buffetch.Ref,
+	flags *flags,
+) ([]bufwire.ImageConfig, []bufanalysis.FileAnnotation, map[bufwire.ImageConfig][]bufcheck.Rule, error) {
 	imageConfigs, fileAnnotations, err := imageConfigReader.GetImageConfigs(
 		ctx,
 		container,
@@ -138,7 +275,7 @@ func run(
 		false,              // we must include source info for linting
 	)
 	if err != nil {
-		return err
+		return nil, nil, nil, err
 	}
 	if len(fileAnnotations) > 0 {
 		formatString := flags.ErrorFormat
@@ -146,31 +283,169 @@ func run(
 			formatString = "text"
 		}
 		if err := bufanalysis.PrintFileAnnotations(container.Stdout(), fileAnnotations, formatString); err != nil {
-			return err
+			return nil, nil, nil, err
 		}
-		return bufcli.ErrFileAnnotation
+		return nil, nil, nil, bufcli.ErrFileAnnotation
 	}
 	var allFileAnnotations []bufanalysis.FileAnnotation
+	imageConfigToRules := make(map[bufwire.ImageConfig][]bufcheck.Rule, len(imageConfigs))
 	for _, imageConfig := range imageConfigs {
-		fileAnnotations, err := buflint.NewHandler(container.Logger()).Check(
+		fileAnnotations, rules, err := handler.Check(
 			ctx,
 			imageConfig.Config().Lint,
 			imageConfig.Image(),
 		)
 		if err != nil {
-			return err
+			return nil, nil, nil, err
+		}
+		// When writing the baseline, all violations are recorded.
+		if baselinePath := imageConfig.Config().Lint.Baseline; baselinePath != "" && flags.WriteBaseline == "" {
+			fileAnnotations, err = filterBaseline(ctx, container, imageConfig, fileAnnotations, baselinePath)
+			if err != nil {
+				return nil, nil, nil, err
+			}
 		}
+		imageConfigToRules[imageConfig] = rules
 		allFileAnnotations = append(allFileAnnotations, fileAnnotations...)
 	}
-	if len(allFileAnnotations) > 0 {
-		if err := buflintconfig.PrintFileAnnotations(
-			container.Stdout(),
-			bufanalysis.DeduplicateAndSortFileAnnotations(allFileAnnotations),
-			flags.ErrorFormat,
-		); err != nil {
+	return imageConfigs, allFileAnnotations, imageConfigToRules, nil
+}
+
+// filterBaseline removes the violations recorded in the baseline at the path relative
+// to the root of the module, and warns about the violations in the baseline that are fixed.
+func filterBaseline(
+	ctx context.Context,
+	container appflag.Container,
+	imageConfig bufwire.ImageConfig,
+	fileAnnotations []bufanalysis.FileAnnotation,
+	baselinePath string,
+) (_ []bufanalysis.FileAnnotation, retErr error) {
+	sourceReadBucket := imageConfig.SourceReadBucket()
+	if sourceReadBucket == nil {
+		container.Logger().Sugar().Warnf("lint.baseline %q is ignored, as the input is not a source input.", baselinePath)
+		return fileAnnotations, nil
+	}
+	normalizedBaselinePath, err := normalpath.NormalizeAndValidate(baselinePath)
+	if err != nil {
+		return nil, fmt.Errorf("invalid lint.baseline: %w", err)
+	}
+	readObjectCloser, err := sourceReadBucket.Get(ctx, normalizedBaselinePath)
+	if err != nil {
+		return nil, fmt.Errorf("could not open lint.baseline: %w", err)
+	}
+	defer func() {
+		retErr = multierr.Append(retErr, readObjectCloser.Close())
+	}()
+	baselineFilePath := readObjectCloser.ExternalPath()
+	baseline, err := buflintbaseline.ReadBaseline(readObjectCloser)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", baselineFilePath, err)
+	}
+	fileAnnotations, fixedEntries := buflintbaseline.Filter(imageConfig.Image(), baseline, fileAnnotations)
+	for _, fixedEntry := range fixedEntries {
+		element := ""
+		if fixedEntry.Element != "" {
+			element = " on " + fixedEntry.Element
+		}
+		container.Logger().Sugar().Warnf(
+			"%s: %d violation(s) of %s%s in %s are fixed. Update the baseline with --%s.",
+			baselineFilePath,
+			fixedEntry.Count,
+			fixedEntry.Rule,
+			element,
+			fixedEntry.Path,
+			writeBaselineFlagName,
+		)
+	}
+	return fileAnnotations, nil
+}
+
+// writeBaseline writes the violations to the baseline file at the path.
+func writeBaseline(
+	imageConfigs []bufwire.ImageConfig,
+	fileAnnotations []bufanalysis.FileAnnotation,
+	baselinePath string,
+) (retErr error) {
+	if len(imageConfigs) != 1 {
+		// Each module has its own baseline, as the baseline is keyed by the
+		// paths of the files within the module.
+		return fmt.Errorf("--%s cannot be used with workspaces that contain more than one module", writeBaselineFlagName)
+	}
+	baseline := buflintbaseline.NewBaseline(imageConfigs[0].Image(), fileAnnotations)
+	file, err := os.Create(baselinePath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		retErr = multierr.Append(retErr, file.Close())
+	}()
+	return buflintbaseline.WriteBaseline(file, baseline)
+}
+
+// fix fixes the FileAnnotations and rewrites the fixed files in-place.
+//
+// The fixed FileAnnotations are printed to stderr. Returns true if any files
+// were rewritten.
+func fix(
+	ctx context.Context,
+	container appflag.Container,
+	imageConfigs []bufwire.ImageConfig,
+	fileAnnotations []bufanalysis.FileAnnotation,
+) (bool, error) {
+	if len(imageConfigs) != 1 {
+		// A rename in one module could break the references in the other modules
+		// of the workspace, as these files are not part of the same image.
+		return false, fmt.Errorf("--%s cannot be used with workspaces that contain more than one module", fixFlagName)
+	}
+	imageConfig := imageConfigs[0]
+	// The sources are read through the module, so that the files are read
+	// with the same storageos bucket as the build.
+	readWriteBucket := storagemem.NewReadWriteBucket()
+	if err := bufmodule.TargetModuleFilesToBucket(
+		ctx,
+		imageConfig.Module(),
+		readWriteBucket,
+	); err != nil {
+		return false, err
+	}
+	result, err := buflintfix.Fix(
+		ctx,
+		imageConfig.Config().Lint,
+		imageConfig.Config().Format,
+		imageConfig.Image(),
+		readWriteBucket,
+		bufanalysis.DeduplicateAndSortFileAnnotations(fileAnnotations),
+	)
+	if err != nil {
+		return false, err
+	}
+	fixed := false
+	if err := storage.WalkReadObjects(
+		ctx,
+		result.ReadBucket,
+		"",
+		func(readObject storage.ReadObject) (retErr error) {
+			fixed = true
+			// The files are rewritten in-place based on their external path, as
+			// is done by buf format. The file is not created or truncated with a
+			// new mode, so the mode of the existing file is preserved.
+			file, err := os.OpenFile(readObject.ExternalPath(), os.O_WRONLY|os.O_TRUNC, 0)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				retErr = multierr.Append(retErr, file.Close())
+			}()
+			_, err = file.ReadFrom(readObject)
 			return err
+		},
+	); err != nil {
+		return false, err
+	}
+	for _, fileAnnotation := range result.FixedFileAnnotations {
+		if _, err := fmt.Fprintf(container.Stderr(), "fixed: %s\n", fileAnnotation.String()); err != nil {
+			return false, err
 		}
-		return bufcli.ErrFileAnnotation
 	}
-	return nil
+	return fixed, nil
 }
@@ -28,6 +28,7 @@ import (
 	"github.com/bufbuild/buf/private/pkg/app"
 	"github.com/bufbuild/buf/private/pkg/app/applog"
 	"github.com/bufbuild/buf/private/pkg/app/appproto"
+	"github.com/bufbuild/buf/private/pkg/command"
 	"github.com/bufbuild/buf/private/pkg/encoding"
 	"github.com/bufbuild/buf/private/pkg/storage/storageos"
 	"google.golang.org/protobuf/types/pluginpb"
@@ -107,7 +108,7 @@ func handle(
 	if err != nil {
 		return err
 	}
-	fileAnnotations, err := buflint.NewHandler(logger).Check(
+	fileAnnotations, _, err := buflint.NewHandler(logger, command.NewRunner()).Check(
 		ctx,
 		config.Lint,
 		image,
@@ -36,6 +36,10 @@ const (
 	//
 	// See https://docs.github.com/en/actions/using-workflows/workflow-commands-for-github-actions#setting-an-error-message.
 	FormatGithubActions
+	// FormatSARIF is the SARIF 2.1.0 format for FileAnnotations.
+	//
+	// See https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html.
+	FormatSARIF
 )
 
 var (
@@ -48,6 +52,7 @@ var (
 		"msvs",
 		"junit",
 		"github-actions",
+		"sarif",
 	}
 	// AllFormatStringsWithAliases is all format strings with aliases.
 	//
@@ -59,6 +64,7 @@ var (
 		"msvs",
 		"junit",
 		"github-actions",
+		"sarif",
 	}
 
 	stringToFormat = map[string]Format{
@@ -69,6 +75,7 @@ var (
 		"msvs":           FormatMSVS,
 		"junit":          FormatJUnit,
 		"github-actions": FormatGithubActions,
+		"sarif":          FormatSARIF,
 	}
 	formatToString = map[Format]string{
 		FormatText:          "text",
@@ -76,6 +83,7 @@ var (
 		FormatMSVS:          "msvs",
 		FormatJUnit:         "junit",
 		FormatGithubActions: "github-actions",
+		FormatSARIF:         "sarif",
 	}
 )
 
@@ -112,6 +120,15 @@ type FileInfo interface {
 	ExternalPath() string
 }
 
+// RuleInfo is a minimal Rule interface.
+//
+// This is used to add rule metadata to formats that support it.
+type RuleInfo interface {
+	ID() string
+	Categories() []string
+	Purpose() string
+}
+
 // FileAnnotation is a file annotation.
 type FileAnnotation interface {
 	// Stringer returns the string representation of this annotation.
@@ -202,11 +219,20 @@ func DeduplicateAndSortFileAnnotations(fileAnnotations []FileAnnotation) []FileA
 }
 
 // PrintFileAnnotations prints the file annotations separated by newlines.
-func PrintFileAnnotations(writer io.Writer, fileAnnotations []FileAnnotation, formatString string) error {
+func PrintFileAnnotations(
+	writer io.Writer,
+	fileAnnotations []FileAnnotation,
+	formatString string,
+	options ...PrintFileAnnotationsOption,
+) error {
 	format, err := ParseFormat(formatString)
 	if err != nil {
 		return err
 	}
+	printFileAnnotationsOptions := newPrintFileAnnotationsOptions()
+	for _, option := range options {
+		option(printFileAnnotationsOptions)
+	}
 
 	switch format {
 	case FormatText:
@@ -219,11 +245,35 @@ func PrintFileAnnotations(writer io.Writer, fileAnnotations []FileAnnotation, fo
 		return printAsJUnit(writer, fileAnnotations)
 	case FormatGithubActions:
 		return printAsGithubActions(writer, fileAnnotations)
+	case FormatSARIF:
+		return printAsSARIF(writer, fileAnnotations, printFileAnnotationsOptions.ruleInfos)
 	default:
 		return fmt.Errorf("unknown FileAnnotation Format: %v", format)
 	}
 }
 
+// PrintFileAnnotationsOption is an option for PrintFileAnnotations.
+type PrintFileAnnotationsOption func(*printFileAnnotationsOptions)
+
+// PrintFileAnnotationsWithRuleInfos returns a new PrintFileAnnotationsOption that adds
+// the metadata of the given rules to formats that support it.
+//
+// Currently, only FormatSARIF includes rule metadata. The rules are matched to
+// FileAnnotations by comparing the ID of the rule with the type of the FileAnnotation.
+func PrintFileAnnotationsWithRuleInfos(ruleInfos ...RuleInfo) PrintFileAnnotationsOption {
+	return func(printFileAnnotationsOptions *printFileAnnotationsOptions) {
+		printFileAnnotationsOptions.ruleInfos = append(printFileAnnotationsOptions.ruleInfos, ruleInfos...)
+	}
+}
+
+type printFileAnnotationsOptions struct {
+	ruleInfos []RuleInfo
+}
+
+func newPrintFileAnnotationsOptions() *printFileAnnotationsOptions {
+	return &printFileAnnotationsOptions{}
+}
+
 // hash returns a hash value that uniquely identifies the given FileAnnotation.
 func hash(fileAnnotation FileAnnotation) string {
 	path := ""
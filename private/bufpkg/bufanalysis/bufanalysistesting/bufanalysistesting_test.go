@@ -131,3 +131,176 @@ path/to/file.proto(2,1) : error FOO : Hello.
 		sb.String(),
 	)
 }
+
+func TestSARIF(t *testing.T) {
+	t.Parallel()
+	fileAnnotations := []bufanalysis.FileAnnotation{
+		newFileAnnotation(
+			t,
+			"path/to/file.proto",
+			1,
+			0,
+			1,
+			0,
+			"FOO",
+			"Hello.",
+		),
+		newFileAnnotation(
+			t,
+			"path/to/file.proto",
+			2,
+			1,
+			2,
+			5,
+			"FOO",
+			"Hello.",
+		),
+		newFileAnnotation(
+			t,
+			"",
+			0,
+			0,
+			0,
+			0,
+			"COMPILE",
+			"Failure.",
+		),
+	}
+	sb := &strings.Builder{}
+	err := bufanalysis.PrintFileAnnotations(
+		sb,
+		fileAnnotations,
+		"sarif",
+		bufanalysis.PrintFileAnnotationsWithRuleInfos(
+			&testRuleInfo{
+				id:         "FOO",
+				categories: []string{"BAR", "BAZ"},
+				purpose:    "Checks that foo.",
+			},
+			&testRuleInfo{
+				id:      "UNUSED",
+				purpose: "Checks that unused.",
+			},
+		),
+	)
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		`{
+  "$schema": "https://json.schemastore.org/sarif-2.1.0.json",
+  "version": "2.1.0",
+  "runs": [
+    {
+      "tool": {
+        "driver": {
+          "name": "buf",
+          "informationUri": "https://github.com/bufbuild/buf",
+          "rules": [
+            {
+              "id": "COMPILE"
+            },
+            {
+              "id": "FOO",
+              "shortDescription": {
+                "text": "Checks that foo."
+              },
+              "properties": {
+                "categories": [
+                  "BAR",
+                  "BAZ"
+                ]
+              }
+            }
+          ]
+        }
+      },
+      "results": [
+        {
+          "ruleId": "FOO",
+          "ruleIndex": 1,
+          "level": "error",
+          "message": {
+            "text": "Hello."
+          },
+          "locations": [
+            {
+              "physicalLocation": {
+                "artifactLocation": {
+                  "uri": "path/to/file.proto",
+                  "uriBaseId": "%SRCROOT%"
+                },
+                "region": {
+                  "startLine": 1,
+                  "endLine": 1
+                }
+              }
+            }
+          ],
+          "fingerprints": {
+            "buf/v1": "b6c47874862d218a2a3cd82e61fbeebb5a9e29e7c39680aa9581135a64238b61:1"
+          }
+        },
+        {
+          "ruleId": "FOO",
+          "ruleIndex": 1,
+          "level": "error",
+          "message": {
+            "text": "Hello."
+          },
+          "locations": [
+            {
+              "physicalLocation": {
+                "artifactLocation": {
+                  "uri": "path/to/file.proto",
+                  "uriBaseId": "%SRCROOT%"
+                },
+                "region": {
+                  "startLine": 2,
+                  "startColumn": 1,
+                  "endLine": 2,
+                  "endColumn": 5
+                }
+              }
+            }
+          ],
+          "fingerprints": {
+            "buf/v1": "b6c47874862d218a2a3cd82e61fbeebb5a9e29e7c39680aa9581135a64238b61:2"
+          }
+        },
+        {
+          "ruleId": "COMPILE",
+          "ruleIndex": 0,
+          "level": "error",
+          "message": {
+            "text": "Failure."
+          },
+          "fingerprints": {
+            "buf/v1": "2bc8efcdf4e8c8f146fe89fba156b6ae876300bcf77ad6ad61875bc58162ecfe:1"
+          }
+        }
+      ]
+    }
+  ]
+}
+`,
+		sb.String(),
+	)
+}
+
+type testRuleInfo struct {
+	id         string
+	categories []string
+	purpose    string
+}
+
+func (r *testRuleInfo) ID() string {
+	return r.id
+}
+
+func (r *testRuleInfo) Categories() []string {
+	return r.categories
+}
+
+func (r *testRuleInfo) Purpose() string {
+	return r.purpose
+}
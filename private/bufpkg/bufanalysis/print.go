@@ -16,14 +16,27 @@ package bufanalysis
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"encoding/xml"
 	"fmt"
 	"io"
+	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 )
 
+const (
+	sarifSchema         = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion        = "2.1.0"
+	sarifToolName       = "buf"
+	sarifToolURI        = "https://github.com/bufbuild/buf"
+	sarifSourceRoot     = "%SRCROOT%"
+	sarifFingerprintKey = "buf/v1"
+)
+
 func printAsText(writer io.Writer, fileAnnotations []FileAnnotation) error {
 	return printEachAnnotationOnNewLine(
 		writer,
@@ -104,6 +117,65 @@ func printAsJUnit(writer io.Writer, fileAnnotations []FileAnnotation) error {
 	return nil
 }
 
+func printAsSARIF(writer io.Writer, fileAnnotations []FileAnnotation, ruleInfos []RuleInfo) error {
+	idToRuleInfo := make(map[string]RuleInfo, len(ruleInfos))
+	for _, ruleInfo := range ruleInfos {
+		idToRuleInfo[ruleInfo.ID()] = ruleInfo
+	}
+	// Only the rules that have results are included, so that the output does
+	// not depend on the rules that were configured but did not fail.
+	var ruleIDs []string
+	ruleIDToIndex := make(map[string]int)
+	for _, fileAnnotation := range fileAnnotations {
+		if _, ok := ruleIDToIndex[fileAnnotation.Type()]; !ok {
+			ruleIDToIndex[fileAnnotation.Type()] = 0
+			ruleIDs = append(ruleIDs, fileAnnotation.Type())
+		}
+	}
+	sort.Strings(ruleIDs)
+	rules := make([]*sarifRule, 0, len(ruleIDs))
+	for i, ruleID := range ruleIDs {
+		ruleIDToIndex[ruleID] = i
+		rules = append(rules, newSARIFRule(ruleID, idToRuleInfo[ruleID]))
+	}
+	results := make([]*sarifResult, 0, len(fileAnnotations))
+	fingerprintToCount := make(map[string]int)
+	for _, fileAnnotation := range fileAnnotations {
+		// FileAnnotations with the same path, type, and message are distinguished
+		// by the order in which they occur.
+		fingerprint := sarifFingerprint(fileAnnotation)
+		fingerprintToCount[fingerprint]++
+		results = append(
+			results,
+			newSARIFResult(
+				fileAnnotation,
+				ruleIDToIndex[fileAnnotation.Type()],
+				fingerprint+":"+strconv.Itoa(fingerprintToCount[fingerprint]),
+			),
+		)
+	}
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(
+		&sarifLog{
+			Schema:  sarifSchema,
+			Version: sarifVersion,
+			Runs: []*sarifRun{
+				{
+					Tool: &sarifTool{
+						Driver: &sarifDriver{
+							Name:           sarifToolName,
+							InformationURI: sarifToolURI,
+							Rules:          rules,
+						},
+					},
+					Results: results,
+				},
+			},
+		},
+	)
+}
+
 func printFileAnnotationAsJUnit(encoder *xml.Encoder, annotation FileAnnotation) error {
 	testcase := xml.StartElement{Name: xml.Name{Local: "testcase"}}
 	name := annotation.Type()
@@ -275,6 +347,154 @@ func newExternalFileAnnotation(f FileAnnotation) externalFileAnnotation {
 	}
 }
 
+type sarifLog struct {
+	Schema  string      `json:"$schema"`
+	Version string      `json:"version"`
+	Runs    []*sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    *sarifTool     `json:"tool"`
+	Results []*sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver *sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string       `json:"name"`
+	InformationURI string       `json:"informationUri"`
+	Rules          []*sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string               `json:"id"`
+	ShortDescription *sarifMessage        `json:"shortDescription,omitempty"`
+	Properties       *sarifRuleProperties `json:"properties,omitempty"`
+}
+
+type sarifRuleProperties struct {
+	Categories []string `json:"categories,omitempty"`
+}
+
+type sarifResult struct {
+	RuleID       string            `json:"ruleId"`
+	RuleIndex    int               `json:"ruleIndex"`
+	Level        string            `json:"level"`
+	Message      *sarifMessage     `json:"message"`
+	Locations    []*sarifLocation  `json:"locations,omitempty"`
+	Fingerprints map[string]string `json:"fingerprints"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation *sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation *sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion           `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId,omitempty"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+	EndLine     int `json:"endLine,omitempty"`
+	EndColumn   int `json:"endColumn,omitempty"`
+}
+
+func newSARIFRule(ruleID string, ruleInfo RuleInfo) *sarifRule {
+	rule := &sarifRule{
+		ID: ruleID,
+	}
+	if ruleInfo == nil {
+		return rule
+	}
+	if purpose := ruleInfo.Purpose(); purpose != "" {
+		rule.ShortDescription = &sarifMessage{
+			Text: purpose,
+		}
+	}
+	if categories := ruleInfo.Categories(); len(categories) > 0 {
+		rule.Properties = &sarifRuleProperties{
+			Categories: categories,
+		}
+	}
+	return rule
+}
+
+func newSARIFResult(f FileAnnotation, ruleIndex int, fingerprint string) *sarifResult {
+	message := f.Message()
+	if message == "" {
+		// should never happen but just in case
+		message = f.Type()
+	}
+	result := &sarifResult{
+		RuleID:    f.Type(),
+		RuleIndex: ruleIndex,
+		Level:     "error",
+		Message: &sarifMessage{
+			Text: message,
+		},
+		Fingerprints: map[string]string{
+			sarifFingerprintKey: fingerprint,
+		},
+	}
+	if f.FileInfo() == nil {
+		return result
+	}
+	path := f.FileInfo().ExternalPath()
+	artifactLocation := &sarifArtifactLocation{
+		URI: filepath.ToSlash(path),
+	}
+	if !filepath.IsAbs(path) {
+		artifactLocation.URIBaseID = sarifSourceRoot
+	}
+	physicalLocation := &sarifPhysicalLocation{
+		ArtifactLocation: artifactLocation,
+	}
+	// SARIF requires the start line of a text region to be at least 1.
+	if f.StartLine() > 0 {
+		physicalLocation.Region = &sarifRegion{
+			StartLine:   f.StartLine(),
+			StartColumn: f.StartColumn(),
+			EndLine:     f.EndLine(),
+			EndColumn:   f.EndColumn(),
+		}
+	}
+	result.Locations = []*sarifLocation{
+		{
+			PhysicalLocation: physicalLocation,
+		},
+	}
+	return result
+}
+
+// sarifFingerprint returns a fingerprint for the FileAnnotation that does not depend
+// on its position, so that results can be matched across runs when lines move.
+func sarifFingerprint(f FileAnnotation) string {
+	path := ""
+	if f.FileInfo() != nil {
+		path = f.FileInfo().ExternalPath()
+	}
+	hash := sha256.New()
+	for _, value := range []string{path, f.Type(), f.Message()} {
+		_, _ = hash.Write([]byte(value))
+		// Separate the values so that different values do not result in the same fingerprint.
+		_, _ = hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
 func printEachAnnotationOnNewLine(
 	writer io.Writer,
 	fileAnnotations []FileAnnotation,
@@ -0,0 +1,117 @@
+// Copyright 2020-2023 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bufchangelog produces changelogs of the delta between two images.
+package bufchangelog
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/bufbuild/buf/private/bufpkg/bufimage"
+	"github.com/bufbuild/buf/private/pkg/protosource"
+)
+
+const (
+	// FormatMarkdown is the Markdown format.
+	FormatMarkdown Format = iota + 1
+	// FormatJSON is the JSON format.
+	FormatJSON
+)
+
+var (
+	// AllFormatStrings is all format strings.
+	AllFormatStrings = []string{
+		"markdown",
+		"json",
+	}
+
+	stringToFormat = map[string]Format{
+		"markdown": FormatMarkdown,
+		"json":     FormatJSON,
+	}
+	formatToString = map[Format]string{
+		FormatMarkdown: "markdown",
+		FormatJSON:     "json",
+	}
+)
+
+// Format is a format to print a Changelog.
+type Format int
+
+// String implements fmt.Stringer.
+func (f Format) String() string {
+	s, ok := formatToString[f]
+	if !ok {
+		return strconv.Itoa(int(f))
+	}
+	return s
+}
+
+// ParseFormat parses the format.
+//
+// The empty string defaults to FormatMarkdown.
+func ParseFormat(s string) (Format, error) {
+	if s == "" {
+		return FormatMarkdown, nil
+	}
+	f, ok := stringToFormat[s]
+	if ok {
+		return f, nil
+	}
+	return 0, fmt.Errorf("unknown format: %q", s)
+}
+
+// Changelog is a changelog of the delta between two images.
+type Changelog struct {
+	// PackageChangelogs are the changelogs for each package with changes, sorted by package.
+	PackageChangelogs []*PackageChangelog
+}
+
+// PackageChangelog is the changelog for a single package.
+type PackageChangelog struct {
+	// Package is the name of the package.
+	Package string
+	// Changes are the changes to the elements of the package, sorted by fully-qualified name.
+	Changes []protosource.Change
+}
+
+// NewChangelog returns a new Changelog of the delta from the previousImage to the image.
+//
+// Imports are not included in the Changelog. Both Images should have source code info
+// for comments to be included.
+func NewChangelog(ctx context.Context, previousImage bufimage.Image, image bufimage.Image) (*Changelog, error) {
+	return newChangelog(ctx, previousImage, image)
+}
+
+// MergeChangelogs merges the Changelogs into a single Changelog.
+//
+// This is used for workspaces, where each module is compared separately.
+func MergeChangelogs(changelogs ...*Changelog) *Changelog {
+	return mergeChangelogs(changelogs...)
+}
+
+// PrintChangelog prints the Changelog to the writer in the given format.
+func PrintChangelog(writer io.Writer, changelog *Changelog, format Format) error {
+	switch format {
+	case FormatMarkdown:
+		return printAsMarkdown(writer, changelog)
+	case FormatJSON:
+		return printAsJSON(writer, changelog)
+	default:
+		return fmt.Errorf("unknown format: %v", format)
+	}
+}
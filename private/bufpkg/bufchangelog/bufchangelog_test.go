@@ -0,0 +1,84 @@
+// Copyright 2020-2023 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bufchangelog
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bufbuild/buf/private/bufpkg/bufconfig"
+	"github.com/bufbuild/buf/private/bufpkg/bufimage"
+	"github.com/bufbuild/buf/private/bufpkg/bufimage/bufimagebuild"
+	"github.com/bufbuild/buf/private/bufpkg/bufmodule"
+	"github.com/bufbuild/buf/private/bufpkg/bufmodule/bufmodulebuild"
+	"github.com/bufbuild/buf/private/pkg/storage/storageos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestChangelog(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	previousImage := testBuild(t, ctx, filepath.Join("testdata", "previous"))
+	image := testBuild(t, ctx, filepath.Join("testdata", "current"))
+	changelog, err := NewChangelog(ctx, previousImage, image)
+	require.NoError(t, err)
+	for _, format := range []Format{FormatMarkdown, FormatJSON} {
+		expected, err := os.ReadFile(filepath.Join("testdata", "changelog."+formatToFileExtension[format]))
+		require.NoError(t, err)
+		buffer := bytes.NewBuffer(nil)
+		require.NoError(t, PrintChangelog(buffer, changelog, format))
+		assert.Equal(t, string(expected), buffer.String(), format.String())
+	}
+}
+
+func TestChangelogNoChanges(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	image := testBuild(t, ctx, filepath.Join("testdata", "current"))
+	changelog, err := NewChangelog(ctx, image, image)
+	require.NoError(t, err)
+	buffer := bytes.NewBuffer(nil)
+	require.NoError(t, PrintChangelog(buffer, changelog, FormatMarkdown))
+	assert.Equal(t, "# Changelog\n\nNo changes.\n", buffer.String())
+	buffer.Reset()
+	require.NoError(t, PrintChangelog(buffer, changelog, FormatJSON))
+	assert.Equal(t, "{}\n", buffer.String())
+}
+
+var formatToFileExtension = map[Format]string{
+	FormatMarkdown: "md",
+	FormatJSON:     "json",
+}
+
+func testBuild(t *testing.T, ctx context.Context, dirPath string) bufimage.Image {
+	readWriteBucket, err := storageos.NewProvider().NewReadWriteBucket(dirPath)
+	require.NoError(t, err)
+	config, err := bufconfig.GetConfigForBucket(ctx, readWriteBucket)
+	require.NoError(t, err)
+	module, err := bufmodulebuild.NewModuleBucketBuilder().BuildForBucket(ctx, readWriteBucket, config.Build)
+	require.NoError(t, err)
+	image, fileAnnotations, err := bufimagebuild.NewBuilder(zap.NewNop(), bufmodule.NewNopModuleReader()).Build(ctx, module)
+	require.NoError(t, err)
+	require.Empty(t, fileAnnotations)
+	return image
+}
@@ -0,0 +1,88 @@
+// Copyright 2020-2023 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bufchangelog
+
+import (
+	"context"
+	"sort"
+
+	"github.com/bufbuild/buf/private/bufpkg/bufimage"
+	"github.com/bufbuild/buf/private/bufpkg/bufimage/bufimageutil"
+	"github.com/bufbuild/buf/private/pkg/protosource"
+)
+
+func newChangelog(ctx context.Context, previousImage bufimage.Image, image bufimage.Image) (*Changelog, error) {
+	previousFiles, err := protosource.NewFilesUnstable(ctx, bufimageutil.NewInputFiles(bufimage.ImageWithoutImports(previousImage).Files())...)
+	if err != nil {
+		return nil, err
+	}
+	files, err := protosource.NewFilesUnstable(ctx, bufimageutil.NewInputFiles(bufimage.ImageWithoutImports(image).Files())...)
+	if err != nil {
+		return nil, err
+	}
+	changes, err := protosource.Diff(previousFiles, files)
+	if err != nil {
+		return nil, err
+	}
+	changelog := &Changelog{}
+	for _, change := range changes {
+		// Changes are sorted by package.
+		numPackageChangelogs := len(changelog.PackageChangelogs)
+		if numPackageChangelogs == 0 || changelog.PackageChangelogs[numPackageChangelogs-1].Package != change.Package() {
+			changelog.PackageChangelogs = append(
+				changelog.PackageChangelogs,
+				&PackageChangelog{
+					Package: change.Package(),
+				},
+			)
+			numPackageChangelogs++
+		}
+		packageChangelog := changelog.PackageChangelogs[numPackageChangelogs-1]
+		packageChangelog.Changes = append(packageChangelog.Changes, change)
+	}
+	return changelog, nil
+}
+
+func mergeChangelogs(changelogs ...*Changelog) *Changelog {
+	packageToPackageChangelog := make(map[string]*PackageChangelog)
+	for _, changelog := range changelogs {
+		for _, packageChangelog := range changelog.PackageChangelogs {
+			existing, ok := packageToPackageChangelog[packageChangelog.Package]
+			if !ok {
+				packageChangelogCopy := *packageChangelog
+				packageToPackageChangelog[packageChangelog.Package] = &packageChangelogCopy
+				continue
+			}
+			existing.Changes = append(existing.Changes, packageChangelog.Changes...)
+			sort.SliceStable(
+				existing.Changes,
+				func(i int, j int) bool {
+					return existing.Changes[i].FullName() < existing.Changes[j].FullName()
+				},
+			)
+		}
+	}
+	changelog := &Changelog{}
+	for _, packageChangelog := range packageToPackageChangelog {
+		changelog.PackageChangelogs = append(changelog.PackageChangelogs, packageChangelog)
+	}
+	sort.Slice(
+		changelog.PackageChangelogs,
+		func(i int, j int) bool {
+			return changelog.PackageChangelogs[i].Package < changelog.PackageChangelogs[j].Package
+		},
+	)
+	return changelog
+}
@@ -0,0 +1,190 @@
+// Copyright 2020-2023 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bufchangelog
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/bufbuild/buf/private/pkg/protosource"
+)
+
+const noPackageName = "<no package>"
+
+var (
+	// changeTypeSections are the change types in the order they are printed, along
+	// with their section headings.
+	changeTypeSections = []struct {
+		changeType protosource.ChangeType
+		heading    string
+	}{
+		{protosource.ChangeTypeAdded, "Added"},
+		{protosource.ChangeTypeChanged, "Changed"},
+		{protosource.ChangeTypeDeprecated, "Deprecated"},
+		{protosource.ChangeTypeRemoved, "Removed"},
+	}
+	elementTypeToMarkdownName = map[protosource.ElementType]string{
+		protosource.ElementTypeMessage:   "Message",
+		protosource.ElementTypeField:     "Field",
+		protosource.ElementTypeExtension: "Extension",
+		protosource.ElementTypeEnum:      "Enum",
+		protosource.ElementTypeEnumValue: "Enum value",
+		protosource.ElementTypeService:   "Service",
+		protosource.ElementTypeMethod:    "RPC",
+	}
+)
+
+func printAsMarkdown(writer io.Writer, changelog *Changelog) error {
+	buffer := bytes.NewBuffer(nil)
+	_, _ = buffer.WriteString("# Changelog\n")
+	if len(changelog.PackageChangelogs) == 0 {
+		_, _ = buffer.WriteString("\nNo changes.\n")
+	}
+	for _, packageChangelog := range changelog.PackageChangelogs {
+		_, _ = buffer.WriteString("\n## ")
+		if packageChangelog.Package == "" {
+			_, _ = buffer.WriteString(noPackageName)
+		} else {
+			_, _ = buffer.WriteString("`" + packageChangelog.Package + "`")
+		}
+		_, _ = buffer.WriteRune('\n')
+		for _, changeTypeSection := range changeTypeSections {
+			var changes []protosource.Change
+			for _, change := range packageChangelog.Changes {
+				if change.Type() == changeTypeSection.changeType {
+					changes = append(changes, change)
+				}
+			}
+			if len(changes) == 0 {
+				continue
+			}
+			_, _ = buffer.WriteString("\n### ")
+			_, _ = buffer.WriteString(changeTypeSection.heading)
+			_, _ = buffer.WriteString("\n\n")
+			var previousHasComment bool
+			for _, change := range changes {
+				if previousHasComment {
+					// Separate the comment of the previous change from this change.
+					_, _ = buffer.WriteRune('\n')
+				}
+				previousHasComment = writeChangeAsMarkdown(buffer, change)
+			}
+		}
+	}
+	_, err := writer.Write(buffer.Bytes())
+	return err
+}
+
+// writeChangeAsMarkdown writes the change as a list item, and returns true if
+// the comment of the element was written.
+func writeChangeAsMarkdown(buffer *bytes.Buffer, change protosource.Change) bool {
+	_, _ = buffer.WriteString("- ")
+	_, _ = buffer.WriteString(elementTypeToMarkdownName[change.ElementType()])
+	_, _ = buffer.WriteString(" `")
+	_, _ = buffer.WriteString(change.FullName())
+	_, _ = buffer.WriteRune('`')
+	if descriptions := change.Descriptions(); len(descriptions) > 0 {
+		_, _ = buffer.WriteString(": ")
+		_, _ = buffer.WriteString(strings.Join(descriptions, ", "))
+		_, _ = buffer.WriteRune('.')
+	}
+	_, _ = buffer.WriteRune('\n')
+	comment := changeComment(change)
+	if comment == "" {
+		return false
+	}
+	_, _ = buffer.WriteRune('\n')
+	for _, line := range strings.Split(comment, "\n") {
+		if line != "" {
+			_, _ = buffer.WriteString("  ")
+			_, _ = buffer.WriteString(line)
+		}
+		_, _ = buffer.WriteRune('\n')
+	}
+	return true
+}
+
+func printAsJSON(writer io.Writer, changelog *Changelog) error {
+	data, err := json.Marshal(newExternalChangelog(changelog))
+	if err != nil {
+		return err
+	}
+	if _, err := writer.Write(append(data, '\n')); err != nil {
+		return err
+	}
+	return nil
+}
+
+type externalChangelog struct {
+	Packages []externalPackageChangelog `json:"packages,omitempty" yaml:"packages,omitempty"`
+}
+
+type externalPackageChangelog struct {
+	Package string           `json:"package,omitempty" yaml:"package,omitempty"`
+	Changes []externalChange `json:"changes,omitempty" yaml:"changes,omitempty"`
+}
+
+type externalChange struct {
+	Type         string   `json:"type,omitempty" yaml:"type,omitempty"`
+	ElementType  string   `json:"element_type,omitempty" yaml:"element_type,omitempty"`
+	Name         string   `json:"name,omitempty" yaml:"name,omitempty"`
+	Descriptions []string `json:"descriptions,omitempty" yaml:"descriptions,omitempty"`
+	Comment      string   `json:"comment,omitempty" yaml:"comment,omitempty"`
+}
+
+func newExternalChangelog(changelog *Changelog) externalChangelog {
+	externalPackageChangelogs := make([]externalPackageChangelog, len(changelog.PackageChangelogs))
+	for i, packageChangelog := range changelog.PackageChangelogs {
+		externalChanges := make([]externalChange, len(packageChangelog.Changes))
+		for j, change := range packageChangelog.Changes {
+			externalChanges[j] = externalChange{
+				Type:         change.Type().String(),
+				ElementType:  change.ElementType().String(),
+				Name:         change.FullName(),
+				Descriptions: change.Descriptions(),
+				Comment:      changeComment(change),
+			}
+		}
+		externalPackageChangelogs[i] = externalPackageChangelog{
+			Package: packageChangelog.Package,
+			Changes: externalChanges,
+		}
+	}
+	return externalChangelog{
+		Packages: externalPackageChangelogs,
+	}
+}
+
+// changeComment returns the leading comment of the changed element.
+//
+// The comment of the current element is used, unless the element was removed.
+// The leading space that is conventionally put after "//" is removed from each line.
+func changeComment(change protosource.Change) string {
+	descriptor := change.Current()
+	if descriptor == nil {
+		descriptor = change.Previous()
+	}
+	location := descriptor.Location()
+	if location == nil {
+		return ""
+	}
+	lines := strings.Split(strings.TrimRight(location.LeadingComments(), "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(strings.TrimPrefix(line, " "), " \t")
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
@@ -52,6 +52,19 @@ func NewHandler(logger *zap.Logger) Handler {
 	return newHandler(logger)
 }
 
+// PackageForFileAnnotation returns the package of the elements that the FileAnnotation
+// is for, and true if the FileAnnotation was returned by a Handler returned by NewHandler.
+//
+// Unlike the path of the FileInfo, the package is also known for FileAnnotations for
+// deleted files and packages, which do not have a FileInfo.
+func PackageForFileAnnotation(fileAnnotation bufanalysis.FileAnnotation) (string, bool) {
+	packageFileAnnotation, ok := fileAnnotation.(internal.PackageFileAnnotation)
+	if !ok {
+		return "", false
+	}
+	return packageFileAnnotation.Package(), true
+}
+
 // RulesForConfig returns the rules for a given config.
 //
 // Should only be used for printing.
@@ -123,6 +136,8 @@ func internalConfigForConfig(config *bufbreakingconfig.Config) (*internal.Config
 		IgnoreRootPaths:               config.IgnoreRootPaths,
 		IgnoreIDOrCategoryToRootPaths: config.IgnoreIDOrCategoryToRootPaths,
 		IgnoreUnstablePackages:        config.IgnoreUnstablePackages,
+		AllowCommentIgnores:           config.AllowCommentIgnores,
+		CustomOptions:                 config.CustomOptions,
 	}.NewConfig(
 		versionSpec,
 	)
@@ -708,6 +708,94 @@ func TestRunBreakingIgnoreUnstablePackagesDeleteFile(t *testing.T) {
 	)
 }
 
+func TestRunBreakingCommentIgnores(t *testing.T) {
+	t.Parallel()
+	testBreaking(
+		t,
+		"breaking_comment_ignores",
+		bufanalysistesting.NewFileAnnotation(t, "1.proto", 9, 3, 9, 9, "FIELD_SAME_TYPE"),
+		bufanalysistesting.NewFileAnnotation(t, "1.proto", 11, 3, 11, 9, "FIELD_SAME_TYPE"),
+		bufanalysistesting.NewFileAnnotation(t, "1.proto", 12, 3, 12, 9, "FIELD_SAME_TYPE"),
+	)
+}
+
+func TestRunBreakingCustomOptionSameValue(t *testing.T) {
+	t.Parallel()
+	testBreaking(
+		t,
+		"breaking_custom_option_same_value",
+		bufanalysistesting.NewFileAnnotation(t, "1.proto", 7, 1, 7, 32, "CUSTOM_OPTION_SAME_VALUE"),
+		bufanalysistesting.NewFileAnnotation(t, "1.proto", 23, 5, 23, 43, "CUSTOM_OPTION_SAME_VALUE"),
+		bufanalysistesting.NewFileAnnotation(t, "1.proto", 25, 3, 25, 43, "CUSTOM_OPTION_SAME_VALUE"),
+		bufanalysistesting.NewFileAnnotation(t, "1.proto", 27, 5, 29, 7, "CUSTOM_OPTION_SAME_VALUE"),
+		bufanalysistesting.NewFileAnnotation(t, "1.proto", 32, 5, 32, 37, "CUSTOM_OPTION_SAME_VALUE"),
+	)
+}
+
+func TestRunBreakingSource(t *testing.T) {
+	t.Parallel()
+	testBreaking(
+		t,
+		"breaking_source",
+		bufanalysistesting.NewFileAnnotation(t, "1.proto", 7, 10, 7, 14, "FIELD_SAME_GO_NAME"),
+		bufanalysistesting.NewFileAnnotation(t, "1.proto", 7, 10, 7, 14, "FIELD_SAME_JAVA_NAME"),
+		bufanalysistesting.NewFileAnnotation(t, "1.proto", 8, 19, 8, 24, "FIELD_SAME_JAVA_NAME"),
+		bufanalysistesting.NewFileAnnotation(t, "1.proto", 9, 10, 9, 19, "FIELD_SAME_GO_NAME"),
+		bufanalysistesting.NewFileAnnotation(t, "1.proto", 16, 1, 18, 2, "MESSAGE_CPP_CLASS_NO_DELETE"),
+		bufanalysistesting.NewFileAnnotation(t, "1.proto", 29, 23, 29, 27, "FIELD_SAME_GO_NAME"),
+		bufanalysistesting.NewFileAnnotation(t, "1.proto", 29, 23, 29, 27, "FIELD_SAME_JAVA_NAME"),
+		bufanalysistesting.NewFileAnnotation(t, "1.proto", 34, 10, 34, 14, "FIELD_SAME_GO_NAME"),
+		bufanalysistesting.NewFileAnnotation(t, "1.proto", 39, 5, 39, 19, "FIELD_SAME_ONEOF"),
+	)
+}
+
+func TestRunBreakingDeprecateBeforeDelete(t *testing.T) {
+	t.Parallel()
+	testBreaking(
+		t,
+		"breaking_deprecate_before_delete",
+		bufanalysistesting.NewFileAnnotation(t, "1.proto", 5, 1, 9, 2, "DEPRECATE_BEFORE_DELETE"),
+		bufanalysistesting.NewFileAnnotation(t, "1.proto", 5, 1, 9, 2, "DEPRECATE_BEFORE_DELETE"),
+		bufanalysistesting.NewFileAnnotation(t, "1.proto", 8, 3, 8, 20, "DEPRECATE_BEFORE_DELETE"),
+	)
+}
+
+func TestRunBreakingDeprecationRPCReference(t *testing.T) {
+	t.Parallel()
+	testBreaking(
+		t,
+		"breaking_deprecation_rpc_reference",
+		bufanalysistesting.NewFileAnnotation(t, "1.proto", 22, 30, 22, 33, "DEPRECATION_RPC_REFERENCE"),
+		bufanalysistesting.NewFileAnnotation(t, "1.proto", 23, 17, 23, 20, "DEPRECATION_RPC_REFERENCE"),
+		bufanalysistesting.NewFileAnnotation(t, "1.proto", 23, 17, 23, 20, "DEPRECATION_RPC_REFERENCE"),
+		bufanalysistesting.NewFileAnnotation(t, "1.proto", 31, 30, 31, 33, "DEPRECATION_RPC_REFERENCE"),
+		bufanalysistesting.NewFileAnnotation(t, "1.proto", 31, 30, 31, 33, "DEPRECATION_RPC_REFERENCE"),
+	)
+}
+
+func TestRunBreakingProtovalidateNoTightening(t *testing.T) {
+	t.Parallel()
+	testBreakingWithValidate(
+		t,
+		"breaking_protovalidate_no_tightening",
+		bufanalysistesting.NewFileAnnotation(t, "1.proto", 14, 3, 18, 5, "PROTOVALIDATE_NO_TIGHTENING"),
+		bufanalysistesting.NewFileAnnotation(t, "1.proto", 19, 26, 19, 65, "PROTOVALIDATE_NO_TIGHTENING"),
+		bufanalysistesting.NewFileAnnotation(t, "1.proto", 20, 25, 20, 64, "PROTOVALIDATE_NO_TIGHTENING"),
+		bufanalysistesting.NewFileAnnotation(t, "1.proto", 21, 18, 21, 51, "PROTOVALIDATE_NO_TIGHTENING"),
+		bufanalysistesting.NewFileAnnotation(t, "1.proto", 22, 21, 22, 56, "PROTOVALIDATE_NO_TIGHTENING"),
+		bufanalysistesting.NewFileAnnotation(t, "1.proto", 23, 21, 25, 4, "PROTOVALIDATE_NO_TIGHTENING"),
+		bufanalysistesting.NewFileAnnotation(t, "1.proto", 26, 24, 28, 4, "PROTOVALIDATE_NO_TIGHTENING"),
+		bufanalysistesting.NewFileAnnotation(t, "1.proto", 30, 5, 30, 41, "PROTOVALIDATE_NO_TIGHTENING"),
+		bufanalysistesting.NewFileAnnotation(t, "1.proto", 31, 5, 31, 45, "PROTOVALIDATE_NO_TIGHTENING"),
+		bufanalysistesting.NewFileAnnotation(t, "1.proto", 33, 29, 38, 4, "PROTOVALIDATE_NO_TIGHTENING"),
+		bufanalysistesting.NewFileAnnotation(t, "1.proto", 39, 41, 39, 90, "PROTOVALIDATE_NO_TIGHTENING"),
+		bufanalysistesting.NewFileAnnotation(t, "1.proto", 40, 3, 40, 23, "PROTOVALIDATE_NO_TIGHTENING"),
+		bufanalysistesting.NewFileAnnotation(t, "1.proto", 42, 5, 42, 49, "PROTOVALIDATE_NO_TIGHTENING"),
+		bufanalysistesting.NewFileAnnotation(t, "1.proto", 48, 1, 55, 2, "PROTOVALIDATE_NO_TIGHTENING"),
+		bufanalysistesting.NewFileAnnotation(t, "1.proto", 50, 20, 54, 4, "PROTOVALIDATE_NO_TIGHTENING"),
+	)
+}
+
 func TestRunBreakingIntEnum(t *testing.T) {
 	t.Parallel()
 	testBreaking(
@@ -755,6 +843,33 @@ func testBreaking(
 	t *testing.T,
 	relDirPath string,
 	expectedFileAnnotations ...bufanalysis.FileAnnotation,
+) {
+	testBreakingWithDependencies(
+		t,
+		relDirPath,
+		"",
+		expectedFileAnnotations...,
+	)
+}
+
+func testBreakingWithValidate(
+	t *testing.T,
+	relDirPath string,
+	expectedFileAnnotations ...bufanalysis.FileAnnotation,
+) {
+	testBreakingWithDependencies(
+		t,
+		relDirPath,
+		"deps/protovalidate",
+		expectedFileAnnotations...,
+	)
+}
+
+func testBreakingWithDependencies(
+	t *testing.T,
+	relDirPath string,
+	dependencyPathPrefix string,
+	expectedFileAnnotations ...bufanalysis.FileAnnotation,
 ) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -778,9 +893,22 @@ func testBreaking(
 	previousConfig := testGetConfig(t, previousReadWriteBucket)
 	config := testGetConfig(t, readWriteBucket)
 
+	// The dependencies are the same for the previous and current modules.
+	var previousReadBucket storage.ReadBucket = previousReadWriteBucket
+	var readBucket storage.ReadBucket = readWriteBucket
+	if dependencyPathPrefix != "" {
+		dependencyReadWriteBucket, err := storageosProvider.NewReadWriteBucket(
+			filepath.Join("testdata", dependencyPathPrefix),
+			storageos.ReadWriteBucketWithSymlinksIfSupported(),
+		)
+		require.NoError(t, err)
+		previousReadBucket = storage.MultiReadBucket(dependencyReadWriteBucket, previousReadWriteBucket)
+		readBucket = storage.MultiReadBucket(dependencyReadWriteBucket, readWriteBucket)
+	}
+
 	previousModule, err := bufmodulebuild.NewModuleBucketBuilder().BuildForBucket(
 		context.Background(),
-		previousReadWriteBucket,
+		previousReadBucket,
 		previousConfig.Build,
 	)
 	require.NoError(t, err)
@@ -798,7 +926,7 @@ func testBreaking(
 
 	module, err := bufmodulebuild.NewModuleBucketBuilder().BuildForBucket(
 		context.Background(),
-		readWriteBucket,
+		readBucket,
 		config.Build,
 	)
 	require.NoError(t, err)
@@ -46,6 +46,15 @@ type Config struct {
 	//   v\d+(alpha|beta)\d+
 	//   v\d+p\d+(alpha|beta)\d+
 	IgnoreUnstablePackages bool
+	// AllowCommentIgnores turns on comment-driven ignores.
+	//
+	// A breaking change is ignored if the current element has a leading comment of the form
+	// "buf:breaking:ignore RULE_ID justification". The justification is required.
+	AllowCommentIgnores bool
+	// CustomOptions is a list of the fully-qualified names of the extensions of the
+	// google.protobuf.*Options messages whose values are checked by the
+	// CUSTOM_OPTION_SAME_VALUE rule.
+	CustomOptions []string
 	// Version represents the version of the breaking change rule and category IDs that should be used with this config.
 	Version string
 }
@@ -70,6 +79,8 @@ func NewConfigV1(externalConfig ExternalConfigV1) *Config {
 		IgnoreRootPaths:               externalConfig.Ignore,
 		IgnoreIDOrCategoryToRootPaths: externalConfig.IgnoreOnly,
 		IgnoreUnstablePackages:        externalConfig.IgnoreUnstablePackages,
+		AllowCommentIgnores:           externalConfig.AllowCommentIgnores,
+		CustomOptions:                 externalConfig.CustomOptions,
 		Version:                       v1Version,
 	}
 }
@@ -82,6 +93,8 @@ func ConfigForProto(protoConfig *breakingv1.Config) *Config {
 		IgnoreRootPaths:               protoConfig.GetIgnorePaths(),
 		IgnoreIDOrCategoryToRootPaths: ignoreIDOrCategoryToRootPathsForProto(protoConfig.GetIgnoreIdPaths()),
 		IgnoreUnstablePackages:        protoConfig.GetIgnoreUnstablePackages(),
+		AllowCommentIgnores:           protoConfig.GetAllowCommentIgnores(),
+		CustomOptions:                 protoConfig.GetCustomOptions(),
 		Version:                       protoConfig.GetVersion(),
 	}
 }
@@ -94,6 +107,8 @@ func ProtoForConfig(config *Config) *breakingv1.Config {
 		IgnorePaths:            config.IgnoreRootPaths,
 		IgnoreIdPaths:          protoForIgnoreIDOrCategoryToRootPaths(config.IgnoreIDOrCategoryToRootPaths),
 		IgnoreUnstablePackages: config.IgnoreUnstablePackages,
+		AllowCommentIgnores:    config.AllowCommentIgnores,
+		CustomOptions:          config.CustomOptions,
 		Version:                config.Version,
 	}
 }
@@ -118,6 +133,8 @@ type ExternalConfigV1 struct {
 	// IgnoreIDOrCategoryToRootPaths
 	IgnoreOnly             map[string][]string `json:"ignore_only,omitempty" yaml:"ignore_only,omitempty"`
 	IgnoreUnstablePackages bool                `json:"ignore_unstable_packages,omitempty" yaml:"ignore_unstable_packages,omitempty"`
+	AllowCommentIgnores    bool                `json:"allow_comment_ignores,omitempty" yaml:"allow_comment_ignores,omitempty"`
+	CustomOptions          []string            `json:"custom_options,omitempty" yaml:"custom_options,omitempty"`
 }
 
 // ExternalConfigV1Beta1ForConfig takes a *Config and returns the v1beta1 external config representation.
@@ -139,6 +156,8 @@ func ExternalConfigV1ForConfig(config *Config) ExternalConfigV1 {
 		Ignore:                 config.IgnoreRootPaths,
 		IgnoreOnly:             config.IgnoreIDOrCategoryToRootPaths,
 		IgnoreUnstablePackages: config.IgnoreUnstablePackages,
+		AllowCommentIgnores:    config.AllowCommentIgnores,
+		CustomOptions:          config.CustomOptions,
 	}
 }
 
@@ -158,6 +177,8 @@ type configJSON struct {
 	IgnoreRootPaths               []string      `json:"ignore_root_paths,omitempty"`
 	IgnoreIDOrCategoryToRootPaths []idPathsJSON `json:"ignore_id_to_root_paths,omitempty"`
 	IgnoreUnstablePackages        bool          `json:"ignore_unstable_packages,omitempty"`
+	AllowCommentIgnores           bool          `json:"allow_comment_ignores,omitempty"`
+	CustomOptions                 []string      `json:"custom_options,omitempty"`
 	Version                       string        `json:"version,omitempty"`
 }
 
@@ -186,15 +207,23 @@ func configToJSON(config *Config) *configJSON {
 	copy(except, config.Except)
 	ignoreRootPaths := make([]string, len(config.IgnoreRootPaths))
 	copy(ignoreRootPaths, config.IgnoreRootPaths)
+	var customOptions []string
+	if len(config.CustomOptions) > 0 {
+		customOptions = make([]string, len(config.CustomOptions))
+		copy(customOptions, config.CustomOptions)
+	}
 	sort.Strings(use)
 	sort.Strings(except)
 	sort.Strings(ignoreRootPaths)
+	sort.Strings(customOptions)
 	return &configJSON{
 		Use:                           use,
 		Except:                        except,
 		IgnoreRootPaths:               ignoreRootPaths,
 		IgnoreIDOrCategoryToRootPaths: ignoreIDPathsJSON,
 		IgnoreUnstablePackages:        config.IgnoreUnstablePackages,
+		AllowCommentIgnores:           config.AllowCommentIgnores,
+		CustomOptions:                 customOptions,
 		Version:                       config.Version,
 	}
 }
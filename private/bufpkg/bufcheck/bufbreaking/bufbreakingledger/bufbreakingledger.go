@@ -0,0 +1,252 @@
+// Copyright 2020-2023 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bufbreakingledger records every field number that each message has
+// used across the versions of a schema, so that the reuse of a number by a
+// different field is detected even after the original field was deleted.
+package bufbreakingledger
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/bufbuild/buf/private/bufpkg/bufanalysis"
+	"github.com/bufbuild/buf/private/bufpkg/bufimage"
+	"github.com/bufbuild/buf/private/bufpkg/bufimage/bufimageutil"
+	"github.com/bufbuild/buf/private/pkg/protodescriptor"
+	"github.com/bufbuild/buf/private/pkg/protosource"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// FieldNumberNoReuseID is the type of the FileAnnotations returned by Check.
+const FieldNumberNoReuseID = "FIELD_NUMBER_NO_REUSE"
+
+// Ledger records the fields that have used each number of each message.
+//
+// A field that is renamed between two adjacent versions without changing its
+// type is the same field, and keeps its Entry.
+type Ledger struct {
+	messageNameToNumberToEntries map[string]map[int][]*Entry
+	// the Entries of the fields of the last version added
+	lastMessageNameToNumberToEntry map[string]map[int]*Entry
+}
+
+// Entry is a field that used a number of a message.
+type Entry struct {
+	// Name is the name of the field.
+	//
+	// If the field was renamed, this is the name in the last version added.
+	Name string
+	// Type is the type of the field, such as "int32", "repeated string" or ".acme.v1.Foo".
+	Type string
+	// Version is the first version that the field was added in.
+	Version string
+}
+
+// NewLedger returns a new empty Ledger.
+func NewLedger() *Ledger {
+	return &Ledger{
+		messageNameToNumberToEntries:   make(map[string]map[int][]*Entry),
+		lastMessageNameToNumberToEntry: make(map[string]map[int]*Entry),
+	}
+}
+
+// Add adds the fields of the messages of the non-import files of the Image to the Ledger.
+//
+// The version identifies the Image, such as a git commit or tag. Add the oldest
+// version first, so that the first version that a field was added in is recorded.
+func (l *Ledger) Add(ctx context.Context, image bufimage.Image, version string) error {
+	messageNameToNumberToEntry := make(map[string]map[int]*Entry)
+	if err := forEachField(
+		ctx,
+		image,
+		func(messageName string, field protosource.Field) error {
+			numberToEntries, ok := l.messageNameToNumberToEntries[messageName]
+			if !ok {
+				numberToEntries = make(map[int][]*Entry)
+				l.messageNameToNumberToEntries[messageName] = numberToEntries
+			}
+			numberToEntry, ok := messageNameToNumberToEntry[messageName]
+			if !ok {
+				numberToEntry = make(map[int]*Entry)
+				messageNameToNumberToEntry[messageName] = numberToEntry
+			}
+			numberToEntry[field.Number()] = l.getOrAddEntry(messageName, numberToEntries, field, version)
+			return nil
+		},
+	); err != nil {
+		return err
+	}
+	l.lastMessageNameToNumberToEntry = messageNameToNumberToEntry
+	return nil
+}
+
+// Entries returns the Entries for the number of the message, in the order that they were added.
+//
+// The message name is fully-qualified and does not have a leading dot.
+func (l *Ledger) Entries(messageName string, number int) []*Entry {
+	return l.messageNameToNumberToEntries[messageName][number]
+}
+
+// Check returns a FileAnnotation for each field of the non-import files of the Image
+// whose number was used by a field with a different name or type in the Ledger.
+//
+// The Image is checked as the version after the last version added, so a field that
+// has the number and type of a field of the last version added was renamed, and is
+// not reported.
+func Check(ctx context.Context, ledger *Ledger, image bufimage.Image) ([]bufanalysis.FileAnnotation, error) {
+	var fileAnnotations []bufanalysis.FileAnnotation
+	if err := forEachField(
+		ctx,
+		image,
+		func(messageName string, field protosource.Field) error {
+			name, typeString := field.Name(), fieldTypeString(field)
+			lastEntry := ledger.lastMessageNameToNumberToEntry[messageName][field.Number()]
+			for _, entry := range ledger.Entries(messageName, field.Number()) {
+				if entry.Type == typeString && (entry.Name == name || entry == lastEntry) {
+					continue
+				}
+				fileAnnotations = append(
+					fileAnnotations,
+					newFileAnnotation(field, entry),
+				)
+			}
+			return nil
+		},
+	); err != nil {
+		return nil, err
+	}
+	return fileAnnotations, nil
+}
+
+// getOrAddEntry returns the Entry for the field, adding a new Entry if the field
+// is not in the Ledger.
+//
+// If the field has the number and type of a field of the last version added, but
+// a different name, the field was renamed, and the name of the Entry is updated.
+func (l *Ledger) getOrAddEntry(
+	messageName string,
+	numberToEntries map[int][]*Entry,
+	field protosource.Field,
+	version string,
+) *Entry {
+	name, typeString := field.Name(), fieldTypeString(field)
+	for _, entry := range numberToEntries[field.Number()] {
+		if entry.Name == name && entry.Type == typeString {
+			return entry
+		}
+	}
+	if lastEntry := l.lastMessageNameToNumberToEntry[messageName][field.Number()]; lastEntry != nil && lastEntry.Type == typeString {
+		lastEntry.Name = name
+		return lastEntry
+	}
+	entry := &Entry{
+		Name:    name,
+		Type:    typeString,
+		Version: version,
+	}
+	numberToEntries[field.Number()] = append(numberToEntries[field.Number()], entry)
+	return entry
+}
+
+func forEachField(
+	ctx context.Context,
+	image bufimage.Image,
+	f func(messageName string, field protosource.Field) error,
+) error {
+	var imageFiles []bufimage.ImageFile
+	for _, imageFile := range image.Files() {
+		if !imageFile.IsImport() {
+			imageFiles = append(imageFiles, imageFile)
+		}
+	}
+	files, err := protosource.NewFilesUnstable(ctx, bufimageutil.NewInputFiles(imageFiles)...)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if err := protosource.ForEachMessage(
+			func(message protosource.Message) error {
+				for _, field := range message.Fields() {
+					if err := f(message.FullName(), field); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+			file,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newFileAnnotation(field protosource.Field, entry *Entry) bufanalysis.FileAnnotation {
+	var startLine, startColumn, endLine, endColumn int
+	if location := field.NumberLocation(); location != nil {
+		startLine = location.StartLine()
+		startColumn = location.StartColumn()
+		endLine = location.EndLine()
+		endColumn = location.EndColumn()
+	}
+	var message string
+	if entry.Name != field.Name() {
+		message = fmt.Sprintf(
+			`Field %q with name %q on message %q reuses the number of field %q of type %q that was added in %s.`,
+			strconv.Itoa(field.Number()),
+			field.Name(),
+			field.ParentMessage().Name(),
+			entry.Name,
+			entry.Type,
+			entry.Version,
+		)
+	} else {
+		message = fmt.Sprintf(
+			`Field %q with name %q on message %q changed type from %q in %s to %q.`,
+			strconv.Itoa(field.Number()),
+			field.Name(),
+			field.ParentMessage().Name(),
+			entry.Type,
+			entry.Version,
+			fieldTypeString(field),
+		)
+	}
+	return bufanalysis.NewFileAnnotation(
+		field.File(),
+		startLine,
+		startColumn,
+		endLine,
+		endColumn,
+		FieldNumberNoReuseID,
+		message,
+	)
+}
+
+func fieldTypeString(field protosource.Field) string {
+	var typeString string
+	switch field.Type() {
+	case descriptorpb.FieldDescriptorProto_TYPE_MESSAGE,
+		descriptorpb.FieldDescriptorProto_TYPE_ENUM,
+		descriptorpb.FieldDescriptorProto_TYPE_GROUP:
+		typeString = field.TypeName()
+	default:
+		typeString = protodescriptor.FieldDescriptorProtoTypePrettyString(field.Type())
+	}
+	if field.Label() == descriptorpb.FieldDescriptorProto_LABEL_REPEATED {
+		return "repeated " + typeString
+	}
+	return typeString
+}
@@ -0,0 +1,91 @@
+// Copyright 2020-2023 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bufbreakingledger
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/bufbuild/buf/private/bufpkg/bufanalysis"
+	"github.com/bufbuild/buf/private/bufpkg/bufconfig"
+	"github.com/bufbuild/buf/private/bufpkg/bufimage"
+	"github.com/bufbuild/buf/private/bufpkg/bufimage/bufimagebuild"
+	"github.com/bufbuild/buf/private/bufpkg/bufmodule"
+	"github.com/bufbuild/buf/private/bufpkg/bufmodule/bufmodulebuild"
+	"github.com/bufbuild/buf/private/pkg/storage/storageos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestCheck(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	ledger := NewLedger()
+	require.NoError(t, ledger.Add(ctx, testBuild(t, ctx, filepath.Join("testdata", "v1")), "v1"))
+	require.NoError(t, ledger.Add(ctx, testBuild(t, ctx, filepath.Join("testdata", "v2")), "v2"))
+	assert.Equal(
+		t,
+		[]*Entry{
+			{
+				Name:    "two",
+				Type:    "string",
+				Version: "v1",
+			},
+		},
+		ledger.Entries("acme.v1.A", 2),
+	)
+	assert.Equal(
+		t,
+		[]*Entry{
+			{
+				Name:    "sixth",
+				Type:    "string",
+				Version: "v1",
+			},
+		},
+		ledger.Entries("acme.v1.A", 6),
+	)
+	fileAnnotations, err := Check(ctx, ledger, testBuild(t, ctx, filepath.Join("testdata", "current")))
+	require.NoError(t, err)
+	fileAnnotationStrings := make([]string, len(fileAnnotations))
+	for i, fileAnnotation := range bufanalysis.DeduplicateAndSortFileAnnotations(fileAnnotations) {
+		assert.Equal(t, FieldNumberNoReuseID, fileAnnotation.Type())
+		fileAnnotationStrings[i] = fileAnnotation.String()
+	}
+	assert.Equal(
+		t,
+		[]string{
+			filepath.FromSlash("testdata/current/acme/v1/a.proto") + `:7:19:Field "2" with name "second" on message "A" reuses the number of field "two" of type "string" that was added in v1.`,
+			filepath.FromSlash("testdata/current/acme/v1/a.proto") + `:8:18:Field "3" with name "three" on message "A" changed type from "int32" in v1 to "string".`,
+			filepath.FromSlash("testdata/current/acme/v1/a.proto") + `:15:27:Field "8" with name "eight" on message "A" changed type from "string" in v1 to "repeated string".`,
+		},
+		fileAnnotationStrings,
+	)
+}
+
+func testBuild(t *testing.T, ctx context.Context, dirPath string) bufimage.Image {
+	readWriteBucket, err := storageos.NewProvider().NewReadWriteBucket(dirPath)
+	require.NoError(t, err)
+	config, err := bufconfig.GetConfigForBucket(ctx, readWriteBucket)
+	require.NoError(t, err)
+	module, err := bufmodulebuild.NewModuleBucketBuilder().BuildForBucket(ctx, readWriteBucket, config.Build)
+	require.NoError(t, err)
+	image, fileAnnotations, err := bufimagebuild.NewBuilder(zap.NewNop(), bufmodule.NewNopModuleReader()).Build(ctx, module)
+	require.NoError(t, err)
+	require.Empty(t, fileAnnotations)
+	return image
+}
@@ -0,0 +1,180 @@
+// Copyright 2020-2023 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bufbreakingsummary summarizes the delta between two images and
+// recommends a semantic version bump for each package.
+package bufbreakingsummary
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/bufbuild/buf/private/bufpkg/bufanalysis"
+	"github.com/bufbuild/buf/private/bufpkg/bufcheck/bufbreaking"
+	"github.com/bufbuild/buf/private/bufpkg/bufcheck/bufbreaking/bufbreakingconfig"
+	"github.com/bufbuild/buf/private/bufpkg/bufimage"
+	"github.com/bufbuild/buf/private/pkg/protosource"
+)
+
+const (
+	// BumpNone says that no version bump is needed.
+	BumpNone Bump = iota
+	// BumpPatch says that the package changed without adding, deprecating,
+	// or breaking any elements.
+	BumpPatch
+	// BumpMinor says that elements were added or deprecated.
+	BumpMinor
+	// BumpMajor says that the package has breaking changes.
+	BumpMajor
+)
+
+const (
+	// FormatText is the text format.
+	FormatText Format = iota + 1
+	// FormatJSON is the JSON format.
+	FormatJSON
+)
+
+var (
+	// AllFormatStrings is all format strings.
+	AllFormatStrings = []string{
+		"text",
+		"json",
+	}
+
+	bumpToString = map[Bump]string{
+		BumpNone:  "none",
+		BumpPatch: "patch",
+		BumpMinor: "minor",
+		BumpMajor: "major",
+	}
+	stringToFormat = map[string]Format{
+		"text": FormatText,
+		"json": FormatJSON,
+	}
+	formatToString = map[Format]string{
+		FormatText: "text",
+		FormatJSON: "json",
+	}
+)
+
+// Bump is a recommended semantic version bump.
+type Bump int
+
+// String implements fmt.Stringer.
+func (b Bump) String() string {
+	s, ok := bumpToString[b]
+	if !ok {
+		return strconv.Itoa(int(b))
+	}
+	return s
+}
+
+// Format is a format to print a Summary.
+type Format int
+
+// String implements fmt.Stringer.
+func (f Format) String() string {
+	s, ok := formatToString[f]
+	if !ok {
+		return strconv.Itoa(int(f))
+	}
+	return s
+}
+
+// ParseFormat parses the format.
+//
+// The empty string defaults to FormatText.
+func ParseFormat(s string) (Format, error) {
+	if s == "" {
+		return FormatText, nil
+	}
+	f, ok := stringToFormat[s]
+	if ok {
+		return f, nil
+	}
+	return 0, fmt.Errorf("unknown format: %q", s)
+}
+
+// Summary is a summary of the delta between two images.
+type Summary struct {
+	// Bump is the largest Bump of all the PackageSummaries.
+	Bump Bump
+	// PackageSummaries are the summaries for each package, sorted by package.
+	PackageSummaries []*PackageSummary
+}
+
+// PackageSummary is a summary of the delta for a single package.
+type PackageSummary struct {
+	// Package is the name of the package.
+	Package string
+	// Bump is the recommended version bump for the package.
+	Bump Bump
+	// Additions are the elements that were added.
+	Additions []protosource.Change
+	// Deprecations are the elements that were deprecated.
+	Deprecations []protosource.Change
+	// WireBreaking are the breaking changes that break the wire format.
+	WireBreaking []bufanalysis.FileAnnotation
+	// JSONBreaking are the breaking changes that break the JSON format, but
+	// not the wire format.
+	JSONBreaking []bufanalysis.FileAnnotation
+	// SourceBreaking are the breaking changes that only break generated source code.
+	SourceBreaking []bufanalysis.FileAnnotation
+}
+
+// Summarize summarizes the delta between the previousImage and the image.
+//
+// The breaking changes are the FileAnnotations produced by the Handler for the config.
+// Breaking changes from rules in the WIRE category are wire-breaking, breaking changes
+// from rules in the WIRE_JSON category are JSON-breaking, and all other breaking changes
+// are source-breaking. If the config uses rules that are at least as strict as the WIRE
+// or WIRE_JSON categories, the rules in these categories are also run, so that for example
+// a deleted field is reported as wire-breaking when only the FILE category is used.
+//
+// Packages with breaking changes get BumpMajor, packages with added or deprecated
+// elements get BumpMinor, and packages with any other change to their files
+// get BumpPatch.
+//
+// Images should be filtered with regards to imports before passing to this function.
+func Summarize(
+	ctx context.Context,
+	handler bufbreaking.Handler,
+	config *bufbreakingconfig.Config,
+	previousImage bufimage.Image,
+	image bufimage.Image,
+) (*Summary, error) {
+	return summarize(ctx, handler, config, previousImage, image)
+}
+
+// MergeSummaries merges the Summaries into a single Summary.
+//
+// This is used for workspaces, where each module is summarized separately.
+func MergeSummaries(summaries ...*Summary) *Summary {
+	return mergeSummaries(summaries...)
+}
+
+// PrintSummary prints the Summary to the writer in the given format.
+func PrintSummary(writer io.Writer, summary *Summary, format Format) error {
+	switch format {
+	case FormatText:
+		return printAsText(writer, summary)
+	case FormatJSON:
+		return printAsJSON(writer, summary)
+	default:
+		return fmt.Errorf("unknown format: %v", format)
+	}
+}
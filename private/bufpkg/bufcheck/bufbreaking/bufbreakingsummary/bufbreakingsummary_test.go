@@ -0,0 +1,216 @@
+// Copyright 2020-2023 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bufbreakingsummary
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bufbuild/buf/private/bufpkg/bufanalysis"
+	"github.com/bufbuild/buf/private/bufpkg/bufcheck/bufbreaking"
+	"github.com/bufbuild/buf/private/bufpkg/bufcheck/bufbreaking/bufbreakingconfig"
+	"github.com/bufbuild/buf/private/bufpkg/bufconfig"
+	"github.com/bufbuild/buf/private/bufpkg/bufimage"
+	"github.com/bufbuild/buf/private/bufpkg/bufimage/bufimagebuild"
+	"github.com/bufbuild/buf/private/bufpkg/bufmodule"
+	"github.com/bufbuild/buf/private/bufpkg/bufmodule/bufmodulebuild"
+	"github.com/bufbuild/buf/private/pkg/storage/storageos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestSummarizeFile(t *testing.T) {
+	t.Parallel()
+	testSummarize(
+		t,
+		[]string{"FILE"},
+		BumpMajor,
+		`acme.bar.v1: minor
+  added field acme.bar.v1.Bar.kind
+  added field acme.bar.v1.Bar.labels
+  added message acme.bar.v1.Baz
+  added enum value acme.bar.v1.Kind.KIND_A
+  deprecated field acme.bar.v1.Bar.one
+acme.baz.v1: major
+  source-breaking: testdata/current/acme/baz/v1/q.proto:4:1:File option "go_package" changed from "" to "x". (FILE_SAME_GO_PACKAGE)
+acme.foo.v1: major
+  wire-breaking: testdata/current/acme/foo/v1/foo.proto:5:3:Field "2" on message "Foo" changed type from "int32" to "string". See https://developers.google.com/protocol-buffers/docs/proto3#updating for wire compatibility rules. (FIELD_WIRE_COMPATIBLE_TYPE)
+  source-breaking: <input>:1:1:Previously present file "acme/foo/v1/removed.proto" was deleted. (FILE_NO_DELETE)
+acme.gone.v1: major
+  source-breaking: <input>:1:1:Previously present file "acme/gone/v1/gone.proto" was deleted. (FILE_NO_DELETE)
+acme.qux.v1: patch
+acme.same.v1: none
+`,
+		`{"bump":"major","packages":[{"package":"acme.bar.v1","bump":"minor","additions":[{"type":"field","name":"acme.bar.v1.Bar.kind"},{"type":"field","name":"acme.bar.v1.Bar.labels"},{"type":"message","name":"acme.bar.v1.Baz"},{"type":"enum value","name":"acme.bar.v1.Kind.KIND_A"}],"deprecations":[{"type":"field","name":"acme.bar.v1.Bar.one"}]},{"package":"acme.baz.v1","bump":"major","source_breaking":[{"path":"testdata/current/acme/baz/v1/q.proto","start_line":4,"start_column":1,"end_line":4,"end_column":25,"type":"FILE_SAME_GO_PACKAGE","message":"File option \"go_package\" changed from \"\" to \"x\"."}]},{"package":"acme.foo.v1","bump":"major","wire_breaking":[{"path":"testdata/current/acme/foo/v1/foo.proto","start_line":5,"start_column":3,"end_line":5,"end_column":9,"type":"FIELD_WIRE_COMPATIBLE_TYPE","message":"Field \"2\" on message \"Foo\" changed type from \"int32\" to \"string\". See https://developers.google.com/protocol-buffers/docs/proto3#updating for wire compatibility rules."}],"source_breaking":[{"type":"FILE_NO_DELETE","message":"Previously present file \"acme/foo/v1/removed.proto\" was deleted."}]},{"package":"acme.gone.v1","bump":"major","source_breaking":[{"type":"FILE_NO_DELETE","message":"Previously present file \"acme/gone/v1/gone.proto\" was deleted."}]},{"package":"acme.qux.v1","bump":"patch"},{"package":"acme.same.v1","bump":"none"}]}
+`,
+	)
+}
+
+func TestSummarizeWireJSON(t *testing.T) {
+	t.Parallel()
+	// The go_package change and the deleted files are not breaking for WIRE_JSON.
+	testSummarize(
+		t,
+		[]string{"WIRE_JSON"},
+		BumpMajor,
+		`acme.bar.v1: minor
+  added field acme.bar.v1.Bar.kind
+  added field acme.bar.v1.Bar.labels
+  added message acme.bar.v1.Baz
+  added enum value acme.bar.v1.Kind.KIND_A
+  deprecated field acme.bar.v1.Bar.one
+acme.baz.v1: patch
+acme.foo.v1: major
+  wire-breaking: testdata/current/acme/foo/v1/foo.proto:5:3:Field "2" on message "Foo" changed type from "int32" to "string". See https://developers.google.com/protocol-buffers/docs/proto3#updating for wire compatibility rules. (FIELD_WIRE_COMPATIBLE_TYPE)
+acme.gone.v1: patch
+acme.qux.v1: patch
+acme.same.v1: none
+`,
+		"",
+	)
+}
+
+func TestSummarizePackage(t *testing.T) {
+	t.Parallel()
+	// The deleted package has no file in the current image, so it must be
+	// attributed from the previous image.
+	testSummarize(
+		t,
+		[]string{"PACKAGE"},
+		BumpMajor,
+		`acme.bar.v1: minor
+  added field acme.bar.v1.Bar.kind
+  added field acme.bar.v1.Bar.labels
+  added message acme.bar.v1.Baz
+  added enum value acme.bar.v1.Kind.KIND_A
+  deprecated field acme.bar.v1.Bar.one
+acme.baz.v1: major
+  source-breaking: testdata/current/acme/baz/v1/q.proto:4:1:File option "go_package" changed from "" to "x". (FILE_SAME_GO_PACKAGE)
+acme.foo.v1: major
+  wire-breaking: testdata/current/acme/foo/v1/foo.proto:5:3:Field "2" on message "Foo" changed type from "int32" to "string". See https://developers.google.com/protocol-buffers/docs/proto3#updating for wire compatibility rules. (FIELD_WIRE_COMPATIBLE_TYPE)
+  source-breaking: <input>:1:1:Previously present message "Removed" was deleted from package "acme.foo.v1". (PACKAGE_MESSAGE_NO_DELETE)
+acme.gone.v1: major
+  source-breaking: <input>:1:1:Previously present package "acme.gone.v1" was deleted. (PACKAGE_NO_DELETE)
+acme.qux.v1: patch
+acme.same.v1: none
+`,
+		"",
+	)
+}
+
+func TestFilterFileAnnotationsByLocation(t *testing.T) {
+	t.Parallel()
+	seenLocations := make(map[string]struct{})
+	wireFileAnnotations := filterFileAnnotationsByLocation(
+		[]bufanalysis.FileAnnotation{
+			bufanalysis.NewFileAnnotation(nil, 0, 0, 0, 0, "FIELD_NO_DELETE", `Previously present field "1" was deleted.`),
+		},
+		seenLocations,
+	)
+	assert.Len(t, wireFileAnnotations, 1)
+	// FileAnnotations without a location are only filtered out if they have the
+	// same type and message.
+	sourceFileAnnotations := filterFileAnnotationsByLocation(
+		[]bufanalysis.FileAnnotation{
+			bufanalysis.NewFileAnnotation(nil, 0, 0, 0, 0, "FIELD_NO_DELETE", `Previously present field "1" was deleted.`),
+			bufanalysis.NewFileAnnotation(nil, 0, 0, 0, 0, "FILE_NO_DELETE", `Previously present file "a.proto" was deleted.`),
+		},
+		seenLocations,
+	)
+	require.Len(t, sourceFileAnnotations, 1)
+	assert.Equal(t, "FILE_NO_DELETE", sourceFileAnnotations[0].Type())
+}
+
+func TestMergeSummaries(t *testing.T) {
+	t.Parallel()
+	summary := MergeSummaries(
+		&Summary{
+			PackageSummaries: []*PackageSummary{
+				{Package: "b", Bump: BumpPatch},
+				{Package: "a", Bump: BumpNone},
+			},
+		},
+		&Summary{
+			PackageSummaries: []*PackageSummary{
+				{Package: "b", Bump: BumpMinor},
+			},
+		},
+	)
+	assert.Equal(
+		t,
+		&Summary{
+			Bump: BumpMinor,
+			PackageSummaries: []*PackageSummary{
+				{Package: "a", Bump: BumpNone},
+				{Package: "b", Bump: BumpMinor},
+			},
+		},
+		summary,
+	)
+}
+
+func testSummarize(
+	t *testing.T,
+	use []string,
+	expectedBump Bump,
+	expectedText string,
+	expectedJSON string,
+) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	previousImage := testBuild(t, ctx, filepath.Join("testdata", "previous"), true)
+	image := testBuild(t, ctx, filepath.Join("testdata", "current"), false)
+	summary, err := Summarize(
+		ctx,
+		bufbreaking.NewHandler(zap.NewNop()),
+		&bufbreakingconfig.Config{
+			Use:     use,
+			Version: bufconfig.V1Version,
+		},
+		previousImage,
+		image,
+	)
+	require.NoError(t, err)
+	assert.Equal(t, expectedBump, summary.Bump)
+	buffer := bytes.NewBuffer(nil)
+	require.NoError(t, PrintSummary(buffer, summary, FormatText))
+	assert.Equal(t, expectedText, buffer.String())
+	if expectedJSON != "" {
+		buffer.Reset()
+		require.NoError(t, PrintSummary(buffer, summary, FormatJSON))
+		assert.Equal(t, expectedJSON, buffer.String())
+	}
+}
+
+func testBuild(t *testing.T, ctx context.Context, dirPath string, excludeSourceCodeInfo bool) bufimage.Image {
+	readWriteBucket, err := storageos.NewProvider().NewReadWriteBucket(dirPath)
+	require.NoError(t, err)
+	config, err := bufconfig.GetConfigForBucket(ctx, readWriteBucket)
+	require.NoError(t, err)
+	module, err := bufmodulebuild.NewModuleBucketBuilder().BuildForBucket(ctx, readWriteBucket, config.Build)
+	require.NoError(t, err)
+	var options []bufimagebuild.BuildOption
+	if excludeSourceCodeInfo {
+		options = append(options, bufimagebuild.WithExcludeSourceCodeInfo())
+	}
+	image, fileAnnotations, err := bufimagebuild.NewBuilder(zap.NewNop(), bufmodule.NewNopModuleReader()).Build(ctx, module, options...)
+	require.NoError(t, err)
+	require.Empty(t, fileAnnotations)
+	return bufimage.ImageWithoutImports(image)
+}
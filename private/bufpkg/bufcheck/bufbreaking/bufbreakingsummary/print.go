@@ -0,0 +1,168 @@
+// Copyright 2020-2023 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bufbreakingsummary
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+
+	"github.com/bufbuild/buf/private/bufpkg/bufanalysis"
+	"github.com/bufbuild/buf/private/pkg/protosource"
+)
+
+const noPackageName = "<no package>"
+
+func printAsText(writer io.Writer, summary *Summary) error {
+	buffer := bytes.NewBuffer(nil)
+	for _, packageSummary := range summary.PackageSummaries {
+		pkg := packageSummary.Package
+		if pkg == "" {
+			pkg = noPackageName
+		}
+		_, _ = buffer.WriteString(pkg)
+		_, _ = buffer.WriteString(": ")
+		_, _ = buffer.WriteString(packageSummary.Bump.String())
+		_, _ = buffer.WriteRune('\n')
+		writeChangesAsText(buffer, packageSummary.Additions)
+		writeChangesAsText(buffer, packageSummary.Deprecations)
+		writeFileAnnotationsAsText(buffer, "wire-breaking", packageSummary.WireBreaking)
+		writeFileAnnotationsAsText(buffer, "json-breaking", packageSummary.JSONBreaking)
+		writeFileAnnotationsAsText(buffer, "source-breaking", packageSummary.SourceBreaking)
+	}
+	_, err := writer.Write(buffer.Bytes())
+	return err
+}
+
+func writeChangesAsText(buffer *bytes.Buffer, changes []protosource.Change) {
+	for _, change := range changes {
+		_, _ = buffer.WriteString("  ")
+		_, _ = buffer.WriteString(change.Type().String())
+		_, _ = buffer.WriteRune(' ')
+		_, _ = buffer.WriteString(change.ElementType().String())
+		_, _ = buffer.WriteRune(' ')
+		_, _ = buffer.WriteString(change.FullName())
+		_, _ = buffer.WriteRune('\n')
+	}
+}
+
+func writeFileAnnotationsAsText(buffer *bytes.Buffer, prefix string, fileAnnotations []bufanalysis.FileAnnotation) {
+	for _, fileAnnotation := range fileAnnotations {
+		_, _ = buffer.WriteString("  ")
+		_, _ = buffer.WriteString(prefix)
+		_, _ = buffer.WriteString(": ")
+		_, _ = buffer.WriteString(fileAnnotation.String())
+		_, _ = buffer.WriteString(" (")
+		_, _ = buffer.WriteString(fileAnnotation.Type())
+		_, _ = buffer.WriteString(")\n")
+	}
+}
+
+func printAsJSON(writer io.Writer, summary *Summary) error {
+	data, err := json.Marshal(newExternalSummary(summary))
+	if err != nil {
+		return err
+	}
+	if _, err := writer.Write(append(data, '\n')); err != nil {
+		return err
+	}
+	return nil
+}
+
+type externalSummary struct {
+	Bump     string                   `json:"bump,omitempty" yaml:"bump,omitempty"`
+	Packages []externalPackageSummary `json:"packages,omitempty" yaml:"packages,omitempty"`
+}
+
+type externalPackageSummary struct {
+	Package        string                   `json:"package,omitempty" yaml:"package,omitempty"`
+	Bump           string                   `json:"bump,omitempty" yaml:"bump,omitempty"`
+	Additions      []externalChange         `json:"additions,omitempty" yaml:"additions,omitempty"`
+	Deprecations   []externalChange         `json:"deprecations,omitempty" yaml:"deprecations,omitempty"`
+	WireBreaking   []externalFileAnnotation `json:"wire_breaking,omitempty" yaml:"wire_breaking,omitempty"`
+	JSONBreaking   []externalFileAnnotation `json:"json_breaking,omitempty" yaml:"json_breaking,omitempty"`
+	SourceBreaking []externalFileAnnotation `json:"source_breaking,omitempty" yaml:"source_breaking,omitempty"`
+}
+
+type externalChange struct {
+	Type string `json:"type,omitempty" yaml:"type,omitempty"`
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+}
+
+type externalFileAnnotation struct {
+	Path        string `json:"path,omitempty" yaml:"path,omitempty"`
+	StartLine   int    `json:"start_line,omitempty" yaml:"start_line,omitempty"`
+	StartColumn int    `json:"start_column,omitempty" yaml:"start_column,omitempty"`
+	EndLine     int    `json:"end_line,omitempty" yaml:"end_line,omitempty"`
+	EndColumn   int    `json:"end_column,omitempty" yaml:"end_column,omitempty"`
+	Type        string `json:"type,omitempty" yaml:"type,omitempty"`
+	Message     string `json:"message,omitempty" yaml:"message,omitempty"`
+}
+
+func newExternalSummary(summary *Summary) externalSummary {
+	externalPackageSummaries := make([]externalPackageSummary, len(summary.PackageSummaries))
+	for i, packageSummary := range summary.PackageSummaries {
+		externalPackageSummaries[i] = externalPackageSummary{
+			Package:        packageSummary.Package,
+			Bump:           packageSummary.Bump.String(),
+			Additions:      newExternalChanges(packageSummary.Additions),
+			Deprecations:   newExternalChanges(packageSummary.Deprecations),
+			WireBreaking:   newExternalFileAnnotations(packageSummary.WireBreaking),
+			JSONBreaking:   newExternalFileAnnotations(packageSummary.JSONBreaking),
+			SourceBreaking: newExternalFileAnnotations(packageSummary.SourceBreaking),
+		}
+	}
+	return externalSummary{
+		Bump:     summary.Bump.String(),
+		Packages: externalPackageSummaries,
+	}
+}
+
+func newExternalChanges(changes []protosource.Change) []externalChange {
+	if len(changes) == 0 {
+		return nil
+	}
+	externalChanges := make([]externalChange, len(changes))
+	for i, change := range changes {
+		externalChanges[i] = externalChange{
+			Type: change.ElementType().String(),
+			Name: change.FullName(),
+		}
+	}
+	return externalChanges
+}
+
+func newExternalFileAnnotations(fileAnnotations []bufanalysis.FileAnnotation) []externalFileAnnotation {
+	if len(fileAnnotations) == 0 {
+		return nil
+	}
+	externalFileAnnotations := make([]externalFileAnnotation, len(fileAnnotations))
+	for i, fileAnnotation := range fileAnnotations {
+		var path string
+		if fileInfo := fileAnnotation.FileInfo(); fileInfo != nil {
+			path = fileInfo.ExternalPath()
+		}
+		externalFileAnnotations[i] = externalFileAnnotation{
+			Path:        path,
+			StartLine:   fileAnnotation.StartLine(),
+			StartColumn: fileAnnotation.StartColumn(),
+			EndLine:     fileAnnotation.EndLine(),
+			EndColumn:   fileAnnotation.EndColumn(),
+			Type:        fileAnnotation.Type(),
+			Message:     fileAnnotation.Message(),
+		}
+	}
+	return externalFileAnnotations
+}
@@ -0,0 +1,390 @@
+// Copyright 2020-2023 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bufbreakingsummary
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/bufbuild/buf/private/bufpkg/bufanalysis"
+	"github.com/bufbuild/buf/private/bufpkg/bufcheck/bufbreaking"
+	"github.com/bufbuild/buf/private/bufpkg/bufcheck/bufbreaking/bufbreakingconfig"
+	"github.com/bufbuild/buf/private/bufpkg/bufimage"
+	"github.com/bufbuild/buf/private/bufpkg/bufimage/bufimageutil"
+	"github.com/bufbuild/buf/private/pkg/protosource"
+	"github.com/bufbuild/buf/private/pkg/slicesext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+const (
+	wireCategory     = "WIRE"
+	wireJSONCategory = "WIRE_JSON"
+)
+
+// categoryToStricterCategories maps each category to itself and all categories
+// that are stricter.
+//
+// If a config uses rules from any of these categories, it also cares about
+// the changes detected by the category.
+var categoryToStricterCategories = map[string][]string{
+	wireCategory:     {"FILE", "PACKAGE", wireJSONCategory, wireCategory},
+	wireJSONCategory: {"FILE", "PACKAGE", wireJSONCategory},
+}
+
+func summarize(
+	ctx context.Context,
+	handler bufbreaking.Handler,
+	config *bufbreakingconfig.Config,
+	previousImage bufimage.Image,
+	image bufimage.Image,
+) (*Summary, error) {
+	rules, err := bufbreaking.RulesForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	ruleIDToCategories := make(map[string]map[string]struct{}, len(rules))
+	categories := make(map[string]struct{})
+	for _, rule := range rules {
+		ruleIDToCategories[rule.ID()] = slicesext.ToStructMap(rule.Categories())
+		for _, category := range rule.Categories() {
+			categories[category] = struct{}{}
+		}
+	}
+	fileAnnotations, err := handler.Check(ctx, config, previousImage, image)
+	if err != nil {
+		return nil, err
+	}
+	wireFileAnnotations, err := checkCategory(ctx, handler, config, categories, wireCategory, previousImage, image)
+	if err != nil {
+		return nil, err
+	}
+	wireJSONFileAnnotations, err := checkCategory(ctx, handler, config, categories, wireJSONCategory, previousImage, image)
+	if err != nil {
+		return nil, err
+	}
+	builder, err := newSummaryBuilder(ctx, previousImage, image)
+	if err != nil {
+		return nil, err
+	}
+	var sourceFileAnnotations []bufanalysis.FileAnnotation
+	for _, fileAnnotation := range fileAnnotations {
+		ruleCategories := ruleIDToCategories[fileAnnotation.Type()]
+		if _, ok := ruleCategories[wireCategory]; ok {
+			wireFileAnnotations = append(wireFileAnnotations, fileAnnotation)
+		} else if _, ok := ruleCategories[wireJSONCategory]; ok {
+			wireJSONFileAnnotations = append(wireJSONFileAnnotations, fileAnnotation)
+		} else {
+			sourceFileAnnotations = append(sourceFileAnnotations, fileAnnotation)
+		}
+	}
+	// A single change is usually reported by a rule in each category, for example
+	// FIELD_SAME_TYPE, FIELD_WIRE_JSON_COMPATIBLE_TYPE, and FIELD_WIRE_COMPATIBLE_TYPE.
+	// Each change is only reported with its most severe classification, where changes
+	// are matched by location.
+	seenLocations := make(map[string]struct{})
+	for _, fileAnnotation := range filterFileAnnotationsByLocation(wireFileAnnotations, seenLocations) {
+		builder.addWireBreaking(fileAnnotation)
+	}
+	for _, fileAnnotation := range filterFileAnnotationsByLocation(wireJSONFileAnnotations, seenLocations) {
+		builder.addJSONBreaking(fileAnnotation)
+	}
+	for _, fileAnnotation := range filterFileAnnotationsByLocation(sourceFileAnnotations, seenLocations) {
+		builder.addSourceBreaking(fileAnnotation)
+	}
+	if err := builder.addChanges(); err != nil {
+		return nil, err
+	}
+	return builder.build(), nil
+}
+
+// checkCategory runs the rules of the category with the ignores of the config, if
+// the config uses rules that are at least as strict as the category.
+func checkCategory(
+	ctx context.Context,
+	handler bufbreaking.Handler,
+	config *bufbreakingconfig.Config,
+	categories map[string]struct{},
+	category string,
+	previousImage bufimage.Image,
+	image bufimage.Image,
+) ([]bufanalysis.FileAnnotation, error) {
+	var usesCategory bool
+	for _, stricterCategory := range categoryToStricterCategories[category] {
+		if _, ok := categories[stricterCategory]; ok {
+			usesCategory = true
+			break
+		}
+	}
+	if !usesCategory {
+		return nil, nil
+	}
+	categoryConfig := *config
+	categoryConfig.Use = []string{category}
+	return handler.Check(ctx, &categoryConfig, previousImage, image)
+}
+
+// filterFileAnnotationsByLocation deduplicates and sorts the FileAnnotations, and
+// filters out those with a location in seenLocations. The locations of the returned
+// FileAnnotations are added to seenLocations. The location of a FileAnnotation
+// without a location includes its type and message.
+func filterFileAnnotationsByLocation(
+	fileAnnotations []bufanalysis.FileAnnotation,
+	seenLocations map[string]struct{},
+) []bufanalysis.FileAnnotation {
+	fileAnnotations = bufanalysis.DeduplicateAndSortFileAnnotations(fileAnnotations)
+	filteredFileAnnotations := make([]bufanalysis.FileAnnotation, 0, len(fileAnnotations))
+	newLocations := make(map[string]struct{})
+	for _, fileAnnotation := range fileAnnotations {
+		var path string
+		if fileInfo := fileAnnotation.FileInfo(); fileInfo != nil {
+			path = fileInfo.Path()
+		}
+		location := fmt.Sprintf("%s:%d:%d", path, fileAnnotation.StartLine(), fileAnnotation.StartColumn())
+		if fileAnnotation.StartLine() == 0 {
+			// FileAnnotations without a location, such as those for deleted files,
+			// cannot be matched by location, so these are only matched if they
+			// report the same violation.
+			location = fmt.Sprintf("%s:%s:%s", location, fileAnnotation.Type(), fileAnnotation.Message())
+		}
+		if _, ok := seenLocations[location]; ok {
+			continue
+		}
+		newLocations[location] = struct{}{}
+		filteredFileAnnotations = append(filteredFileAnnotations, fileAnnotation)
+	}
+	for location := range newLocations {
+		seenLocations[location] = struct{}{}
+	}
+	return filteredFileAnnotations
+}
+
+type summaryBuilder struct {
+	previousImage    bufimage.Image
+	image            bufimage.Image
+	previousFiles    []protosource.File
+	files            []protosource.File
+	pathToPackage    map[string]string
+	packageToSummary map[string]*PackageSummary
+	changedPackages  map[string]struct{}
+}
+
+func newSummaryBuilder(
+	ctx context.Context,
+	previousImage bufimage.Image,
+	image bufimage.Image,
+) (*summaryBuilder, error) {
+	previousFiles, err := protosource.NewFilesUnstable(ctx, bufimageutil.NewInputFiles(nonImportImageFiles(previousImage))...)
+	if err != nil {
+		return nil, err
+	}
+	files, err := protosource.NewFilesUnstable(ctx, bufimageutil.NewInputFiles(nonImportImageFiles(image))...)
+	if err != nil {
+		return nil, err
+	}
+	builder := &summaryBuilder{
+		previousImage:    previousImage,
+		image:            image,
+		previousFiles:    previousFiles,
+		files:            files,
+		pathToPackage:    make(map[string]string),
+		packageToSummary: make(map[string]*PackageSummary),
+		changedPackages:  make(map[string]struct{}),
+	}
+	// The current image takes precedence if a file moved between packages.
+	for _, imageFiles := range [][]bufimage.ImageFile{previousImage.Files(), image.Files()} {
+		for _, imageFile := range imageFiles {
+			builder.pathToPackage[imageFile.Path()] = imageFile.FileDescriptorProto().GetPackage()
+		}
+	}
+	for _, file := range previousFiles {
+		builder.getPackageSummary(file.Package())
+	}
+	for _, file := range files {
+		builder.getPackageSummary(file.Package())
+	}
+	return builder, nil
+}
+
+func (b *summaryBuilder) addWireBreaking(fileAnnotation bufanalysis.FileAnnotation) {
+	packageSummary := b.getPackageSummaryForFileAnnotation(fileAnnotation)
+	packageSummary.WireBreaking = append(packageSummary.WireBreaking, fileAnnotation)
+}
+
+func (b *summaryBuilder) addJSONBreaking(fileAnnotation bufanalysis.FileAnnotation) {
+	packageSummary := b.getPackageSummaryForFileAnnotation(fileAnnotation)
+	packageSummary.JSONBreaking = append(packageSummary.JSONBreaking, fileAnnotation)
+}
+
+func (b *summaryBuilder) addSourceBreaking(fileAnnotation bufanalysis.FileAnnotation) {
+	packageSummary := b.getPackageSummaryForFileAnnotation(fileAnnotation)
+	packageSummary.SourceBreaking = append(packageSummary.SourceBreaking, fileAnnotation)
+}
+
+func (b *summaryBuilder) addChanges() error {
+	changes, err := protosource.Diff(b.previousFiles, b.files)
+	if err != nil {
+		return err
+	}
+	for _, change := range changes {
+		packageSummary := b.getPackageSummary(change.Package())
+		switch change.Type() {
+		case protosource.ChangeTypeAdded:
+			packageSummary.Additions = append(packageSummary.Additions, change)
+		case protosource.ChangeTypeDeprecated:
+			packageSummary.Deprecations = append(packageSummary.Deprecations, change)
+		default:
+			// Removals are reported by the breaking change rules, or were
+			// explicitly allowed by the config.
+			b.changedPackages[change.Package()] = struct{}{}
+		}
+	}
+	// Any other change to the files of a package, such as a changed option or a
+	// moved element, results in a patch. Source code info is not compared, as the
+	// previous image generally does not have it.
+	pathToPreviousFile := make(map[string]*descriptorpb.FileDescriptorProto)
+	for _, imageFile := range nonImportImageFiles(b.previousImage) {
+		pathToPreviousFile[imageFile.Path()] = fileDescriptorProtoWithoutSourceCodeInfo(imageFile)
+	}
+	for _, imageFile := range nonImportImageFiles(b.image) {
+		fileDescriptorProto := fileDescriptorProtoWithoutSourceCodeInfo(imageFile)
+		previousFileDescriptorProto, ok := pathToPreviousFile[imageFile.Path()]
+		delete(pathToPreviousFile, imageFile.Path())
+		if !ok || !proto.Equal(previousFileDescriptorProto, fileDescriptorProto) {
+			b.changedPackages[fileDescriptorProto.GetPackage()] = struct{}{}
+			if ok {
+				b.changedPackages[previousFileDescriptorProto.GetPackage()] = struct{}{}
+			}
+		}
+	}
+	for _, previousFileDescriptorProto := range pathToPreviousFile {
+		b.changedPackages[previousFileDescriptorProto.GetPackage()] = struct{}{}
+	}
+	return nil
+}
+
+func (b *summaryBuilder) build() *Summary {
+	summary := &Summary{}
+	for pkg, packageSummary := range b.packageToSummary {
+		_, changed := b.changedPackages[pkg]
+		switch {
+		case len(packageSummary.WireBreaking) > 0, len(packageSummary.JSONBreaking) > 0, len(packageSummary.SourceBreaking) > 0:
+			packageSummary.Bump = BumpMajor
+		case len(packageSummary.Additions) > 0, len(packageSummary.Deprecations) > 0:
+			packageSummary.Bump = BumpMinor
+		case changed:
+			packageSummary.Bump = BumpPatch
+		default:
+			packageSummary.Bump = BumpNone
+		}
+		packageSummary.WireBreaking = bufanalysis.DeduplicateAndSortFileAnnotations(packageSummary.WireBreaking)
+		packageSummary.JSONBreaking = bufanalysis.DeduplicateAndSortFileAnnotations(packageSummary.JSONBreaking)
+		packageSummary.SourceBreaking = bufanalysis.DeduplicateAndSortFileAnnotations(packageSummary.SourceBreaking)
+		summary.PackageSummaries = append(summary.PackageSummaries, packageSummary)
+	}
+	sortPackageSummaries(summary)
+	return summary
+}
+
+func (b *summaryBuilder) getPackageSummaryForFileAnnotation(fileAnnotation bufanalysis.FileAnnotation) *PackageSummary {
+	// FileAnnotations for deleted files and packages do not have a FileInfo, so the
+	// package recorded when the FileAnnotation was produced is used if available.
+	if pkg, ok := bufbreaking.PackageForFileAnnotation(fileAnnotation); ok {
+		return b.getPackageSummary(pkg)
+	}
+	if fileInfo := fileAnnotation.FileInfo(); fileInfo != nil {
+		return b.getPackageSummary(b.pathToPackage[fileInfo.Path()])
+	}
+	return b.getPackageSummary("")
+}
+
+func (b *summaryBuilder) getPackageSummary(pkg string) *PackageSummary {
+	packageSummary, ok := b.packageToSummary[pkg]
+	if !ok {
+		packageSummary = &PackageSummary{
+			Package: pkg,
+		}
+		b.packageToSummary[pkg] = packageSummary
+	}
+	return packageSummary
+}
+
+func mergeSummaries(summaries ...*Summary) *Summary {
+	packageToSummary := make(map[string]*PackageSummary)
+	for _, summary := range summaries {
+		for _, packageSummary := range summary.PackageSummaries {
+			existing, ok := packageToSummary[packageSummary.Package]
+			if !ok {
+				packageSummaryCopy := *packageSummary
+				packageToSummary[packageSummary.Package] = &packageSummaryCopy
+				continue
+			}
+			if packageSummary.Bump > existing.Bump {
+				existing.Bump = packageSummary.Bump
+			}
+			existing.Additions = append(existing.Additions, packageSummary.Additions...)
+			existing.Deprecations = append(existing.Deprecations, packageSummary.Deprecations...)
+			existing.WireBreaking = mergeFileAnnotations(existing.WireBreaking, packageSummary.WireBreaking)
+			existing.JSONBreaking = mergeFileAnnotations(existing.JSONBreaking, packageSummary.JSONBreaking)
+			existing.SourceBreaking = mergeFileAnnotations(existing.SourceBreaking, packageSummary.SourceBreaking)
+		}
+	}
+	summary := &Summary{}
+	for _, packageSummary := range packageToSummary {
+		summary.PackageSummaries = append(summary.PackageSummaries, packageSummary)
+	}
+	sortPackageSummaries(summary)
+	return summary
+}
+
+func mergeFileAnnotations(one []bufanalysis.FileAnnotation, two []bufanalysis.FileAnnotation) []bufanalysis.FileAnnotation {
+	if len(two) == 0 {
+		return one
+	}
+	return bufanalysis.DeduplicateAndSortFileAnnotations(append(one, two...))
+}
+
+// sortPackageSummaries sorts the PackageSummaries and sets the Bump of the Summary.
+func sortPackageSummaries(summary *Summary) {
+	sort.Slice(
+		summary.PackageSummaries,
+		func(i int, j int) bool {
+			return summary.PackageSummaries[i].Package < summary.PackageSummaries[j].Package
+		},
+	)
+	summary.Bump = BumpNone
+	for _, packageSummary := range summary.PackageSummaries {
+		if packageSummary.Bump > summary.Bump {
+			summary.Bump = packageSummary.Bump
+		}
+	}
+}
+
+func nonImportImageFiles(image bufimage.Image) []bufimage.ImageFile {
+	var imageFiles []bufimage.ImageFile
+	for _, imageFile := range image.Files() {
+		if !imageFile.IsImport() {
+			imageFiles = append(imageFiles, imageFile)
+		}
+	}
+	return imageFiles
+}
+
+func fileDescriptorProtoWithoutSourceCodeInfo(imageFile bufimage.ImageFile) *descriptorpb.FileDescriptorProto {
+	fileDescriptorProto := proto.Clone(imageFile.FileDescriptorProto()).(*descriptorpb.FileDescriptorProto)
+	fileDescriptorProto.SourceCodeInfo = nil
+	return fileDescriptorProto
+}
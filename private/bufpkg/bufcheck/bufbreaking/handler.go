@@ -19,6 +19,7 @@ import (
 
 	"github.com/bufbuild/buf/private/bufpkg/bufanalysis"
 	"github.com/bufbuild/buf/private/bufpkg/bufcheck/bufbreaking/bufbreakingconfig"
+	"github.com/bufbuild/buf/private/bufpkg/bufcheck/bufbreaking/internal/bufbreakingcheck"
 	"github.com/bufbuild/buf/private/bufpkg/bufcheck/internal"
 	"github.com/bufbuild/buf/private/bufpkg/bufimage"
 	"github.com/bufbuild/buf/private/bufpkg/bufimage/bufimageutil"
@@ -36,9 +37,13 @@ func newHandler(
 ) *handler {
 	return &handler{
 		logger: logger,
-		// comment ignores are not allowed for breaking changes
-		// so do not set the ignore prefix per the RunnerWithIgnorePrefix comments
-		runner: internal.NewRunner(logger),
+		// breaking changes allow for comment ignores on the current element,
+		// as long as a justification is given
+		// note that comment ignores still need to be enabled within the config
+		runner: internal.NewRunner(
+			logger,
+			internal.RunnerWithJustifiedIgnorePrefix(bufbreakingcheck.CommentIgnorePrefix),
+		),
 	}
 }
 
@@ -21,11 +21,37 @@
 package bufbreakingbuild
 
 import (
+	"github.com/bufbuild/buf/private/bufpkg/bufanalysis"
 	"github.com/bufbuild/buf/private/bufpkg/bufcheck/bufbreaking/internal/bufbreakingcheck"
 	"github.com/bufbuild/buf/private/bufpkg/bufcheck/internal"
+	"github.com/bufbuild/buf/private/pkg/protosource"
 )
 
 var (
+	// CustomOptionSameValueRuleBuilder is a rule builder.
+	CustomOptionSameValueRuleBuilder = internal.NewRuleBuilder(
+		"CUSTOM_OPTION_SAME_VALUE",
+		func(configBuilder internal.ConfigBuilder) (string, error) {
+			return "custom options are not added, changed, or removed (custom options are configurable)", nil
+		},
+		func(configBuilder internal.ConfigBuilder) (internal.CheckFunc, error) {
+			return internal.CheckFunc(func(id string, ignoreFunc internal.IgnoreFunc, previousFiles []protosource.File, files []protosource.File) ([]bufanalysis.FileAnnotation, error) {
+				return bufbreakingcheck.CheckCustomOptionSameValue(id, ignoreFunc, previousFiles, files, configBuilder.CustomOptions)
+			}), nil
+		},
+	)
+	// DeprecateBeforeDeleteRuleBuilder is a rule builder.
+	DeprecateBeforeDeleteRuleBuilder = internal.NewNopRuleBuilder(
+		"DEPRECATE_BEFORE_DELETE",
+		"fields are deprecated in the previous version before they are deleted",
+		bufbreakingcheck.CheckDeprecateBeforeDelete,
+	)
+	// DeprecationRPCReferenceRuleBuilder is a rule builder.
+	DeprecationRPCReferenceRuleBuilder = internal.NewNopRuleBuilder(
+		"DEPRECATION_RPC_REFERENCE",
+		"RPCs that are added and not deprecated do not use deprecated messages, or messages with deprecated fields, as their request or response",
+		bufbreakingcheck.CheckDeprecationRPCReference,
+	)
 	// EnumNoDeleteRuleBuilder is a rule builder.
 	EnumNoDeleteRuleBuilder = internal.NewNopRuleBuilder(
 		"ENUM_NO_DELETE",
@@ -86,6 +112,18 @@ var (
 		"fields have the same value for the ctype option",
 		bufbreakingcheck.CheckFieldSameCType,
 	)
+	// FieldSameGoNameRuleBuilder is a rule builder.
+	FieldSameGoNameRuleBuilder = internal.NewNopRuleBuilder(
+		"FIELD_SAME_GO_NAME",
+		"fields have the same names in code generated for Go by protoc-gen-go",
+		bufbreakingcheck.CheckFieldSameGoName,
+	)
+	// FieldSameJavaNameRuleBuilder is a rule builder.
+	FieldSameJavaNameRuleBuilder = internal.NewNopRuleBuilder(
+		"FIELD_SAME_JAVA_NAME",
+		"fields have the same accessor names in code generated for Java by protoc",
+		bufbreakingcheck.CheckFieldSameJavaName,
+	)
 	// FieldSameJSONNameRuleBuilder is a rule builder.
 	FieldSameJSONNameRuleBuilder = internal.NewNopRuleBuilder(
 		"FIELD_SAME_JSON_NAME",
@@ -260,6 +298,12 @@ var (
 		"files have the same syntax",
 		bufbreakingcheck.CheckFileSameSyntax,
 	)
+	// MessageCppClassNoDeleteRuleBuilder is a rule builder.
+	MessageCppClassNoDeleteRuleBuilder = internal.NewNopRuleBuilder(
+		"MESSAGE_CPP_CLASS_NO_DELETE",
+		"messages are not moved or deleted in a way that deletes the classes generated for C++ by protoc",
+		bufbreakingcheck.CheckMessageCppClassNoDelete,
+	)
 	// MessageNoDeleteRuleBuilder is a rule builder.
 	MessageNoDeleteRuleBuilder = internal.NewNopRuleBuilder(
 		"MESSAGE_NO_DELETE",
@@ -314,6 +358,12 @@ var (
 		"services are not deleted from a given package",
 		bufbreakingcheck.CheckPackageServiceNoDelete,
 	)
+	// ProtovalidateNoTighteningRuleBuilder is a rule builder.
+	ProtovalidateNoTighteningRuleBuilder = internal.NewNopRuleBuilder(
+		"PROTOVALIDATE_NO_TIGHTENING",
+		"protovalidate constraints are not tightened",
+		bufbreakingcheck.CheckProtovalidateNoTightening,
+	)
 	// ReservedEnumNoDeleteRuleBuilder is a rule builder.
 	ReservedEnumNoDeleteRuleBuilder = internal.NewNopRuleBuilder(
 		"RESERVED_ENUM_NO_DELETE",
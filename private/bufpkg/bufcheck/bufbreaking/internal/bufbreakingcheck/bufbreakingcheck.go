@@ -18,18 +18,228 @@
 package bufbreakingcheck
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"strconv"
 	"strings"
 
+	"github.com/bufbuild/buf/private/bufpkg/bufanalysis"
+	"github.com/bufbuild/buf/private/bufpkg/bufcheck/bufbreaking/internal/bufbreakingvalidate"
+	"github.com/bufbuild/buf/private/bufpkg/bufcheck/internal"
 	"github.com/bufbuild/buf/private/pkg/protodescriptor"
 	"github.com/bufbuild/buf/private/pkg/protosource"
 	"github.com/bufbuild/buf/private/pkg/slicesext"
 	"github.com/bufbuild/buf/private/pkg/stringutil"
+	"google.golang.org/protobuf/reflect/protoreflect"
 	"google.golang.org/protobuf/types/descriptorpb"
 )
 
+const (
+	// CommentIgnorePrefix is the comment ignore prefix.
+	//
+	// This is used in bufbreaking when constructing a new Runner, and is passed to the
+	// RunnerWithJustifiedIgnorePrefix option.
+	CommentIgnorePrefix = "buf:breaking:ignore"
+)
+
+// CheckCustomOptionSameValue is a check function.
+var CheckCustomOptionSameValue = func(
+	id string,
+	ignoreFunc internal.IgnoreFunc,
+	previousFiles []protosource.File,
+	files []protosource.File,
+	customOptions []string,
+) ([]bufanalysis.FileAnnotation, error) {
+	if len(customOptions) == 0 {
+		return nil, nil
+	}
+	previousNameToExtensionType, err := getNameToExtensionType(previousFiles, customOptions)
+	if err != nil {
+		return nil, err
+	}
+	nameToExtensionType, err := getNameToExtensionType(files, customOptions)
+	if err != nil {
+		return nil, err
+	}
+	checkDescriptorPair := func(
+		add addFunc,
+		previousDescriptor optionExtensionLocationDescriptor,
+		descriptor optionExtensionLocationDescriptor,
+		location protosource.Location,
+		subject string,
+	) error {
+		return checkCustomOptionSameValue(
+			add,
+			customOptions,
+			previousNameToExtensionType,
+			nameToExtensionType,
+			previousDescriptor,
+			descriptor,
+			location,
+			subject,
+		)
+	}
+	return combineCheckFuncs(
+		newFilePairCheckFunc(
+			func(add addFunc, _ *corpus, previousFile protosource.File, file protosource.File) error {
+				return checkDescriptorPair(add, previousFile, file, nil, "File")
+			},
+		),
+		newMessagePairCheckFunc(
+			func(add addFunc, _ *corpus, previousMessage protosource.Message, message protosource.Message) error {
+				return checkDescriptorPair(add, previousMessage, message, message.Location(), fmt.Sprintf("Message %q", message.Name()))
+			},
+		),
+		newFieldPairCheckFunc(
+			func(add addFunc, _ *corpus, previousField protosource.Field, field protosource.Field) error {
+				// otherwise prints as hex
+				numberString := strconv.FormatInt(int64(field.Number()), 10)
+				return checkDescriptorPair(add, previousField, field, field.Location(), fmt.Sprintf("Field %q with name %q on message %q", numberString, field.Name(), field.ParentMessage().Name()))
+			},
+		),
+		newEnumPairCheckFunc(
+			func(add addFunc, _ *corpus, previousEnum protosource.Enum, enum protosource.Enum) error {
+				return checkDescriptorPair(add, previousEnum, enum, enum.Location(), fmt.Sprintf("Enum %q", enum.Name()))
+			},
+		),
+		newEnumValuePairCheckFunc(
+			func(add addFunc, _ *corpus, previousNameToEnumValue map[string]protosource.EnumValue, nameToEnumValue map[string]protosource.EnumValue) error {
+				for name, previousEnumValue := range previousNameToEnumValue {
+					enumValue, ok := nameToEnumValue[name]
+					if !ok {
+						continue
+					}
+					// otherwise prints as hex
+					numberString := strconv.FormatInt(int64(enumValue.Number()), 10)
+					if err := checkDescriptorPair(add, previousEnumValue, enumValue, enumValue.Location(), fmt.Sprintf("Enum value %q with name %q on enum %q", numberString, enumValue.Name(), enumValue.Enum().Name())); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		),
+		newServicePairCheckFunc(
+			func(add addFunc, _ *corpus, previousService protosource.Service, service protosource.Service) error {
+				return checkDescriptorPair(add, previousService, service, service.Location(), fmt.Sprintf("Service %q", service.Name()))
+			},
+		),
+		newMethodPairCheckFunc(
+			func(add addFunc, _ *corpus, previousMethod protosource.Method, method protosource.Method) error {
+				return checkDescriptorPair(add, previousMethod, method, method.Location(), fmt.Sprintf("RPC %q on service %q", method.Name(), method.Service().Name()))
+			},
+		),
+	)(id, ignoreFunc, previousFiles, files)
+}
+
+func checkCustomOptionSameValue(
+	add addFunc,
+	customOptions []string,
+	previousNameToExtensionType map[string]protoreflect.ExtensionType,
+	nameToExtensionType map[string]protoreflect.ExtensionType,
+	previousDescriptor optionExtensionLocationDescriptor,
+	descriptor optionExtensionLocationDescriptor,
+	location protosource.Location,
+	subject string,
+) error {
+	for _, customOption := range customOptions {
+		previousValue, previousOK, err := getCustomOptionValue(previousDescriptor, previousNameToExtensionType[customOption])
+		if err != nil {
+			return err
+		}
+		value, ok, err := getCustomOptionValue(descriptor, nameToExtensionType[customOption])
+		if err != nil {
+			return err
+		}
+		switch {
+		case previousOK && !ok:
+			add(descriptor, nil, location, `%s removed option "(%s)".`, subject, customOption)
+		case !previousOK && ok:
+			// Adding a custom option may tighten the constraints on an element,
+			// for example when adding protovalidate constraints.
+			add(descriptor, nil, withBackupLocation(descriptor.OptionExtensionLocation(nameToExtensionType[customOption]), location), `%s added option "(%s)".`, subject, customOption)
+		case previousOK && ok && !bytes.Equal(previousValue, value):
+			add(descriptor, nil, withBackupLocation(descriptor.OptionExtensionLocation(nameToExtensionType[customOption]), location), `%s changed the value of option "(%s)".`, subject, customOption)
+		}
+	}
+	return nil
+}
+
+// CheckDeprecateBeforeDelete is a check function.
+var CheckDeprecateBeforeDelete = newMessagePairCheckFunc(checkDeprecateBeforeDelete)
+
+func checkDeprecateBeforeDelete(add addFunc, corpus *corpus, previousMessage protosource.Message, message protosource.Message) error {
+	previousNumberToField, err := protosource.NumberToMessageField(previousMessage)
+	if err != nil {
+		return err
+	}
+	numberToField, err := protosource.NumberToMessageField(message)
+	if err != nil {
+		return err
+	}
+	for previousNumber, previousField := range previousNumberToField {
+		if _, ok := numberToField[previousNumber]; !ok && !previousField.Deprecated() {
+			// otherwise prints as hex
+			previousNumberString := strconv.FormatInt(int64(previousNumber), 10)
+			add(message, nil, message.Location(), `Previously present field %q with name %q on message %q was deleted without first being deprecated.`, previousNumberString, previousField.Name(), message.Name())
+		}
+	}
+	return nil
+}
+
+// CheckDeprecationRPCReference is a check function.
+var CheckDeprecationRPCReference = newFilesCheckFunc(checkDeprecationRPCReference)
+
+func checkDeprecationRPCReference(add addFunc, corpus *corpus) error {
+	previousFullNameToService, err := protosource.FullNameToService(corpus.previousFiles...)
+	if err != nil {
+		return err
+	}
+	fullNameToMessage, err := protosource.FullNameToMessage(corpus.files...)
+	if err != nil {
+		return err
+	}
+	checkType := func(method protosource.Method, typeName string, location protosource.Location, kind string) {
+		message, ok := fullNameToMessage[typeName]
+		if !ok {
+			return
+		}
+		if message.Deprecated() {
+			add(method, nil, location, "New RPC %q uses deprecated message %q as its %s.", method.Name(), typeName, kind)
+			return
+		}
+		for _, field := range message.Fields() {
+			if field.Deprecated() {
+				add(method, nil, location, "New RPC %q uses message %q as its %s, which has deprecated field %q.", method.Name(), typeName, kind, field.Name())
+			}
+		}
+	}
+	for _, file := range corpus.files {
+		for _, service := range file.Services() {
+			if service.Deprecated() {
+				continue
+			}
+			var previousNameToMethod map[string]protosource.Method
+			if previousService, ok := previousFullNameToService[service.FullName()]; ok {
+				previousNameToMethod, err = protosource.NameToMethod(previousService)
+				if err != nil {
+					return err
+				}
+			}
+			for _, method := range service.Methods() {
+				// Only RPCs added since the previous version are checked, so that
+				// deprecating a field never newly fails the RPCs that already use it.
+				if _, ok := previousNameToMethod[method.Name()]; ok || method.Deprecated() {
+					continue
+				}
+				checkType(method, method.InputTypeName(), method.InputTypeLocation(), "request")
+				checkType(method, method.OutputTypeName(), method.OutputTypeLocation(), "response")
+			}
+		}
+	}
+	return nil
+}
+
 // CheckEnumNoDelete is a check function.
 var CheckEnumNoDelete = newFilePairCheckFunc(checkEnumNoDelete)
 
@@ -232,6 +442,44 @@ func checkFieldSameCType(add addFunc, corpus *corpus, previousField protosource.
 	return nil
 }
 
+// CheckFieldSameGoName is a check function.
+var CheckFieldSameGoName = newMessagePairCheckFunc(checkFieldSameGoName)
+
+func checkFieldSameGoName(add addFunc, corpus *corpus, previousMessage protosource.Message, message protosource.Message) error {
+	return checkFieldSameGeneratedName(add, previousMessage, message, "Go", getNumberToGoName)
+}
+
+// CheckFieldSameJavaName is a check function.
+var CheckFieldSameJavaName = newMessagePairCheckFunc(checkFieldSameJavaName)
+
+func checkFieldSameJavaName(add addFunc, corpus *corpus, previousMessage protosource.Message, message protosource.Message) error {
+	return checkFieldSameGeneratedName(add, previousMessage, message, "Java", getNumberToJavaName)
+}
+
+func checkFieldSameGeneratedName(
+	add addFunc,
+	previousMessage protosource.Message,
+	message protosource.Message,
+	language string,
+	getNumberToName func(protosource.Message) map[int]string,
+) error {
+	numberToField, err := protosource.NumberToMessageField(message)
+	if err != nil {
+		return err
+	}
+	previousNumberToName := getNumberToName(previousMessage)
+	numberToName := getNumberToName(message)
+	for previousNumber, previousName := range previousNumberToName {
+		if name, ok := numberToName[previousNumber]; ok && name != previousName {
+			field := numberToField[previousNumber]
+			// otherwise prints as hex
+			numberString := strconv.FormatInt(int64(field.Number()), 10)
+			add(field, nil, field.NameLocation(), `Field %q on message %q changed generated %s name from %q to %q.`, numberString, message.Name(), language, previousName, name)
+		}
+	}
+	return nil
+}
+
 // CheckFieldSameJSONName is a check function.
 var CheckFieldSameJSONName = newFieldPairCheckFunc(checkFieldSameJSONName)
 
@@ -694,6 +942,57 @@ func checkFileSameValue(add addFunc, previousValue interface{}, value interface{
 	return nil
 }
 
+// CheckMessageCppClassNoDelete is a check function.
+var CheckMessageCppClassNoDelete = newFilesCheckFunc(checkMessageCppClassNoDelete)
+
+func checkMessageCppClassNoDelete(add addFunc, corpus *corpus) error {
+	previousPackageToNestedNameToMessage, err := protosource.PackageToNestedNameToMessage(corpus.previousFiles...)
+	if err != nil {
+		return err
+	}
+	packageToNestedNameToMessage, err := protosource.PackageToNestedNameToMessage(corpus.files...)
+	if err != nil {
+		return err
+	}
+	// caching across loops
+	var filePathToFile map[string]protosource.File
+	for previousPackage, previousNestedNameToMessage := range previousPackageToNestedNameToMessage {
+		nestedNameToMessage, ok := packageToNestedNameToMessage[previousPackage]
+		if !ok {
+			// Deleted packages are checked by PACKAGE_NO_DELETE.
+			continue
+		}
+		cppClassNames := make(map[string]struct{}, len(nestedNameToMessage))
+		for nestedName, message := range nestedNameToMessage {
+			if !message.IsMapEntry() {
+				cppClassNames[getCppClassName(nestedName)] = struct{}{}
+			}
+		}
+		for previousNestedName, previousMessage := range previousNestedNameToMessage {
+			if previousMessage.IsMapEntry() {
+				continue
+			}
+			cppClassName := getCppClassName(previousNestedName)
+			if _, ok := cppClassNames[cppClassName]; ok {
+				continue
+			}
+			if filePathToFile == nil {
+				filePathToFile, err = protosource.FilePathToFile(corpus.files...)
+				if err != nil {
+					return err
+				}
+			}
+			if file, ok := filePathToFile[previousMessage.File().Path()]; ok {
+				descriptor, location := getDescriptorAndLocationForDeletedMessage(file, nestedNameToMessage, previousNestedName)
+				add(descriptor, nil, location, `Previously present message %q was moved or deleted, which deletes the C++ class %q in package %q.`, previousNestedName, cppClassName, previousPackage)
+			} else {
+				add(nil, []protosource.Descriptor{previousMessage}, nil, `Previously present message %q was moved or deleted, which deletes the C++ class %q in package %q.`, previousNestedName, cppClassName, previousPackage)
+			}
+		}
+	}
+	return nil
+}
+
 // CheckMessageNoDelete is a check function.
 var CheckMessageNoDelete = newFilePairCheckFunc(checkMessageNoDelete)
 
@@ -959,6 +1258,20 @@ func checkPackageServiceNoDelete(add addFunc, corpus *corpus) error {
 	return nil
 }
 
+// CheckProtovalidateNoTightening is a check function.
+var CheckProtovalidateNoTightening = combineCheckFuncs(
+	newMessagePairCheckFunc(checkProtovalidateNoTighteningForMessage),
+	newFieldPairCheckFunc(checkProtovalidateNoTighteningForField),
+)
+
+func checkProtovalidateNoTighteningForMessage(add addFunc, corpus *corpus, previousMessage protosource.Message, message protosource.Message) error {
+	return bufbreakingvalidate.CheckMessagePair(add, previousMessage, message)
+}
+
+func checkProtovalidateNoTighteningForField(add addFunc, corpus *corpus, previousField protosource.Field, field protosource.Field) error {
+	return bufbreakingvalidate.CheckFieldPair(add, previousField, field)
+}
+
 // CheckReservedEnumNoDelete is a check function.
 var CheckReservedEnumNoDelete = newEnumPairCheckFunc(checkReservedEnumNoDelete)
 
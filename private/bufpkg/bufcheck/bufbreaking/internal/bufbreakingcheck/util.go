@@ -15,14 +15,22 @@
 package bufbreakingcheck
 
 import (
+	"errors"
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/bufbuild/buf/private/bufpkg/bufanalysis"
 	"github.com/bufbuild/buf/private/bufpkg/bufcheck/internal"
+	"github.com/bufbuild/buf/private/pkg/protodescriptor"
 	"github.com/bufbuild/buf/private/pkg/protosource"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
 	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
 )
 
 var (
@@ -246,6 +254,24 @@ func newFieldPairCheckFunc(
 	)
 }
 
+// combineCheckFuncs returns a check function that runs all of the given check
+// functions and concatenates their annotations.
+func combineCheckFuncs(
+	checkFuncs ...func(string, internal.IgnoreFunc, []protosource.File, []protosource.File) ([]bufanalysis.FileAnnotation, error),
+) func(string, internal.IgnoreFunc, []protosource.File, []protosource.File) ([]bufanalysis.FileAnnotation, error) {
+	return func(id string, ignoreFunc internal.IgnoreFunc, previousFiles []protosource.File, files []protosource.File) ([]bufanalysis.FileAnnotation, error) {
+		var fileAnnotations []bufanalysis.FileAnnotation
+		for _, checkFunc := range checkFuncs {
+			checkFileAnnotations, err := checkFunc(id, ignoreFunc, previousFiles, files)
+			if err != nil {
+				return nil, err
+			}
+			fileAnnotations = append(fileAnnotations, checkFileAnnotations...)
+		}
+		return fileAnnotations, nil
+	}
+}
+
 func newServicePairCheckFunc(
 	f func(addFunc, *corpus, protosource.Service, protosource.Service) error,
 ) func(string, internal.IgnoreFunc, []protosource.File, []protosource.File) ([]bufanalysis.FileAnnotation, error) {
@@ -351,3 +377,237 @@ func withBackupLocation(primary protosource.Location, secondary protosource.Loca
 	}
 	return secondary
 }
+
+// optionExtensionLocationDescriptor is a descriptor that has option extensions.
+type optionExtensionLocationDescriptor interface {
+	protosource.Descriptor
+	protosource.OptionExtensionDescriptor
+}
+
+// getNameToExtensionType returns the extension types for the given fully-qualified
+// extension names that are defined within the files.
+//
+// The files may not include their imports, so unresolvable references are allowed.
+// If a name is not defined within the files, the extension type linked into this
+// binary is used if there is one, and the name is skipped otherwise.
+func getNameToExtensionType(files []protosource.File, names []string) (map[string]protoreflect.ExtensionType, error) {
+	fileDescriptors := make([]protodescriptor.FileDescriptor, 0, len(files))
+	for _, file := range files {
+		fileDescriptors = append(fileDescriptors, file.FileDescriptor())
+	}
+	resolver, err := protodesc.FileOptions{AllowUnresolvable: true}.NewFiles(
+		protodescriptor.FileDescriptorSetForFileDescriptors(fileDescriptors...),
+	)
+	if err != nil {
+		return nil, err
+	}
+	nameToExtensionType := make(map[string]protoreflect.ExtensionType, len(names))
+	for _, name := range names {
+		descriptor, err := resolver.FindDescriptorByName(protoreflect.FullName(name))
+		if err != nil {
+			if !errors.Is(err, protoregistry.NotFound) {
+				return nil, err
+			}
+			extensionType, err := protoregistry.GlobalTypes.FindExtensionByName(protoreflect.FullName(name))
+			if err != nil {
+				if !errors.Is(err, protoregistry.NotFound) {
+					return nil, err
+				}
+				continue
+			}
+			nameToExtensionType[name] = extensionType
+			continue
+		}
+		extensionDescriptor, ok := descriptor.(protoreflect.ExtensionDescriptor)
+		if !ok || !extensionDescriptor.IsExtension() {
+			return nil, fmt.Errorf("custom option %q is not an extension", name)
+		}
+		nameToExtensionType[name] = dynamicpb.NewExtensionType(extensionDescriptor)
+	}
+	return nameToExtensionType, nil
+}
+
+// getCustomOptionValue returns the deterministic wire-format encoding of the value
+// of the extension on the options of the descriptor.
+//
+// Returns false if the extension type is nil or the extension is not set.
+func getCustomOptionValue(descriptor protosource.OptionExtensionDescriptor, extensionType protoreflect.ExtensionType) ([]byte, bool, error) {
+	if extensionType == nil {
+		return nil, false, nil
+	}
+	value, ok, err := descriptor.OptionExtension(extensionType)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	extensionTypeDescriptor := extensionType.TypeDescriptor()
+	message := dynamicpb.NewMessage(extensionTypeDescriptor.ContainingMessage())
+	message.Set(extensionTypeDescriptor, extensionType.ValueOf(value))
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(message)
+	if err != nil {
+		return nil, false, err
+	}
+	return data, true, nil
+}
+
+// getCppClassName returns the name of the C++ class generated by protoc for a
+// message with the nested name, which joins the names of nested messages with
+// underscores.
+func getCppClassName(nestedName string) string {
+	return strings.ReplaceAll(nestedName, ".", "_")
+}
+
+// getNumberToGoName returns the names of the Go struct fields generated by
+// protoc-gen-go for the fields of the message.
+//
+// This mirrors protogen, which appends underscores to names that conflict with
+// the methods of generated messages, the getters of earlier fields, or the names of
+// earlier oneofs, in the order that the fields are declared.
+func getNumberToGoName(message protosource.Message) map[int]string {
+	usedNames := map[string]bool{
+		"Reset":               true,
+		"String":              true,
+		"ProtoMessage":        true,
+		"Marshal":             true,
+		"Unmarshal":           true,
+		"ExtensionRangeArray": true,
+		"ExtensionMap":        true,
+		"Descriptor":          true,
+	}
+	makeNameUnique := func(name string, hasGetter bool) string {
+		for usedNames[name] || (hasGetter && usedNames["Get"+name]) {
+			name += "_"
+		}
+		usedNames[name] = true
+		usedNames["Get"+name] = hasGetter
+		return name
+	}
+	numberToGoName := make(map[int]string)
+	for _, field := range message.Fields() {
+		numberToGoName[field.Number()] = makeNameUnique(goCamelCase(field.Name()), true)
+		if oneof := field.Oneof(); oneof != nil && oneof.Fields()[0].Number() == field.Number() {
+			makeNameUnique(goCamelCase(oneof.Name()), false)
+		}
+	}
+	return numberToGoName
+}
+
+// getNumberToJavaName returns the capitalized names used for the accessors generated
+// by protoc for Java for the fields of the message.
+//
+// This mirrors protoc, which appends the field number to the names of fields
+// whose accessors conflict with the accessors of another field.
+func getNumberToJavaName(message protosource.Message) map[int]string {
+	fields := message.Fields()
+	names := make([]string, len(fields))
+	for i, field := range fields {
+		names[i] = javaCapitalizedCamelCase(field.Name())
+	}
+	isConflict := make([]bool, len(fields))
+	for i := range fields {
+		for j := i + 1; j < len(fields); j++ {
+			if names[i] == names[j] || isJavaConflict(fields[i], names[i], fields[j], names[j]) {
+				isConflict[i] = true
+				isConflict[j] = true
+			}
+		}
+	}
+	numberToJavaName := make(map[int]string, len(fields))
+	for i, field := range fields {
+		name := names[i]
+		if isConflict[i] {
+			name += strconv.Itoa(field.Number())
+		}
+		numberToJavaName[field.Number()] = name
+	}
+	return numberToJavaName
+}
+
+// isJavaConflict returns true if the getters generated for a repeated field
+// conflict with the getter generated for a singular field.
+func isJavaConflict(one protosource.Field, oneName string, two protosource.Field, twoName string) bool {
+	oneRepeated := one.Label() == descriptorpb.FieldDescriptorProto_LABEL_REPEATED
+	twoRepeated := two.Label() == descriptorpb.FieldDescriptorProto_LABEL_REPEATED
+	switch {
+	case oneRepeated && !twoRepeated:
+		return oneName+"Count" == twoName || oneName+"List" == twoName
+	case !oneRepeated && twoRepeated:
+		return twoName+"Count" == oneName || twoName+"List" == oneName
+	default:
+		return false
+	}
+}
+
+// goCamelCase converts the name of a field or oneof to the name used in Go.
+//
+// This is a copy of GoCamelCase in google.golang.org/protobuf/internal/strs.
+func goCamelCase(s string) string {
+	var b []byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '.' && i+1 < len(s) && isASCIILower(s[i+1]):
+			// Skip over '.' in ".{{lowercase}}".
+		case c == '.':
+			b = append(b, '_')
+		case c == '_' && (i == 0 || s[i-1] == '.'):
+			// Convert initial '_' to ensure we start with a capital letter.
+			b = append(b, 'X')
+		case c == '_' && i+1 < len(s) && isASCIILower(s[i+1]):
+			// Skip over '_' in "_{{lowercase}}".
+		case isASCIIDigit(c):
+			b = append(b, c)
+		default:
+			// Assume we have a letter now - if not, it's a bogus identifier.
+			if isASCIILower(c) {
+				c -= 'a' - 'A'
+			}
+			b = append(b, c)
+			// Accept lower case sequence that follows.
+			for ; i+1 < len(s) && isASCIILower(s[i+1]); i++ {
+				b = append(b, s[i+1])
+			}
+		}
+	}
+	return string(b)
+}
+
+// javaCapitalizedCamelCase converts the name of a field to the capitalized name
+// used in the accessors generated by protoc for Java.
+//
+// This mirrors UnderscoresToCamelCase in protoc with the first letter capitalized.
+func javaCapitalizedCamelCase(s string) string {
+	var b []byte
+	capitalizeNext := true
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case isASCIILower(c):
+			if capitalizeNext {
+				c -= 'a' - 'A'
+			}
+			b = append(b, c)
+			capitalizeNext = false
+		case isASCIIUpper(c):
+			b = append(b, c)
+			capitalizeNext = false
+		case isASCIIDigit(c):
+			b = append(b, c)
+			capitalizeNext = true
+		default:
+			capitalizeNext = true
+		}
+	}
+	return string(b)
+}
+
+func isASCIILower(c byte) bool {
+	return 'a' <= c && c <= 'z'
+}
+
+func isASCIIUpper(c byte) bool {
+	return 'A' <= c && c <= 'Z'
+}
+
+func isASCIIDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
@@ -0,0 +1,1095 @@
+// Copyright 2020-2023 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bufbreakingv1
+
+import "github.com/bufbuild/buf/private/bufpkg/bufcheck/internal"
+
+// v1IDToExplanation are the explanations of the rules, printed by buf breaking --explain.
+//
+// The examples show the previous and the current version of the source.
+var v1IDToExplanation = map[string]*internal.Explanation{
+	"CUSTOM_OPTION_SAME_VALUE": {
+		Rationale: `Custom options are often read by code generators, proxies, or other tools at runtime. Changing their value can change the generated code or the behavior of these tools for existing clients.`,
+		BadExample: `// Previous
+message Order {
+  option (acme.v1.table_name) = "orders";
+}
+// Current
+message Order {
+  option (acme.v1.table_name) = "order";
+}`,
+		GoodExample: `// Previous
+message Order {
+  option (acme.v1.table_name) = "orders";
+}
+// Current
+message Order {
+  option (acme.v1.table_name) = "orders";
+}`,
+		ConfigKeys: []string{
+			"breaking.custom_options",
+		},
+		Fix: `Restore the previous value of the custom option. Only the custom options listed in breaking.custom_options are checked.`,
+	},
+	"DEPRECATE_BEFORE_DELETE": {
+		Rationale: `Deleting a field without deprecating it first gives clients no warning to stop using it. Deprecating the field in one version and deleting it in a later version gives clients a release to migrate.`,
+		BadExample: `// Previous
+message Order {
+  string customer = 2;
+}
+// Current
+message Order {
+  reserved 2;
+}`,
+		GoodExample: `// Previous
+message Order {
+  string customer = 2 [deprecated = true];
+}
+// Current
+message Order {
+  reserved 2;
+}`,
+		Fix: `Restore the field, mark it as deprecated = true, and delete it in a later version.`,
+	},
+	"DEPRECATION_RPC_REFERENCE": {
+		Rationale: `A new RPC that uses a deprecated message, or a message with deprecated fields, as its request or response makes clients of a new API depend on elements that are going away. RPCs that already existed in the previous version are not checked, so deprecating a field does not fail the RPCs that use it.`,
+		BadExample: `// Previous
+message Order {
+  string customer = 1 [deprecated = true];
+}
+// Current
+message Order {
+  string customer = 1 [deprecated = true];
+}
+service OrderService {
+  rpc GetOrder(GetOrderRequest) returns (Order);
+}`,
+		GoodExample: `// Previous
+message Order {
+  string customer = 1 [deprecated = true];
+}
+// Current
+message Order {
+  string customer = 1 [deprecated = true];
+}
+message OrderV2 {
+  string customer_id = 2;
+}
+service OrderService {
+  rpc GetOrder(GetOrderRequest) returns (OrderV2);
+}`,
+		Fix: `Use a request or response message without deprecated fields for the new RPC, or deprecate the new RPC as well.`,
+	},
+	"ENUM_NO_DELETE": {
+		Rationale: `Deleting an enum deletes the generated code for it, which breaks the source code of clients that use it.`,
+		BadExample: `// Previous
+enum Status {
+  STATUS_UNSPECIFIED = 0;
+}
+// Current`,
+		GoodExample: `// Previous
+enum Status {
+  STATUS_UNSPECIFIED = 0;
+}
+// Current
+enum Status {
+  option deprecated = true;
+  STATUS_UNSPECIFIED = 0;
+}`,
+		Fix: `Restore the enum, and deprecate it instead. If the enum was moved to another file, move it back or use the PACKAGE category instead of FILE.`,
+	},
+	"ENUM_VALUE_NO_DELETE": {
+		Rationale: `Deleting an enum value deletes the generated constant for it, which breaks the source code of clients that use it, and clients that still send the value get an unknown value.`,
+		BadExample: `// Previous
+enum Status {
+  STATUS_UNSPECIFIED = 0;
+  STATUS_ACTIVE = 1;
+}
+// Current
+enum Status {
+  STATUS_UNSPECIFIED = 0;
+}`,
+		GoodExample: `// Previous
+enum Status {
+  STATUS_UNSPECIFIED = 0;
+  STATUS_ACTIVE = 1;
+}
+// Current
+enum Status {
+  STATUS_UNSPECIFIED = 0;
+  STATUS_ACTIVE = 1 [deprecated = true];
+}`,
+		Fix: `Restore the enum value, and deprecate it instead.`,
+	},
+	"ENUM_VALUE_NO_DELETE_UNLESS_NAME_RESERVED": {
+		Rationale: `The JSON and text formats encode enum values by name. If the name of a deleted value is not reserved, it can be reused for a value with a different number, and data written by older clients is read as the wrong value.`,
+		BadExample: `// Previous
+enum Status {
+  STATUS_UNSPECIFIED = 0;
+  STATUS_ACTIVE = 1;
+}
+// Current
+enum Status {
+  STATUS_UNSPECIFIED = 0;
+}`,
+		GoodExample: `// Previous
+enum Status {
+  STATUS_UNSPECIFIED = 0;
+  STATUS_ACTIVE = 1;
+}
+// Current
+enum Status {
+  reserved 1;
+  reserved "STATUS_ACTIVE";
+  STATUS_UNSPECIFIED = 0;
+}`,
+		Fix: `Reserve the name of the deleted enum value.`,
+	},
+	"ENUM_VALUE_NO_DELETE_UNLESS_NUMBER_RESERVED": {
+		Rationale: `The binary format encodes enum values by number. If the number of a deleted value is not reserved, it can be reused for a value with a different meaning, and data written by older clients is read as the wrong value.`,
+		BadExample: `// Previous
+enum Status {
+  STATUS_UNSPECIFIED = 0;
+  STATUS_ACTIVE = 1;
+}
+// Current
+enum Status {
+  STATUS_UNSPECIFIED = 0;
+}`,
+		GoodExample: `// Previous
+enum Status {
+  STATUS_UNSPECIFIED = 0;
+  STATUS_ACTIVE = 1;
+}
+// Current
+enum Status {
+  reserved 1;
+  STATUS_UNSPECIFIED = 0;
+}`,
+		Fix: `Reserve the number of the deleted enum value.`,
+	},
+	"ENUM_VALUE_SAME_NAME": {
+		Rationale: `The JSON and text formats encode enum values by name, and generated code uses the name for the constant. Renaming an enum value breaks data written by older clients, and the source code of clients that use it.`,
+		BadExample: `// Previous
+enum Status {
+  STATUS_ACTIVE = 1;
+}
+// Current
+enum Status {
+  STATUS_ENABLED = 1;
+}`,
+		GoodExample: `// Previous
+enum Status {
+  STATUS_ACTIVE = 1;
+}
+// Current
+enum Status {
+  STATUS_ACTIVE = 1 [deprecated = true];
+  STATUS_ENABLED = 2;
+}`,
+		Fix: `Restore the previous name. To rename a value, add a value with a new number and the new name, and deprecate the previous value.`,
+	},
+	"EXTENSION_MESSAGE_NO_DELETE": {
+		Rationale: `Deleting an extension range from a message breaks the extensions that other files, possibly owned by other teams, declare in that range.`,
+		BadExample: `// Previous
+message Order {
+  extensions 100 to 199;
+}
+// Current
+message Order {}`,
+		GoodExample: `// Previous
+message Order {
+  extensions 100 to 199;
+}
+// Current
+message Order {
+  extensions 100 to 199;
+}`,
+		Fix: `Restore the extension range.`,
+	},
+	"FIELD_NO_DELETE": {
+		Rationale: `Deleting a field deletes the generated accessors for it, which breaks the source code of clients that use it.`,
+		BadExample: `// Previous
+message Order {
+  string customer = 2;
+}
+// Current
+message Order {}`,
+		GoodExample: `// Previous
+message Order {
+  string customer = 2;
+}
+// Current
+message Order {
+  string customer = 2 [deprecated = true];
+}`,
+		Fix: `Restore the field, and deprecate it instead. If only the wire format matters, use the WIRE or WIRE_JSON category instead, and reserve the number and name of the deleted field.`,
+	},
+	"FIELD_NO_DELETE_UNLESS_NAME_RESERVED": {
+		Rationale: `The JSON and text formats encode fields by name. If the name of a deleted field is not reserved, it can be reused for a field with a different type, and data written by older clients fails to parse or is read as the wrong value.`,
+		BadExample: `// Previous
+message Order {
+  string customer = 2;
+}
+// Current
+message Order {}`,
+		GoodExample: `// Previous
+message Order {
+  string customer = 2;
+}
+// Current
+message Order {
+  reserved 2;
+  reserved "customer";
+}`,
+		Fix: `Reserve the name of the deleted field.`,
+	},
+	"FIELD_NO_DELETE_UNLESS_NUMBER_RESERVED": {
+		Rationale: `The binary format encodes fields by number. If the number of a deleted field is not reserved, it can be reused for a field with a different type, and data written by older clients is corrupted.`,
+		BadExample: `// Previous
+message Order {
+  string customer = 2;
+}
+// Current
+message Order {}`,
+		GoodExample: `// Previous
+message Order {
+  string customer = 2;
+}
+// Current
+message Order {
+  reserved 2;
+}`,
+		Fix: `Reserve the number of the deleted field.`,
+	},
+	"FIELD_SAME_CTYPE": {
+		Rationale: `The ctype option changes the type of the generated C++ accessors, which breaks the source code of C++ clients.`,
+		BadExample: `// Previous
+message Order {
+  string id = 1;
+}
+// Current
+message Order {
+  string id = 1 [ctype = CORD];
+}`,
+		GoodExample: `// Previous
+message Order {
+  string id = 1 [ctype = CORD];
+}
+// Current
+message Order {
+  string id = 1 [ctype = CORD];
+}`,
+		Fix: `Restore the previous value of the ctype option.`,
+	},
+	"FIELD_SAME_GO_NAME": {
+		Rationale: `protoc-gen-go derives the names of struct fields and getters from the field name in CamelCase. A change to the field name that changes the Go name breaks the source code of Go clients, even when the wire and JSON formats are not affected.`,
+		BadExample: `// Previous
+message Order {
+  string url = 1;
+}
+// Current
+message Order {
+  string uri = 1 [json_name = "url"];
+}`,
+		GoodExample: `// Previous
+message Order {
+  string user_id = 1;
+}
+// Current
+message Order {
+  string userId = 1 [json_name = "userId"];
+}`,
+		Fix: `Restore the previous field name, or use a new name that results in the same Go name.`,
+	},
+	"FIELD_SAME_JAVA_NAME": {
+		Rationale: `protoc derives the names of Java accessors, such as getUserId, from the field name in camelCase. A change to the field name that changes the accessor names breaks the source code of Java clients, even when the wire and JSON formats are not affected.`,
+		BadExample: `// Previous
+message Order {
+  string url = 1;
+}
+// Current
+message Order {
+  string uri = 1 [json_name = "url"];
+}`,
+		GoodExample: `// Previous
+message Order {
+  string user_id = 1;
+}
+// Current
+message Order {
+  string userId = 1 [json_name = "userId"];
+}`,
+		Fix: `Restore the previous field name, or use a new name that results in the same Java accessor names.`,
+	},
+	"FIELD_SAME_JSON_NAME": {
+		Rationale: `The JSON format encodes fields by their JSON name. Changing the json_name option breaks data written by older clients, and JSON clients that use the previous name.`,
+		BadExample: `// Previous
+message Order {
+  string customer_id = 1;
+}
+// Current
+message Order {
+  string customer_id = 1 [json_name = "customer"];
+}`,
+		GoodExample: `// Previous
+message Order {
+  string customer_id = 1;
+}
+// Current
+message Order {
+  string customer_id = 1 [json_name = "customerId"];
+}`,
+		Fix: `Restore the previous JSON name, or set json_name to the previous default JSON name.`,
+	},
+	"FIELD_SAME_JSTYPE": {
+		Rationale: `The jstype option changes the type of the generated JavaScript accessors, such as from number to string, which breaks the source code of JavaScript clients.`,
+		BadExample: `// Previous
+message Order {
+  int64 total = 1;
+}
+// Current
+message Order {
+  int64 total = 1 [jstype = JS_STRING];
+}`,
+		GoodExample: `// Previous
+message Order {
+  int64 total = 1 [jstype = JS_STRING];
+}
+// Current
+message Order {
+  int64 total = 1 [jstype = JS_STRING];
+}`,
+		Fix: `Restore the previous value of the jstype option.`,
+	},
+	"FIELD_SAME_LABEL": {
+		Rationale: `Changing a field between singular, optional, required, and repeated changes the generated accessors and how the field is encoded. Older clients drop or misread the values written by newer clients.`,
+		BadExample: `// Previous
+message Order {
+  string item = 1;
+}
+// Current
+message Order {
+  repeated string item = 1;
+}`,
+		GoodExample: `// Previous
+message Order {
+  string item = 1;
+}
+// Current
+message Order {
+  string item = 1 [deprecated = true];
+  repeated string items = 2;
+}`,
+		Fix: `Restore the previous label. To change the label, add a new field with a new number, and deprecate the previous field.`,
+	},
+	"FIELD_SAME_NAME": {
+		Rationale: `The JSON and text formats encode fields by name, and generated code uses the name for the accessors. Renaming a field breaks data written by older clients, and the source code of clients that use it.`,
+		BadExample: `// Previous
+message Order {
+  string customer = 2;
+}
+// Current
+message Order {
+  string customer_id = 2;
+}`,
+		GoodExample: `// Previous
+message Order {
+  string customer = 2;
+}
+// Current
+message Order {
+  string customer = 2 [deprecated = true];
+  string customer_id = 3;
+}`,
+		Fix: `Restore the previous name. To rename a field, add a field with a new number and the new name, and deprecate the previous field.`,
+	},
+	"FIELD_SAME_ONEOF": {
+		Rationale: `Moving a field into or out of a oneof changes the generated accessors, and changes whether setting another field clears it. Older clients can read a message with more than one field of the oneof set.`,
+		BadExample: `// Previous
+message Payment {
+  Card card = 1;
+}
+// Current
+message Payment {
+  oneof method {
+    Card card = 1;
+  }
+}`,
+		GoodExample: `// Previous
+message Payment {
+  oneof method {
+    Card card = 1;
+  }
+}
+// Current
+message Payment {
+  oneof method {
+    Card card = 1;
+    Transfer transfer = 2;
+  }
+}`,
+		Fix: `Restore the previous oneof of the field. New fields can be added to an existing oneof.`,
+	},
+	"FIELD_SAME_TYPE": {
+		Rationale: `Changing the type of a field changes the type of the generated accessors, which breaks the source code of clients, and can corrupt data written by older clients.`,
+		BadExample: `// Previous
+message Order {
+  int32 quantity = 1;
+}
+// Current
+message Order {
+  int64 quantity = 1;
+}`,
+		GoodExample: `// Previous
+message Order {
+  int32 quantity = 1;
+}
+// Current
+message Order {
+  int32 quantity = 1 [deprecated = true];
+  int64 quantity_v2 = 2;
+}`,
+		Fix: `Restore the previous type. To change the type, add a new field with a new number, and deprecate the previous field. If only the wire format matters, use the WIRE or WIRE_JSON category instead, which allow compatible type changes.`,
+	},
+	"FIELD_WIRE_COMPATIBLE_TYPE": {
+		Rationale: `Some types, such as int32 and int64, are encoded the same way in the binary format, and a field can be changed between them. Other changes, such as from string to int32, corrupt data written by older clients.`,
+		BadExample: `// Previous
+message Order {
+  string quantity = 1;
+}
+// Current
+message Order {
+  int32 quantity = 1;
+}`,
+		GoodExample: `// Previous
+message Order {
+  int32 quantity = 1;
+}
+// Current
+message Order {
+  int64 quantity = 1;
+}`,
+		Fix: `Restore the previous type, or change it to a type that is wire compatible. To change to an incompatible type, add a new field with a new number, and deprecate the previous field.`,
+	},
+	"FIELD_WIRE_JSON_COMPATIBLE_TYPE": {
+		Rationale: `Some type changes that are compatible in the binary format are not compatible in the JSON format. For example, int64 is encoded as a string in JSON and int32 as a number, so JSON clients of the previous type fail to parse values of the new type.`,
+		BadExample: `// Previous
+message Order {
+  int32 quantity = 1;
+}
+// Current
+message Order {
+  int64 quantity = 1;
+}`,
+		GoodExample: `// Previous
+message Order {
+  int32 quantity = 1;
+}
+// Current
+message Order {
+  sint32 quantity = 1;
+}`,
+		Fix: `Restore the previous type, or change it to a type that is both wire and JSON compatible. To change to an incompatible type, add a new field with a new number, and deprecate the previous field.`,
+	},
+	"FILE_NO_DELETE": {
+		Rationale: `Deleting a file deletes the generated code for it, which breaks the source code of clients that import it.`,
+		BadExample: `// Previous
+acme/v1/order.proto
+// Current`,
+		GoodExample: `// Previous
+acme/v1/order.proto
+// Current
+acme/v1/order.proto`,
+		Fix: `Restore the file. If the types of the file were moved to other files of the same package, use the PACKAGE category instead of FILE.`,
+	},
+	"FILE_SAME_CSHARP_NAMESPACE": {
+		Rationale: `The csharp_namespace option is the namespace of the generated C# code. Changing it breaks the source code of C# clients.`,
+		BadExample: `// Previous
+option csharp_namespace = "Acme.V1";
+// Current
+option csharp_namespace = "Acme.Orders.V1";`,
+		GoodExample: `// Previous
+option csharp_namespace = "Acme.V1";
+// Current
+option csharp_namespace = "Acme.V1";`,
+		Fix: `Restore the previous value of the csharp_namespace option.`,
+	},
+	"FILE_SAME_GO_PACKAGE": {
+		Rationale: `The go_package option is the import path and name of the generated Go package. Changing it breaks the source code of Go clients.`,
+		BadExample: `// Previous
+option go_package = "github.com/acme/gen/acmev1";
+// Current
+option go_package = "github.com/acme/gen/go/acmev1";`,
+		GoodExample: `// Previous
+option go_package = "github.com/acme/gen/acmev1";
+// Current
+option go_package = "github.com/acme/gen/acmev1";`,
+		Fix: `Restore the previous value of the go_package option.`,
+	},
+	"FILE_SAME_JAVA_MULTIPLE_FILES": {
+		Rationale: `The java_multiple_files option controls whether the generated Java classes are nested in the outer class. Changing it moves the classes, which breaks the source code of Java clients.`,
+		BadExample: `// Previous
+option java_multiple_files = true;
+// Current
+option java_multiple_files = false;`,
+		GoodExample: `// Previous
+option java_multiple_files = true;
+// Current
+option java_multiple_files = true;`,
+		Fix: `Restore the previous value of the java_multiple_files option.`,
+	},
+	"FILE_SAME_JAVA_OUTER_CLASSNAME": {
+		Rationale: `The java_outer_classname option is the name of the outer class of the generated Java code. Changing it breaks the source code of Java clients.`,
+		BadExample: `// Previous
+option java_outer_classname = "OrderProto";
+// Current
+option java_outer_classname = "Orders";`,
+		GoodExample: `// Previous
+option java_outer_classname = "OrderProto";
+// Current
+option java_outer_classname = "OrderProto";`,
+		Fix: `Restore the previous value of the java_outer_classname option.`,
+	},
+	"FILE_SAME_JAVA_PACKAGE": {
+		Rationale: `The java_package option is the package of the generated Java code. Changing it breaks the source code of Java clients.`,
+		BadExample: `// Previous
+option java_package = "com.acme.v1";
+// Current
+option java_package = "com.acme.orders.v1";`,
+		GoodExample: `// Previous
+option java_package = "com.acme.v1";
+// Current
+option java_package = "com.acme.v1";`,
+		Fix: `Restore the previous value of the java_package option.`,
+	},
+	"FILE_SAME_JAVA_STRING_CHECK_UTF8": {
+		Rationale: `The java_string_check_utf8 option controls whether the generated Java code rejects strings that are not valid UTF-8. Changing it changes whether existing data can be parsed.`,
+		BadExample: `// Previous
+option java_string_check_utf8 = false;
+// Current
+option java_string_check_utf8 = true;`,
+		GoodExample: `// Previous
+option java_string_check_utf8 = true;
+// Current
+option java_string_check_utf8 = true;`,
+		Fix: `Restore the previous value of the java_string_check_utf8 option.`,
+	},
+	"FILE_SAME_OBJC_CLASS_PREFIX": {
+		Rationale: `The objc_class_prefix option is the prefix of the generated Objective-C classes. Changing it breaks the source code of Objective-C clients.`,
+		BadExample: `// Previous
+option objc_class_prefix = "AXX";
+// Current
+option objc_class_prefix = "ACM";`,
+		GoodExample: `// Previous
+option objc_class_prefix = "AXX";
+// Current
+option objc_class_prefix = "AXX";`,
+		Fix: `Restore the previous value of the objc_class_prefix option.`,
+	},
+	"FILE_SAME_PACKAGE": {
+		Rationale: `The package is part of the fully-qualified name of every type of the file. Changing it changes the names used by the JSON form of google.protobuf.Any, by gRPC for service paths, and by generated code.`,
+		BadExample: `// Previous
+package acme.v1;
+// Current
+package acme.orders.v1;`,
+		GoodExample: `// Previous
+package acme.v1;
+// Current
+package acme.v1;`,
+		Fix: `Restore the previous package. To move types to a new package, copy them to a new file in the new package, and deprecate the previous types.`,
+	},
+	"FILE_SAME_PHP_CLASS_PREFIX": {
+		Rationale: `The php_class_prefix option is the prefix of the generated PHP classes. Changing it breaks the source code of PHP clients.`,
+		BadExample: `// Previous
+option php_class_prefix = "A";
+// Current
+option php_class_prefix = "Acme";`,
+		GoodExample: `// Previous
+option php_class_prefix = "A";
+// Current
+option php_class_prefix = "A";`,
+		Fix: `Restore the previous value of the php_class_prefix option.`,
+	},
+	"FILE_SAME_PHP_METADATA_NAMESPACE": {
+		Rationale: `The php_metadata_namespace option is the namespace of the generated PHP metadata classes. Changing it breaks the source code of PHP clients.`,
+		BadExample: `// Previous
+option php_metadata_namespace = "Acme\\V1\\Metadata";
+// Current
+option php_metadata_namespace = "Acme\\Metadata";`,
+		GoodExample: `// Previous
+option php_metadata_namespace = "Acme\\V1\\Metadata";
+// Current
+option php_metadata_namespace = "Acme\\V1\\Metadata";`,
+		Fix: `Restore the previous value of the php_metadata_namespace option.`,
+	},
+	"FILE_SAME_PHP_NAMESPACE": {
+		Rationale: `The php_namespace option is the namespace of the generated PHP code. Changing it breaks the source code of PHP clients.`,
+		BadExample: `// Previous
+option php_namespace = "Acme\\V1";
+// Current
+option php_namespace = "Acme\\Orders\\V1";`,
+		GoodExample: `// Previous
+option php_namespace = "Acme\\V1";
+// Current
+option php_namespace = "Acme\\V1";`,
+		Fix: `Restore the previous value of the php_namespace option.`,
+	},
+	"FILE_SAME_RUBY_PACKAGE": {
+		Rationale: `The ruby_package option is the module of the generated Ruby code. Changing it breaks the source code of Ruby clients.`,
+		BadExample: `// Previous
+option ruby_package = "Acme::V1";
+// Current
+option ruby_package = "Acme::Orders::V1";`,
+		GoodExample: `// Previous
+option ruby_package = "Acme::V1";
+// Current
+option ruby_package = "Acme::V1";`,
+		Fix: `Restore the previous value of the ruby_package option.`,
+	},
+	"FILE_SAME_SWIFT_PREFIX": {
+		Rationale: `The swift_prefix option is the prefix of the generated Swift types. Changing it breaks the source code of Swift clients.`,
+		BadExample: `// Previous
+option swift_prefix = "AV1";
+// Current
+option swift_prefix = "Acme";`,
+		GoodExample: `// Previous
+option swift_prefix = "AV1";
+// Current
+option swift_prefix = "AV1";`,
+		Fix: `Restore the previous value of the swift_prefix option.`,
+	},
+	"FILE_SAME_OPTIMIZE_FOR": {
+		Rationale: `The optimize_for option controls whether the generated C++ and Java code supports reflection and descriptors. Changing it to CODE_SIZE or LITE_RUNTIME removes APIs that clients may use.`,
+		BadExample: `// Previous
+option optimize_for = SPEED;
+// Current
+option optimize_for = LITE_RUNTIME;`,
+		GoodExample: `// Previous
+option optimize_for = SPEED;
+// Current
+option optimize_for = SPEED;`,
+		Fix: `Restore the previous value of the optimize_for option.`,
+	},
+	"FILE_SAME_CC_GENERIC_SERVICES": {
+		Rationale: `The cc_generic_services option controls whether protoc generates abstract service classes for C++. Changing it adds or removes classes that clients may use.`,
+		BadExample: `// Previous
+option cc_generic_services = true;
+// Current
+option cc_generic_services = false;`,
+		GoodExample: `// Previous
+option cc_generic_services = true;
+// Current
+option cc_generic_services = true;`,
+		Fix: `Restore the previous value of the cc_generic_services option.`,
+	},
+	"FILE_SAME_JAVA_GENERIC_SERVICES": {
+		Rationale: `The java_generic_services option controls whether protoc generates abstract service classes for Java. Changing it adds or removes classes that clients may use.`,
+		BadExample: `// Previous
+option java_generic_services = true;
+// Current
+option java_generic_services = false;`,
+		GoodExample: `// Previous
+option java_generic_services = true;
+// Current
+option java_generic_services = true;`,
+		Fix: `Restore the previous value of the java_generic_services option.`,
+	},
+	"FILE_SAME_PY_GENERIC_SERVICES": {
+		Rationale: `The py_generic_services option controls whether protoc generates abstract service classes for Python. Changing it adds or removes classes that clients may use.`,
+		BadExample: `// Previous
+option py_generic_services = true;
+// Current
+option py_generic_services = false;`,
+		GoodExample: `// Previous
+option py_generic_services = true;
+// Current
+option py_generic_services = true;`,
+		Fix: `Restore the previous value of the py_generic_services option.`,
+	},
+	"FILE_SAME_PHP_GENERIC_SERVICES": {
+		Rationale: `The php_generic_services option controls whether protoc generates abstract service classes for PHP. Changing it adds or removes classes that clients may use.`,
+		BadExample: `// Previous
+option php_generic_services = true;
+// Current
+option php_generic_services = false;`,
+		GoodExample: `// Previous
+option php_generic_services = true;
+// Current
+option php_generic_services = true;`,
+		Fix: `Restore the previous value of the php_generic_services option.`,
+	},
+	"FILE_SAME_CC_ENABLE_ARENAS": {
+		Rationale: `The cc_enable_arenas option controls whether the generated C++ code supports arena allocation. Changing it changes the generated APIs that clients may use.`,
+		BadExample: `// Previous
+option cc_enable_arenas = true;
+// Current
+option cc_enable_arenas = false;`,
+		GoodExample: `// Previous
+option cc_enable_arenas = true;
+// Current
+option cc_enable_arenas = true;`,
+		Fix: `Restore the previous value of the cc_enable_arenas option.`,
+	},
+	"FILE_SAME_SYNTAX": {
+		Rationale: `Changing the syntax of a file changes the semantics of its fields, such as field presence, default values, and how unknown enum values are handled, and changes the generated code.`,
+		BadExample: `// Previous
+syntax = "proto2";
+// Current
+syntax = "proto3";`,
+		GoodExample: `// Previous
+syntax = "proto3";
+// Current
+syntax = "proto3";`,
+		Fix: `Restore the previous syntax. To move to a new syntax, create new files in a new version of the package.`,
+	},
+	"MESSAGE_CPP_CLASS_NO_DELETE": {
+		Rationale: `protoc generates a C++ class for each message, named after the message and its parent messages joined by underscores. Deleting or moving a message in a way that deletes this class breaks the source code of C++ clients, even if a message with the same name exists elsewhere in the package.`,
+		BadExample: `// Previous
+message Order {
+  message Item {}
+}
+// Current
+message Order {}
+message Item {}`,
+		GoodExample: `// Previous
+message Order {
+  message Item {}
+}
+// Current
+message Order {}
+message Order_Item {}`,
+		Fix: `Restore the message, or move it so that the name of its C++ class does not change.`,
+	},
+	"MESSAGE_NO_DELETE": {
+		Rationale: `Deleting a message deletes the generated code for it, which breaks the source code of clients that use it.`,
+		BadExample: `// Previous
+message Order {}
+// Current`,
+		GoodExample: `// Previous
+message Order {}
+// Current
+message Order {
+  option deprecated = true;
+}`,
+		Fix: `Restore the message, and deprecate it instead. If the message was moved to another file of the same package, use the PACKAGE category instead of FILE.`,
+	},
+	"MESSAGE_NO_REMOVE_STANDARD_DESCRIPTOR_ACCESSOR": {
+		Rationale: `Setting no_standard_descriptor_accessor to true removes the descriptor accessor from the generated code, which breaks the source code of clients that use it.`,
+		BadExample: `// Previous
+message Order {}
+// Current
+message Order {
+  option no_standard_descriptor_accessor = true;
+}`,
+		GoodExample: `// Previous
+message Order {}
+// Current
+message Order {
+  option no_standard_descriptor_accessor = false;
+}`,
+		Fix: `Restore the previous value of the no_standard_descriptor_accessor option.`,
+	},
+	"MESSAGE_SAME_MESSAGE_SET_WIRE_FORMAT": {
+		Rationale: `The message_set_wire_format option changes how the message is encoded in the binary format. Changing it makes the message unreadable by older clients.`,
+		BadExample: `// Previous
+message Container {
+  option message_set_wire_format = true;
+  extensions 4 to max;
+}
+// Current
+message Container {
+  extensions 4 to max;
+}`,
+		GoodExample: `// Previous
+message Container {
+  option message_set_wire_format = true;
+  extensions 4 to max;
+}
+// Current
+message Container {
+  option message_set_wire_format = true;
+  extensions 4 to max;
+}`,
+		Fix: `Restore the previous value of the message_set_wire_format option.`,
+	},
+	"MESSAGE_SAME_REQUIRED_FIELDS": {
+		Rationale: `Messages that are missing a required field fail to parse. Adding a required field breaks older clients that do not set it, and deleting one breaks older clients that expect it.`,
+		BadExample: `// Previous
+message Order {
+  required string id = 1;
+}
+// Current
+message Order {
+  required string id = 1;
+  required string customer_id = 2;
+}`,
+		GoodExample: `// Previous
+message Order {
+  required string id = 1;
+}
+// Current
+message Order {
+  required string id = 1;
+  optional string customer_id = 2;
+}`,
+		Fix: `Restore the previous required fields. Add new fields as optional instead.`,
+	},
+	"ONEOF_NO_DELETE": {
+		Rationale: `Deleting a oneof deletes the generated accessors for it, such as the case accessor, which breaks the source code of clients that use it.`,
+		BadExample: `// Previous
+message Payment {
+  oneof method {
+    Card card = 1;
+  }
+}
+// Current
+message Payment {
+  Card card = 1;
+}`,
+		GoodExample: `// Previous
+message Payment {
+  oneof method {
+    Card card = 1;
+  }
+}
+// Current
+message Payment {
+  oneof method {
+    Card card = 1;
+  }
+}`,
+		Fix: `Restore the oneof.`,
+	},
+	"PACKAGE_ENUM_NO_DELETE": {
+		Rationale: `Deleting an enum from a package deletes the generated code for it, which breaks the source code of clients that use it. Moving an enum between files of the same package is allowed.`,
+		BadExample: `// Previous
+package acme.v1;
+enum Status {}
+// Current
+package acme.v1;`,
+		GoodExample: `// Previous
+// acme/v1/a.proto
+package acme.v1;
+enum Status {}
+// Current
+// acme/v1/b.proto
+package acme.v1;
+enum Status {}`,
+		Fix: `Restore the enum in any file of the package, and deprecate it instead.`,
+	},
+	"PACKAGE_MESSAGE_NO_DELETE": {
+		Rationale: `Deleting a message from a package deletes the generated code for it, which breaks the source code of clients that use it. Moving a message between files of the same package is allowed.`,
+		BadExample: `// Previous
+package acme.v1;
+message Order {}
+// Current
+package acme.v1;`,
+		GoodExample: `// Previous
+// acme/v1/a.proto
+package acme.v1;
+message Order {}
+// Current
+// acme/v1/b.proto
+package acme.v1;
+message Order {}`,
+		Fix: `Restore the message in any file of the package, and deprecate it instead.`,
+	},
+	"PACKAGE_NO_DELETE": {
+		Rationale: `Deleting a package deletes all of its types and services, which breaks every client that uses it.`,
+		BadExample: `// Previous
+package acme.v1;
+// Current`,
+		GoodExample: `// Previous
+package acme.v1;
+// Current
+package acme.v1;`,
+		Fix: `Restore the files of the package.`,
+	},
+	"PACKAGE_SERVICE_NO_DELETE": {
+		Rationale: `Deleting a service from a package deletes the generated clients and servers for it, and clients that call it get an unimplemented error. Moving a service between files of the same package is allowed.`,
+		BadExample: `// Previous
+package acme.v1;
+service OrderService {}
+// Current
+package acme.v1;`,
+		GoodExample: `// Previous
+// acme/v1/a.proto
+package acme.v1;
+service OrderService {}
+// Current
+// acme/v1/b.proto
+package acme.v1;
+service OrderService {}`,
+		Fix: `Restore the service in any file of the package, and deprecate it instead.`,
+	},
+	"PROTOVALIDATE_NO_TIGHTENING": {
+		Rationale: `Tightening a protovalidate constraint, such as lowering a maximum or adding a required constraint, makes messages that older clients send, or that are already stored, fail validation.`,
+		BadExample: `// Previous
+string name = 1 [(buf.validate.field).string.max_len = 100];
+// Current
+string name = 1 [(buf.validate.field).string.max_len = 50];`,
+		GoodExample: `// Previous
+string name = 1 [(buf.validate.field).string.max_len = 50];
+// Current
+string name = 1 [(buf.validate.field).string.max_len = 100];`,
+		Fix: `Restore the previous constraint, or loosen it instead. To tighten a constraint, add a new field and deprecate the previous field.`,
+	},
+	"RESERVED_ENUM_NO_DELETE": {
+		Rationale: `Reserved numbers and names protect the numbers and names of deleted enum values from being reused with a different meaning. Deleting a reservation allows them to be reused.`,
+		BadExample: `// Previous
+enum Status {
+  reserved 1;
+  STATUS_UNSPECIFIED = 0;
+}
+// Current
+enum Status {
+  STATUS_UNSPECIFIED = 0;
+}`,
+		GoodExample: `// Previous
+enum Status {
+  reserved 1;
+  STATUS_UNSPECIFIED = 0;
+}
+// Current
+enum Status {
+  reserved 1, 2;
+  STATUS_UNSPECIFIED = 0;
+}`,
+		Fix: `Restore the deleted reserved ranges and names.`,
+	},
+	"RESERVED_MESSAGE_NO_DELETE": {
+		Rationale: `Reserved numbers and names protect the numbers and names of deleted fields from being reused with a different type. Deleting a reservation allows them to be reused.`,
+		BadExample: `// Previous
+message Order {
+  reserved 2;
+  reserved "customer";
+}
+// Current
+message Order {}`,
+		GoodExample: `// Previous
+message Order {
+  reserved 2;
+  reserved "customer";
+}
+// Current
+message Order {
+  reserved 2, 3;
+  reserved "customer", "total";
+}`,
+		Fix: `Restore the deleted reserved ranges and names.`,
+	},
+	"RPC_NO_DELETE": {
+		Rationale: `Deleting an RPC deletes the generated client and server methods for it, and clients that call it get an unimplemented error.`,
+		BadExample: `// Previous
+service OrderService {
+  rpc GetOrder(GetOrderRequest) returns (GetOrderResponse);
+}
+// Current
+service OrderService {}`,
+		GoodExample: `// Previous
+service OrderService {
+  rpc GetOrder(GetOrderRequest) returns (GetOrderResponse);
+}
+// Current
+service OrderService {
+  rpc GetOrder(GetOrderRequest) returns (GetOrderResponse) {
+    option deprecated = true;
+  }
+}`,
+		Fix: `Restore the RPC, and deprecate it instead.`,
+	},
+	"RPC_SAME_CLIENT_STREAMING": {
+		Rationale: `Changing whether an RPC is client streaming changes the generated client and server methods, and clients of the previous version can no longer call it.`,
+		BadExample: `// Previous
+rpc Upload(UploadRequest) returns (UploadResponse);
+// Current
+rpc Upload(stream UploadRequest) returns (UploadResponse);`,
+		GoodExample: `// Previous
+rpc Upload(UploadRequest) returns (UploadResponse);
+// Current
+rpc Upload(UploadRequest) returns (UploadResponse);
+rpc UploadStream(stream UploadStreamRequest) returns (UploadStreamResponse);`,
+		Fix: `Restore the previous streaming value. To stream, add a new RPC, and deprecate the previous RPC.`,
+	},
+	"RPC_SAME_IDEMPOTENCY_LEVEL": {
+		Rationale: `The idempotency_level option tells clients and proxies whether an RPC can be retried or sent with GET. Changing it can make existing clients retry RPCs that are no longer safe to retry, or send requests that the server no longer accepts.`,
+		BadExample: `// Previous
+rpc GetOrder(GetOrderRequest) returns (GetOrderResponse) {
+  option idempotency_level = NO_SIDE_EFFECTS;
+}
+// Current
+rpc GetOrder(GetOrderRequest) returns (GetOrderResponse);`,
+		GoodExample: `// Previous
+rpc GetOrder(GetOrderRequest) returns (GetOrderResponse) {
+  option idempotency_level = NO_SIDE_EFFECTS;
+}
+// Current
+rpc GetOrder(GetOrderRequest) returns (GetOrderResponse) {
+  option idempotency_level = NO_SIDE_EFFECTS;
+}`,
+		Fix: `Restore the previous value of the idempotency_level option.`,
+	},
+	"RPC_SAME_REQUEST_TYPE": {
+		Rationale: `Changing the request type of an RPC breaks the source code of clients, and the server misreads requests sent by clients of the previous version.`,
+		BadExample: `// Previous
+rpc GetOrder(GetOrderRequest) returns (GetOrderResponse);
+// Current
+rpc GetOrder(OrderQuery) returns (GetOrderResponse);`,
+		GoodExample: `// Previous
+rpc GetOrder(GetOrderRequest) returns (GetOrderResponse);
+// Current
+rpc GetOrder(GetOrderRequest) returns (GetOrderResponse);`,
+		Fix: `Restore the previous request type. To change the request type, add fields to the existing request type, or add a new RPC and deprecate the previous RPC.`,
+	},
+	"RPC_SAME_RESPONSE_TYPE": {
+		Rationale: `Changing the response type of an RPC breaks the source code of clients, and clients of the previous version misread the responses of the server.`,
+		BadExample: `// Previous
+rpc GetOrder(GetOrderRequest) returns (GetOrderResponse);
+// Current
+rpc GetOrder(GetOrderRequest) returns (Order);`,
+		GoodExample: `// Previous
+rpc GetOrder(GetOrderRequest) returns (GetOrderResponse);
+// Current
+rpc GetOrder(GetOrderRequest) returns (GetOrderResponse);`,
+		Fix: `Restore the previous response type. To change the response type, add fields to the existing response type, or add a new RPC and deprecate the previous RPC.`,
+	},
+	"RPC_SAME_SERVER_STREAMING": {
+		Rationale: `Changing whether an RPC is server streaming changes the generated client and server methods, and clients of the previous version can no longer call it.`,
+		BadExample: `// Previous
+rpc ListOrders(ListOrdersRequest) returns (ListOrdersResponse);
+// Current
+rpc ListOrders(ListOrdersRequest) returns (stream ListOrdersResponse);`,
+		GoodExample: `// Previous
+rpc ListOrders(ListOrdersRequest) returns (ListOrdersResponse);
+// Current
+rpc ListOrders(ListOrdersRequest) returns (ListOrdersResponse);
+rpc StreamOrders(StreamOrdersRequest) returns (stream StreamOrdersResponse);`,
+		Fix: `Restore the previous streaming value. To stream, add a new RPC, and deprecate the previous RPC.`,
+	},
+	"SERVICE_NO_DELETE": {
+		Rationale: `Deleting a service deletes the generated clients and servers for it, and clients that call it get an unimplemented error.`,
+		BadExample: `// Previous
+service OrderService {}
+// Current`,
+		GoodExample: `// Previous
+service OrderService {}
+// Current
+service OrderService {
+  option deprecated = true;
+}`,
+		Fix: `Restore the service, and deprecate it instead. If the service was moved to another file of the same package, use the PACKAGE category instead of FILE.`,
+	},
+}
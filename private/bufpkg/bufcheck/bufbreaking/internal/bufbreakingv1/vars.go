@@ -22,6 +22,9 @@ import (
 var (
 	// v1RuleBuilders are the rule builders.
 	v1RuleBuilders = []*internal.RuleBuilder{
+		bufbreakingbuild.CustomOptionSameValueRuleBuilder,
+		bufbreakingbuild.DeprecateBeforeDeleteRuleBuilder,
+		bufbreakingbuild.DeprecationRPCReferenceRuleBuilder,
 		bufbreakingbuild.EnumNoDeleteRuleBuilder,
 		bufbreakingbuild.EnumValueNoDeleteRuleBuilder,
 		bufbreakingbuild.EnumValueNoDeleteUnlessNameReservedRuleBuilder,
@@ -32,6 +35,8 @@ var (
 		bufbreakingbuild.FieldNoDeleteUnlessNameReservedRuleBuilder,
 		bufbreakingbuild.FieldNoDeleteUnlessNumberReservedRuleBuilder,
 		bufbreakingbuild.FieldSameCTypeRuleBuilder,
+		bufbreakingbuild.FieldSameGoNameRuleBuilder,
+		bufbreakingbuild.FieldSameJavaNameRuleBuilder,
 		bufbreakingbuild.FieldSameJSONNameRuleBuilder,
 		bufbreakingbuild.FieldSameJSTypeRuleBuilder,
 		bufbreakingbuild.FieldSameLabelRuleBuilder,
@@ -61,6 +66,7 @@ var (
 		bufbreakingbuild.FileSamePhpGenericServicesRuleBuilder,
 		bufbreakingbuild.FileSameCcEnableArenasRuleBuilder,
 		bufbreakingbuild.FileSameSyntaxRuleBuilder,
+		bufbreakingbuild.MessageCppClassNoDeleteRuleBuilder,
 		bufbreakingbuild.MessageNoDeleteRuleBuilder,
 		bufbreakingbuild.MessageNoRemoveStandardDescriptorAccessorRuleBuilder,
 		bufbreakingbuild.MessageSameMessageSetWireFormatRuleBuilder,
@@ -70,6 +76,7 @@ var (
 		bufbreakingbuild.PackageMessageNoDeleteRuleBuilder,
 		bufbreakingbuild.PackageNoDeleteRuleBuilder,
 		bufbreakingbuild.PackageServiceNoDeleteRuleBuilder,
+		bufbreakingbuild.ProtovalidateNoTighteningRuleBuilder,
 		bufbreakingbuild.ReservedEnumNoDeleteRuleBuilder,
 		bufbreakingbuild.ReservedMessageNoDeleteRuleBuilder,
 		bufbreakingbuild.RPCNoDeleteRuleBuilder,
@@ -87,6 +94,16 @@ var (
 	}
 	// v1IDToCategories associates IDs to categories.
 	v1IDToCategories = map[string][]string{
+		"CUSTOM_OPTION_SAME_VALUE": {
+			"FILE",
+			"PACKAGE",
+		},
+		"DEPRECATE_BEFORE_DELETE": {
+			"DEPRECATION",
+		},
+		"DEPRECATION_RPC_REFERENCE": {
+			"DEPRECATION",
+		},
 		"ENUM_NO_DELETE": {
 			"FILE",
 		},
@@ -125,6 +142,12 @@ var (
 			"FILE",
 			"PACKAGE",
 		},
+		"FIELD_SAME_GO_NAME": {
+			"SOURCE",
+		},
+		"FIELD_SAME_JAVA_NAME": {
+			"SOURCE",
+		},
 		"FIELD_SAME_JSON_NAME": {
 			"FILE",
 			"PACKAGE",
@@ -150,6 +173,7 @@ var (
 			"PACKAGE",
 			"WIRE_JSON",
 			"WIRE",
+			"SOURCE",
 		},
 		"FIELD_SAME_TYPE": {
 			"FILE",
@@ -246,6 +270,9 @@ var (
 			"FILE",
 			"PACKAGE",
 		},
+		"MESSAGE_CPP_CLASS_NO_DELETE": {
+			"SOURCE",
+		},
 		"MESSAGE_NO_DELETE": {
 			"FILE",
 		},
@@ -281,6 +308,9 @@ var (
 		"PACKAGE_SERVICE_NO_DELETE": {
 			"PACKAGE",
 		},
+		"PROTOVALIDATE_NO_TIGHTENING": {
+			"PROTOVALIDATE",
+		},
 		"RESERVED_ENUM_NO_DELETE": {
 			"FILE",
 			"PACKAGE",
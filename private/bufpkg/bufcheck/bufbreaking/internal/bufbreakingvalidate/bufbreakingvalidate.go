@@ -0,0 +1,626 @@
+// Copyright 2020-2023 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bufbreakingvalidate checks that protovalidate constraints are not tightened.
+//
+// Tightening a constraint is wire compatible, but values that were previously valid
+// may now be rejected, which breaks existing clients.
+package bufbreakingvalidate
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	"github.com/bufbuild/buf/private/pkg/protosource"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	// https://buf.build/bufbuild/protovalidate/docs/v0.5.1:buf.validate#buf.validate.MessageConstraints
+	disabledFieldNumberInMessageConstraints = 1
+	celFieldNumberInMessageConstraints      = 3
+	// https://buf.build/bufbuild/protovalidate/docs/v0.5.1:buf.validate#buf.validate.OneofConstraints
+	requiredFieldNumberInOneofConstraints = 1
+	// https://buf.build/bufbuild/protovalidate/docs/v0.5.1:buf.validate#buf.validate.FieldConstraints
+	celFieldNumberInFieldConstraints = 23
+	skippedFieldNumber               = 24
+	requiredFieldNumber              = 25
+	ignoreEmptyFieldNumber           = 26
+)
+
+var (
+	fieldConstraintsDescriptor = (&validate.FieldConstraints{}).ProtoReflect().Descriptor()
+	typeOneofDescriptor        = fieldConstraintsDescriptor.Oneofs().ByName("type")
+
+	// Raising the value of any of these rules tightens the constraints.
+	lowerBoundRuleNames = map[protoreflect.Name]struct{}{
+		"min_len":   {},
+		"min_bytes": {},
+		"min_items": {},
+		"min_pairs": {},
+	}
+	// Lowering the value of any of these rules tightens the constraints.
+	upperBoundRuleNames = map[protoreflect.Name]struct{}{
+		"max_len":   {},
+		"max_bytes": {},
+		"max_items": {},
+		"max_pairs": {},
+		"within":    {},
+	}
+)
+
+// CheckMessagePair checks that the message constraints and the constraints of the
+// oneofs of the message were not tightened.
+func CheckMessagePair(
+	add func(protosource.Descriptor, []protosource.Descriptor, protosource.Location, string, ...interface{}),
+	previousMessage protosource.Message,
+	message protosource.Message,
+) error {
+	messageAdder := &adder{
+		add:           add,
+		descriptor:    message,
+		extensionType: validate.E_Message,
+		subject:       fmt.Sprintf("Message %q", message.Name()),
+	}
+	previousMessageConstraints, err := getMessageConstraints(previousMessage)
+	if err != nil {
+		return err
+	}
+	messageConstraints, err := getMessageConstraints(message)
+	if err != nil {
+		return err
+	}
+	if previousMessageConstraints.GetDisabled() && !messageConstraints.GetDisabled() {
+		messageAdder.addForPathf(
+			[]int32{disabledFieldNumberInMessageConstraints},
+			`%s removed protovalidate constraint "(buf.validate.message).disabled", which enables validation.`,
+			messageAdder.subject,
+		)
+	}
+	if !messageConstraints.GetDisabled() {
+		checkCEL(messageAdder, previousMessageConstraints.GetCel(), messageConstraints.GetCel())
+	}
+	previousNameToOneof := make(map[string]protosource.Oneof)
+	for _, previousOneof := range previousMessage.Oneofs() {
+		previousNameToOneof[previousOneof.Name()] = previousOneof
+	}
+	for _, oneof := range message.Oneofs() {
+		previousOneof, ok := previousNameToOneof[oneof.Name()]
+		if !ok {
+			continue
+		}
+		previousOneofConstraints, err := getOneofConstraints(previousOneof)
+		if err != nil {
+			return err
+		}
+		oneofConstraints, err := getOneofConstraints(oneof)
+		if err != nil {
+			return err
+		}
+		if !previousOneofConstraints.GetRequired() && oneofConstraints.GetRequired() {
+			oneofAdder := &adder{
+				add:           add,
+				descriptor:    oneof,
+				extensionType: validate.E_Oneof,
+				subject:       fmt.Sprintf("Oneof %q on message %q", oneof.Name(), message.Name()),
+			}
+			oneofAdder.addForPathf(
+				[]int32{requiredFieldNumberInOneofConstraints},
+				`%s added protovalidate constraint "(buf.validate.oneof).required".`,
+				oneofAdder.subject,
+			)
+		}
+	}
+	return nil
+}
+
+// CheckFieldPair checks that the field constraints were not tightened.
+func CheckFieldPair(
+	add func(protosource.Descriptor, []protosource.Descriptor, protosource.Location, string, ...interface{}),
+	previousField protosource.Field,
+	field protosource.Field,
+) error {
+	previousFieldConstraints, err := getFieldConstraints(previousField)
+	if err != nil {
+		return err
+	}
+	fieldConstraints, err := getFieldConstraints(field)
+	if err != nil {
+		return err
+	}
+	fieldAdder := &adder{
+		add:           add,
+		descriptor:    field,
+		extensionType: validate.E_Field,
+		// otherwise prints as hex
+		subject: fmt.Sprintf("Field %q with name %q on message %q", strconv.FormatInt(int64(field.Number()), 10), field.Name(), field.ParentMessage().Name()),
+	}
+	checkFieldConstraints(fieldAdder, previousFieldConstraints.ProtoReflect(), fieldConstraints.ProtoReflect(), "(buf.validate.field)", nil)
+	return nil
+}
+
+// checkFieldConstraints checks a pair of buf.validate.FieldConstraints messages.
+//
+// This is called recursively for the items of repeated fields and the keys and
+// values of map fields, with the name and path of the nested constraints.
+func checkFieldConstraints(
+	adder *adder,
+	previousFieldConstraints protoreflect.Message,
+	fieldConstraints protoreflect.Message,
+	name string,
+	path []int32,
+) {
+	skippedFieldDescriptor := fieldConstraintsDescriptor.Fields().ByNumber(skippedFieldNumber)
+	if fieldConstraints.Get(skippedFieldDescriptor).Bool() {
+		return
+	}
+	if previousFieldConstraints.Get(skippedFieldDescriptor).Bool() {
+		adder.addForPathf(
+			appendPath(path, skippedFieldNumber),
+			`%s removed protovalidate constraint "%s.skipped", which enables validation.`,
+			adder.subject,
+			name,
+		)
+		return
+	}
+	ignoreEmptyFieldDescriptor := fieldConstraintsDescriptor.Fields().ByNumber(ignoreEmptyFieldNumber)
+	if previousFieldConstraints.Get(ignoreEmptyFieldDescriptor).Bool() && !fieldConstraints.Get(ignoreEmptyFieldDescriptor).Bool() {
+		adder.addForPathf(
+			appendPath(path, ignoreEmptyFieldNumber),
+			`%s removed protovalidate constraint "%s.ignore_empty", which enables validation of empty values.`,
+			adder.subject,
+			name,
+		)
+	}
+	requiredFieldDescriptor := fieldConstraintsDescriptor.Fields().ByNumber(requiredFieldNumber)
+	if !previousFieldConstraints.Get(requiredFieldDescriptor).Bool() && fieldConstraints.Get(requiredFieldDescriptor).Bool() {
+		adder.addForPathf(
+			appendPath(path, requiredFieldNumber),
+			`%s added protovalidate constraint "%s.required".`,
+			adder.subject,
+			name,
+		)
+	}
+	checkCEL(
+		adder,
+		constraintsForList(previousFieldConstraints.Get(fieldConstraintsDescriptor.Fields().ByNumber(celFieldNumberInFieldConstraints)).List()),
+		constraintsForList(fieldConstraints.Get(fieldConstraintsDescriptor.Fields().ByNumber(celFieldNumberInFieldConstraints)).List()),
+		path...,
+	)
+	typeFieldDescriptor := fieldConstraints.WhichOneof(typeOneofDescriptor)
+	if typeFieldDescriptor == nil {
+		return
+	}
+	rules := fieldConstraints.Get(typeFieldDescriptor).Message()
+	previousRules := rules.Type().Zero()
+	// If the type of the rules changed, all the rules are new.
+	if previousFieldConstraints.WhichOneof(typeOneofDescriptor) == typeFieldDescriptor {
+		previousRules = previousFieldConstraints.Get(typeFieldDescriptor).Message()
+	}
+	checkRules(
+		adder,
+		previousRules,
+		rules,
+		name+"."+string(typeFieldDescriptor.Name()),
+		appendPath(path, int32(typeFieldDescriptor.Number())),
+	)
+}
+
+// checkRules checks a pair of type-specific rules messages, such as buf.validate.StringRules.
+func checkRules(
+	adder *adder,
+	previousRules protoreflect.Message,
+	rules protoreflect.Message,
+	name string,
+	path []int32,
+) {
+	fields := rules.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fieldDescriptor := fields.Get(i)
+		ruleName := name + "." + string(fieldDescriptor.Name())
+		rulePath := appendPath(path, int32(fieldDescriptor.Number()))
+		if oneofDescriptor := fieldDescriptor.ContainingOneof(); oneofDescriptor != nil && isBoundOneof(oneofDescriptor) {
+			// Bounds are checked once per oneof, as the rule used for the bound can change,
+			// for example from gte to gt.
+			if rules.WhichOneof(oneofDescriptor) == fieldDescriptor {
+				checkBoundOneof(adder, previousRules, rules, oneofDescriptor, name, path)
+			}
+			continue
+		}
+		if fieldDescriptor.Message() != nil && fieldDescriptor.Message().FullName() == fieldConstraintsDescriptor.FullName() {
+			// The items of repeated fields, and the keys and values of map fields.
+			if rules.Has(fieldDescriptor) {
+				checkFieldConstraints(
+					adder,
+					previousRules.Get(fieldDescriptor).Message(),
+					rules.Get(fieldDescriptor).Message(),
+					ruleName,
+					rulePath,
+				)
+			}
+			continue
+		}
+		switch {
+		case fieldDescriptor.Name() == "in":
+			checkIn(adder, previousRules, rules, fieldDescriptor, ruleName, rulePath)
+		case fieldDescriptor.Name() == "not_in":
+			checkNotIn(adder, previousRules, rules, fieldDescriptor, ruleName, rulePath)
+		case fieldDescriptor.IsList():
+			// There are no other repeated rules, but if there were, we would have no way
+			// to know whether a change tightens the constraints.
+		case fieldDescriptor.Kind() == protoreflect.BoolKind:
+			// Setting a boolean rule, such as unique or email, always adds a constraint.
+			if rules.Get(fieldDescriptor).Bool() && !previousRules.Get(fieldDescriptor).Bool() {
+				adder.addForPathf(rulePath, `%s added protovalidate constraint %q.`, adder.subject, ruleName)
+			}
+		default:
+			checkValue(adder, previousRules, rules, fieldDescriptor, ruleName, rulePath)
+		}
+	}
+}
+
+// checkValue checks a rule with a single value.
+func checkValue(
+	adder *adder,
+	previousRules protoreflect.Message,
+	rules protoreflect.Message,
+	fieldDescriptor protoreflect.FieldDescriptor,
+	ruleName string,
+	rulePath []int32,
+) {
+	if !rules.Has(fieldDescriptor) {
+		return
+	}
+	if !previousRules.Has(fieldDescriptor) {
+		adder.addForPathf(rulePath, `%s added protovalidate constraint %q.`, adder.subject, ruleName)
+		return
+	}
+	previousValue := previousRules.Get(fieldDescriptor)
+	value := rules.Get(fieldDescriptor)
+	_, isLowerBound := lowerBoundRuleNames[fieldDescriptor.Name()]
+	_, isUpperBound := upperBoundRuleNames[fieldDescriptor.Name()]
+	if isLowerBound || isUpperBound {
+		compare, ok := compareValues(fieldDescriptor, previousValue, value)
+		if ok {
+			if (isLowerBound && compare < 0) || (isUpperBound && compare > 0) {
+				adder.addForPathf(
+					rulePath,
+					`%s tightened protovalidate constraint %q from %s to %s.`,
+					adder.subject,
+					ruleName,
+					formatValue(fieldDescriptor, previousValue),
+					formatValue(fieldDescriptor, value),
+				)
+			}
+			return
+		}
+	}
+	if formatValue(fieldDescriptor, previousValue) != formatValue(fieldDescriptor, value) {
+		adder.addForPathf(
+			rulePath,
+			`%s changed protovalidate constraint %q from %s to %s.`,
+			adder.subject,
+			ruleName,
+			formatValue(fieldDescriptor, previousValue),
+			formatValue(fieldDescriptor, value),
+		)
+	}
+}
+
+// checkBoundOneof checks the less_than and greater_than oneofs of the numeric,
+// duration, and timestamp rules.
+func checkBoundOneof(
+	adder *adder,
+	previousRules protoreflect.Message,
+	rules protoreflect.Message,
+	oneofDescriptor protoreflect.OneofDescriptor,
+	name string,
+	path []int32,
+) {
+	fieldDescriptor := rules.WhichOneof(oneofDescriptor)
+	ruleName := name + "." + string(fieldDescriptor.Name())
+	rulePath := appendPath(path, int32(fieldDescriptor.Number()))
+	previousFieldDescriptor := previousRules.WhichOneof(oneofDescriptor)
+	if previousFieldDescriptor == nil {
+		adder.addForPathf(rulePath, `%s added protovalidate constraint %q.`, adder.subject, ruleName)
+		return
+	}
+	previousRuleName := name + "." + string(previousFieldDescriptor.Name())
+	previousValue := previousRules.Get(previousFieldDescriptor)
+	value := rules.Get(fieldDescriptor)
+	// lt_now and gt_now cannot be compared with the other bounds.
+	if fieldDescriptor.Kind() == protoreflect.BoolKind || previousFieldDescriptor.Kind() == protoreflect.BoolKind {
+		if fieldDescriptor != previousFieldDescriptor {
+			adder.addForPathf(
+				rulePath,
+				`%s changed protovalidate constraint %q to %q.`,
+				adder.subject,
+				previousRuleName,
+				ruleName,
+			)
+		}
+		return
+	}
+	compare, ok := compareValues(fieldDescriptor, previousValue, value)
+	if !ok {
+		return
+	}
+	// An exclusive bound is tighter than an inclusive bound with the same value.
+	isExclusive := fieldDescriptor.Name() == "lt" || fieldDescriptor.Name() == "gt"
+	previousIsExclusive := previousFieldDescriptor.Name() == "lt" || previousFieldDescriptor.Name() == "gt"
+	if oneofDescriptor.Name() == "less_than" {
+		compare = -compare
+	}
+	if compare < 0 || (compare == 0 && isExclusive && !previousIsExclusive) {
+		if fieldDescriptor == previousFieldDescriptor {
+			adder.addForPathf(
+				rulePath,
+				`%s tightened protovalidate constraint %q from %s to %s.`,
+				adder.subject,
+				ruleName,
+				formatValue(previousFieldDescriptor, previousValue),
+				formatValue(fieldDescriptor, value),
+			)
+			return
+		}
+		adder.addForPathf(
+			rulePath,
+			`%s tightened protovalidate constraint from %q with value %s to %q with value %s.`,
+			adder.subject,
+			previousRuleName,
+			formatValue(previousFieldDescriptor, previousValue),
+			ruleName,
+			formatValue(fieldDescriptor, value),
+		)
+	}
+}
+
+// checkIn checks that no values were removed from an in rule.
+func checkIn(
+	adder *adder,
+	previousRules protoreflect.Message,
+	rules protoreflect.Message,
+	fieldDescriptor protoreflect.FieldDescriptor,
+	ruleName string,
+	rulePath []int32,
+) {
+	list := rules.Get(fieldDescriptor).List()
+	if list.Len() == 0 {
+		return
+	}
+	previousList := previousRules.Get(fieldDescriptor).List()
+	if previousList.Len() == 0 {
+		adder.addForPathf(rulePath, `%s added protovalidate constraint %q.`, adder.subject, ruleName)
+		return
+	}
+	if removedValues := listDifference(fieldDescriptor, previousList, list); len(removedValues) > 0 {
+		adder.addForPathf(
+			rulePath,
+			`%s removed %s from protovalidate constraint %q.`,
+			adder.subject,
+			strings.Join(removedValues, ", "),
+			ruleName,
+		)
+	}
+}
+
+// checkNotIn checks that no values were added to a not_in rule.
+func checkNotIn(
+	adder *adder,
+	previousRules protoreflect.Message,
+	rules protoreflect.Message,
+	fieldDescriptor protoreflect.FieldDescriptor,
+	ruleName string,
+	rulePath []int32,
+) {
+	if addedValues := listDifference(fieldDescriptor, rules.Get(fieldDescriptor).List(), previousRules.Get(fieldDescriptor).List()); len(addedValues) > 0 {
+		adder.addForPathf(
+			rulePath,
+			`%s added %s to protovalidate constraint %q.`,
+			adder.subject,
+			strings.Join(addedValues, ", "),
+			ruleName,
+		)
+	}
+}
+
+// checkCEL checks that no CEL expressions were added or changed.
+//
+// Expressions are matched by their ID.
+func checkCEL(adder *adder, previousConstraints []*validate.Constraint, constraints []*validate.Constraint, path ...int32) {
+	previousIDToConstraint := make(map[string]*validate.Constraint, len(previousConstraints))
+	for _, previousConstraint := range previousConstraints {
+		previousIDToConstraint[previousConstraint.GetId()] = previousConstraint
+	}
+	celFieldNumber := int32(celFieldNumberInFieldConstraints)
+	if adder.extensionType == validate.E_Message {
+		celFieldNumber = celFieldNumberInMessageConstraints
+	}
+	for i, constraint := range constraints {
+		constraintPath := appendPath(path, celFieldNumber, int32(i))
+		previousConstraint, ok := previousIDToConstraint[constraint.GetId()]
+		if !ok {
+			adder.addForPathf(constraintPath, `%s added protovalidate CEL expression %q.`, adder.subject, constraint.GetId())
+			continue
+		}
+		if previousConstraint.GetExpression() != constraint.GetExpression() {
+			adder.addForPathf(constraintPath, `%s changed protovalidate CEL expression %q.`, adder.subject, constraint.GetId())
+		}
+	}
+}
+
+type adder struct {
+	add           func(protosource.Descriptor, []protosource.Descriptor, protosource.Location, string, ...interface{})
+	descriptor    optionExtensionLocationDescriptor
+	extensionType protoreflect.ExtensionType
+	subject       string
+}
+
+func (a *adder) addForPathf(path []int32, format string, args ...interface{}) {
+	location := a.descriptor.OptionExtensionLocation(a.extensionType, path...)
+	if location == nil {
+		location = a.descriptor.Location()
+	}
+	a.add(a.descriptor, nil, location, format, args...)
+}
+
+type optionExtensionLocationDescriptor interface {
+	protosource.Descriptor
+	protosource.OptionExtensionDescriptor
+
+	Location() protosource.Location
+}
+
+func getMessageConstraints(message protosource.Message) (*validate.MessageConstraints, error) {
+	value, ok, err := message.OptionExtension(validate.E_Message)
+	if err != nil || !ok {
+		return nil, err
+	}
+	messageConstraints, _ := value.(*validate.MessageConstraints)
+	return messageConstraints, nil
+}
+
+func getOneofConstraints(oneof protosource.Oneof) (*validate.OneofConstraints, error) {
+	value, ok, err := oneof.OptionExtension(validate.E_Oneof)
+	if err != nil || !ok {
+		return nil, err
+	}
+	oneofConstraints, _ := value.(*validate.OneofConstraints)
+	return oneofConstraints, nil
+}
+
+// getFieldConstraints returns the field constraints, or an empty message if the
+// field has no constraints.
+func getFieldConstraints(field protosource.Field) (*validate.FieldConstraints, error) {
+	value, ok, err := field.OptionExtension(validate.E_Field)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return &validate.FieldConstraints{}, nil
+	}
+	fieldConstraints, ok := value.(*validate.FieldConstraints)
+	if !ok {
+		return &validate.FieldConstraints{}, nil
+	}
+	return fieldConstraints, nil
+}
+
+func constraintsForList(list protoreflect.List) []*validate.Constraint {
+	constraints := make([]*validate.Constraint, 0, list.Len())
+	for i := 0; i < list.Len(); i++ {
+		if constraint, ok := list.Get(i).Message().Interface().(*validate.Constraint); ok {
+			constraints = append(constraints, constraint)
+		}
+	}
+	return constraints
+}
+
+func isBoundOneof(oneofDescriptor protoreflect.OneofDescriptor) bool {
+	return oneofDescriptor.Name() == "less_than" || oneofDescriptor.Name() == "greater_than"
+}
+
+// compareValues compares two values of a numeric, duration, or timestamp rule.
+//
+// Returns false if the values cannot be compared.
+func compareValues(fieldDescriptor protoreflect.FieldDescriptor, one protoreflect.Value, two protoreflect.Value) (int, bool) {
+	switch fieldDescriptor.Kind() {
+	case protoreflect.Int32Kind, protoreflect.Int64Kind,
+		protoreflect.Sint32Kind, protoreflect.Sint64Kind,
+		protoreflect.Sfixed32Kind, protoreflect.Sfixed64Kind:
+		return compareOrdered(one.Int(), two.Int()), true
+	case protoreflect.Uint32Kind, protoreflect.Uint64Kind,
+		protoreflect.Fixed32Kind, protoreflect.Fixed64Kind:
+		return compareOrdered(one.Uint(), two.Uint()), true
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return compareOrdered(one.Float(), two.Float()), true
+	case protoreflect.MessageKind:
+		// google.protobuf.Duration and google.protobuf.Timestamp both have
+		// seconds as field 1 and nanos as field 2.
+		oneMessage := one.Message()
+		twoMessage := two.Message()
+		secondsFieldDescriptor := oneMessage.Descriptor().Fields().ByName("seconds")
+		nanosFieldDescriptor := oneMessage.Descriptor().Fields().ByName("nanos")
+		if secondsFieldDescriptor == nil || nanosFieldDescriptor == nil {
+			return 0, false
+		}
+		if compare := compareOrdered(oneMessage.Get(secondsFieldDescriptor).Int(), twoMessage.Get(secondsFieldDescriptor).Int()); compare != 0 {
+			return compare, true
+		}
+		return compareOrdered(oneMessage.Get(nanosFieldDescriptor).Int(), twoMessage.Get(nanosFieldDescriptor).Int()), true
+	default:
+		return 0, false
+	}
+}
+
+func compareOrdered[T int64 | uint64 | float64](one T, two T) int {
+	switch {
+	case one < two:
+		return -1
+	case one > two:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// listDifference returns the formatted values that are in one but not in two, sorted.
+func listDifference(fieldDescriptor protoreflect.FieldDescriptor, one protoreflect.List, two protoreflect.List) []string {
+	twoValues := make(map[string]struct{}, two.Len())
+	for i := 0; i < two.Len(); i++ {
+		twoValues[formatValue(fieldDescriptor, two.Get(i))] = struct{}{}
+	}
+	var difference []string
+	for i := 0; i < one.Len(); i++ {
+		value := formatValue(fieldDescriptor, one.Get(i))
+		if _, ok := twoValues[value]; !ok {
+			difference = append(difference, value)
+		}
+	}
+	sort.Strings(difference)
+	return difference
+}
+
+func formatValue(fieldDescriptor protoreflect.FieldDescriptor, value protoreflect.Value) string {
+	switch fieldDescriptor.Kind() {
+	case protoreflect.StringKind:
+		return fmt.Sprintf("%q", value.String())
+	case protoreflect.BytesKind:
+		return fmt.Sprintf("%q", string(value.Bytes()))
+	case protoreflect.EnumKind:
+		if enumValueDescriptor := fieldDescriptor.Enum().Values().ByNumber(value.Enum()); enumValueDescriptor != nil {
+			return string(enumValueDescriptor.Name())
+		}
+		return fmt.Sprintf("%d", value.Enum())
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		data, err := protojson.Marshal(value.Message().Interface())
+		if err != nil {
+			return fmt.Sprintf("%v", value.Message().Interface())
+		}
+		return string(data)
+	default:
+		return fmt.Sprintf("%v", value.Interface())
+	}
+}
+
+func appendPath(path []int32, elements ...int32) []int32 {
+	// Copy path so it won't be modified by append.
+	newPath := make([]int32, len(path), len(path)+len(elements))
+	copy(newPath, path)
+	return append(newPath, elements...)
+}
@@ -20,6 +20,7 @@
 package bufcheck
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -53,6 +54,37 @@ type Rule interface {
 	//
 	// Full sentence.
 	Purpose() string
+	// Rationale returns why the Rule exists.
+	//
+	// Full sentences.
+	// May be empty.
+	Rationale() string
+	// BadExample returns Protobuf source that violates the Rule.
+	//
+	// May be empty.
+	BadExample() string
+	// GoodExample returns Protobuf source that does not violate the Rule.
+	//
+	// May be empty.
+	GoodExample() string
+	// ConfigKeys returns the keys of buf.yaml that affect the Rule, other
+	// than use, except, ignore, and ignore_only.
+	//
+	// May be empty.
+	ConfigKeys() []string
+	// Fix returns how to fix violations of the Rule.
+	//
+	// Full sentences.
+	// May be empty.
+	Fix() string
+	// FileLocal returns true if the FileAnnotations of the Rule for a file only
+	// depend on the file and its transitive imports.
+	//
+	// The Rule only reports FileAnnotations for the files that are not imports,
+	// and reports the same FileAnnotations for a file when checking any set of
+	// files that contains the file, as long as the file's transitive imports are
+	// available.
+	FileLocal() bool
 }
 
 // PrintRules prints the rules to the writer.
@@ -89,6 +121,43 @@ func PrintRules(writer io.Writer, rules []Rule, formatString string) (retErr err
 	return nil
 }
 
+// PrintRuleExplanation prints the long-form documentation of the rule to the writer.
+//
+// Sections that are empty for the rule are not printed.
+func PrintRuleExplanation(writer io.Writer, rule Rule) error {
+	var buffer bytes.Buffer
+	_, _ = fmt.Fprintf(&buffer, "%s\n\n", rule.ID())
+	if categories := rule.Categories(); len(categories) > 0 {
+		_, _ = fmt.Fprintf(&buffer, "Categories: %s\n", strings.Join(categories, ", "))
+	}
+	_, _ = fmt.Fprintf(&buffer, "%s\n", rule.Purpose())
+	for _, section := range []struct {
+		title string
+		text  string
+	}{
+		{title: "Rationale", text: rule.Rationale()},
+		{title: "Bad", text: rule.BadExample()},
+		{title: "Good", text: rule.GoodExample()},
+		{title: "Configuration", text: strings.Join(rule.ConfigKeys(), "\n")},
+		{title: "Fix", text: rule.Fix()},
+	} {
+		text := strings.TrimSpace(section.text)
+		if text == "" {
+			continue
+		}
+		_, _ = fmt.Fprintf(&buffer, "\n%s:\n", section.title)
+		for _, line := range strings.Split(text, "\n") {
+			if line == "" {
+				_, _ = buffer.WriteString("\n")
+				continue
+			}
+			_, _ = fmt.Fprintf(&buffer, "  %s\n", line)
+		}
+	}
+	_, err := writer.Write(buffer.Bytes())
+	return err
+}
+
 func printRule(writer io.Writer, rule Rule, asJSON bool) error {
 	if asJSON {
 		data, err := json.Marshal(rule)
@@ -0,0 +1,72 @@
+// Copyright 2020-2023 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bufcheckcache
+
+import (
+	"context"
+	"time"
+
+	"github.com/bufbuild/buf/private/bufpkg/bufanalysis"
+	"github.com/bufbuild/buf/private/bufpkg/bufcheck/bufbreaking"
+	"github.com/bufbuild/buf/private/bufpkg/bufcheck/bufbreaking/bufbreakingconfig"
+	"github.com/bufbuild/buf/private/bufpkg/bufimage"
+	"github.com/bufbuild/buf/private/pkg/storage"
+	"go.uber.org/zap"
+)
+
+const breakingKind = "breaking"
+
+type breakingHandler struct {
+	delegate bufbreaking.Handler
+	cache    *cache
+}
+
+func newBreakingHandler(
+	logger *zap.Logger,
+	delegate bufbreaking.Handler,
+	readWriteBucket storage.ReadWriteBucket,
+	version string,
+	now time.Time,
+) *breakingHandler {
+	return &breakingHandler{
+		delegate: delegate,
+		cache:    newCache(logger, readWriteBucket, version, breakingKind, now),
+	}
+}
+
+func (h *breakingHandler) Check(
+	ctx context.Context,
+	config *bufbreakingconfig.Config,
+	previousImage bufimage.Image,
+	image bufimage.Image,
+) ([]bufanalysis.FileAnnotation, error) {
+	h.cache.prune(ctx)
+	// Breaking rules compare elements across files, for example to detect
+	// that a message was moved to another file, so results are cached for
+	// the whole images.
+	key, err := h.cache.getImageKey(config, previousImage, image)
+	if err != nil {
+		return nil, err
+	}
+	if fileAnnotations, ok := h.cache.get(ctx, key, image, previousImage); ok {
+		return fileAnnotations, nil
+	}
+	fileAnnotations, err := h.delegate.Check(ctx, config, previousImage, image)
+	if err != nil {
+		return nil, err
+	}
+	h.cache.put(ctx, key, fileAnnotations)
+	return fileAnnotations, nil
+}
@@ -0,0 +1,63 @@
+// Copyright 2020-2023 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bufcheckcache caches the results of lint and breaking change detection.
+//
+// Results are keyed by the bufcas digests of the files of the images, the effective
+// config, and the version of buf, so a cached result is only used if the rules would
+// produce the same result.
+//
+// The results of file-local lint rules are cached per file, keyed by the digests of
+// the file and its transitive imports, so only the files that changed, or whose
+// imports changed, are checked again. Other rules, such as RPC_REQUEST_RESPONSE_UNIQUE,
+// PACKAGE_SAME_GO_PACKAGE, and all breaking rules, depend on files other than the file
+// that they report on, so their results are cached per image, that is per module of
+// a workspace.
+//
+// Entries that are not used for a week or two are pruned.
+package bufcheckcache
+
+import (
+	"time"
+
+	"github.com/bufbuild/buf/private/bufpkg/bufcheck/bufbreaking"
+	"github.com/bufbuild/buf/private/bufpkg/bufcheck/buflint"
+	"github.com/bufbuild/buf/private/pkg/storage"
+	"go.uber.org/zap"
+)
+
+// NewLintHandler returns a new buflint.Handler that caches the results of the
+// delegate in the bucket.
+//
+// Results are not cached if the config has lint plugins, as the plugins may change
+// without the config changing.
+func NewLintHandler(
+	logger *zap.Logger,
+	delegate buflint.Handler,
+	readWriteBucket storage.ReadWriteBucket,
+	version string,
+) buflint.Handler {
+	return newLintHandler(logger, delegate, readWriteBucket, version, time.Now())
+}
+
+// NewBreakingHandler returns a new bufbreaking.Handler that caches the results
+// of the delegate in the bucket.
+func NewBreakingHandler(
+	logger *zap.Logger,
+	delegate bufbreaking.Handler,
+	readWriteBucket storage.ReadWriteBucket,
+	version string,
+) bufbreaking.Handler {
+	return newBreakingHandler(logger, delegate, readWriteBucket, version, time.Now())
+}
@@ -0,0 +1,293 @@
+// Copyright 2020-2023 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bufcheckcache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bufbuild/buf/private/bufpkg/bufanalysis"
+	"github.com/bufbuild/buf/private/bufpkg/bufcheck"
+	"github.com/bufbuild/buf/private/bufpkg/bufcheck/bufbreaking/bufbreakingconfig"
+	"github.com/bufbuild/buf/private/bufpkg/bufcheck/buflint/buflintconfig"
+	"github.com/bufbuild/buf/private/bufpkg/bufimage"
+	"github.com/bufbuild/buf/private/bufpkg/bufimage/bufimagebuild"
+	"github.com/bufbuild/buf/private/bufpkg/bufmodule"
+	"github.com/bufbuild/buf/private/bufpkg/bufmodule/bufmodulebuild"
+	"github.com/bufbuild/buf/private/bufpkg/bufmodule/bufmoduleconfig"
+	"github.com/bufbuild/buf/private/pkg/storage"
+	"github.com/bufbuild/buf/private/pkg/storage/storagemem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const (
+	testFileA = `syntax = "proto3";
+
+package acme.v1;
+
+message A {
+  string one = 1;
+}
+`
+	testFileB = `syntax = "proto3";
+
+package acme.v1;
+
+import "acme/v1/a.proto";
+
+message B {
+  A a = 1;
+}
+`
+	testFileC = `syntax = "proto3";
+
+package acme.v1;
+
+message C {}
+`
+)
+
+func TestLintHandlerFileLocal(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	delegate := &testLintHandler{}
+	handler := NewLintHandler(zap.NewNop(), delegate, storagemem.NewReadWriteBucket(), "1.0.0")
+	// FIELD_LOWER_SNAKE_CASE is file-local.
+	config := &buflintconfig.Config{Use: []string{"FIELD_LOWER_SNAKE_CASE"}, Version: "v1"}
+
+	image := testBuild(t, ctx, testFileA, testFileB, testFileC)
+	fileAnnotations, _, err := handler.Check(ctx, config, image)
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"acme/v1/a.proto", "acme/v1/b.proto", "acme/v1/c.proto"}}, delegate.calls)
+	expected := []string{
+		"FIELD_LOWER_SNAKE_CASE acme/v1/a.proto:1:1:test",
+		"FIELD_LOWER_SNAKE_CASE acme/v1/b.proto:1:1:test",
+		"FIELD_LOWER_SNAKE_CASE acme/v1/c.proto:1:1:test",
+	}
+	assert.Equal(t, expected, testFileAnnotationStrings(fileAnnotations))
+
+	// The rules are returned even if all results are cached.
+	fileAnnotations, rules, err := handler.Check(ctx, config, image)
+	require.NoError(t, err)
+	assert.Len(t, delegate.calls, 1)
+	assert.Equal(t, expected, testFileAnnotationStrings(fileAnnotations))
+	require.Len(t, rules, 1)
+	assert.Equal(t, "FIELD_LOWER_SNAKE_CASE", rules[0].ID())
+
+	// Only the changed file is checked again.
+	fileAnnotations, _, err = handler.Check(ctx, config, testBuild(t, ctx, testFileA, testFileB, testFileC+"\nmessage D {}\n"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"acme/v1/c.proto"}, delegate.calls[1])
+	assert.Equal(t, expected, testFileAnnotationStrings(fileAnnotations))
+
+	// Files that import the changed file are checked again as well.
+	_, _, err = handler.Check(ctx, config, testBuild(t, ctx, testFileA+"\nmessage D {}\n", testFileB, testFileC))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"acme/v1/a.proto", "acme/v1/b.proto"}, delegate.calls[2])
+
+	_, _, err = handler.Check(ctx, &buflintconfig.Config{Use: []string{"FIELD_LOWER_SNAKE_CASE"}, EnumZeroValueSuffix: "_NONE", Version: "v1"}, image)
+	require.NoError(t, err)
+	assert.Len(t, delegate.calls, 4)
+
+	_, _, err = NewLintHandler(zap.NewNop(), delegate, storagemem.NewReadWriteBucket(), "1.0.1").Check(ctx, config, image)
+	require.NoError(t, err)
+	assert.Len(t, delegate.calls, 5)
+}
+
+func TestLintHandlerCrossFile(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	delegate := &testLintHandler{}
+	handler := NewLintHandler(zap.NewNop(), delegate, storagemem.NewReadWriteBucket(), "1.0.0")
+	// PACKAGE_SAME_GO_PACKAGE is not file-local, and FIELD_LOWER_SNAKE_CASE is.
+	config := &buflintconfig.Config{Use: []string{"PACKAGE_SAME_GO_PACKAGE", "FIELD_LOWER_SNAKE_CASE"}, Version: "v1"}
+
+	image := testBuild(t, ctx, testFileA, testFileB, testFileC)
+	fileAnnotations, _, err := handler.Check(ctx, config, image)
+	require.NoError(t, err)
+	assert.Len(t, delegate.calls, 2)
+	expected := []string{
+		"FIELD_LOWER_SNAKE_CASE acme/v1/a.proto:1:1:test",
+		"PACKAGE_SAME_GO_PACKAGE acme/v1/a.proto:1:1:test",
+		"FIELD_LOWER_SNAKE_CASE acme/v1/b.proto:1:1:test",
+		"PACKAGE_SAME_GO_PACKAGE acme/v1/b.proto:1:1:test",
+		"FIELD_LOWER_SNAKE_CASE acme/v1/c.proto:1:1:test",
+		"PACKAGE_SAME_GO_PACKAGE acme/v1/c.proto:1:1:test",
+	}
+	assert.Equal(t, expected, testFileAnnotationStrings(fileAnnotations))
+
+	fileAnnotations, _, err = handler.Check(ctx, config, image)
+	require.NoError(t, err)
+	assert.Len(t, delegate.calls, 2)
+	assert.Equal(t, expected, testFileAnnotationStrings(fileAnnotations))
+
+	// The whole image is checked again for the rules that are not file-local.
+	_, _, err = handler.Check(ctx, config, testBuild(t, ctx, testFileA, testFileB, testFileC+"\nmessage D {}\n"))
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"acme/v1/a.proto", "acme/v1/b.proto", "acme/v1/c.proto"}, {"acme/v1/c.proto"}}, delegate.calls[2:])
+}
+
+func TestLintHandlerPrune(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	delegate := &testLintHandler{}
+	readWriteBucket := storagemem.NewReadWriteBucket()
+	config := &buflintconfig.Config{Use: []string{"FIELD_LOWER_SNAKE_CASE"}, Version: "v1"}
+	image := testBuild(t, ctx, testFileA)
+	now := time.Unix(0, 0).Add(100 * generationDuration)
+
+	_, _, err := newLintHandler(zap.NewNop(), delegate, readWriteBucket, "1.0.0", now).Check(ctx, config, image)
+	require.NoError(t, err)
+	assert.Len(t, delegate.calls, 1)
+	assert.Equal(t, []string{"lint/100"}, testGenerationDirPaths(t, ctx, readWriteBucket))
+
+	// Entries of the previous generation are used, and moved to the current generation.
+	_, _, err = newLintHandler(zap.NewNop(), delegate, readWriteBucket, "1.0.0", now.Add(generationDuration)).Check(ctx, config, image)
+	require.NoError(t, err)
+	assert.Len(t, delegate.calls, 1)
+	assert.Equal(t, []string{"lint/100", "lint/101"}, testGenerationDirPaths(t, ctx, readWriteBucket))
+
+	_, _, err = newLintHandler(zap.NewNop(), delegate, readWriteBucket, "1.0.0", now.Add(2*generationDuration)).Check(ctx, config, image)
+	require.NoError(t, err)
+	assert.Len(t, delegate.calls, 1)
+	assert.Equal(t, []string{"lint/101", "lint/102"}, testGenerationDirPaths(t, ctx, readWriteBucket))
+
+	// Entries that are not used for a whole generation are pruned.
+	_, _, err = newLintHandler(zap.NewNop(), delegate, readWriteBucket, "1.0.0", now.Add(4*generationDuration)).Check(ctx, config, image)
+	require.NoError(t, err)
+	assert.Len(t, delegate.calls, 2)
+	assert.Equal(t, []string{"lint/104"}, testGenerationDirPaths(t, ctx, readWriteBucket))
+}
+
+func TestBreakingHandler(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	image := testBuild(t, ctx, testFileA)
+	delegate := &testBreakingHandler{}
+	handler := NewBreakingHandler(zap.NewNop(), delegate, storagemem.NewReadWriteBucket(), "1.0.0")
+	config := &bufbreakingconfig.Config{Use: []string{"FILE"}}
+
+	fileAnnotations, err := handler.Check(ctx, config, image, image)
+	require.NoError(t, err)
+	assert.Equal(t, 1, delegate.calls)
+	expected := testFileAnnotationStrings(fileAnnotations)
+	assert.Equal(t, []string{"TEST acme/v1/a.proto:6:3:test", "TEST <input>:1:1:test"}, expected)
+
+	fileAnnotations, err = handler.Check(ctx, config, image, image)
+	require.NoError(t, err)
+	assert.Equal(t, 1, delegate.calls)
+	assert.Equal(t, expected, testFileAnnotationStrings(fileAnnotations))
+
+	_, err = handler.Check(ctx, config, bufimage.ImageWithoutImports(image), image)
+	require.NoError(t, err)
+	assert.Equal(t, 1, delegate.calls)
+
+	_, err = handler.Check(ctx, &bufbreakingconfig.Config{Use: []string{"WIRE"}}, image, image)
+	require.NoError(t, err)
+	assert.Equal(t, 2, delegate.calls)
+}
+
+// testLintHandler reports a FileAnnotation for each rule of the config on each
+// file that is not an import, and records the files that are not imports for
+// each call.
+type testLintHandler struct {
+	calls [][]string
+}
+
+func (h *testLintHandler) Check(
+	_ context.Context,
+	config *buflintconfig.Config,
+	image bufimage.Image,
+) ([]bufanalysis.FileAnnotation, []bufcheck.Rule, error) {
+	var paths []string
+	var fileAnnotations []bufanalysis.FileAnnotation
+	for _, imageFile := range image.Files() {
+		if imageFile.IsImport() {
+			continue
+		}
+		paths = append(paths, imageFile.Path())
+		for _, id := range config.Use {
+			fileAnnotations = append(fileAnnotations, bufanalysis.NewFileAnnotation(imageFile, 1, 1, 1, 1, id, "test"))
+		}
+	}
+	h.calls = append(h.calls, paths)
+	bufanalysis.SortFileAnnotations(fileAnnotations)
+	return fileAnnotations, nil, nil
+}
+
+type testBreakingHandler struct {
+	calls int
+}
+
+func (h *testBreakingHandler) Check(
+	_ context.Context,
+	_ *bufbreakingconfig.Config,
+	_ bufimage.Image,
+	image bufimage.Image,
+) ([]bufanalysis.FileAnnotation, error) {
+	h.calls++
+	return []bufanalysis.FileAnnotation{
+		bufanalysis.NewFileAnnotation(image.GetFile("acme/v1/a.proto"), 6, 3, 6, 18, "TEST", "test"),
+		bufanalysis.NewFileAnnotation(nil, 0, 0, 0, 0, "TEST", "test"),
+	}, nil
+}
+
+func testFileAnnotationStrings(fileAnnotations []bufanalysis.FileAnnotation) []string {
+	fileAnnotationStrings := make([]string, len(fileAnnotations))
+	for i, fileAnnotation := range fileAnnotations {
+		fileAnnotationStrings[i] = fileAnnotation.Type() + " " + fileAnnotation.String()
+	}
+	return fileAnnotationStrings
+}
+
+func testGenerationDirPaths(t *testing.T, ctx context.Context, readBucket storage.ReadBucket) []string {
+	paths, err := storage.AllPaths(ctx, readBucket, "")
+	require.NoError(t, err)
+	var dirPaths []string
+	seenDirPaths := make(map[string]struct{})
+	for _, path := range paths {
+		if path == "lint/"+generationFileName {
+			continue
+		}
+		dirPath := path[:len("lint/100")]
+		if _, ok := seenDirPaths[dirPath]; !ok {
+			seenDirPaths[dirPath] = struct{}{}
+			dirPaths = append(dirPaths, dirPath)
+		}
+	}
+	return dirPaths
+}
+
+// testBuild builds an image of acme/v1/a.proto, acme/v1/b.proto, and acme/v1/c.proto
+// with the given contents, in that order.
+func testBuild(t *testing.T, ctx context.Context, fileContents ...string) bufimage.Image {
+	pathToData := make(map[string][]byte)
+	for i, fileContent := range fileContents {
+		pathToData["acme/v1/"+string(rune('a'+i))+".proto"] = []byte(fileContent)
+	}
+	readBucket, err := storagemem.NewReadBucket(pathToData)
+	require.NoError(t, err)
+	moduleConfig, err := bufmoduleconfig.NewConfigV1(bufmoduleconfig.ExternalConfigV1{})
+	require.NoError(t, err)
+	module, err := bufmodulebuild.NewModuleBucketBuilder().BuildForBucket(ctx, readBucket, moduleConfig)
+	require.NoError(t, err)
+	image, fileAnnotations, err := bufimagebuild.NewBuilder(zap.NewNop(), bufmodule.NewNopModuleReader()).Build(ctx, module)
+	require.NoError(t, err)
+	require.Empty(t, fileAnnotations)
+	return image
+}
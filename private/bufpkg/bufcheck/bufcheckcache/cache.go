@@ -0,0 +1,390 @@
+// Copyright 2020-2023 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bufcheckcache
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bufbuild/buf/private/bufpkg/bufanalysis"
+	"github.com/bufbuild/buf/private/bufpkg/bufcas"
+	"github.com/bufbuild/buf/private/bufpkg/bufimage"
+	"github.com/bufbuild/buf/private/pkg/normalpath"
+	"github.com/bufbuild/buf/private/pkg/protoencoding"
+	"github.com/bufbuild/buf/private/pkg/storage"
+	"go.uber.org/zap"
+)
+
+const (
+	// generationDuration is the duration of a generation of cache entries.
+	//
+	// Entries are written to the directory of the current generation. Entries of
+	// the previous generation are still used, and are copied to the current generation
+	// when they are. Older generations are deleted, so entries that are not used for
+	// a whole generation are pruned.
+	generationDuration = 7 * 24 * time.Hour
+	// generationFileName is the name of the file that contains the last generation
+	// that was used, relative to the directory of the kind of check.
+	generationFileName = "generation"
+)
+
+type cache struct {
+	logger          *zap.Logger
+	readWriteBucket storage.ReadWriteBucket
+	version         string
+	kind            string
+	generation      int64
+	pruneOnce       sync.Once
+}
+
+func newCache(
+	logger *zap.Logger,
+	readWriteBucket storage.ReadWriteBucket,
+	version string,
+	kind string,
+	now time.Time,
+) *cache {
+	return &cache{
+		logger:          logger,
+		readWriteBucket: readWriteBucket,
+		version:         version,
+		kind:            kind,
+		generation:      now.UnixNano() / int64(generationDuration),
+	}
+}
+
+// getImageKey returns the key of the cache entry for the config and the images.
+//
+// This is used for rules that are not file-local, as their results may depend
+// on any file of the images.
+func (c *cache) getImageKey(config interface{}, images ...bufimage.Image) (string, error) {
+	buffer, err := c.newKeyBuffer(config)
+	if err != nil {
+		return "", err
+	}
+	for _, image := range images {
+		manifest, err := newManifestForImage(image)
+		if err != nil {
+			return "", err
+		}
+		_, _ = buffer.WriteString("image\n")
+		_, _ = buffer.WriteString(manifest.String())
+		for _, imageFile := range image.Files() {
+			writeImageFileProperties(buffer, imageFile)
+		}
+	}
+	return newKeyForContent(buffer)
+}
+
+// getFileKeys returns the keys of the cache entries for the config and each
+// file of the image that is not an import, by path.
+//
+// This is used for file-local rules, so the key of a file only covers the file
+// and its transitive imports, and editing a file does not invalidate the entries
+// of the files that do not import it.
+func (c *cache) getFileKeys(config interface{}, image bufimage.Image) (map[string]string, error) {
+	pathToDigest, err := getPathToDigest(image)
+	if err != nil {
+		return nil, err
+	}
+	pathToKey := make(map[string]string)
+	for _, imageFile := range image.Files() {
+		if imageFile.IsImport() {
+			continue
+		}
+		buffer, err := c.newKeyBuffer(config)
+		if err != nil {
+			return nil, err
+		}
+		_, _ = buffer.WriteString("file\n")
+		writeImageFileProperties(buffer, imageFile)
+		for _, path := range getTransitiveClosurePaths(image, imageFile) {
+			_, _ = fmt.Fprintf(buffer, "%s %s\n", path, pathToDigest[path])
+		}
+		key, err := newKeyForContent(buffer)
+		if err != nil {
+			return nil, err
+		}
+		pathToKey[imageFile.Path()] = key
+	}
+	return pathToKey, nil
+}
+
+// get returns the cached FileAnnotations for the key.
+//
+// The FileInfos of the FileAnnotations are the ImageFiles of the first image that
+// contains the file. Returns false if there is no usable cache entry.
+func (c *cache) get(ctx context.Context, key string, images ...bufimage.Image) ([]bufanalysis.FileAnnotation, bool) {
+	path := c.getPath(c.generation, key)
+	data, err := storage.ReadPath(ctx, c.readWriteBucket, path)
+	if err != nil && storage.IsNotExist(err) {
+		// Entries of the previous generation are moved to the current generation
+		// when they are used, so that they are not pruned.
+		path = c.getPath(c.generation-1, key)
+		data, err = storage.ReadPath(ctx, c.readWriteBucket, path)
+		if err == nil {
+			c.putData(ctx, c.getPath(c.generation, key), data)
+		}
+	}
+	if err != nil {
+		if !storage.IsNotExist(err) {
+			c.logger.Debug("check_cache_read_error", zap.String("path", path), zap.Error(err))
+		}
+		return nil, false
+	}
+	var externalFileAnnotations []*externalFileAnnotation
+	if err := json.Unmarshal(data, &externalFileAnnotations); err != nil {
+		c.logger.Debug("check_cache_invalid_entry", zap.String("path", path), zap.Error(err))
+		return nil, false
+	}
+	fileAnnotations := make([]bufanalysis.FileAnnotation, len(externalFileAnnotations))
+	for i, externalFileAnnotation := range externalFileAnnotations {
+		var fileInfo bufanalysis.FileInfo
+		if externalFileAnnotation.Path != "" {
+			for _, image := range images {
+				if imageFile := image.GetFile(externalFileAnnotation.Path); imageFile != nil {
+					fileInfo = imageFile
+					break
+				}
+			}
+			if fileInfo == nil {
+				c.logger.Debug("check_cache_invalid_entry", zap.String("path", path), zap.String("file_path", externalFileAnnotation.Path))
+				return nil, false
+			}
+		}
+		fileAnnotations[i] = bufanalysis.NewFileAnnotation(
+			fileInfo,
+			externalFileAnnotation.StartLine,
+			externalFileAnnotation.StartColumn,
+			externalFileAnnotation.EndLine,
+			externalFileAnnotation.EndColumn,
+			externalFileAnnotation.Type,
+			externalFileAnnotation.Message,
+		)
+	}
+	c.logger.Debug("check_cache_hit", zap.String("path", path))
+	return fileAnnotations, true
+}
+
+// put caches the FileAnnotations for the key.
+//
+// Failing to write to the cache does not fail the check, so errors are only logged.
+func (c *cache) put(ctx context.Context, key string, fileAnnotations []bufanalysis.FileAnnotation) {
+	externalFileAnnotations := make([]*externalFileAnnotation, len(fileAnnotations))
+	for i, fileAnnotation := range fileAnnotations {
+		externalFileAnnotations[i] = newExternalFileAnnotation(fileAnnotation)
+	}
+	path := c.getPath(c.generation, key)
+	data, err := json.Marshal(externalFileAnnotations)
+	if err != nil {
+		c.logger.Debug("check_cache_write_error", zap.String("path", path), zap.Error(err))
+		return
+	}
+	c.putData(ctx, path, data)
+}
+
+// prune deletes the generations of cache entries that are older than the
+// previous generation.
+//
+// Only the generations that may have been written since the last prune are
+// deleted, so that pruning does not need to walk the cache. Pruning is only
+// done once per cache, and failing to prune does not fail the check, so errors
+// are only logged.
+func (c *cache) prune(ctx context.Context) {
+	c.pruneOnce.Do(func() {
+		generationFilePath := normalpath.Join(c.kind, generationFileName)
+		lastGeneration, ok := c.getLastGeneration(ctx, generationFilePath)
+		if ok {
+			if lastGeneration == c.generation {
+				return
+			}
+			// When the last generation was current, the entries of the generation
+			// before it were kept as well.
+			for _, generation := range []int64{lastGeneration - 1, lastGeneration} {
+				if generation >= c.generation-1 {
+					continue
+				}
+				generationDirPath := normalpath.Join(c.kind, strconv.FormatInt(generation, 10))
+				if err := c.readWriteBucket.DeleteAll(ctx, generationDirPath); err != nil {
+					c.logger.Debug("check_cache_prune_error", zap.String("path", generationDirPath), zap.Error(err))
+					return
+				}
+				c.logger.Debug("check_cache_prune", zap.String("path", generationDirPath))
+			}
+		}
+		c.putData(ctx, generationFilePath, []byte(strconv.FormatInt(c.generation, 10)+"\n"))
+	})
+}
+
+// getLastGeneration returns the last generation that was used.
+//
+// Returns false if there is no usable last generation.
+func (c *cache) getLastGeneration(ctx context.Context, generationFilePath string) (int64, bool) {
+	data, err := storage.ReadPath(ctx, c.readWriteBucket, generationFilePath)
+	if err != nil {
+		if !storage.IsNotExist(err) {
+			c.logger.Debug("check_cache_read_error", zap.String("path", generationFilePath), zap.Error(err))
+		}
+		return 0, false
+	}
+	lastGeneration, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil {
+		c.logger.Debug("check_cache_invalid_entry", zap.String("path", generationFilePath), zap.Error(err))
+		return 0, false
+	}
+	return lastGeneration, true
+}
+
+func (c *cache) getPath(generation int64, key string) string {
+	return normalpath.Join(c.kind, strconv.FormatInt(generation, 10), key+".json")
+}
+
+func (c *cache) putData(ctx context.Context, path string, data []byte) {
+	if err := storage.PutPath(ctx, c.readWriteBucket, path, data); err != nil {
+		c.logger.Debug("check_cache_write_error", zap.String("path", path), zap.Error(err))
+	}
+}
+
+// newKeyBuffer returns a new buffer for the content of a key that covers the
+// version, the kind of check, and the config.
+func (c *cache) newKeyBuffer(config interface{}) (*bytes.Buffer, error) {
+	configData, err := json.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+	buffer := bytes.NewBuffer(nil)
+	_, _ = buffer.WriteString(c.version + "\n")
+	_, _ = buffer.WriteString(c.kind + "\n")
+	_, _ = buffer.Write(configData)
+	_, _ = buffer.WriteString("\n")
+	return buffer, nil
+}
+
+type externalFileAnnotation struct {
+	Path        string `json:"path,omitempty"`
+	StartLine   int    `json:"start_line,omitempty"`
+	StartColumn int    `json:"start_column,omitempty"`
+	EndLine     int    `json:"end_line,omitempty"`
+	EndColumn   int    `json:"end_column,omitempty"`
+	Type        string `json:"type,omitempty"`
+	Message     string `json:"message,omitempty"`
+}
+
+func newExternalFileAnnotation(fileAnnotation bufanalysis.FileAnnotation) *externalFileAnnotation {
+	var path string
+	if fileInfo := fileAnnotation.FileInfo(); fileInfo != nil {
+		path = fileInfo.Path()
+	}
+	return &externalFileAnnotation{
+		Path:        path,
+		StartLine:   fileAnnotation.StartLine(),
+		StartColumn: fileAnnotation.StartColumn(),
+		EndLine:     fileAnnotation.EndLine(),
+		EndColumn:   fileAnnotation.EndColumn(),
+		Type:        fileAnnotation.Type(),
+		Message:     fileAnnotation.Message(),
+	}
+}
+
+// writeImageFileProperties writes the properties of the ImageFile that are not
+// part of the FileDescriptorProto, but also affect the results of the rules.
+func writeImageFileProperties(buffer *bytes.Buffer, imageFile bufimage.ImageFile) {
+	_, _ = fmt.Fprintf(
+		buffer,
+		"%s %t %t %v\n",
+		imageFile.Path(),
+		imageFile.IsImport(),
+		imageFile.IsSyntaxUnspecified(),
+		imageFile.UnusedDependencyIndexes(),
+	)
+}
+
+func newKeyForContent(buffer *bytes.Buffer) (string, error) {
+	digest, err := bufcas.NewDigestForContent(buffer)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(digest.Value()), nil
+}
+
+// getTransitiveClosurePaths returns the sorted paths of the ImageFile and its
+// transitive imports.
+func getTransitiveClosurePaths(image bufimage.Image, imageFile bufimage.ImageFile) []string {
+	seenPaths := make(map[string]struct{})
+	var addPaths func(bufimage.ImageFile)
+	addPaths = func(imageFile bufimage.ImageFile) {
+		if _, ok := seenPaths[imageFile.Path()]; ok {
+			return
+		}
+		seenPaths[imageFile.Path()] = struct{}{}
+		for _, importPath := range imageFile.FileDescriptorProto().GetDependency() {
+			if importFile := image.GetFile(importPath); importFile != nil {
+				addPaths(importFile)
+			}
+		}
+	}
+	addPaths(imageFile)
+	paths := make([]string, 0, len(seenPaths))
+	for path := range seenPaths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// newManifestForImage returns a new Manifest of the digests of the FileDescriptorProtos
+// of the files of the Image, including source code info.
+func newManifestForImage(image bufimage.Image) (bufcas.Manifest, error) {
+	pathToDigest, err := getPathToDigest(image)
+	if err != nil {
+		return nil, err
+	}
+	fileNodes := make([]bufcas.FileNode, 0, len(image.Files()))
+	for _, imageFile := range image.Files() {
+		fileNode, err := bufcas.NewFileNode(imageFile.Path(), pathToDigest[imageFile.Path()])
+		if err != nil {
+			return nil, err
+		}
+		fileNodes = append(fileNodes, fileNode)
+	}
+	return bufcas.NewManifest(fileNodes)
+}
+
+// getPathToDigest returns the digests of the FileDescriptorProtos of the files
+// of the Image, including source code info, by path.
+func getPathToDigest(image bufimage.Image) (map[string]bufcas.Digest, error) {
+	marshaler := protoencoding.NewWireMarshaler()
+	pathToDigest := make(map[string]bufcas.Digest, len(image.Files()))
+	for _, imageFile := range image.Files() {
+		data, err := marshaler.Marshal(imageFile.FileDescriptorProto())
+		if err != nil {
+			return nil, err
+		}
+		digest, err := bufcas.NewDigestForContent(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		pathToDigest[imageFile.Path()] = digest
+	}
+	return pathToDigest, nil
+}
@@ -0,0 +1,180 @@
+// Copyright 2020-2023 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bufcheckcache
+
+import (
+	"context"
+	"time"
+
+	"github.com/bufbuild/buf/private/bufpkg/bufanalysis"
+	"github.com/bufbuild/buf/private/bufpkg/bufcheck"
+	"github.com/bufbuild/buf/private/bufpkg/bufcheck/buflint"
+	"github.com/bufbuild/buf/private/bufpkg/bufcheck/buflint/buflintconfig"
+	"github.com/bufbuild/buf/private/bufpkg/bufimage"
+	"github.com/bufbuild/buf/private/pkg/storage"
+	"go.uber.org/zap"
+)
+
+const lintKind = "lint"
+
+type lintHandler struct {
+	logger   *zap.Logger
+	delegate buflint.Handler
+	cache    *cache
+}
+
+func newLintHandler(
+	logger *zap.Logger,
+	delegate buflint.Handler,
+	readWriteBucket storage.ReadWriteBucket,
+	version string,
+	now time.Time,
+) *lintHandler {
+	return &lintHandler{
+		logger:   logger,
+		delegate: delegate,
+		cache:    newCache(logger, readWriteBucket, version, lintKind, now),
+	}
+}
+
+func (h *lintHandler) Check(
+	ctx context.Context,
+	config *buflintconfig.Config,
+	image bufimage.Image,
+) ([]bufanalysis.FileAnnotation, []bufcheck.Rule, error) {
+	if len(config.Plugins) > 0 {
+		return h.delegate.Check(ctx, config, image)
+	}
+	h.cache.prune(ctx)
+	rules, err := buflint.RulesForConfig(config)
+	if err != nil {
+		return nil, nil, err
+	}
+	var fileLocalIDs []string
+	var crossFileIDs []string
+	for _, rule := range rules {
+		if rule.FileLocal() {
+			fileLocalIDs = append(fileLocalIDs, rule.ID())
+		} else {
+			crossFileIDs = append(crossFileIDs, rule.ID())
+		}
+	}
+	var fileAnnotations []bufanalysis.FileAnnotation
+	if len(crossFileIDs) > 0 {
+		crossFileAnnotations, err := h.checkImage(ctx, configWithUse(config, crossFileIDs), image)
+		if err != nil {
+			return nil, nil, err
+		}
+		fileAnnotations = append(fileAnnotations, crossFileAnnotations...)
+	}
+	if len(fileLocalIDs) > 0 {
+		fileLocalAnnotations, err := h.checkFiles(ctx, configWithUse(config, fileLocalIDs), image)
+		if err != nil {
+			return nil, nil, err
+		}
+		fileAnnotations = append(fileAnnotations, fileLocalAnnotations...)
+	}
+	bufanalysis.SortFileAnnotations(fileAnnotations)
+	return fileAnnotations, rules, nil
+}
+
+// checkImage checks the image with a single cache entry for the whole image.
+func (h *lintHandler) checkImage(
+	ctx context.Context,
+	config *buflintconfig.Config,
+	image bufimage.Image,
+) ([]bufanalysis.FileAnnotation, error) {
+	key, err := h.cache.getImageKey(config, image)
+	if err != nil {
+		return nil, err
+	}
+	if fileAnnotations, ok := h.cache.get(ctx, key, image); ok {
+		return fileAnnotations, nil
+	}
+	fileAnnotations, _, err := h.delegate.Check(ctx, config, image)
+	if err != nil {
+		return nil, err
+	}
+	h.cache.put(ctx, key, fileAnnotations)
+	return fileAnnotations, nil
+}
+
+// checkFiles checks the image with a cache entry for each file, and only checks
+// the files that have no cache entry.
+//
+// All rules of the config must be file-local.
+func (h *lintHandler) checkFiles(
+	ctx context.Context,
+	config *buflintconfig.Config,
+	image bufimage.Image,
+) ([]bufanalysis.FileAnnotation, error) {
+	pathToKey, err := h.cache.getFileKeys(config, image)
+	if err != nil {
+		return nil, err
+	}
+	var fileAnnotations []bufanalysis.FileAnnotation
+	var uncachedPaths []string
+	for _, imageFile := range image.Files() {
+		if imageFile.IsImport() {
+			continue
+		}
+		if cachedFileAnnotations, ok := h.cache.get(ctx, pathToKey[imageFile.Path()], image); ok {
+			fileAnnotations = append(fileAnnotations, cachedFileAnnotations...)
+			continue
+		}
+		uncachedPaths = append(uncachedPaths, imageFile.Path())
+	}
+	if len(uncachedPaths) == 0 {
+		return fileAnnotations, nil
+	}
+	uncachedImage := image
+	if len(uncachedPaths) < len(pathToKey) {
+		uncachedImage, err = bufimage.ImageWithOnlyPaths(image, uncachedPaths, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+	uncachedFileAnnotations, _, err := h.delegate.Check(ctx, config, uncachedImage)
+	if err != nil {
+		return nil, err
+	}
+	pathToFileAnnotations := make(map[string][]bufanalysis.FileAnnotation)
+	for _, fileAnnotation := range uncachedFileAnnotations {
+		var path string
+		if fileInfo := fileAnnotation.FileInfo(); fileInfo != nil {
+			path = fileInfo.Path()
+		}
+		if _, ok := pathToKey[path]; !ok {
+			// File-local rules only report FileAnnotations for the files that
+			// are not imports, so this is a system error, but the results are
+			// still correct, so just do not cache them.
+			h.logger.Debug("check_cache_unexpected_file_annotation", zap.String("file_path", path))
+			return append(fileAnnotations, uncachedFileAnnotations...), nil
+		}
+		pathToFileAnnotations[path] = append(pathToFileAnnotations[path], fileAnnotation)
+	}
+	for _, path := range uncachedPaths {
+		h.cache.put(ctx, pathToKey[path], pathToFileAnnotations[path])
+	}
+	return append(fileAnnotations, uncachedFileAnnotations...), nil
+}
+
+// configWithUse returns a copy of the config that only uses the rules with the IDs.
+func configWithUse(config *buflintconfig.Config, ids []string) *buflintconfig.Config {
+	configCopy := *config
+	configCopy.Use = ids
+	configCopy.Except = nil
+	return &configCopy
+}
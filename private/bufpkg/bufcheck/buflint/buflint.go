@@ -28,6 +28,7 @@ import (
 	"github.com/bufbuild/buf/private/bufpkg/bufcheck/internal"
 	"github.com/bufbuild/buf/private/bufpkg/bufconfig"
 	"github.com/bufbuild/buf/private/bufpkg/bufimage"
+	"github.com/bufbuild/buf/private/pkg/command"
 	"go.uber.org/zap"
 )
 
@@ -44,20 +45,32 @@ type Handler interface {
 	// The image should have source code info for this to work properly.
 	//
 	// Images should *not* be filtered with regards to imports before passing to this function.
+	//
+	// If the config has plugins, these are run with the Runner given to NewHandler.
+	//
+	// The Rules that were checked are also returned, including the rules provided by
+	// the plugins, so that the plugins do not need to be run again to print the Rules.
 	Check(
 		ctx context.Context,
 		config *buflintconfig.Config,
 		image bufimage.Image,
-	) ([]bufanalysis.FileAnnotation, error)
+	) ([]bufanalysis.FileAnnotation, []bufcheck.Rule, error)
 }
 
 // NewHandler returns a new Handler.
-func NewHandler(logger *zap.Logger) Handler {
-	return newHandler(logger)
+//
+// The runner is used to run lint plugins.
+func NewHandler(logger *zap.Logger, runner command.Runner) Handler {
+	return newHandler(logger, runner)
 }
 
 // RulesForConfig returns the rules for a given config.
 //
+// Rules provided by plugins are not included, as plugins are only run
+// when checking an image. Handler.Check returns the rules including these.
+// Unknown IDs in the excepts and ignores of a config with plugins are
+// assumed to be the IDs of plugin rules.
+//
 // Should only be used for printing.
 func RulesForConfig(config *buflintconfig.Config) ([]bufcheck.Rule, error) {
 	internalConfig, err := internalConfigForConfig(config)
@@ -67,6 +80,24 @@ func RulesForConfig(config *buflintconfig.Config) ([]bufcheck.Rule, error) {
 	return rulesForInternalRules(internalConfig.Rules), nil
 }
 
+// EnumZeroValueSuffixForConfig returns the suffix that enum zero values must
+// have for the given config, which is the default suffix if none is configured.
+func EnumZeroValueSuffixForConfig(config *buflintconfig.Config) string {
+	if config.EnumZeroValueSuffix == "" {
+		return internal.DefaultEnumZeroValueSuffix
+	}
+	return config.EnumZeroValueSuffix
+}
+
+// ServiceSuffixForConfig returns the suffix that services must have for the
+// given config, which is the default suffix if none is configured.
+func ServiceSuffixForConfig(config *buflintconfig.Config) string {
+	if config.ServiceSuffix == "" {
+		return internal.DefaultServiceSuffix
+	}
+	return config.ServiceSuffix
+}
+
 // GetAllRulesV1Beta1 gets all known rules.
 //
 // Should only be used for printing.
@@ -113,7 +144,10 @@ func GetAllRulesAndCategoriesV1() []string {
 	return internal.AllCategoriesAndIDsForVersionSpec(buflintv1.VersionSpec)
 }
 
-func internalConfigForConfig(config *buflintconfig.Config) (*internal.Config, error) {
+func internalConfigForConfig(
+	config *buflintconfig.Config,
+	pluginRuleBuilders ...*internal.RuleBuilder,
+) (*internal.Config, error) {
 	var versionSpec *internal.VersionSpec
 	switch config.Version {
 	case bufconfig.V1Beta1Version:
@@ -132,11 +166,33 @@ func internalConfigForConfig(config *buflintconfig.Config) (*internal.Config, er
 		RPCAllowGoogleProtobufEmptyRequests:  config.RPCAllowGoogleProtobufEmptyRequests,
 		RPCAllowGoogleProtobufEmptyResponses: config.RPCAllowGoogleProtobufEmptyResponses,
 		ServiceSuffix:                        config.ServiceSuffix,
+		NamingRules:                          namingRulesForNamingRuleConfigs(config.NamingRules),
+		TypeUsedRootOptions:                  config.TypeUsedRootOptions,
+		TypeUsedRootTypes:                    config.TypeUsedRootTypes,
+		FieldNumberLowFirstMessages:          config.FieldNumberLowFirstMessages,
+		PluginRuleBuilders:                   pluginRuleBuilders,
+		HasPluginsNotRun:                     len(config.Plugins) > 0 && len(pluginRuleBuilders) == 0,
 	}.NewConfig(
 		versionSpec,
 	)
 }
 
+func namingRulesForNamingRuleConfigs(namingRuleConfigs []*buflintconfig.NamingRuleConfig) []*internal.NamingRule {
+	if namingRuleConfigs == nil {
+		return nil
+	}
+	namingRules := make([]*internal.NamingRule, len(namingRuleConfigs))
+	for i, namingRuleConfig := range namingRuleConfigs {
+		namingRules[i] = &internal.NamingRule{
+			Kind:    namingRuleConfig.Kind,
+			Pattern: namingRuleConfig.Pattern,
+			Style:   namingRuleConfig.Style,
+			Message: namingRuleConfig.Message,
+		}
+	}
+	return namingRules
+}
+
 func rulesForInternalRules(rules []*internal.Rule) []bufcheck.Rule {
 	if rules == nil {
 		return nil
@@ -16,18 +16,27 @@ package buflint_test
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/bufbuild/buf/private/bufpkg/bufanalysis"
 	"github.com/bufbuild/buf/private/bufpkg/bufanalysis/bufanalysistesting"
+	"github.com/bufbuild/buf/private/bufpkg/bufcheck"
 	"github.com/bufbuild/buf/private/bufpkg/bufcheck/buflint"
+	"github.com/bufbuild/buf/private/bufpkg/bufcheck/buflint/buflintconfig"
 	"github.com/bufbuild/buf/private/bufpkg/bufconfig"
 	"github.com/bufbuild/buf/private/bufpkg/bufimage"
 	"github.com/bufbuild/buf/private/bufpkg/bufimage/bufimagebuild"
 	"github.com/bufbuild/buf/private/bufpkg/bufmodule"
 	"github.com/bufbuild/buf/private/bufpkg/bufmodule/bufmodulebuild"
+	lintv1 "github.com/bufbuild/buf/private/gen/proto/go/buf/alpha/lint/v1"
+	"github.com/bufbuild/buf/private/pkg/command"
+	"github.com/bufbuild/buf/private/pkg/protoencoding"
 	"github.com/bufbuild/buf/private/pkg/storage"
 	"github.com/bufbuild/buf/private/pkg/storage/storageos"
 	"github.com/stretchr/testify/assert"
@@ -35,12 +44,58 @@ import (
 	"go.uber.org/zap"
 )
 
+const testPluginName = "buf-lint-house"
+
+func TestMain(m *testing.M) {
+	// TestRunPlugin invokes a copy of the test binary named testPluginName as a lint plugin.
+	if strings.TrimSuffix(filepath.Base(os.Args[0]), filepath.Ext(os.Args[0])) == testPluginName {
+		if err := runTestPlugin(os.Stdin, os.Stdout); err != nil {
+			_, _ = fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
 // Hint on how to get these:
 // 1. cd into the specific directory
 // 2. buf lint --error-format=json | jq '[.path, .start_line, .start_column, .end_line, .end_column, .type] | @csv' --raw-output
 //      or
 //    buf lint --error-format=json | jq -r '"bufanalysistesting.NewFileAnnotation(t, \"\(.path)\", \(.start_line|tostring), \(.start_column|tostring), \(.end_line|tostring), \(.end_column|tostring), \"\(.type)\"),"'
 
+func TestRunAIP(t *testing.T) {
+	t.Parallel()
+	testLintWithModifiers(
+		t,
+		"aip",
+		func(config *bufconfig.Config) {
+			config.Lint.IgnoreRootPaths = []string{"google/api"}
+		},
+		nil,
+		"deps/googleapis",
+		nil,
+		bufanalysistesting.NewFileAnnotation(t, "a.proto", 25, 5, 25, 43, "AIP_FIELD_BEHAVIOR"),
+		bufanalysistesting.NewFileAnnotation(t, "a.proto", 61, 42, 61, 58, "AIP_STANDARD_METHOD"),
+		bufanalysistesting.NewFileAnnotation(t, "a.proto", 63, 19, 63, 34, "AIP_STANDARD_METHOD"),
+		bufanalysistesting.NewFileAnnotation(t, "a.proto", 68, 9, 68, 14, "AIP_RESOURCE"),
+		bufanalysistesting.NewFileAnnotation(t, "a.proto", 69, 3, 69, 50, "AIP_RESOURCE"),
+		bufanalysistesting.NewFileAnnotation(t, "a.proto", 69, 3, 69, 50, "AIP_RESOURCE"),
+		bufanalysistesting.NewFileAnnotation(t, "a.proto", 73, 9, 73, 15, "AIP_RESOURCE"),
+		bufanalysistesting.NewFileAnnotation(t, "a.proto", 78, 10, 78, 14, "AIP_FIELD_BEHAVIOR"),
+		bufanalysistesting.NewFileAnnotation(t, "a.proto", 85, 9, 85, 27, "AIP_PAGINATION"),
+		bufanalysistesting.NewFileAnnotation(t, "a.proto", 86, 3, 86, 8, "AIP_PAGINATION"),
+		bufanalysistesting.NewFileAnnotation(t, "a.proto", 89, 9, 89, 28, "AIP_PAGINATION"),
+		bufanalysistesting.NewFileAnnotation(t, "a.proto", 89, 9, 89, 28, "AIP_PAGINATION"),
+		bufanalysistesting.NewFileAnnotation(t, "a.proto", 94, 9, 94, 14, "AIP_FIELD_BEHAVIOR"),
+		bufanalysistesting.NewFileAnnotation(t, "a.proto", 97, 9, 97, 27, "AIP_STANDARD_METHOD"),
+		bufanalysistesting.NewFileAnnotation(t, "a.proto", 98, 3, 98, 9, "AIP_STANDARD_METHOD"),
+		bufanalysistesting.NewFileAnnotation(t, "a.proto", 99, 5, 99, 46, "AIP_FIELD_BEHAVIOR"),
+		bufanalysistesting.NewFileAnnotation(t, "a.proto", 105, 3, 105, 8, "AIP_STANDARD_METHOD"),
+		bufanalysistesting.NewFileAnnotation(t, "a.proto", 105, 9, 105, 13, "AIP_FIELD_BEHAVIOR"),
+	)
+}
+
 func TestRunComments(t *testing.T) {
 	t.Parallel()
 	testLint(
@@ -148,6 +203,18 @@ func TestRunComments(t *testing.T) {
 	)
 }
 
+func TestRunDeprecation(t *testing.T) {
+	t.Parallel()
+	testLint(
+		t,
+		"deprecation",
+		bufanalysistesting.NewFileAnnotation(t, "a.proto", 13, 3, 13, 38, "DEPRECATION_COMMENT"),
+		bufanalysistesting.NewFileAnnotation(t, "a.proto", 24, 1, 28, 2, "DEPRECATION_COMMENT"),
+		bufanalysistesting.NewFileAnnotation(t, "a.proto", 27, 3, 27, 36, "DEPRECATION_COMMENT"),
+		bufanalysistesting.NewFileAnnotation(t, "a.proto", 40, 1, 43, 2, "DEPRECATION_COMMENT"),
+	)
+}
+
 func TestRunDirectorySamePackage(t *testing.T) {
 	t.Parallel()
 	testLint(
@@ -193,6 +260,21 @@ func TestRunImportUsed(t *testing.T) {
 	)
 }
 
+func TestRunTypeUsed(t *testing.T) {
+	t.Parallel()
+	testLint(
+		t,
+		"type_used",
+		bufanalysistesting.NewFileAnnotation(t, "a.proto", 31, 11, 31, 23, "MESSAGE_USED"),
+		bufanalysistesting.NewFileAnnotation(t, "a.proto", 32, 8, 32, 24, "ENUM_USED"),
+		bufanalysistesting.NewFileAnnotation(t, "a.proto", 46, 9, 46, 15, "MESSAGE_USED"),
+		bufanalysistesting.NewFileAnnotation(t, "a.proto", 50, 9, 50, 20, "MESSAGE_USED"),
+		bufanalysistesting.NewFileAnnotation(t, "a.proto", 52, 6, 52, 16, "ENUM_USED"),
+		bufanalysistesting.NewFileAnnotation(t, "c.proto", 13, 9, 13, 19, "MESSAGE_USED"),
+		bufanalysistesting.NewFileAnnotation(t, "c.proto", 21, 9, 21, 23, "MESSAGE_USED"),
+	)
+}
+
 func TestRunEnumFirstValueZero(t *testing.T) {
 	t.Parallel()
 	testLint(
@@ -402,6 +484,40 @@ func TestRunMessagePascalCase(t *testing.T) {
 	)
 }
 
+func TestRunNaming(t *testing.T) {
+	t.Parallel()
+	testLint(
+		t,
+		"naming",
+		bufanalysistesting.NewFileAnnotation(t, "acme/weather/v1/weather.proto", 7, 9, 7, 17, "FIELD_NAMING"),
+		bufanalysistesting.NewFileAnnotation(t, "acme/weather/v1/weather.proto", 8, 9, 8, 13, "ONEOF_NAMING"),
+		bufanalysistesting.NewFileAnnotation(t, "acme/weather/v1/weather.proto", 17, 9, 17, 40, "MESSAGE_NAMING"),
+		bufanalysistesting.NewFileAnnotation(t, "acme/weather/v1/weather.proto", 17, 9, 17, 40, "MESSAGE_NAMING"),
+		bufanalysistesting.NewFileAnnotation(t, "acme/weather/v1/weather.proto", 21, 3, 21, 21, "ENUM_VALUE_NAMING"),
+		bufanalysistesting.NewFileAnnotation(t, "acme/weather/v1/weather.proto", 24, 6, 24, 10, "ENUM_NAMING"),
+		bufanalysistesting.NewFileAnnotation(t, "acme/weather/v1/weather.proto", 30, 7, 30, 15, "RPC_NAMING"),
+		bufanalysistesting.NewFileAnnotation(t, "acme/weather/v1/weather.proto", 33, 9, 33, 23, "SERVICE_NAMING"),
+		bufanalysistesting.NewFileAnnotationNoLocation(t, "other/otherFile.proto", "FILE_NAMING"),
+		bufanalysistesting.NewFileAnnotation(t, "other/otherFile.proto", 3, 1, 3, 15, "PACKAGE_NAMING"),
+	)
+}
+
+func TestRunNumbering(t *testing.T) {
+	t.Parallel()
+	testLint(
+		t,
+		"numbering",
+		bufanalysistesting.NewFileAnnotation(t, "a.proto", 12, 6, 12, 9, "ENUM_VALUE_NUMBER_CONTIGUOUS"),
+		bufanalysistesting.NewFileAnnotation(t, "a.proto", 25, 9, 25, 13, "FIELD_NUMBER_CONTIGUOUS"),
+		bufanalysistesting.NewFileAnnotation(t, "a.proto", 59, 12, 59, 17, "RESERVED_NAME_WITH_NUMBER"),
+		bufanalysistesting.NewFileAnnotation(t, "a.proto", 64, 12, 64, 37, "RESERVED_NAME_WITH_NUMBER"),
+		bufanalysistesting.NewFileAnnotation(t, "a.proto", 67, 9, 67, 20, "FIELD_NUMBER_CONTIGUOUS"),
+		bufanalysistesting.NewFileAnnotation(t, "a.proto", 70, 18, 70, 20, "FIELD_NUMBER_LOW_FIRST"),
+		// NotListed is not in field_number_low_first_messages, so only FIELD_NUMBER_CONTIGUOUS applies.
+		bufanalysistesting.NewFileAnnotation(t, "a.proto", 73, 9, 73, 18, "FIELD_NUMBER_CONTIGUOUS"),
+	)
+}
+
 func TestRunOneofLowerSnakeCase(t *testing.T) {
 	t.Parallel()
 	testLint(
@@ -480,6 +596,7 @@ func TestRunPackageNoImportCycle(t *testing.T) {
 			return newImage
 		},
 		"",
+		nil,
 		bufanalysistesting.NewFileAnnotation(t, "c1.proto", 5, 1, 5, 19, "PACKAGE_NO_IMPORT_CYCLE"),
 		bufanalysistesting.NewFileAnnotation(t, "d1.proto", 5, 1, 5, 19, "PACKAGE_NO_IMPORT_CYCLE"),
 	)
@@ -687,6 +804,23 @@ func TestRunProtovalidateRules(t *testing.T) {
 	)
 }
 
+func TestRunProtovalidateStrict(t *testing.T) {
+	t.Parallel()
+	testLintWithValidate(
+		t,
+		"protovalidate_strict",
+		bufanalysistesting.NewFileAnnotation(t, "strict.proto", 11, 40, 11, 76, "PROTOVALIDATE_STRICT"),
+		bufanalysistesting.NewFileAnnotation(t, "strict.proto", 29, 5, 29, 53, "PROTOVALIDATE_STRICT"),
+		bufanalysistesting.NewFileAnnotation(t, "strict.proto", 31, 5, 31, 53, "PROTOVALIDATE_STRICT"),
+		bufanalysistesting.NewFileAnnotation(t, "strict.proto", 40, 5, 40, 57, "PROTOVALIDATE_STRICT"),
+		bufanalysistesting.NewFileAnnotation(t, "strict.proto", 42, 5, 42, 56, "PROTOVALIDATE_STRICT"),
+		bufanalysistesting.NewFileAnnotation(t, "strict.proto", 46, 5, 46, 68, "PROTOVALIDATE_STRICT"),
+		bufanalysistesting.NewFileAnnotation(t, "strict.proto", 48, 5, 48, 67, "PROTOVALIDATE_STRICT"),
+		bufanalysistesting.NewFileAnnotation(t, "strict.proto", 52, 5, 52, 67, "PROTOVALIDATE_STRICT"),
+		bufanalysistesting.NewFileAnnotation(t, "strict.proto", 54, 5, 54, 67, "PROTOVALIDATE_STRICT"),
+	)
+}
+
 func TestRunRPCNoStreaming(t *testing.T) {
 	t.Parallel()
 	testLint(
@@ -1029,6 +1163,7 @@ func TestCommentIgnoresOn(t *testing.T) {
 		},
 		nil,
 		"",
+		nil,
 	)
 }
 
@@ -1083,7 +1218,74 @@ func TestCommentIgnoresCascadeOn(t *testing.T) {
 		},
 		nil,
 		"",
+		nil,
+	)
+}
+
+func TestRunPlugin(t *testing.T) {
+	t.Parallel()
+	// The test binary acts as the plugin when invoked with the plugin name, see TestMain.
+	executablePath, err := os.Executable()
+	require.NoError(t, err)
+	executableData, err := os.ReadFile(executablePath)
+	require.NoError(t, err)
+	pluginPath := filepath.Join(t.TempDir(), testPluginName+filepath.Ext(executablePath))
+	require.NoError(t, os.WriteFile(pluginPath, executableData, 0755))
+	var (
+		lintConfig *buflintconfig.Config
+		image      bufimage.Image
+	)
+	testLintWithModifiers(
+		t,
+		"plugin",
+		func(config *bufconfig.Config) {
+			require.Len(t, config.Lint.Plugins, 1)
+			require.Equal(t, testPluginName, config.Lint.Plugins[0].Path)
+			config.Lint.Plugins[0].Path = pluginPath
+			lintConfig = config.Lint
+		},
+		func(builtImage bufimage.Image) bufimage.Image {
+			image = builtImage
+			return builtImage
+		},
+		"",
+		nil,
+		bufanalysistesting.NewFileAnnotation(t, "a.proto", 9, 1, 11, 2, "REQUEST_ID_FIELD"),
+		bufanalysistesting.NewFileAnnotation(t, "a.proto", 18, 1, 18, 28, "REQUEST_ID_FIELD"),
 	)
+	// The rules provided by the plugin are returned with the FileAnnotations.
+	_, rules, err := buflint.NewHandler(zap.NewNop(), command.NewRunner()).Check(context.Background(), lintConfig, image)
+	require.NoError(t, err)
+	idToRule := make(map[string]bufcheck.Rule, len(rules))
+	for _, rule := range rules {
+		idToRule[rule.ID()] = rule
+	}
+	require.Contains(t, idToRule, "REQUEST_ID_FIELD")
+	assert.Equal(t, "Checks that all request messages have the configured field.", idToRule["REQUEST_ID_FIELD"].Purpose())
+	assert.Empty(t, idToRule["REQUEST_ID_FIELD"].Categories())
+	// MESSAGE_NOT_EMPTY is excluded by the config.
+	assert.NotContains(t, idToRule, "MESSAGE_NOT_EMPTY")
+	assert.Contains(t, idToRule, "PACKAGE_DEFINED")
+}
+
+func TestRulesForConfigWithPluginIDs(t *testing.T) {
+	t.Parallel()
+	readBucket, err := storageos.NewProvider().NewReadWriteBucket(filepath.Join("testdata", "plugin"))
+	require.NoError(t, err)
+	config := testGetConfig(t, readBucket)
+	// The plugin is not run, so the MESSAGE_NOT_EMPTY and REQUEST_ID_FIELD rules
+	// in except and ignore_only are not known.
+	rules, err := buflint.RulesForConfig(config.Lint)
+	require.NoError(t, err)
+	ids := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		ids = append(ids, rule.ID())
+	}
+	assert.Equal(t, []string{"PACKAGE_DEFINED"}, ids)
+	// Unknown IDs are still an error without plugins.
+	config.Lint.Plugins = nil
+	_, err = buflint.RulesForConfig(config.Lint)
+	require.Error(t, err)
 }
 
 func testLint(
@@ -1097,6 +1299,7 @@ func testLint(
 		nil,
 		nil,
 		"",
+		nil,
 		expectedFileAnnotations...,
 	)
 }
@@ -1114,6 +1317,7 @@ func testLintWithValidate(
 		},
 		nil,
 		"deps/protovalidate",
+		nil,
 		expectedFileAnnotations...,
 	)
 }
@@ -1124,6 +1328,7 @@ func testLintWithModifiers(
 	configModifier func(*bufconfig.Config),
 	imageModifier func(bufimage.Image) bufimage.Image,
 	dependencyPathPrefix string,
+	commandRunner command.Runner,
 	expectedFileAnnotations ...bufanalysis.FileAnnotation,
 ) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -1179,8 +1384,11 @@ func testLintWithModifiers(
 		image = imageModifier(image)
 	}
 
-	handler := buflint.NewHandler(logger)
-	fileAnnotations, err = handler.Check(
+	if commandRunner == nil {
+		commandRunner = command.NewRunner()
+	}
+	handler := buflint.NewHandler(logger, commandRunner)
+	fileAnnotations, _, err = handler.Check(
 		ctx,
 		config.Lint,
 		image,
@@ -1203,3 +1411,72 @@ func testGetConfig(
 	require.NoError(t, err)
 	return config
 }
+
+// runTestPlugin runs the lint plugin used by TestRunPlugin.
+//
+// The plugin provides the REQUEST_ID_FIELD rule, which checks that all messages
+// ending in Request have the field given by the field option, and the
+// MESSAGE_NOT_EMPTY rule, which checks that all messages have at least one field.
+func runTestPlugin(stdin io.Reader, stdout io.Writer) error {
+	requestData, err := io.ReadAll(stdin)
+	if err != nil {
+		return err
+	}
+	request := &lintv1.PluginRequest{}
+	if err := protoencoding.NewWireUnmarshaler(nil).Unmarshal(requestData, request); err != nil {
+		return err
+	}
+	requiredFieldName := strings.TrimPrefix(request.GetParameter(), "field=")
+	response := &lintv1.PluginResponse{
+		Rules: []*lintv1.PluginRule{
+			{
+				Id:      "REQUEST_ID_FIELD",
+				Purpose: "all request messages have the configured field",
+			},
+			{
+				Id:      "MESSAGE_NOT_EMPTY",
+				Purpose: "all messages have at least one field",
+			},
+		},
+	}
+	for _, imageFile := range request.GetImage().GetFile() {
+		if imageFile.GetBufExtension().GetIsImport() {
+			continue
+		}
+		for i, message := range imageFile.GetMessageType() {
+			// 4 is the field number of message_type in FileDescriptorProto.
+			path := []int32{4, int32(i)}
+			if len(message.GetField()) == 0 {
+				response.Annotations = append(response.Annotations, &lintv1.PluginAnnotation{
+					RuleId:   "MESSAGE_NOT_EMPTY",
+					FilePath: imageFile.GetName(),
+					Path:     path,
+					Message:  fmt.Sprintf("Message %q has no fields.", message.GetName()),
+				})
+			}
+			if !strings.HasSuffix(message.GetName(), "Request") {
+				continue
+			}
+			var found bool
+			for _, field := range message.GetField() {
+				if field.GetName() == requiredFieldName {
+					found = true
+				}
+			}
+			if !found {
+				response.Annotations = append(response.Annotations, &lintv1.PluginAnnotation{
+					RuleId:   "REQUEST_ID_FIELD",
+					FilePath: imageFile.GetName(),
+					Path:     path,
+					Message:  fmt.Sprintf("Message %q does not have a %s field.", message.GetName(), requiredFieldName),
+				})
+			}
+		}
+	}
+	responseData, err := protoencoding.NewWireMarshaler().Marshal(response)
+	if err != nil {
+		return err
+	}
+	_, err = stdout.Write(responseData)
+	return err
+}
@@ -59,10 +59,66 @@ type Config struct {
 	ServiceSuffix string
 	// AllowCommentIgnores turns on comment-driven ignores.
 	AllowCommentIgnores bool
+	// NamingRules are the naming rules checked by the *_NAMING rule IDs, such as MESSAGE_NAMING.
+	//
+	// The *_NAMING rules do nothing for kinds of elements that have no naming rules.
+	NamingRules []*NamingRuleConfig
+	// TypeUsedRootOptions are the fully-qualified names of custom message and enum options
+	// that mark the messages and enums they are set on as used for the TYPE_USED rule IDs.
+	TypeUsedRootOptions []string
+	// TypeUsedRootTypes are the fully-qualified names of messages, enums, or packages that
+	// are considered used for the TYPE_USED rule IDs, in addition to the request and response
+	// types of all RPCs. For packages, all messages and enums within the package are used.
+	TypeUsedRootTypes []string
+	// FieldNumberLowFirstMessages are the fully-qualified names of the messages that are
+	// checked by the FIELD_NUMBER_LOW_FIRST rule ID, such as messages that are sent often
+	// enough for their size on the wire to matter.
+	//
+	// The FIELD_NUMBER_LOW_FIRST rule ID does nothing if this is empty.
+	FieldNumberLowFirstMessages []string
+	// Plugins are the lint plugins to run in addition to the builtin rules.
+	//
+	// Rules provided by plugins are always used unless excluded with Except.
+	Plugins []*PluginConfig
+	// Baseline is the path to the baseline file, relative to the root of the module.
+	//
+	// Violations recorded in the baseline are not reported.
+	Baseline string
 	// Version represents the version of the lint rule and category IDs that should be used with this config.
 	Version string
 }
 
+// PluginConfig is the configuration for a lint plugin.
+type PluginConfig struct {
+	// Path is the path to the plugin binary, or the name of a binary on the $PATH.
+	Path string
+	// Options are the options passed to the plugin.
+	//
+	// These are joined with a comma to form the parameter of the PluginRequest.
+	Options []string
+}
+
+// NamingRuleConfig is the configuration for a naming rule.
+type NamingRuleConfig struct {
+	// Kind is the kind of element that the naming rule applies to.
+	//
+	// Must be one of package, message, field, oneof, enum, enum_value, service, rpc, or file.
+	Kind string
+	// Pattern is a regular expression that the names of elements must match.
+	//
+	// For packages this is matched against the full package name, and for files
+	// this is matched against the path of the file relative to the root of the module.
+	Pattern string
+	// Style is the case style that the names of elements must be in.
+	//
+	// Must be one of lower_snake_case, UPPER_SNAKE_CASE, PascalCase, or camelCase.
+	// For packages this applies to each component of the package, and for files
+	// this applies to the file name without the .proto extension.
+	Style string
+	// Message is a custom message to use for violations of the naming rule.
+	Message string
+}
+
 // NewConfigV1Beta1 returns a new Config.
 func NewConfigV1Beta1(externalConfig ExternalConfigV1Beta1) *Config {
 	return &Config{
@@ -93,6 +149,12 @@ func NewConfigV1(externalConfig ExternalConfigV1) *Config {
 		RPCAllowGoogleProtobufEmptyResponses: externalConfig.RPCAllowGoogleProtobufEmptyResponses,
 		ServiceSuffix:                        externalConfig.ServiceSuffix,
 		AllowCommentIgnores:                  externalConfig.AllowCommentIgnores,
+		NamingRules:                          namingRuleConfigsForExternalNamingRuleConfigsV1(externalConfig.Naming),
+		TypeUsedRootOptions:                  externalConfig.TypeUsedRootOptions,
+		TypeUsedRootTypes:                    externalConfig.TypeUsedRootTypes,
+		FieldNumberLowFirstMessages:          externalConfig.FieldNumberLowFirstMessages,
+		Plugins:                              pluginConfigsForExternalPluginConfigsV1(externalConfig.Plugins),
+		Baseline:                             externalConfig.Baseline,
 		Version:                              v1Version,
 	}
 }
@@ -154,13 +216,34 @@ type ExternalConfigV1 struct {
 	// IgnoreRootPaths
 	Ignore []string `json:"ignore,omitempty" yaml:"ignore,omitempty"`
 	// IgnoreIDOrCategoryToRootPaths
-	IgnoreOnly                           map[string][]string `json:"ignore_only,omitempty" yaml:"ignore_only,omitempty"`
-	EnumZeroValueSuffix                  string              `json:"enum_zero_value_suffix,omitempty" yaml:"enum_zero_value_suffix,omitempty"`
-	RPCAllowSameRequestResponse          bool                `json:"rpc_allow_same_request_response,omitempty" yaml:"rpc_allow_same_request_response,omitempty"`
-	RPCAllowGoogleProtobufEmptyRequests  bool                `json:"rpc_allow_google_protobuf_empty_requests,omitempty" yaml:"rpc_allow_google_protobuf_empty_requests,omitempty"`
-	RPCAllowGoogleProtobufEmptyResponses bool                `json:"rpc_allow_google_protobuf_empty_responses,omitempty" yaml:"rpc_allow_google_protobuf_empty_responses,omitempty"`
-	ServiceSuffix                        string              `json:"service_suffix,omitempty" yaml:"service_suffix,omitempty"`
-	AllowCommentIgnores                  bool                `json:"allow_comment_ignores,omitempty" yaml:"allow_comment_ignores,omitempty"`
+	IgnoreOnly                           map[string][]string          `json:"ignore_only,omitempty" yaml:"ignore_only,omitempty"`
+	EnumZeroValueSuffix                  string                       `json:"enum_zero_value_suffix,omitempty" yaml:"enum_zero_value_suffix,omitempty"`
+	RPCAllowSameRequestResponse          bool                         `json:"rpc_allow_same_request_response,omitempty" yaml:"rpc_allow_same_request_response,omitempty"`
+	RPCAllowGoogleProtobufEmptyRequests  bool                         `json:"rpc_allow_google_protobuf_empty_requests,omitempty" yaml:"rpc_allow_google_protobuf_empty_requests,omitempty"`
+	RPCAllowGoogleProtobufEmptyResponses bool                         `json:"rpc_allow_google_protobuf_empty_responses,omitempty" yaml:"rpc_allow_google_protobuf_empty_responses,omitempty"`
+	ServiceSuffix                        string                       `json:"service_suffix,omitempty" yaml:"service_suffix,omitempty"`
+	AllowCommentIgnores                  bool                         `json:"allow_comment_ignores,omitempty" yaml:"allow_comment_ignores,omitempty"`
+	Naming                               []ExternalNamingRuleConfigV1 `json:"naming,omitempty" yaml:"naming,omitempty"`
+	TypeUsedRootOptions                  []string                     `json:"type_used_root_options,omitempty" yaml:"type_used_root_options,omitempty"`
+	TypeUsedRootTypes                    []string                     `json:"type_used_root_types,omitempty" yaml:"type_used_root_types,omitempty"`
+	FieldNumberLowFirstMessages          []string                     `json:"field_number_low_first_messages,omitempty" yaml:"field_number_low_first_messages,omitempty"`
+	Plugins                              []ExternalPluginConfigV1     `json:"plugins,omitempty" yaml:"plugins,omitempty"`
+	Baseline                             string                       `json:"baseline,omitempty" yaml:"baseline,omitempty"`
+}
+
+// ExternalNamingRuleConfigV1 is an external naming rule configuration.
+type ExternalNamingRuleConfigV1 struct {
+	Kind    string `json:"kind,omitempty" yaml:"kind,omitempty"`
+	Pattern string `json:"pattern,omitempty" yaml:"pattern,omitempty"`
+	Style   string `json:"style,omitempty" yaml:"style,omitempty"`
+	Message string `json:"message,omitempty" yaml:"message,omitempty"`
+}
+
+// ExternalPluginConfigV1 is an external lint plugin configuration.
+type ExternalPluginConfigV1 struct {
+	// Plugin is the path to the plugin binary, or the name of a binary on the $PATH.
+	Plugin string   `json:"plugin,omitempty" yaml:"plugin,omitempty"`
+	Opt    []string `json:"opt,omitempty" yaml:"opt,omitempty"`
 }
 
 // ExternalConfigV1Beta1ForConfig takes a *Config and returns the v1beta1 externalconfig representation.
@@ -192,6 +275,12 @@ func ExternalConfigV1ForConfig(config *Config) ExternalConfigV1 {
 		RPCAllowGoogleProtobufEmptyResponses: config.RPCAllowGoogleProtobufEmptyResponses,
 		ServiceSuffix:                        config.ServiceSuffix,
 		AllowCommentIgnores:                  config.AllowCommentIgnores,
+		Naming:                               externalNamingRuleConfigsV1ForNamingRuleConfigs(config.NamingRules),
+		TypeUsedRootOptions:                  config.TypeUsedRootOptions,
+		TypeUsedRootTypes:                    config.TypeUsedRootTypes,
+		FieldNumberLowFirstMessages:          config.FieldNumberLowFirstMessages,
+		Plugins:                              externalPluginConfigsV1ForPluginConfigs(config.Plugins),
+		Baseline:                             config.Baseline,
 	}
 }
 
@@ -206,17 +295,35 @@ func BytesForConfig(config *Config) ([]byte, error) {
 }
 
 type configJSON struct {
-	Use                                  []string      `json:"use,omitempty"`
-	Except                               []string      `json:"except,omitempty"`
-	IgnoreRootPaths                      []string      `json:"ignore_root_paths,omitempty"`
-	IgnoreIDOrCategoryToRootPaths        []idPathsJSON `json:"ignore_id_to_root_paths,omitempty"`
-	EnumZeroValueSuffix                  string        `json:"enum_zero_value_suffix,omitempty"`
-	RPCAllowSameRequestResponse          bool          `json:"rpc_allow_same_request_response,omitempty"`
-	RPCAllowGoogleProtobufEmptyRequests  bool          `json:"rpc_allow_google_protobuf_empty_requests,omitempty"`
-	RPCAllowGoogleProtobufEmptyResponses bool          `json:"rpc_allow_google_protobuf_empty_response,omitempty"`
-	ServiceSuffix                        string        `json:"service_suffix,omitempty"`
-	AllowCommentIgnores                  bool          `json:"allow_comment_ignores,omitempty"`
-	Version                              string        `json:"version,omitempty"`
+	Use                                  []string         `json:"use,omitempty"`
+	Except                               []string         `json:"except,omitempty"`
+	IgnoreRootPaths                      []string         `json:"ignore_root_paths,omitempty"`
+	IgnoreIDOrCategoryToRootPaths        []idPathsJSON    `json:"ignore_id_to_root_paths,omitempty"`
+	EnumZeroValueSuffix                  string           `json:"enum_zero_value_suffix,omitempty"`
+	RPCAllowSameRequestResponse          bool             `json:"rpc_allow_same_request_response,omitempty"`
+	RPCAllowGoogleProtobufEmptyRequests  bool             `json:"rpc_allow_google_protobuf_empty_requests,omitempty"`
+	RPCAllowGoogleProtobufEmptyResponses bool             `json:"rpc_allow_google_protobuf_empty_response,omitempty"`
+	ServiceSuffix                        string           `json:"service_suffix,omitempty"`
+	AllowCommentIgnores                  bool             `json:"allow_comment_ignores,omitempty"`
+	NamingRules                          []namingRuleJSON `json:"naming_rules,omitempty"`
+	TypeUsedRootOptions                  []string         `json:"type_used_root_options,omitempty"`
+	TypeUsedRootTypes                    []string         `json:"type_used_root_types,omitempty"`
+	FieldNumberLowFirstMessages          []string         `json:"field_number_low_first_messages,omitempty"`
+	Plugins                              []pluginJSON     `json:"plugins,omitempty"`
+	Baseline                             string           `json:"baseline,omitempty"`
+	Version                              string           `json:"version,omitempty"`
+}
+
+type namingRuleJSON struct {
+	Kind    string `json:"kind,omitempty"`
+	Pattern string `json:"pattern,omitempty"`
+	Style   string `json:"style,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type pluginJSON struct {
+	Path    string   `json:"path,omitempty"`
+	Options []string `json:"options,omitempty"`
 }
 
 type idPathsJSON struct {
@@ -247,6 +354,50 @@ func configToJSON(config *Config) *configJSON {
 	sort.Strings(use)
 	sort.Strings(except)
 	sort.Strings(ignoreRootPaths)
+	typeUsedRootOptions := make([]string, len(config.TypeUsedRootOptions))
+	copy(typeUsedRootOptions, config.TypeUsedRootOptions)
+	sort.Strings(typeUsedRootOptions)
+	typeUsedRootTypes := make([]string, len(config.TypeUsedRootTypes))
+	copy(typeUsedRootTypes, config.TypeUsedRootTypes)
+	sort.Strings(typeUsedRootTypes)
+	fieldNumberLowFirstMessages := make([]string, len(config.FieldNumberLowFirstMessages))
+	copy(fieldNumberLowFirstMessages, config.FieldNumberLowFirstMessages)
+	sort.Strings(fieldNumberLowFirstMessages)
+	var namingRulesJSON []namingRuleJSON
+	for _, namingRuleConfig := range config.NamingRules {
+		namingRulesJSON = append(namingRulesJSON, namingRuleJSON{
+			Kind:    namingRuleConfig.Kind,
+			Pattern: namingRuleConfig.Pattern,
+			Style:   namingRuleConfig.Style,
+			Message: namingRuleConfig.Message,
+		})
+	}
+	sort.Slice(
+		namingRulesJSON,
+		func(i, j int) bool {
+			one := namingRulesJSON[i]
+			two := namingRulesJSON[j]
+			if one.Kind != two.Kind {
+				return one.Kind < two.Kind
+			}
+			if one.Pattern != two.Pattern {
+				return one.Pattern < two.Pattern
+			}
+			if one.Style != two.Style {
+				return one.Style < two.Style
+			}
+			return one.Message < two.Message
+		},
+	)
+	// Plugins are not sorted, as the order of plugins is significant
+	// for the order in which they are run.
+	var pluginsJSON []pluginJSON
+	for _, pluginConfig := range config.Plugins {
+		pluginsJSON = append(pluginsJSON, pluginJSON{
+			Path:    pluginConfig.Path,
+			Options: pluginConfig.Options,
+		})
+	}
 	return &configJSON{
 		Use:                                  use,
 		Except:                               except,
@@ -258,6 +409,12 @@ func configToJSON(config *Config) *configJSON {
 		RPCAllowGoogleProtobufEmptyResponses: config.RPCAllowGoogleProtobufEmptyResponses,
 		ServiceSuffix:                        config.ServiceSuffix,
 		AllowCommentIgnores:                  config.AllowCommentIgnores,
+		NamingRules:                          namingRulesJSON,
+		TypeUsedRootOptions:                  typeUsedRootOptions,
+		TypeUsedRootTypes:                    typeUsedRootTypes,
+		FieldNumberLowFirstMessages:          fieldNumberLowFirstMessages,
+		Plugins:                              pluginsJSON,
+		Baseline:                             config.Baseline,
 		Version:                              config.Version,
 	}
 }
@@ -269,12 +426,13 @@ func PrintFileAnnotations(
 	writer io.Writer,
 	fileAnnotations []bufanalysis.FileAnnotation,
 	formatString string,
+	options ...bufanalysis.PrintFileAnnotationsOption,
 ) error {
 	switch s := strings.ToLower(strings.TrimSpace(formatString)); s {
 	case "config-ignore-yaml":
 		return printFileAnnotationsConfigIgnoreYAML(writer, fileAnnotations)
 	default:
-		return bufanalysis.PrintFileAnnotations(writer, fileAnnotations, s)
+		return bufanalysis.PrintFileAnnotations(writer, fileAnnotations, s, options...)
 	}
 }
 
@@ -358,3 +516,63 @@ func protoForIgnoreIDOrCategoryToRootPaths(ignoreIDOrCategoryToRootPaths map[str
 	}
 	return idPathsProto
 }
+
+func namingRuleConfigsForExternalNamingRuleConfigsV1(externalNamingRuleConfigs []ExternalNamingRuleConfigV1) []*NamingRuleConfig {
+	if externalNamingRuleConfigs == nil {
+		return nil
+	}
+	namingRuleConfigs := make([]*NamingRuleConfig, 0, len(externalNamingRuleConfigs))
+	for _, externalNamingRuleConfig := range externalNamingRuleConfigs {
+		namingRuleConfigs = append(namingRuleConfigs, &NamingRuleConfig{
+			Kind:    externalNamingRuleConfig.Kind,
+			Pattern: externalNamingRuleConfig.Pattern,
+			Style:   externalNamingRuleConfig.Style,
+			Message: externalNamingRuleConfig.Message,
+		})
+	}
+	return namingRuleConfigs
+}
+
+func externalNamingRuleConfigsV1ForNamingRuleConfigs(namingRuleConfigs []*NamingRuleConfig) []ExternalNamingRuleConfigV1 {
+	if namingRuleConfigs == nil {
+		return nil
+	}
+	externalNamingRuleConfigs := make([]ExternalNamingRuleConfigV1, 0, len(namingRuleConfigs))
+	for _, namingRuleConfig := range namingRuleConfigs {
+		externalNamingRuleConfigs = append(externalNamingRuleConfigs, ExternalNamingRuleConfigV1{
+			Kind:    namingRuleConfig.Kind,
+			Pattern: namingRuleConfig.Pattern,
+			Style:   namingRuleConfig.Style,
+			Message: namingRuleConfig.Message,
+		})
+	}
+	return externalNamingRuleConfigs
+}
+
+func pluginConfigsForExternalPluginConfigsV1(externalPluginConfigs []ExternalPluginConfigV1) []*PluginConfig {
+	if externalPluginConfigs == nil {
+		return nil
+	}
+	pluginConfigs := make([]*PluginConfig, 0, len(externalPluginConfigs))
+	for _, externalPluginConfig := range externalPluginConfigs {
+		pluginConfigs = append(pluginConfigs, &PluginConfig{
+			Path:    externalPluginConfig.Plugin,
+			Options: externalPluginConfig.Opt,
+		})
+	}
+	return pluginConfigs
+}
+
+func externalPluginConfigsV1ForPluginConfigs(pluginConfigs []*PluginConfig) []ExternalPluginConfigV1 {
+	if pluginConfigs == nil {
+		return nil
+	}
+	externalPluginConfigs := make([]ExternalPluginConfigV1, 0, len(pluginConfigs))
+	for _, pluginConfig := range pluginConfigs {
+		externalPluginConfigs = append(externalPluginConfigs, ExternalPluginConfigV1{
+			Plugin: pluginConfig.Path,
+			Opt:    pluginConfig.Options,
+		})
+	}
+	return externalPluginConfigs
+}
@@ -18,23 +18,28 @@ import (
 	"context"
 
 	"github.com/bufbuild/buf/private/bufpkg/bufanalysis"
+	"github.com/bufbuild/buf/private/bufpkg/bufcheck"
 	"github.com/bufbuild/buf/private/bufpkg/bufcheck/buflint/buflintconfig"
 	"github.com/bufbuild/buf/private/bufpkg/bufcheck/buflint/internal/buflintcheck"
+	"github.com/bufbuild/buf/private/bufpkg/bufcheck/buflint/internal/buflintplugin"
 	"github.com/bufbuild/buf/private/bufpkg/bufcheck/internal"
 	"github.com/bufbuild/buf/private/bufpkg/bufimage"
 	"github.com/bufbuild/buf/private/bufpkg/bufimage/bufimageutil"
+	"github.com/bufbuild/buf/private/pkg/command"
 	"github.com/bufbuild/buf/private/pkg/protosource"
 	"go.uber.org/zap"
 )
 
 type handler struct {
-	logger *zap.Logger
-	runner *internal.Runner
+	logger        *zap.Logger
+	commandRunner command.Runner
+	runner        *internal.Runner
 }
 
-func newHandler(logger *zap.Logger) *handler {
+func newHandler(logger *zap.Logger, commandRunner command.Runner) *handler {
 	return &handler{
-		logger: logger,
+		logger:        logger,
+		commandRunner: commandRunner,
 		// linting allows for comment ignores
 		// note that comment ignores still need to be enabled within the config
 		// for a given check, this just says that comment ignores are allowed
@@ -50,14 +55,41 @@ func (h *handler) Check(
 	ctx context.Context,
 	config *buflintconfig.Config,
 	image bufimage.Image,
-) ([]bufanalysis.FileAnnotation, error) {
+) ([]bufanalysis.FileAnnotation, []bufcheck.Rule, error) {
 	files, err := protosource.NewFilesUnstable(ctx, bufimageutil.NewInputFiles(image.Files())...)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	internalConfig, err := internalConfigForConfig(config)
+	pluginRuleBuilders, err := newPluginRuleBuilders(ctx, h.commandRunner, config, image)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	return h.runner.Check(ctx, internalConfig, nil, files)
+	internalConfig, err := internalConfigForConfig(config, pluginRuleBuilders...)
+	if err != nil {
+		return nil, nil, err
+	}
+	fileAnnotations, err := h.runner.Check(ctx, internalConfig, nil, files)
+	if err != nil {
+		return nil, nil, err
+	}
+	return fileAnnotations, rulesForInternalRules(internalConfig.Rules), nil
+}
+
+// newPluginRuleBuilders runs the plugins of the config against the image, and returns
+// the RuleBuilders for the rules provided by the plugins.
+func newPluginRuleBuilders(
+	ctx context.Context,
+	commandRunner command.Runner,
+	config *buflintconfig.Config,
+	image bufimage.Image,
+) ([]*internal.RuleBuilder, error) {
+	var pluginRuleBuilders []*internal.RuleBuilder
+	for _, pluginConfig := range config.Plugins {
+		ruleBuilders, err := buflintplugin.NewRuleBuilders(ctx, commandRunner, pluginConfig, image)
+		if err != nil {
+			return nil, err
+		}
+		pluginRuleBuilders = append(pluginRuleBuilders, ruleBuilders...)
+	}
+	return pluginRuleBuilders, nil
 }
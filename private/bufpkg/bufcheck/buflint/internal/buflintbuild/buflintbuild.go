@@ -25,48 +25,78 @@ import (
 )
 
 var (
+	// AIPFieldBehaviorRuleBuilder is a rule builder.
+	AIPFieldBehaviorRuleBuilder = internal.NewNopRuleBuilder(
+		"AIP_FIELD_BEHAVIOR",
+		"fields do not have conflicting google.api.field_behavior annotations, and that the resource name, parent and resource fields of standard method requests are annotated as REQUIRED",
+		newAdapter(buflintcheck.CheckAIPFieldBehavior),
+	)
+	// AIPPaginationRuleBuilder is a rule builder.
+	AIPPaginationRuleBuilder = internal.NewNopRuleBuilder(
+		"AIP_PAGINATION",
+		"List requests have page_size and page_token fields, and List responses have a next_page_token field and a repeated field",
+		newAdapter(buflintcheck.CheckAIPPagination),
+	)
+	// AIPResourceRuleBuilder is a rule builder.
+	AIPResourceRuleBuilder = internal.NewNopRuleBuilder(
+		"AIP_RESOURCE",
+		"the resources of standard Get methods are annotated with google.api.resource, and that resource annotations have a valid type, a pattern, and a name field",
+		newAdapter(buflintcheck.CheckAIPResource),
+	)
+	// AIPStandardMethodRuleBuilder is a rule builder.
+	AIPStandardMethodRuleBuilder = internal.NewNopRuleBuilder(
+		"AIP_STANDARD_METHOD",
+		"standard Get, List, Create, Update and Delete methods have the standard request and response types and request fields",
+		newAdapter(buflintcheck.CheckAIPStandardMethod),
+	)
 	// CommentEnumRuleBuilder is a rule builder.
-	CommentEnumRuleBuilder = internal.NewNopRuleBuilder(
+	CommentEnumRuleBuilder = internal.NewNopFileRuleBuilder(
 		"COMMENT_ENUM",
 		"enums have non-empty comments",
 		newAdapter(buflintcheck.CheckCommentEnum),
 	)
 	// CommentEnumValueRuleBuilder is a rule builder.
-	CommentEnumValueRuleBuilder = internal.NewNopRuleBuilder(
+	CommentEnumValueRuleBuilder = internal.NewNopFileRuleBuilder(
 		"COMMENT_ENUM_VALUE",
 		"enum values have non-empty comments",
 		newAdapter(buflintcheck.CheckCommentEnumValue),
 	)
 	// CommentFieldRuleBuilder is a rule builder.
-	CommentFieldRuleBuilder = internal.NewNopRuleBuilder(
+	CommentFieldRuleBuilder = internal.NewNopFileRuleBuilder(
 		"COMMENT_FIELD",
 		"fields have non-empty comments",
 		newAdapter(buflintcheck.CheckCommentField),
 	)
 	// CommentMessageRuleBuilder is a rule builder.
-	CommentMessageRuleBuilder = internal.NewNopRuleBuilder(
+	CommentMessageRuleBuilder = internal.NewNopFileRuleBuilder(
 		"COMMENT_MESSAGE",
 		"messages have non-empty comments",
 		newAdapter(buflintcheck.CheckCommentMessage),
 	)
 	// CommentOneofRuleBuilder is a rule builder.
-	CommentOneofRuleBuilder = internal.NewNopRuleBuilder(
+	CommentOneofRuleBuilder = internal.NewNopFileRuleBuilder(
 		"COMMENT_ONEOF",
 		"oneof have non-empty comments",
 		newAdapter(buflintcheck.CheckCommentOneof),
 	)
 	// CommentRPCRuleBuilder is a rule builder.
-	CommentRPCRuleBuilder = internal.NewNopRuleBuilder(
+	CommentRPCRuleBuilder = internal.NewNopFileRuleBuilder(
 		"COMMENT_RPC",
 		"RPCs have non-empty comments",
 		newAdapter(buflintcheck.CheckCommentRPC),
 	)
 	// CommentServiceRuleBuilder is a rule builder.
-	CommentServiceRuleBuilder = internal.NewNopRuleBuilder(
+	CommentServiceRuleBuilder = internal.NewNopFileRuleBuilder(
 		"COMMENT_SERVICE",
 		"services have non-empty comments",
 		newAdapter(buflintcheck.CheckCommentService),
 	)
+	// DeprecationCommentRuleBuilder is a rule builder.
+	DeprecationCommentRuleBuilder = internal.NewNopFileRuleBuilder(
+		"DEPRECATION_COMMENT",
+		"deprecated elements have a leading comment that explains why or when they will be removed",
+		newAdapter(buflintcheck.CheckDeprecationComment),
+	)
 	// DirectorySamePackageRuleBuilder is a rule builder.
 	DirectorySamePackageRuleBuilder = internal.NewNopRuleBuilder(
 		"DIRECTORY_SAME_PACKAGE",
@@ -74,37 +104,63 @@ var (
 		newAdapter(buflintcheck.CheckDirectorySamePackage),
 	)
 	// EnumFirstValueZeroRuleBuilder is a rule builder.
-	EnumFirstValueZeroRuleBuilder = internal.NewNopRuleBuilder(
+	EnumFirstValueZeroRuleBuilder = internal.NewNopFileRuleBuilder(
 		"ENUM_FIRST_VALUE_ZERO",
 		"all first values of enums have a numeric value of 0",
 		newAdapter(buflintcheck.CheckEnumFirstValueZero),
 	)
+	// EnumNamingRuleBuilder is a rule builder.
+	EnumNamingRuleBuilder = newNamingRuleBuilder(
+		"ENUM_NAMING",
+		buflintcheck.NamingKindEnum,
+		"enum names",
+		buflintcheck.CheckEnumNaming,
+	)
 	// EnumNoAllowAliasRuleBuilder is a rule builder.
-	EnumNoAllowAliasRuleBuilder = internal.NewNopRuleBuilder(
+	EnumNoAllowAliasRuleBuilder = internal.NewNopFileRuleBuilder(
 		"ENUM_NO_ALLOW_ALIAS",
 		"enums do not have the allow_alias option set",
 		newAdapter(buflintcheck.CheckEnumNoAllowAlias),
 	)
 	// EnumPascalCaseRuleBuilder is a rule builder.
-	EnumPascalCaseRuleBuilder = internal.NewNopRuleBuilder(
+	EnumPascalCaseRuleBuilder = internal.NewNopFileRuleBuilder(
 		"ENUM_PASCAL_CASE",
 		"enums are PascalCase",
 		newAdapter(buflintcheck.CheckEnumPascalCase),
 	)
+	// EnumUsedRuleBuilder is a rule builder.
+	EnumUsedRuleBuilder = newTypeUsedRuleBuilder(
+		"ENUM_USED",
+		"enums are referenced by an RPC, field, or extension (roots are configurable)",
+		buflintcheck.CheckEnumUsed,
+	)
+	// EnumValueNamingRuleBuilder is a rule builder.
+	EnumValueNamingRuleBuilder = newNamingRuleBuilder(
+		"ENUM_VALUE_NAMING",
+		buflintcheck.NamingKindEnumValue,
+		"enum value names",
+		buflintcheck.CheckEnumValueNaming,
+	)
+	// EnumValueNumberContiguousRuleBuilder is a rule builder.
+	EnumValueNumberContiguousRuleBuilder = internal.NewNopFileRuleBuilder(
+		"ENUM_VALUE_NUMBER_CONTIGUOUS",
+		"enum value numbers are contiguous, or the gaps are reserved",
+		newAdapter(buflintcheck.CheckEnumValueNumberContiguous),
+	)
 	// EnumValuePrefixRuleBuilder is a rule builder.
-	EnumValuePrefixRuleBuilder = internal.NewNopRuleBuilder(
+	EnumValuePrefixRuleBuilder = internal.NewNopFileRuleBuilder(
 		"ENUM_VALUE_PREFIX",
 		"enum values are prefixed with ENUM_NAME_UPPER_SNAKE_CASE",
 		newAdapter(buflintcheck.CheckEnumValuePrefix),
 	)
 	// EnumValueUpperSnakeCaseRuleBuilder is a rule builder.
-	EnumValueUpperSnakeCaseRuleBuilder = internal.NewNopRuleBuilder(
+	EnumValueUpperSnakeCaseRuleBuilder = internal.NewNopFileRuleBuilder(
 		"ENUM_VALUE_UPPER_SNAKE_CASE",
 		"enum values are UPPER_SNAKE_CASE",
 		newAdapter(buflintcheck.CheckEnumValueUpperSnakeCase),
 	)
 	// EnumZeroValueSuffixRuleBuilder is a rule builder.
-	EnumZeroValueSuffixRuleBuilder = internal.NewRuleBuilder(
+	EnumZeroValueSuffixRuleBuilder = internal.NewFileRuleBuilder(
 		"ENUM_ZERO_VALUE_SUFFIX",
 		func(configBuilder internal.ConfigBuilder) (string, error) {
 			if configBuilder.EnumZeroValueSuffix == "" {
@@ -122,71 +178,130 @@ var (
 		},
 	)
 	// FieldLowerSnakeCaseRuleBuilder is a rule builder.
-	FieldLowerSnakeCaseRuleBuilder = internal.NewNopRuleBuilder(
+	FieldLowerSnakeCaseRuleBuilder = internal.NewNopFileRuleBuilder(
 		"FIELD_LOWER_SNAKE_CASE",
 		"field names are lower_snake_case",
 		newAdapter(buflintcheck.CheckFieldLowerSnakeCase),
 	)
+	// FieldNamingRuleBuilder is a rule builder.
+	FieldNamingRuleBuilder = newNamingRuleBuilder(
+		"FIELD_NAMING",
+		buflintcheck.NamingKindField,
+		"field names",
+		buflintcheck.CheckFieldNaming,
+	)
 	// FieldNoDescriptorRuleBuilder is a rule builder.
-	FieldNoDescriptorRuleBuilder = internal.NewNopRuleBuilder(
+	FieldNoDescriptorRuleBuilder = internal.NewNopFileRuleBuilder(
 		"FIELD_NO_DESCRIPTOR",
 		`field names are not name capitalization of "descriptor" with any number of prefix or suffix underscores`,
 		newAdapter(buflintcheck.CheckFieldNoDescriptor),
 	)
+	// FieldNumberContiguousRuleBuilder is a rule builder.
+	FieldNumberContiguousRuleBuilder = internal.NewNopFileRuleBuilder(
+		"FIELD_NUMBER_CONTIGUOUS",
+		"field numbers are contiguous from 1, or the gaps are reserved",
+		newAdapter(buflintcheck.CheckFieldNumberContiguous),
+	)
+	// FieldNumberLowFirstRuleBuilder is a rule builder.
+	FieldNumberLowFirstRuleBuilder = internal.NewFileRuleBuilder(
+		"FIELD_NUMBER_LOW_FIRST",
+		func(configBuilder internal.ConfigBuilder) (string, error) {
+			return "field numbers 1 to 15, which are encoded with a one-byte tag, are used or reserved before higher field numbers in the messages listed in field_number_low_first_messages", nil
+		},
+		func(configBuilder internal.ConfigBuilder) (internal.CheckFunc, error) {
+			return internal.CheckFunc(func(id string, ignoreFunc internal.IgnoreFunc, _ []protosource.File, files []protosource.File) ([]bufanalysis.FileAnnotation, error) {
+				return buflintcheck.CheckFieldNumberLowFirst(id, ignoreFunc, files, configBuilder.FieldNumberLowFirstMessages)
+			}), nil
+		},
+	)
 	// FileLowerSnakeCaseRuleBuilder is a rule builder.
-	FileLowerSnakeCaseRuleBuilder = internal.NewNopRuleBuilder(
+	FileLowerSnakeCaseRuleBuilder = internal.NewNopFileRuleBuilder(
 		"FILE_LOWER_SNAKE_CASE",
 		"filenames are lower_snake_case",
 		newAdapter(buflintcheck.CheckFileLowerSnakeCase),
 	)
+	// FileNamingRuleBuilder is a rule builder.
+	FileNamingRuleBuilder = newNamingRuleBuilder(
+		"FILE_NAMING",
+		buflintcheck.NamingKindFile,
+		"file paths",
+		buflintcheck.CheckFileNaming,
+	)
 	// ImportNoPublicRuleBuilder is a rule builder.
-	ImportNoPublicRuleBuilder = internal.NewNopRuleBuilder(
+	ImportNoPublicRuleBuilder = internal.NewNopFileRuleBuilder(
 		"IMPORT_NO_PUBLIC",
 		"imports are not public",
 		newAdapter(buflintcheck.CheckImportNoPublic),
 	)
 	// ImportNoWeakRuleBuilder is a rule builder.
-	ImportNoWeakRuleBuilder = internal.NewNopRuleBuilder(
+	ImportNoWeakRuleBuilder = internal.NewNopFileRuleBuilder(
 		"IMPORT_NO_WEAK",
 		"imports are not weak",
 		newAdapter(buflintcheck.CheckImportNoWeak),
 	)
 	// ImportUsedRuleBuilder is a rule builder.
-	ImportUsedRuleBuilder = internal.NewNopRuleBuilder(
+	ImportUsedRuleBuilder = internal.NewNopFileRuleBuilder(
 		"IMPORT_USED",
 		"imports are used",
 		newAdapter(buflintcheck.CheckImportUsed),
 	)
+	// MessageNamingRuleBuilder is a rule builder.
+	MessageNamingRuleBuilder = newNamingRuleBuilder(
+		"MESSAGE_NAMING",
+		buflintcheck.NamingKindMessage,
+		"message names",
+		buflintcheck.CheckMessageNaming,
+	)
 	// MessagePascalCaseRuleBuilder is a rule builder.
-	MessagePascalCaseRuleBuilder = internal.NewNopRuleBuilder(
+	MessagePascalCaseRuleBuilder = internal.NewNopFileRuleBuilder(
 		"MESSAGE_PASCAL_CASE",
 		"messages are PascalCase",
 		newAdapter(buflintcheck.CheckMessagePascalCase),
 	)
+	// MessageUsedRuleBuilder is a rule builder.
+	MessageUsedRuleBuilder = newTypeUsedRuleBuilder(
+		"MESSAGE_USED",
+		"messages are referenced by an RPC, field, or extension (roots are configurable)",
+		buflintcheck.CheckMessageUsed,
+	)
 	// OneofLowerSnakeCaseRuleBuilder is a rule builder.
-	OneofLowerSnakeCaseRuleBuilder = internal.NewNopRuleBuilder(
+	OneofLowerSnakeCaseRuleBuilder = internal.NewNopFileRuleBuilder(
 		"ONEOF_LOWER_SNAKE_CASE",
 		"oneof names are lower_snake_case",
 		newAdapter(buflintcheck.CheckOneofLowerSnakeCase),
 	)
+	// OneofNamingRuleBuilder is a rule builder.
+	OneofNamingRuleBuilder = newNamingRuleBuilder(
+		"ONEOF_NAMING",
+		buflintcheck.NamingKindOneof,
+		"oneof names",
+		buflintcheck.CheckOneofNaming,
+	)
 	// PackageDefinedRuleBuilder is a rule builder.
-	PackageDefinedRuleBuilder = internal.NewNopRuleBuilder(
+	PackageDefinedRuleBuilder = internal.NewNopFileRuleBuilder(
 		"PACKAGE_DEFINED",
 		"all files have a package defined",
 		newAdapter(buflintcheck.CheckPackageDefined),
 	)
 	// PackageDirectoryMatchRuleBuilder is a rule builder.
-	PackageDirectoryMatchRuleBuilder = internal.NewNopRuleBuilder(
+	PackageDirectoryMatchRuleBuilder = internal.NewNopFileRuleBuilder(
 		"PACKAGE_DIRECTORY_MATCH",
 		"all files are in a directory that matches their package name",
 		newAdapter(buflintcheck.CheckPackageDirectoryMatch),
 	)
 	// PackageLowerSnakeCaseRuleBuilder is a rule builder.
-	PackageLowerSnakeCaseRuleBuilder = internal.NewNopRuleBuilder(
+	PackageLowerSnakeCaseRuleBuilder = internal.NewNopFileRuleBuilder(
 		"PACKAGE_LOWER_SNAKE_CASE",
 		"packages are lower_snake.case",
 		newAdapter(buflintcheck.CheckPackageLowerSnakeCase),
 	)
+	// PackageNamingRuleBuilder is a rule builder.
+	PackageNamingRuleBuilder = newNamingRuleBuilder(
+		"PACKAGE_NAMING",
+		buflintcheck.NamingKindPackage,
+		"package names",
+		buflintcheck.CheckPackageNaming,
+	)
 	// PackageNoImportCycleRuleBuilder is a rule builder.
 	PackageNoImportCycleRuleBuilder = internal.NewNopRuleBuilder(
 		"PACKAGE_NO_IMPORT_CYCLE",
@@ -242,31 +357,50 @@ var (
 		newAdapter(buflintcheck.CheckPackageSameSwiftPrefix),
 	)
 	// PackageVersionSuffixRuleBuilder is a rule builder.
-	PackageVersionSuffixRuleBuilder = internal.NewNopRuleBuilder(
+	PackageVersionSuffixRuleBuilder = internal.NewNopFileRuleBuilder(
 		"PACKAGE_VERSION_SUFFIX",
 		`the last component of all packages is a version of the form v\d+, v\d+test.*, v\d+(alpha|beta)\d+, or v\d+p\d+(alpha|beta)\d+, where numbers are >=1`,
 		newAdapter(buflintcheck.CheckPackageVersionSuffix),
 	)
 	// ProtovalidateRuleBuilder is a rule builder.
-	ProtovalidateRuleBuilder = internal.NewNopRuleBuilder(
+	ProtovalidateRuleBuilder = internal.NewNopFileRuleBuilder(
 		"PROTOVALIDATE",
 		"protovalidate rules are valid and all CEL expressions compile",
 		newAdapter(buflintcheck.CheckProtovalidate),
 	)
+	// ProtovalidateStrictRuleBuilder is a rule builder.
+	ProtovalidateStrictRuleBuilder = internal.NewNopFileRuleBuilder(
+		"PROTOVALIDATE_STRICT",
+		"protovalidate required rules are only on fields with presence, and duration and timestamp bounds are not inverted",
+		newAdapter(buflintcheck.CheckProtovalidateStrict),
+	)
+	// ReservedNameWithNumberRuleBuilder is a rule builder.
+	ReservedNameWithNumberRuleBuilder = internal.NewNopFileRuleBuilder(
+		"RESERVED_NAME_WITH_NUMBER",
+		"reserved names are accompanied by reserved numbers",
+		newAdapter(buflintcheck.CheckReservedNameWithNumber),
+	)
+	// RPCNamingRuleBuilder is a rule builder.
+	RPCNamingRuleBuilder = newNamingRuleBuilder(
+		"RPC_NAMING",
+		buflintcheck.NamingKindRPC,
+		"RPC names",
+		buflintcheck.CheckRPCNaming,
+	)
 	// RPCNoClientStreamingRuleBuilder is a rule builder.
-	RPCNoClientStreamingRuleBuilder = internal.NewNopRuleBuilder(
+	RPCNoClientStreamingRuleBuilder = internal.NewNopFileRuleBuilder(
 		"RPC_NO_CLIENT_STREAMING",
 		"RPCs are not client streaming",
 		newAdapter(buflintcheck.CheckRPCNoClientStreaming),
 	)
 	// RPCNoServerStreamingRuleBuilder is a rule builder.
-	RPCNoServerStreamingRuleBuilder = internal.NewNopRuleBuilder(
+	RPCNoServerStreamingRuleBuilder = internal.NewNopFileRuleBuilder(
 		"RPC_NO_SERVER_STREAMING",
 		"RPCs are not server streaming",
 		newAdapter(buflintcheck.CheckRPCNoServerStreaming),
 	)
 	// RPCPascalCaseRuleBuilder is a rule builder.
-	RPCPascalCaseRuleBuilder = internal.NewNopRuleBuilder(
+	RPCPascalCaseRuleBuilder = internal.NewNopFileRuleBuilder(
 		"RPC_PASCAL_CASE",
 		"RPCs are PascalCase",
 		newAdapter(buflintcheck.CheckRPCPascalCase),
@@ -291,7 +425,7 @@ var (
 		},
 	)
 	// RPCRequestStandardNameRuleBuilder is a rule builder.
-	RPCRequestStandardNameRuleBuilder = internal.NewRuleBuilder(
+	RPCRequestStandardNameRuleBuilder = internal.NewFileRuleBuilder(
 		"RPC_REQUEST_STANDARD_NAME",
 		func(configBuilder internal.ConfigBuilder) (string, error) {
 			return "RPC request type names are RPCNameRequest or ServiceNameRPCNameRequest (configurable)", nil
@@ -308,7 +442,7 @@ var (
 		},
 	)
 	// RPCResponseStandardNameRuleBuilder is a rule builder.
-	RPCResponseStandardNameRuleBuilder = internal.NewRuleBuilder(
+	RPCResponseStandardNameRuleBuilder = internal.NewFileRuleBuilder(
 		"RPC_RESPONSE_STANDARD_NAME",
 		func(configBuilder internal.ConfigBuilder) (string, error) {
 			return "RPC response type names are RPCNameResponse or ServiceNameRPCNameResponse (configurable)", nil
@@ -324,14 +458,21 @@ var (
 			}), nil
 		},
 	)
+	// ServiceNamingRuleBuilder is a rule builder.
+	ServiceNamingRuleBuilder = newNamingRuleBuilder(
+		"SERVICE_NAMING",
+		buflintcheck.NamingKindService,
+		"service names",
+		buflintcheck.CheckServiceNaming,
+	)
 	// ServicePascalCaseRuleBuilder is a rule builder.
-	ServicePascalCaseRuleBuilder = internal.NewNopRuleBuilder(
+	ServicePascalCaseRuleBuilder = internal.NewNopFileRuleBuilder(
 		"SERVICE_PASCAL_CASE",
 		"services are PascalCase",
 		newAdapter(buflintcheck.CheckServicePascalCase),
 	)
 	// ServiceSuffixRuleBuilder is a rule builder.
-	ServiceSuffixRuleBuilder = internal.NewRuleBuilder(
+	ServiceSuffixRuleBuilder = internal.NewFileRuleBuilder(
 		"SERVICE_SUFFIX",
 		func(configBuilder internal.ConfigBuilder) (string, error) {
 			if configBuilder.ServiceSuffix == "" {
@@ -349,7 +490,7 @@ var (
 		},
 	)
 	// SyntaxSpecifiedRuleBuilder is a rule builder.
-	SyntaxSpecifiedRuleBuilder = internal.NewNopRuleBuilder(
+	SyntaxSpecifiedRuleBuilder = internal.NewNopFileRuleBuilder(
 		"SYNTAX_SPECIFIED",
 		"all files have a syntax specified",
 		newAdapter(buflintcheck.CheckSyntaxSpecified),
@@ -363,3 +504,54 @@ func newAdapter(
 		return f(id, ignoreFunc, files)
 	}
 }
+
+// newNamingRuleBuilder returns a new RuleBuilder for the naming rules of the kind.
+//
+// The rule does nothing if there are no naming rules of the kind.
+func newNamingRuleBuilder(
+	id string,
+	kind string,
+	elementsDescription string,
+	f func(string, internal.IgnoreFunc, []protosource.File, []*buflintcheck.NamingConstraint) ([]bufanalysis.FileAnnotation, error),
+) *internal.RuleBuilder {
+	return internal.NewFileRuleBuilder(
+		id,
+		func(configBuilder internal.ConfigBuilder) (string, error) {
+			return elementsDescription + " match the naming rules (naming rules are configurable)", nil
+		},
+		func(configBuilder internal.ConfigBuilder) (internal.CheckFunc, error) {
+			namingConstraints, err := buflintcheck.NewNamingConstraints(configBuilder.NamingRules, kind)
+			if err != nil {
+				return nil, err
+			}
+			return internal.CheckFunc(func(id string, ignoreFunc internal.IgnoreFunc, _ []protosource.File, files []protosource.File) ([]bufanalysis.FileAnnotation, error) {
+				return f(id, ignoreFunc, files, namingConstraints)
+			}), nil
+		},
+	)
+}
+
+// newTypeUsedRuleBuilder returns a new RuleBuilder for a *_USED type rule.
+func newTypeUsedRuleBuilder(
+	id string,
+	purpose string,
+	checkFunc func(string, internal.IgnoreFunc, []protosource.File, []string, []string) ([]bufanalysis.FileAnnotation, error),
+) *internal.RuleBuilder {
+	return internal.NewRuleBuilder(
+		id,
+		func(configBuilder internal.ConfigBuilder) (string, error) {
+			return purpose, nil
+		},
+		func(configBuilder internal.ConfigBuilder) (internal.CheckFunc, error) {
+			return internal.CheckFunc(func(id string, ignoreFunc internal.IgnoreFunc, _ []protosource.File, files []protosource.File) ([]bufanalysis.FileAnnotation, error) {
+				return checkFunc(
+					id,
+					ignoreFunc,
+					files,
+					configBuilder.TypeUsedRootOptions,
+					configBuilder.TypeUsedRootTypes,
+				)
+			}), nil
+		},
+	)
+}
@@ -20,6 +20,7 @@ package buflintcheck
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -31,6 +32,8 @@ import (
 	"github.com/bufbuild/buf/private/pkg/protoversion"
 	"github.com/bufbuild/buf/private/pkg/slicesext"
 	"github.com/bufbuild/buf/private/pkg/stringutil"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/types/descriptorpb"
 )
 
 const (
@@ -42,6 +45,376 @@ const (
 	CommentIgnorePrefix = "buf:lint:ignore"
 )
 
+// CheckAIPFieldBehavior is a check function.
+var CheckAIPFieldBehavior = newFilesWithImportsCheckFunc(checkAIPFieldBehavior)
+
+func checkAIPFieldBehavior(add addFunc, files []protosource.File) error {
+	fullNameToMessage, err := protosource.FullNameToMessage(files...)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if file.IsImport() {
+			continue
+		}
+		if err := protosource.ForEachMessage(
+			func(message protosource.Message) error {
+				for _, field := range message.Fields() {
+					if err := checkAIPFieldBehaviorConflicts(add, field); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+			file,
+		); err != nil {
+			return err
+		}
+		// A request message is typically used by a single standard method, but
+		// we make sure to only report each field once.
+		checkedFieldFullNames := make(map[string]struct{})
+		for _, service := range file.Services() {
+			for _, method := range service.Methods() {
+				standardMethod, ok := parseAIPStandardMethod(method.Name())
+				if !ok {
+					continue
+				}
+				request, ok := fullNameToMessage[method.InputTypeName()]
+				if !ok || request.File().IsImport() {
+					continue
+				}
+				for _, fieldName := range standardMethod.requiredRequestFieldNames() {
+					field := messageFieldForName(request, fieldName)
+					if field == nil {
+						// A missing field is reported by AIP_STANDARD_METHOD.
+						continue
+					}
+					if _, ok := checkedFieldFullNames[field.FullName()]; ok {
+						continue
+					}
+					checkedFieldFullNames[field.FullName()] = struct{}{}
+					isRequired, err := fieldHasBehavior(field, annotations.FieldBehavior_REQUIRED)
+					if err != nil {
+						return err
+					}
+					if !isRequired {
+						add(
+							field,
+							field.NameLocation(),
+							[]protosource.Location{
+								request.Location(),
+							},
+							"Field %q on request %q of standard method %q should be annotated with (google.api.field_behavior) = REQUIRED.",
+							field.Name(),
+							request.Name(),
+							method.Name(),
+						)
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func checkAIPFieldBehaviorConflicts(add addFunc, field protosource.Field) error {
+	for _, conflictingFieldBehaviors := range aipConflictingFieldBehaviors {
+		hasFirst, err := fieldHasBehavior(field, conflictingFieldBehaviors[0])
+		if err != nil {
+			return err
+		}
+		hasSecond, err := fieldHasBehavior(field, conflictingFieldBehaviors[1])
+		if err != nil {
+			return err
+		}
+		if hasFirst && hasSecond {
+			add(
+				field,
+				field.OptionExtensionLocation(annotations.E_FieldBehavior),
+				[]protosource.Location{
+					field.NameLocation(),
+				},
+				"Field %q has conflicting field behaviors %s and %s.",
+				field.Name(),
+				conflictingFieldBehaviors[0].String(),
+				conflictingFieldBehaviors[1].String(),
+			)
+		}
+	}
+	return nil
+}
+
+// CheckAIPPagination is a check function.
+var CheckAIPPagination = newFilesWithImportsCheckFunc(checkAIPPagination)
+
+func checkAIPPagination(add addFunc, files []protosource.File) error {
+	fullNameToMessage, err := protosource.FullNameToMessage(files...)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if file.IsImport() {
+			continue
+		}
+		for _, service := range file.Services() {
+			for _, method := range service.Methods() {
+				standardMethod, ok := parseAIPStandardMethod(method.Name())
+				if !ok || standardMethod.verb != aipStandardMethodVerbList {
+					continue
+				}
+				if request, ok := fullNameToMessage[method.InputTypeName()]; ok && !request.File().IsImport() {
+					checkAIPMessageHasField(add, request, "List request", "page_size", descriptorpb.FieldDescriptorProto_TYPE_INT32, "")
+					checkAIPMessageHasField(add, request, "List request", "page_token", descriptorpb.FieldDescriptorProto_TYPE_STRING, "")
+				}
+				if response, ok := fullNameToMessage[method.OutputTypeName()]; ok && !response.File().IsImport() {
+					checkAIPMessageHasField(add, response, "List response", "next_page_token", descriptorpb.FieldDescriptorProto_TYPE_STRING, "")
+					if slicesext.Count(
+						response.Fields(),
+						func(field protosource.Field) bool {
+							return field.Label() == descriptorpb.FieldDescriptorProto_LABEL_REPEATED
+						},
+					) == 0 {
+						add(
+							response,
+							response.NameLocation(),
+							nil,
+							"List response %q should have a repeated field containing the listed resources.",
+							response.Name(),
+						)
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// CheckAIPResource is a check function.
+var CheckAIPResource = newFilesWithImportsCheckFunc(checkAIPResource)
+
+func checkAIPResource(add addFunc, files []protosource.File) error {
+	// The resources of standard Get methods, so that we can check that they are annotated.
+	resourceFullNameToGetMethodName := make(map[string]string)
+	for _, file := range files {
+		if file.IsImport() {
+			continue
+		}
+		for _, service := range file.Services() {
+			for _, method := range service.Methods() {
+				standardMethod, ok := parseAIPStandardMethod(method.Name())
+				if !ok || standardMethod.verb != aipStandardMethodVerbGet {
+					continue
+				}
+				// A response type that is not named after the resource is reported by
+				// AIP_STANDARD_METHOD, and is not considered a resource.
+				if typeNameWithoutPackage(method.OutputTypeName()) != standardMethod.resource {
+					continue
+				}
+				if _, ok := resourceFullNameToGetMethodName[method.OutputTypeName()]; !ok {
+					resourceFullNameToGetMethodName[method.OutputTypeName()] = method.Name()
+				}
+			}
+		}
+	}
+	for _, file := range files {
+		if file.IsImport() {
+			continue
+		}
+		if err := protosource.ForEachMessage(
+			func(message protosource.Message) error {
+				return checkAIPResourceMessage(add, message, resourceFullNameToGetMethodName[message.FullName()])
+			},
+			file,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkAIPResourceMessage(add addFunc, message protosource.Message, getMethodName string) error {
+	value, ok, err := message.OptionExtension(annotations.E_Resource)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		if getMethodName != "" {
+			add(
+				message,
+				message.NameLocation(),
+				nil,
+				"Message %q is the resource of standard method %q and should be annotated with (google.api.resource).",
+				message.Name(),
+				getMethodName,
+			)
+		}
+		return nil
+	}
+	resourceDescriptor, ok := value.(*annotations.ResourceDescriptor)
+	if !ok {
+		return nil
+	}
+	resourceType := resourceDescriptor.GetType()
+	serviceName, kind, _ := strings.Cut(resourceType, "/")
+	if !strings.Contains(serviceName, ".") || kind != message.Name() {
+		add(
+			message,
+			message.OptionExtensionLocation(annotations.E_Resource, aipResourceDescriptorTypeFieldNumber),
+			[]protosource.Location{
+				message.Location(),
+			},
+			`Resource type %q of message %q should be of the form "{service.name}/%s".`,
+			resourceType,
+			message.Name(),
+			message.Name(),
+		)
+	}
+	if len(resourceDescriptor.GetPattern()) == 0 {
+		add(
+			message,
+			message.OptionExtensionLocation(annotations.E_Resource),
+			[]protosource.Location{
+				message.Location(),
+			},
+			"Resource %q should have at least one pattern.",
+			message.Name(),
+		)
+	}
+	nameFieldName := resourceDescriptor.GetNameField()
+	if nameFieldName == "" {
+		nameFieldName = aipResourceNameFieldName
+	}
+	checkAIPMessageHasField(add, message, "Resource", nameFieldName, descriptorpb.FieldDescriptorProto_TYPE_STRING, "")
+	return nil
+}
+
+// CheckAIPStandardMethod is a check function.
+var CheckAIPStandardMethod = newFilesWithImportsCheckFunc(checkAIPStandardMethod)
+
+func checkAIPStandardMethod(add addFunc, files []protosource.File) error {
+	fullNameToMessage, err := protosource.FullNameToMessage(files...)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if file.IsImport() {
+			continue
+		}
+		for _, service := range file.Services() {
+			for _, method := range service.Methods() {
+				standardMethod, ok := parseAIPStandardMethod(method.Name())
+				if !ok {
+					continue
+				}
+				checkAIPStandardMethodTypes(add, method, standardMethod)
+				request, ok := fullNameToMessage[method.InputTypeName()]
+				if !ok || request.File().IsImport() {
+					continue
+				}
+				switch standardMethod.verb {
+				case aipStandardMethodVerbGet, aipStandardMethodVerbDelete:
+					checkAIPMessageHasField(add, request, "Request", aipResourceNameFieldName, descriptorpb.FieldDescriptorProto_TYPE_STRING, "")
+				case aipStandardMethodVerbCreate:
+					checkAIPMessageHasField(add, request, "Request", standardMethod.resourceFieldName(), descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, standardMethod.resource)
+				case aipStandardMethodVerbUpdate:
+					checkAIPMessageHasField(add, request, "Request", standardMethod.resourceFieldName(), descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, standardMethod.resource)
+					checkAIPMessageHasField(add, request, "Request", "update_mask", descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, "google.protobuf.FieldMask")
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func checkAIPStandardMethodTypes(add addFunc, method protosource.Method, standardMethod *aipStandardMethod) {
+	extraIgnoreLocations := []protosource.Location{
+		method.Location(),
+		method.Service().Location(),
+	}
+	expectedRequestName := method.Name() + "Request"
+	if requestName := typeNameWithoutPackage(method.InputTypeName()); requestName != expectedRequestName {
+		add(
+			method,
+			method.InputTypeLocation(),
+			extraIgnoreLocations,
+			"Standard method %q should have request type %q but has %q.",
+			method.Name(),
+			expectedRequestName,
+			requestName,
+		)
+	}
+	expectedResponseNames := standardMethod.responseNames()
+	responseFullName := method.OutputTypeName()
+	if slicesext.Count(
+		expectedResponseNames,
+		func(expectedResponseName string) bool {
+			return typeNameMatches(responseFullName, expectedResponseName)
+		},
+	) == 0 {
+		add(
+			method,
+			method.OutputTypeLocation(),
+			extraIgnoreLocations,
+			"Standard method %q should have response type %s but has %q.",
+			method.Name(),
+			stringutil.SliceToHumanStringOrQuoted(expectedResponseNames),
+			typeNameWithoutPackage(responseFullName),
+		)
+	}
+}
+
+// checkAIPMessageHasField checks that the message has a field with the given name and type.
+//
+// If typeName is not empty, the field's type name must also match, either by full name
+// if typeName contains a '.', or by the name without the package otherwise.
+func checkAIPMessageHasField(
+	add addFunc,
+	message protosource.Message,
+	messageKind string,
+	fieldName string,
+	fieldType descriptorpb.FieldDescriptorProto_Type,
+	typeName string,
+) {
+	field := messageFieldForName(message, fieldName)
+	if field != nil && field.Type() == fieldType && field.Label() != descriptorpb.FieldDescriptorProto_LABEL_REPEATED {
+		if typeName == "" || typeNameMatches(field.TypeName(), typeName) {
+			return
+		}
+	}
+	if typeName == "" {
+		typeName = aipFieldTypeName(fieldType)
+	}
+	if field == nil {
+		add(
+			message,
+			message.NameLocation(),
+			nil,
+			"%s %q should have a field %q of type %s.",
+			messageKind,
+			message.Name(),
+			fieldName,
+			typeName,
+		)
+		return
+	}
+	typeLocation := field.TypeLocation()
+	if field.TypeName() != "" {
+		typeLocation = field.TypeNameLocation()
+	}
+	add(
+		field,
+		typeLocation,
+		[]protosource.Location{
+			message.Location(),
+		},
+		"Field %q of %s %q should be a singular field of type %s.",
+		fieldName,
+		strings.ToLower(messageKind),
+		message.Name(),
+		typeName,
+	)
+}
+
 var (
 	// CheckCommentEnum is a check function.
 	CheckCommentEnum = newEnumCheckFunc(checkCommentEnum)
@@ -103,6 +476,61 @@ func checkCommentNamedDescriptor(
 	return nil
 }
 
+// CheckDeprecationComment is a check function.
+var CheckDeprecationComment = newFileCheckFunc(checkDeprecationComment)
+
+func checkDeprecationComment(add addFunc, file protosource.File) error {
+	checkNamedDescriptor := func(namedDescriptor protosource.NamedDescriptor, deprecated bool, typeName string) {
+		if !deprecated {
+			return
+		}
+		location := namedDescriptor.Location()
+		if location == nil {
+			return
+		}
+		if !validLeadingComment(location.LeadingComments()) {
+			add(namedDescriptor, location, nil, "Deprecated %s %q should have a leading comment that explains why or when it will be removed.", typeName, namedDescriptor.Name())
+		}
+	}
+	if err := protosource.ForEachEnum(
+		func(enum protosource.Enum) error {
+			checkNamedDescriptor(enum, enum.Deprecated(), "enum")
+			for _, enumValue := range enum.Values() {
+				checkNamedDescriptor(enumValue, enumValue.Deprecated(), "enum value")
+			}
+			return nil
+		},
+		file,
+	); err != nil {
+		return err
+	}
+	if err := protosource.ForEachMessage(
+		func(message protosource.Message) error {
+			checkNamedDescriptor(message, message.Deprecated(), "message")
+			for _, field := range message.Fields() {
+				checkNamedDescriptor(field, field.Deprecated(), "field")
+			}
+			for _, extension := range message.Extensions() {
+				checkNamedDescriptor(extension, extension.Deprecated(), "extension")
+			}
+			return nil
+		},
+		file,
+	); err != nil {
+		return err
+	}
+	for _, extension := range file.Extensions() {
+		checkNamedDescriptor(extension, extension.Deprecated(), "extension")
+	}
+	for _, service := range file.Services() {
+		checkNamedDescriptor(service, service.Deprecated(), "service")
+		for _, method := range service.Methods() {
+			checkNamedDescriptor(method, method.Deprecated(), "RPC")
+		}
+	}
+	return nil
+}
+
 // CheckDirectorySamePackage is a check function.
 var CheckDirectorySamePackage = newDirToFilesCheckFunc(checkDirectorySamePackage)
 
@@ -132,6 +560,21 @@ func checkDirectorySamePackage(add addFunc, dirPath string, files []protosource.
 	return nil
 }
 
+// CheckEnumNaming is a check function.
+var CheckEnumNaming = func(
+	id string,
+	ignoreFunc internal.IgnoreFunc,
+	files []protosource.File,
+	namingConstraints []*NamingConstraint,
+) ([]bufanalysis.FileAnnotation, error) {
+	return newEnumCheckFunc(
+		func(add addFunc, enum protosource.Enum) error {
+			checkNaming(add, enum, enum.NameLocation(), nil, "Enum name", enum.Name(), namingConstraints)
+			return nil
+		},
+	)(id, ignoreFunc, files)
+}
+
 // CheckEnumNoAllowAlias is a check function.
 var CheckEnumNoAllowAlias = newEnumCheckFunc(checkEnumNoAllowAlias)
 
@@ -181,6 +624,97 @@ func checkEnumFirstValueZero(add addFunc, enum protosource.Enum) error {
 	return nil
 }
 
+// CheckEnumUsed is a check function.
+var CheckEnumUsed = func(
+	id string,
+	ignoreFunc internal.IgnoreFunc,
+	files []protosource.File,
+	rootOptions []string,
+	rootTypes []string,
+) ([]bufanalysis.FileAnnotation, error) {
+	return newFilesWithImportsCheckFunc(
+		func(add addFunc, files []protosource.File) error {
+			return checkEnumUsed(add, files, rootOptions, rootTypes)
+		},
+	)(id, ignoreFunc, files)
+}
+
+func checkEnumUsed(add addFunc, files []protosource.File, rootOptions []string, rootTypes []string) error {
+	usedFullNames, err := typeUsedFullNames(files, rootOptions, rootTypes)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if file.IsImport() {
+			continue
+		}
+		if err := protosource.ForEachEnum(
+			func(enum protosource.Enum) error {
+				if _, ok := usedFullNames[enum.FullName()]; !ok {
+					add(enum, enum.NameLocation(), nil, "Enum %q is not referenced by any RPC, field, or extension.", enum.Name())
+				}
+				return nil
+			},
+			file,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CheckEnumValueNaming is a check function.
+var CheckEnumValueNaming = func(
+	id string,
+	ignoreFunc internal.IgnoreFunc,
+	files []protosource.File,
+	namingConstraints []*NamingConstraint,
+) ([]bufanalysis.FileAnnotation, error) {
+	return newEnumValueCheckFunc(
+		func(add addFunc, enumValue protosource.EnumValue) error {
+			checkNaming(
+				add,
+				enumValue,
+				enumValue.NameLocation(),
+				[]protosource.Location{
+					enumValue.Enum().Location(),
+				},
+				"Enum value name",
+				enumValue.Name(),
+				namingConstraints,
+			)
+			return nil
+		},
+	)(id, ignoreFunc, files)
+}
+
+// CheckEnumValueNumberContiguous is a check function.
+var CheckEnumValueNumberContiguous = newEnumCheckFunc(checkEnumValueNumberContiguous)
+
+func checkEnumValueNumberContiguous(add addFunc, enum protosource.Enum) error {
+	values := enum.Values()
+	if len(values) == 0 {
+		return nil
+	}
+	numbers := make([]int, len(values))
+	for i, value := range values {
+		numbers[i] = value.Number()
+	}
+	sort.Ints(numbers)
+	gaps := numberGaps(numbers, numbers[0], numbers[len(numbers)-1], tagRangesToNumberRanges(enum.ReservedTagRanges()))
+	if len(gaps) > 0 {
+		add(
+			enum,
+			enum.NameLocation(),
+			nil,
+			"Enum %q has non-contiguous value numbers, %s should be used or reserved.",
+			enum.Name(),
+			numberRangesString(gaps),
+		)
+	}
+	return nil
+}
+
 // CheckEnumValuePrefix is a check function.
 var CheckEnumValuePrefix = newEnumValueCheckFunc(checkEnumValuePrefix)
 
@@ -264,6 +798,111 @@ func checkEnumZeroValueSuffix(add addFunc, enumValue protosource.EnumValue, suff
 	return nil
 }
 
+// CheckFieldNaming is a check function.
+var CheckFieldNaming = func(
+	id string,
+	ignoreFunc internal.IgnoreFunc,
+	files []protosource.File,
+	namingConstraints []*NamingConstraint,
+) ([]bufanalysis.FileAnnotation, error) {
+	return newFieldCheckFunc(
+		func(add addFunc, field protosource.Field) error {
+			checkNaming(
+				add,
+				field,
+				field.NameLocation(),
+				[]protosource.Location{
+					field.ParentMessage().Location(),
+				},
+				"Field name",
+				field.Name(),
+				namingConstraints,
+			)
+			return nil
+		},
+	)(id, ignoreFunc, files)
+}
+
+// CheckFieldNumberContiguous is a check function.
+var CheckFieldNumberContiguous = newMessageCheckFunc(checkFieldNumberContiguous)
+
+func checkFieldNumberContiguous(add addFunc, message protosource.Message) error {
+	numbers := messageFieldNumbers(message)
+	if len(numbers) == 0 {
+		return nil
+	}
+	gaps := numberGaps(numbers, 1, numbers[len(numbers)-1], messageCoveredNumberRanges(message))
+	if len(gaps) > 0 {
+		add(
+			message,
+			message.NameLocation(),
+			nil,
+			"Message %q has non-contiguous field numbers, %s should be used or reserved.",
+			message.Name(),
+			numberRangesString(gaps),
+		)
+	}
+	return nil
+}
+
+// CheckFieldNumberLowFirst is a check function.
+//
+// Only the messages with the given fully-qualified names are checked.
+var CheckFieldNumberLowFirst = func(
+	id string,
+	ignoreFunc internal.IgnoreFunc,
+	files []protosource.File,
+	messageNames []string,
+) ([]bufanalysis.FileAnnotation, error) {
+	if len(messageNames) == 0 {
+		return nil, nil
+	}
+	messageNameMap := make(map[string]struct{}, len(messageNames))
+	for _, messageName := range messageNames {
+		messageNameMap[messageName] = struct{}{}
+	}
+	return newMessageCheckFunc(
+		func(add addFunc, message protosource.Message) error {
+			if _, ok := messageNameMap[message.FullName()]; !ok {
+				return nil
+			}
+			return checkFieldNumberLowFirst(add, message)
+		},
+	)(id, ignoreFunc, files)
+}
+
+func checkFieldNumberLowFirst(add addFunc, message protosource.Message) error {
+	numbers := messageFieldNumbers(message)
+	if len(numbers) == 0 || numbers[len(numbers)-1] <= maxOneByteTagFieldNumber {
+		return nil
+	}
+	gaps := numberGaps(numbers, 1, maxOneByteTagFieldNumber, messageCoveredNumberRanges(message))
+	if len(gaps) == 0 {
+		return nil
+	}
+	// Report on the lowest-numbered field that requires a two-byte tag.
+	var firstHighField protosource.Field
+	for _, field := range message.Fields() {
+		if field.Number() > maxOneByteTagFieldNumber && (firstHighField == nil || field.Number() < firstHighField.Number()) {
+			firstHighField = field
+		}
+	}
+	add(
+		firstHighField,
+		firstHighField.NumberLocation(),
+		// also check the message for this comment ignore
+		[]protosource.Location{
+			message.Location(),
+		},
+		"Field %q has number %d which is encoded with a two-byte tag, but the one-byte tag %s on message %q should be used first.",
+		firstHighField.Name(),
+		firstHighField.Number(),
+		numberRangesString(gaps),
+		message.Name(),
+	)
+	return nil
+}
+
 // CheckFieldLowerSnakeCase is a check function.
 var CheckFieldLowerSnakeCase = newFieldCheckFunc(checkFieldLowerSnakeCase)
 
@@ -332,6 +971,21 @@ func checkFileLowerSnakeCase(add addFunc, file protosource.File) error {
 	return nil
 }
 
+// CheckFileNaming is a check function.
+var CheckFileNaming = func(
+	id string,
+	ignoreFunc internal.IgnoreFunc,
+	files []protosource.File,
+	namingConstraints []*NamingConstraint,
+) ([]bufanalysis.FileAnnotation, error) {
+	return newFileCheckFunc(
+		func(add addFunc, file protosource.File) error {
+			checkNaming(add, file, nil, nil, "File path", file.Path(), namingConstraints)
+			return nil
+		},
+	)(id, ignoreFunc, files)
+}
+
 var (
 	// CheckImportNoPublic is a check function.
 	CheckImportNoPublic = newFileImportCheckFunc(checkImportNoPublic)
@@ -363,6 +1017,21 @@ func checkImportUsed(add addFunc, fileImport protosource.FileImport) error {
 	return nil
 }
 
+// CheckMessageNaming is a check function.
+var CheckMessageNaming = func(
+	id string,
+	ignoreFunc internal.IgnoreFunc,
+	files []protosource.File,
+	namingConstraints []*NamingConstraint,
+) ([]bufanalysis.FileAnnotation, error) {
+	return newMessageCheckFunc(
+		func(add addFunc, message protosource.Message) error {
+			checkNaming(add, message, message.NameLocation(), nil, "Message name", message.Name(), namingConstraints)
+			return nil
+		},
+	)(id, ignoreFunc, files)
+}
+
 // CheckMessagePascalCase is a check function.
 var CheckMessagePascalCase = newMessageCheckFunc(checkMessagePascalCase)
 
@@ -379,6 +1048,49 @@ func checkMessagePascalCase(add addFunc, message protosource.Message) error {
 	return nil
 }
 
+// CheckMessageUsed is a check function.
+var CheckMessageUsed = func(
+	id string,
+	ignoreFunc internal.IgnoreFunc,
+	files []protosource.File,
+	rootOptions []string,
+	rootTypes []string,
+) ([]bufanalysis.FileAnnotation, error) {
+	return newFilesWithImportsCheckFunc(
+		func(add addFunc, files []protosource.File) error {
+			return checkMessageUsed(add, files, rootOptions, rootTypes)
+		},
+	)(id, ignoreFunc, files)
+}
+
+func checkMessageUsed(add addFunc, files []protosource.File, rootOptions []string, rootTypes []string) error {
+	usedFullNames, err := typeUsedFullNames(files, rootOptions, rootTypes)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if file.IsImport() {
+			continue
+		}
+		if err := protosource.ForEachMessage(
+			func(message protosource.Message) error {
+				if message.IsMapEntry() {
+					// map entries are used by their map fields
+					return nil
+				}
+				if _, ok := usedFullNames[message.FullName()]; !ok {
+					add(message, message.NameLocation(), nil, "Message %q is not referenced by any RPC, field, or extension.", message.Name())
+				}
+				return nil
+			},
+			file,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // CheckOneofLowerSnakeCase is a check function.
 var CheckOneofLowerSnakeCase = newOneofCheckFunc(checkOneofLowerSnakeCase)
 
@@ -456,6 +1168,52 @@ func checkPackageLowerSnakeCase(add addFunc, file protosource.File) error {
 	return nil
 }
 
+// CheckPackageNaming is a check function.
+var CheckPackageNaming = func(
+	id string,
+	ignoreFunc internal.IgnoreFunc,
+	files []protosource.File,
+	namingConstraints []*NamingConstraint,
+) ([]bufanalysis.FileAnnotation, error) {
+	return newFileCheckFunc(
+		func(add addFunc, file protosource.File) error {
+			if pkg := file.Package(); pkg != "" {
+				checkNaming(add, file, file.PackageLocation(), nil, "Package name", pkg, namingConstraints)
+			}
+			return nil
+		},
+	)(id, ignoreFunc, files)
+}
+
+// CheckOneofNaming is a check function.
+var CheckOneofNaming = func(
+	id string,
+	ignoreFunc internal.IgnoreFunc,
+	files []protosource.File,
+	namingConstraints []*NamingConstraint,
+) ([]bufanalysis.FileAnnotation, error) {
+	return newOneofCheckFunc(
+		func(add addFunc, oneof protosource.Oneof) error {
+			// if this is an implicit oneof for a proto3 optional field, do not error
+			if fields := oneof.Fields(); len(fields) == 1 && fields[0].Proto3Optional() {
+				return nil
+			}
+			checkNaming(
+				add,
+				oneof,
+				oneof.NameLocation(),
+				[]protosource.Location{
+					oneof.Message().Location(),
+				},
+				"Oneof name",
+				oneof.Name(),
+				namingConstraints,
+			)
+			return nil
+		},
+	)(id, ignoreFunc, files)
+}
+
 // CheckPackageNoImportCycle is a check function.
 //
 // Note that imports are not skipped via the helper, as we want to detect import cycles
@@ -636,6 +1394,96 @@ func checkProtovalidate(add addFunc, files []protosource.File) error {
 	return buflintvalidate.Check(add, files)
 }
 
+// CheckProtovalidateStrict is a check function.
+var CheckProtovalidateStrict = newFilesWithImportsCheckFunc(checkProtovalidateStrict)
+
+func checkProtovalidateStrict(add addFunc, files []protosource.File) error {
+	return buflintvalidate.CheckStrict(add, files)
+}
+
+// CheckReservedNameWithNumber is a check function.
+var CheckReservedNameWithNumber = newFileCheckFunc(checkReservedNameWithNumber)
+
+func checkReservedNameWithNumber(add addFunc, file protosource.File) error {
+	if err := protosource.ForEachEnum(
+		func(enum protosource.Enum) error {
+			checkReservedDescriptorNameWithNumber(add, enum, "Enum", enum.Name())
+			return nil
+		},
+		file,
+	); err != nil {
+		return err
+	}
+	return protosource.ForEachMessage(
+		func(message protosource.Message) error {
+			checkReservedDescriptorNameWithNumber(add, message, "Message", message.Name())
+			return nil
+		},
+		file,
+	)
+}
+
+func checkReservedDescriptorNameWithNumber(
+	add addFunc,
+	reservedDescriptor interface {
+		protosource.NamedDescriptor
+		protosource.ReservedDescriptor
+	},
+	descriptorType string,
+	name string,
+) {
+	reservedNames := reservedDescriptor.ReservedNames()
+	if len(reservedNames) == 0 {
+		return
+	}
+	var numReservedNumbers int
+	for _, reservedTagRange := range reservedDescriptor.ReservedTagRanges() {
+		numReservedNumbers += reservedTagRange.End() - reservedTagRange.Start() + 1
+		if numReservedNumbers >= len(reservedNames) {
+			return
+		}
+	}
+	// We cannot tell which reserved names are missing their numbers, so we report
+	// on the first reserved name.
+	add(
+		reservedNames[0],
+		reservedNames[0].Location(),
+		[]protosource.Location{
+			reservedDescriptor.Location(),
+		},
+		"%s %q reserves %d names but only %d numbers, the numbers of deleted elements should be reserved along with their names.",
+		descriptorType,
+		name,
+		len(reservedNames),
+		numReservedNumbers,
+	)
+}
+
+// CheckRPCNaming is a check function.
+var CheckRPCNaming = func(
+	id string,
+	ignoreFunc internal.IgnoreFunc,
+	files []protosource.File,
+	namingConstraints []*NamingConstraint,
+) ([]bufanalysis.FileAnnotation, error) {
+	return newMethodCheckFunc(
+		func(add addFunc, method protosource.Method) error {
+			checkNaming(
+				add,
+				method,
+				method.NameLocation(),
+				[]protosource.Location{
+					method.Service().Location(),
+				},
+				"RPC name",
+				method.Name(),
+				namingConstraints,
+			)
+			return nil
+		},
+	)(id, ignoreFunc, files)
+}
+
 // CheckRPCNoClientStreaming is a check function.
 var CheckRPCNoClientStreaming = newMethodCheckFunc(checkRPCNoClientStreaming)
 
@@ -938,6 +1786,21 @@ func checkRPCResponseStandardName(add addFunc, method protosource.Method, allowG
 	return nil
 }
 
+// CheckServiceNaming is a check function.
+var CheckServiceNaming = func(
+	id string,
+	ignoreFunc internal.IgnoreFunc,
+	files []protosource.File,
+	namingConstraints []*NamingConstraint,
+) ([]bufanalysis.FileAnnotation, error) {
+	return newServiceCheckFunc(
+		func(add addFunc, service protosource.Service) error {
+			checkNaming(add, service, service.NameLocation(), nil, "Service name", service.Name(), namingConstraints)
+			return nil
+		},
+	)(id, ignoreFunc, files)
+}
+
 // CheckServicePascalCase is a check function.
 var CheckServicePascalCase = newServiceCheckFunc(checkServicePascalCase)
 
@@ -15,14 +15,236 @@
 package buflintcheck
 
 import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"unicode"
 
 	"github.com/bufbuild/buf/private/bufpkg/bufanalysis"
 	"github.com/bufbuild/buf/private/bufpkg/bufcheck/internal"
+	"github.com/bufbuild/buf/private/pkg/normalpath"
 	"github.com/bufbuild/buf/private/pkg/protosource"
+	"github.com/bufbuild/buf/private/pkg/slicesext"
 	"github.com/bufbuild/buf/private/pkg/stringutil"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/types/descriptorpb"
 )
 
+const (
+	// maxOneByteTagFieldNumber is the largest field number that is encoded with a one-byte tag.
+	maxOneByteTagFieldNumber = 15
+	// firstImplementationReservedFieldNumber and lastImplementationReservedFieldNumber
+	// are the field numbers reserved for the Protobuf implementation, which cannot be used.
+	firstImplementationReservedFieldNumber = 19000
+	lastImplementationReservedFieldNumber  = 19999
+
+	aipStandardMethodVerbGet    = "Get"
+	aipStandardMethodVerbList   = "List"
+	aipStandardMethodVerbCreate = "Create"
+	aipStandardMethodVerbUpdate = "Update"
+	aipStandardMethodVerbDelete = "Delete"
+	// aipResourceNameFieldName is the default name of the field that contains the
+	// resource name, and the name of the field on Get and Delete requests.
+	aipResourceNameFieldName = "name"
+	aipParentFieldName       = "parent"
+	// aipResourceDescriptorTypeFieldNumber is the field number of google.api.ResourceDescriptor.type.
+	aipResourceDescriptorTypeFieldNumber = 1
+	aipLongRunningOperationFullName      = "google.longrunning.Operation"
+
+	// NamingKindPackage is the naming rule kind for packages.
+	NamingKindPackage = "package"
+	// NamingKindMessage is the naming rule kind for messages.
+	NamingKindMessage = "message"
+	// NamingKindField is the naming rule kind for fields.
+	NamingKindField = "field"
+	// NamingKindOneof is the naming rule kind for oneofs.
+	NamingKindOneof = "oneof"
+	// NamingKindEnum is the naming rule kind for enums.
+	NamingKindEnum = "enum"
+	// NamingKindEnumValue is the naming rule kind for enum values.
+	NamingKindEnumValue = "enum_value"
+	// NamingKindService is the naming rule kind for services.
+	NamingKindService = "service"
+	// NamingKindRPC is the naming rule kind for RPCs.
+	NamingKindRPC = "rpc"
+	// NamingKindFile is the naming rule kind for file paths.
+	NamingKindFile = "file"
+
+	namingStyleLowerSnakeCase = "lower_snake_case"
+	namingStyleUpperSnakeCase = "UPPER_SNAKE_CASE"
+	namingStylePascalCase     = "PascalCase"
+	namingStyleCamelCase      = "camelCase"
+)
+
+var (
+	aipStandardMethodVerbs = []string{
+		aipStandardMethodVerbGet,
+		aipStandardMethodVerbList,
+		aipStandardMethodVerbCreate,
+		aipStandardMethodVerbUpdate,
+		aipStandardMethodVerbDelete,
+	}
+	// aipConflictingFieldBehaviors are the pairs of field behaviors that cannot
+	// both be set on a field.
+	aipConflictingFieldBehaviors = [][2]annotations.FieldBehavior{
+		{annotations.FieldBehavior_REQUIRED, annotations.FieldBehavior_OPTIONAL},
+		{annotations.FieldBehavior_OUTPUT_ONLY, annotations.FieldBehavior_REQUIRED},
+		{annotations.FieldBehavior_OUTPUT_ONLY, annotations.FieldBehavior_INPUT_ONLY},
+	}
+	namingKinds = []string{
+		NamingKindPackage,
+		NamingKindMessage,
+		NamingKindField,
+		NamingKindOneof,
+		NamingKindEnum,
+		NamingKindEnumValue,
+		NamingKindService,
+		NamingKindRPC,
+		NamingKindFile,
+	}
+	namingStyleToConvertFunc = map[string]func(string) string{
+		namingStyleLowerSnakeCase: fieldToLowerSnakeCase,
+		namingStyleUpperSnakeCase: fieldToUpperSnakeCase,
+		namingStylePascalCase:     stringutil.ToPascalCase,
+		namingStyleCamelCase:      toCamelCase,
+	}
+	namingStyles = []string{
+		namingStyleLowerSnakeCase,
+		namingStyleUpperSnakeCase,
+		namingStylePascalCase,
+		namingStyleCamelCase,
+	}
+)
+
+// NamingConstraint is a validated naming rule.
+type NamingConstraint struct {
+	kind    string
+	pattern *regexp.Regexp
+	style   string
+	message string
+}
+
+// NewNamingConstraints validates the naming rules, and returns the NamingConstraints
+// for the naming rules of the given kind.
+//
+// All naming rules are validated, not only those of the given kind.
+func NewNamingConstraints(namingRules []*internal.NamingRule, kind string) ([]*NamingConstraint, error) {
+	var namingConstraints []*NamingConstraint
+	for _, namingRule := range namingRules {
+		if !slicesext.ElementsContained(namingKinds, []string{namingRule.Kind}) {
+			return nil, fmt.Errorf(
+				"unknown naming rule kind %q, must be one of %s",
+				namingRule.Kind,
+				stringutil.SliceToHumanStringOrQuoted(namingKinds),
+			)
+		}
+		if namingRule.Pattern == "" && namingRule.Style == "" {
+			return nil, fmt.Errorf("naming rule for kind %q must specify a pattern or a style", namingRule.Kind)
+		}
+		if namingRule.Style != "" {
+			if _, ok := namingStyleToConvertFunc[namingRule.Style]; !ok {
+				return nil, fmt.Errorf(
+					"unknown style %q for naming rule for kind %q, must be one of %s",
+					namingRule.Style,
+					namingRule.Kind,
+					stringutil.SliceToHumanStringOrQuoted(namingStyles),
+				)
+			}
+		}
+		var pattern *regexp.Regexp
+		if namingRule.Pattern != "" {
+			var err error
+			pattern, err = regexp.Compile(namingRule.Pattern)
+			if err != nil {
+				return nil, fmt.Errorf("invalid pattern %q for naming rule for kind %q: %w", namingRule.Pattern, namingRule.Kind, err)
+			}
+		}
+		if namingRule.Kind != kind {
+			continue
+		}
+		namingConstraints = append(
+			namingConstraints,
+			&NamingConstraint{
+				kind:    namingRule.Kind,
+				pattern: pattern,
+				style:   namingRule.Style,
+				message: namingRule.Message,
+			},
+		)
+	}
+	return namingConstraints, nil
+}
+
+// checkNaming adds a FileAnnotation for each NamingConstraint that the name violates.
+func checkNaming(
+	add addFunc,
+	descriptor protosource.Descriptor,
+	location protosource.Location,
+	extraIgnoreLocations []protosource.Location,
+	elementType string,
+	name string,
+	namingConstraints []*NamingConstraint,
+) {
+	for _, namingConstraint := range namingConstraints {
+		var defaultMessage string
+		if namingConstraint.style != "" {
+			if expectedName := namingConstraint.expectedName(name); name != expectedName {
+				defaultMessage = fmt.Sprintf("should be %s, such as %q", namingConstraint.style, expectedName)
+			}
+		}
+		if defaultMessage == "" && namingConstraint.pattern != nil && !namingConstraint.pattern.MatchString(name) {
+			defaultMessage = fmt.Sprintf("should match the pattern %q", namingConstraint.pattern.String())
+		}
+		if defaultMessage == "" {
+			continue
+		}
+		if namingConstraint.message != "" {
+			add(descriptor, location, extraIgnoreLocations, "%s %q is invalid: %s", elementType, name, namingConstraint.message)
+		} else {
+			add(descriptor, location, extraIgnoreLocations, "%s %q %s.", elementType, name, defaultMessage)
+		}
+	}
+}
+
+// expectedName returns the name converted to the style of the NamingConstraint.
+//
+// The style applies to each component of a package, and to the base name of
+// a file path without its extension.
+func (n *NamingConstraint) expectedName(name string) string {
+	convertFunc := namingStyleToConvertFunc[n.style]
+	switch n.kind {
+	case NamingKindPackage:
+		split := strings.Split(name, ".")
+		for i, elem := range split {
+			split[i] = convertFunc(elem)
+		}
+		return strings.Join(split, ".")
+	case NamingKindFile:
+		ext := normalpath.Ext(name)
+		baseWithoutExt := strings.TrimSuffix(normalpath.Base(name), ext)
+		expectedBase := convertFunc(baseWithoutExt) + ext
+		if dir := normalpath.Dir(name); dir != "." {
+			return normalpath.Join(dir, expectedBase)
+		}
+		return expectedBase
+	default:
+		return convertFunc(name)
+	}
+}
+
+// toCamelCase converts s to camelCase.
+func toCamelCase(s string) string {
+	pascalCase := stringutil.ToPascalCase(s)
+	if pascalCase == "" {
+		return ""
+	}
+	runes := []rune(pascalCase)
+	runes[0] = unicode.ToLower(runes[0])
+	return string(runes)
+}
+
 // addFunc adds a FileAnnotation.
 //
 // Both the Descriptor and Locations can be nil.
@@ -308,3 +530,361 @@ func newMethodCheckFunc(
 		},
 	)
 }
+
+// numberRange is an inclusive range of numbers.
+type numberRange struct {
+	start int
+	end   int
+}
+
+// messageFieldNumbers returns the sorted numbers of the fields of the message.
+func messageFieldNumbers(message protosource.Message) []int {
+	fields := message.Fields()
+	numbers := make([]int, len(fields))
+	for i, field := range fields {
+		numbers[i] = field.Number()
+	}
+	sort.Ints(numbers)
+	return numbers
+}
+
+// messageCoveredNumberRanges returns the ranges of the message that cannot be used by fields.
+func messageCoveredNumberRanges(message protosource.Message) []numberRange {
+	covered := tagRangesToNumberRanges(message.ReservedTagRanges())
+	for _, extensionRange := range message.ExtensionRanges() {
+		covered = append(covered, numberRange{start: extensionRange.Start(), end: extensionRange.End()})
+	}
+	return append(
+		covered,
+		numberRange{
+			start: firstImplementationReservedFieldNumber,
+			end:   lastImplementationReservedFieldNumber,
+		},
+	)
+}
+
+func tagRangesToNumberRanges(tagRanges []protosource.TagRange) []numberRange {
+	numberRanges := make([]numberRange, len(tagRanges))
+	for i, tagRange := range tagRanges {
+		numberRanges[i] = numberRange{start: tagRange.Start(), end: tagRange.End()}
+	}
+	return numberRanges
+}
+
+// numberGaps returns the ranges of numbers from start to end that are neither in
+// numbers nor covered by coveredNumberRanges.
+func numberGaps(numbers []int, start int, end int, coveredNumberRanges []numberRange) []numberRange {
+	covered := make([]numberRange, 0, len(numbers)+len(coveredNumberRanges))
+	for _, number := range numbers {
+		covered = append(covered, numberRange{start: number, end: number})
+	}
+	covered = append(covered, coveredNumberRanges...)
+	sort.Slice(covered, func(i int, j int) bool { return covered[i].start < covered[j].start })
+	var gaps []numberRange
+	next := start
+	for _, coveredRange := range covered {
+		if next > end {
+			break
+		}
+		if coveredRange.start > next {
+			gapEnd := coveredRange.start - 1
+			if gapEnd > end {
+				gapEnd = end
+			}
+			gaps = append(gaps, numberRange{start: next, end: gapEnd})
+		}
+		if coveredRange.end+1 > next {
+			next = coveredRange.end + 1
+		}
+	}
+	if next <= end {
+		gaps = append(gaps, numberRange{start: next, end: end})
+	}
+	return gaps
+}
+
+// numberRangesString returns a human-readable string for the ranges, such as
+// "number 3" or "numbers 3, 5 to 7".
+func numberRangesString(numberRanges []numberRange) string {
+	rangeStrings := make([]string, len(numberRanges))
+	for i, numberRange := range numberRanges {
+		if numberRange.start == numberRange.end {
+			rangeStrings[i] = strconv.Itoa(numberRange.start)
+		} else {
+			rangeStrings[i] = strconv.Itoa(numberRange.start) + " to " + strconv.Itoa(numberRange.end)
+		}
+	}
+	if len(numberRanges) == 1 && numberRanges[0].start == numberRanges[0].end {
+		return "number " + rangeStrings[0]
+	}
+	return "numbers " + strings.Join(rangeStrings, ", ")
+}
+
+// aipStandardMethod is a standard method as defined by https://google.aip.dev/130.
+type aipStandardMethod struct {
+	verb string
+	// resource is the name of the resource, or the plural name of
+	// the resource for List methods.
+	resource string
+}
+
+// parseAIPStandardMethod parses the method name as a standard method.
+//
+// Returns false if the method name is not of the form VerbResource
+// for one of the standard method verbs.
+func parseAIPStandardMethod(methodName string) (*aipStandardMethod, bool) {
+	for _, verb := range aipStandardMethodVerbs {
+		resource := strings.TrimPrefix(methodName, verb)
+		if resource != methodName && resource != "" && stringutil.IsUpperAlpha(rune(resource[0])) {
+			return &aipStandardMethod{
+				verb:     verb,
+				resource: resource,
+			}, true
+		}
+	}
+	return nil, false
+}
+
+// resourceFieldName returns the name of the field that contains the resource on
+// Create and Update requests.
+func (a *aipStandardMethod) resourceFieldName() string {
+	return fieldToLowerSnakeCase(a.resource)
+}
+
+// responseNames returns the allowed response type names. Names that contain
+// a '.' are full names, all other names are names without the package.
+func (a *aipStandardMethod) responseNames() []string {
+	switch a.verb {
+	case aipStandardMethodVerbGet:
+		return []string{a.resource}
+	case aipStandardMethodVerbList:
+		return []string{aipStandardMethodVerbList + a.resource + "Response"}
+	case aipStandardMethodVerbCreate, aipStandardMethodVerbUpdate:
+		return []string{a.resource, aipLongRunningOperationFullName}
+	case aipStandardMethodVerbDelete:
+		return []string{"google.protobuf.Empty", a.resource, aipLongRunningOperationFullName}
+	default:
+		return nil
+	}
+}
+
+// requiredRequestFieldNames returns the names of the request fields that should
+// be annotated as required if present.
+//
+// The parent field is not present on List and Create requests for top-level resources.
+func (a *aipStandardMethod) requiredRequestFieldNames() []string {
+	switch a.verb {
+	case aipStandardMethodVerbGet, aipStandardMethodVerbDelete:
+		return []string{aipResourceNameFieldName}
+	case aipStandardMethodVerbList:
+		return []string{aipParentFieldName}
+	case aipStandardMethodVerbCreate:
+		return []string{aipParentFieldName, a.resourceFieldName()}
+	case aipStandardMethodVerbUpdate:
+		return []string{a.resourceFieldName()}
+	default:
+		return nil
+	}
+}
+
+// fieldHasBehavior returns true if the field is annotated with the given
+// google.api.field_behavior.
+func fieldHasBehavior(field protosource.Field, fieldBehavior annotations.FieldBehavior) (bool, error) {
+	value, ok, err := field.OptionExtension(annotations.E_FieldBehavior)
+	if err != nil || !ok {
+		return false, err
+	}
+	fieldBehaviors, ok := value.([]annotations.FieldBehavior)
+	if !ok {
+		return false, nil
+	}
+	for _, otherFieldBehavior := range fieldBehaviors {
+		if otherFieldBehavior == fieldBehavior {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// messageFieldForName returns the field of the message with the given name, or nil.
+func messageFieldForName(message protosource.Message, name string) protosource.Field {
+	for _, field := range message.Fields() {
+		if field.Name() == name {
+			return field
+		}
+	}
+	return nil
+}
+
+// typeNameWithoutPackage returns the last component of the full name.
+func typeNameWithoutPackage(fullName string) string {
+	if index := strings.LastIndex(fullName, "."); index >= 0 {
+		return fullName[index+1:]
+	}
+	return fullName
+}
+
+// typeNameMatches returns true if the full name matches the name, which is
+// compared against the full name if it contains a '.', and against the
+// name without the package otherwise.
+func typeNameMatches(fullName string, name string) bool {
+	if strings.Contains(name, ".") {
+		return fullName == name
+	}
+	return typeNameWithoutPackage(fullName) == name
+}
+
+// aipFieldTypeName returns the name of the scalar type as written in a .proto file.
+func aipFieldTypeName(fieldType descriptorpb.FieldDescriptorProto_Type) string {
+	return strings.ToLower(strings.TrimPrefix(fieldType.String(), "TYPE_"))
+}
+
+// typeUsedFullNames returns the full names of the messages and enums that are
+// reachable from the roots of the given files.
+//
+// The roots are the request and response types of all RPCs, the messages and
+// enums that have any of the rootOptions set, and the messages and enums that
+// match rootTypes, either by full name or by package.
+//
+// A message is reachable if it is a root, if it is the type of a field of a reachable
+// message, if it is the type of an extension of a reachable message or of a message
+// defined in an import, or if it contains a reachable message or enum. Enums are
+// reachable in the same manner, except that they have no fields.
+func typeUsedFullNames(files []protosource.File, rootOptions []string, rootTypes []string) (map[string]struct{}, error) {
+	fullNameToMessage, err := protosource.FullNameToMessage(files...)
+	if err != nil {
+		return nil, err
+	}
+	fullNameToEnum, err := protosource.FullNameToEnum(files...)
+	if err != nil {
+		return nil, err
+	}
+	var extensions []protosource.Field
+	for _, file := range files {
+		extensions = append(extensions, file.Extensions()...)
+		if err := protosource.ForEachMessage(
+			func(message protosource.Message) error {
+				extensions = append(extensions, message.Extensions()...)
+				return nil
+			},
+			file,
+		); err != nil {
+			return nil, err
+		}
+	}
+	rootMessageOptionNumbers, rootEnumOptionNumbers, err := typeUsedRootOptionNumbers(extensions, rootOptions)
+	if err != nil {
+		return nil, err
+	}
+	usedFullNames := make(map[string]struct{})
+	var use func(string)
+	use = func(fullName string) {
+		if _, ok := usedFullNames[fullName]; ok {
+			return
+		}
+		if message, ok := fullNameToMessage[fullName]; ok {
+			usedFullNames[fullName] = struct{}{}
+			for _, field := range message.Fields() {
+				use(field.TypeName())
+			}
+			if parent := message.Parent(); parent != nil {
+				use(parent.FullName())
+			}
+			return
+		}
+		if enum, ok := fullNameToEnum[fullName]; ok {
+			usedFullNames[fullName] = struct{}{}
+			if parent := enum.Parent(); parent != nil {
+				use(parent.FullName())
+			}
+		}
+	}
+	for _, file := range files {
+		for _, service := range file.Services() {
+			for _, method := range service.Methods() {
+				use(method.InputTypeName())
+				use(method.OutputTypeName())
+			}
+		}
+	}
+	for fullName, message := range fullNameToMessage {
+		if typeUsedIsRoot(message, message.PresentExtensionNumbers(), rootMessageOptionNumbers, rootTypes) {
+			use(fullName)
+		}
+	}
+	for fullName, enum := range fullNameToEnum {
+		if typeUsedIsRoot(enum, enum.PresentExtensionNumbers(), rootEnumOptionNumbers, rootTypes) {
+			use(fullName)
+		}
+	}
+	// Extensions make their type reachable once their extendee is reachable, which
+	// may only happen after the type of another extension is reachable, so we iterate
+	// until no more types are found.
+	for {
+		numUsedFullNames := len(usedFullNames)
+		for _, extension := range extensions {
+			extendee, ok := fullNameToMessage[extension.Extendee()]
+			if !ok {
+				continue
+			}
+			if _, ok := usedFullNames[extendee.FullName()]; ok || extendee.File().IsImport() {
+				use(extension.TypeName())
+			}
+		}
+		if len(usedFullNames) == numUsedFullNames {
+			return usedFullNames, nil
+		}
+	}
+}
+
+// typeUsedRootOptionNumbers returns the field numbers of the rootOptions that extend
+// google.protobuf.MessageOptions and google.protobuf.EnumOptions respectively.
+//
+// Returns error if any of the rootOptions is not an extension within the extensions.
+func typeUsedRootOptionNumbers(extensions []protosource.Field, rootOptions []string) (map[int32]struct{}, map[int32]struct{}, error) {
+	messageOptionNumbers := make(map[int32]struct{})
+	enumOptionNumbers := make(map[int32]struct{})
+	for _, rootOption := range rootOptions {
+		var found bool
+		for _, extension := range extensions {
+			if extension.FullName() != rootOption {
+				continue
+			}
+			switch extension.Extendee() {
+			case "google.protobuf.MessageOptions":
+				messageOptionNumbers[int32(extension.Number())] = struct{}{}
+			case "google.protobuf.EnumOptions":
+				enumOptionNumbers[int32(extension.Number())] = struct{}{}
+			default:
+				return nil, nil, fmt.Errorf("type_used_root_options: %q is not a message or enum option", rootOption)
+			}
+			found = true
+			break
+		}
+		if !found {
+			return nil, nil, fmt.Errorf("type_used_root_options: unknown option %q", rootOption)
+		}
+	}
+	return messageOptionNumbers, enumOptionNumbers, nil
+}
+
+// typeUsedIsRoot returns true if the message or enum with the given present extension
+// numbers is a root for the *_USED type rules.
+func typeUsedIsRoot(
+	namedDescriptor protosource.NamedDescriptor,
+	presentExtensionNumbers []int32,
+	rootOptionNumbers map[int32]struct{},
+	rootTypes []string,
+) bool {
+	for _, presentExtensionNumber := range presentExtensionNumbers {
+		if _, ok := rootOptionNumbers[presentExtensionNumber]; ok {
+			return true
+		}
+	}
+	for _, rootType := range rootTypes {
+		if namedDescriptor.FullName() == rootType || namedDescriptor.File().Package() == rootType {
+			return true
+		}
+	}
+	return false
+}
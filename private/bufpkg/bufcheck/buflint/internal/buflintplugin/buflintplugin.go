@@ -0,0 +1,167 @@
+// Copyright 2020-2023 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package buflintplugin runs lint plugins.
+//
+// Lint plugins are binaries that read a buf.alpha.lint.v1.PluginRequest from stdin,
+// and write a buf.alpha.lint.v1.PluginResponse to stdout. The rules declared by a
+// plugin are turned into RuleBuilders, so that the annotations returned by the plugin
+// go through the same ignore and except logic as the builtin rules.
+package buflintplugin
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/bufbuild/buf/private/bufpkg/bufanalysis"
+	"github.com/bufbuild/buf/private/bufpkg/bufcheck/buflint/buflintconfig"
+	"github.com/bufbuild/buf/private/bufpkg/bufcheck/internal"
+	"github.com/bufbuild/buf/private/bufpkg/bufimage"
+	lintv1 "github.com/bufbuild/buf/private/gen/proto/go/buf/alpha/lint/v1"
+	"github.com/bufbuild/buf/private/pkg/command"
+	"github.com/bufbuild/buf/private/pkg/protoencoding"
+	"github.com/bufbuild/buf/private/pkg/protosource"
+)
+
+// NewRuleBuilders runs the plugin for the PluginConfig against the Image, and
+// returns a RuleBuilder for each rule declared by the plugin.
+//
+// The image should have source code info for this to work properly.
+func NewRuleBuilders(
+	ctx context.Context,
+	runner command.Runner,
+	pluginConfig *buflintconfig.PluginConfig,
+	image bufimage.Image,
+) ([]*internal.RuleBuilder, error) {
+	if pluginConfig.Path == "" {
+		return nil, errors.New("lint plugin path is empty")
+	}
+	response, err := runPlugin(ctx, runner, pluginConfig, image)
+	if err != nil {
+		return nil, err
+	}
+	if response.GetError() != "" {
+		return nil, fmt.Errorf("lint plugin %s: %s", pluginConfig.Path, response.GetError())
+	}
+	return newRuleBuildersForResponse(pluginConfig.Path, response)
+}
+
+func runPlugin(
+	ctx context.Context,
+	runner command.Runner,
+	pluginConfig *buflintconfig.PluginConfig,
+	image bufimage.Image,
+) (*lintv1.PluginResponse, error) {
+	requestData, err := protoencoding.NewWireMarshaler().Marshal(
+		&lintv1.PluginRequest{
+			Image:     bufimage.ImageToProtoImage(image),
+			Parameter: strings.Join(pluginConfig.Options, ","),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	responseBuffer := bytes.NewBuffer(nil)
+	stderrBuffer := bytes.NewBuffer(nil)
+	if err := runner.Run(
+		ctx,
+		pluginConfig.Path,
+		command.RunWithStdin(bytes.NewReader(requestData)),
+		command.RunWithStdout(responseBuffer),
+		command.RunWithStderr(stderrBuffer),
+	); err != nil {
+		if stderr := strings.TrimSpace(stderrBuffer.String()); stderr != "" {
+			return nil, fmt.Errorf("lint plugin %s: %w: %s", pluginConfig.Path, err, stderr)
+		}
+		return nil, fmt.Errorf("lint plugin %s: %w", pluginConfig.Path, err)
+	}
+	response := &lintv1.PluginResponse{}
+	if err := protoencoding.NewWireUnmarshaler(nil).Unmarshal(responseBuffer.Bytes(), response); err != nil {
+		return nil, fmt.Errorf("lint plugin %s: could not unmarshal response: %w", pluginConfig.Path, err)
+	}
+	return response, nil
+}
+
+func newRuleBuildersForResponse(pluginPath string, response *lintv1.PluginResponse) ([]*internal.RuleBuilder, error) {
+	ruleIDToAnnotations := make(map[string][]*lintv1.PluginAnnotation, len(response.GetRules()))
+	for _, rule := range response.GetRules() {
+		if rule.GetId() == "" {
+			return nil, fmt.Errorf("lint plugin %s: rule has empty ID", pluginPath)
+		}
+		if rule.GetPurpose() == "" {
+			return nil, fmt.Errorf("lint plugin %s: rule %q has empty purpose", pluginPath, rule.GetId())
+		}
+		if _, ok := ruleIDToAnnotations[rule.GetId()]; ok {
+			return nil, fmt.Errorf("lint plugin %s: duplicate rule ID %q", pluginPath, rule.GetId())
+		}
+		ruleIDToAnnotations[rule.GetId()] = nil
+	}
+	for _, annotation := range response.GetAnnotations() {
+		annotations, ok := ruleIDToAnnotations[annotation.GetRuleId()]
+		if !ok {
+			return nil, fmt.Errorf("lint plugin %s: annotation has undeclared rule ID %q", pluginPath, annotation.GetRuleId())
+		}
+		ruleIDToAnnotations[annotation.GetRuleId()] = append(annotations, annotation)
+	}
+	ruleBuilders := make([]*internal.RuleBuilder, 0, len(response.GetRules()))
+	for _, rule := range response.GetRules() {
+		ruleBuilders = append(
+			ruleBuilders,
+			internal.NewNopRuleBuilder(
+				rule.GetId(),
+				rule.GetPurpose(),
+				newCheckFunc(pluginPath, ruleIDToAnnotations[rule.GetId()]),
+			),
+		)
+	}
+	return ruleBuilders, nil
+}
+
+func newCheckFunc(pluginPath string, annotations []*lintv1.PluginAnnotation) internal.CheckFunc {
+	return func(
+		id string,
+		ignoreFunc internal.IgnoreFunc,
+		_ []protosource.File,
+		files []protosource.File,
+	) ([]bufanalysis.FileAnnotation, error) {
+		if len(annotations) == 0 {
+			return nil, nil
+		}
+		filePathToFile, err := protosource.FilePathToFile(files...)
+		if err != nil {
+			return nil, err
+		}
+		helper := internal.NewHelper(id, ignoreFunc)
+		for _, annotation := range annotations {
+			file, ok := filePathToFile[annotation.GetFilePath()]
+			if !ok {
+				return nil, fmt.Errorf("lint plugin %s: annotation for rule %q has unknown file path %q", pluginPath, id, annotation.GetFilePath())
+			}
+			// Our linters do not consider imports, and we hold plugins to the same standard.
+			if file.IsImport() {
+				continue
+			}
+			helper.AddFileAnnotationf(
+				file,
+				file.LocationForPath(annotation.GetPath()),
+				"%s",
+				annotation.GetMessage(),
+			)
+		}
+		return helper.FileAnnotations(), nil
+	}
+}
@@ -77,4 +77,5 @@ var VersionSpec = &internal.VersionSpec{
 	RuleBuilders:      v1RuleBuilders,
 	DefaultCategories: v1DefaultCategories,
 	IDToCategories:    v1IDToCategories,
+	IDToExplanation:   v1IDToExplanation,
 }
@@ -0,0 +1,825 @@
+// Copyright 2020-2023 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package buflintv1
+
+import "github.com/bufbuild/buf/private/bufpkg/bufcheck/internal"
+
+// v1IDToExplanation are the explanations of the rules, printed by buf lint --explain.
+var v1IDToExplanation = map[string]*internal.Explanation{
+	"AIP_FIELD_BEHAVIOR": {
+		Rationale: `AIP-203 uses google.api.field_behavior to document how clients and servers treat each field. A field that is both OUTPUT_ONLY and INPUT_ONLY, or both REQUIRED and OPTIONAL, cannot be honored, and the identifying fields of standard method requests are always required.`,
+		BadExample: `message GetBookRequest {
+  string name = 1;
+}`,
+		GoodExample: `message GetBookRequest {
+  string name = 1 [(google.api.field_behavior) = REQUIRED];
+}`,
+		Fix: `Remove one of the conflicting field behaviors, and annotate the name, parent and resource fields of standard method requests with (google.api.field_behavior) = REQUIRED.`,
+	},
+	"AIP_PAGINATION": {
+		Rationale: `AIP-158 requires List methods to be paginated from the start, as adding pagination later is a breaking change for clients that expect the full collection in one response.`,
+		BadExample: `message ListBooksRequest {
+  string parent = 1;
+}
+message ListBooksResponse {
+  repeated Book books = 1;
+}`,
+		GoodExample: `message ListBooksRequest {
+  string parent = 1;
+  int32 page_size = 2;
+  string page_token = 3;
+}
+message ListBooksResponse {
+  repeated Book books = 1;
+  string next_page_token = 2;
+}`,
+		Fix: `Add an int32 page_size and a string page_token field to the List request, and a string next_page_token field and a repeated field of the resources to the List response.`,
+	},
+	"AIP_RESOURCE": {
+		Rationale: `AIP-123 uses google.api.resource to declare the type and name patterns of resources, which clients and tooling use to construct and parse resource names.`,
+		BadExample: `message Book {
+  string name = 1;
+}`,
+		GoodExample: `message Book {
+  option (google.api.resource) = {
+    type: "library.googleapis.com/Book"
+    pattern: "shelves/{shelf}/books/{book}"
+  };
+  string name = 1;
+}`,
+		Fix: `Annotate the resource message of each standard Get method with (google.api.resource), with a type of the form "{service.name}/{Resource}", at least one pattern, and a string name field.`,
+	},
+	"AIP_STANDARD_METHOD": {
+		Rationale: `AIP-131 to AIP-135 define the request and response types and the request fields of the standard Get, List, Create, Update and Delete methods, so that clients can rely on a consistent shape for every resource.`,
+		BadExample: `service LibraryService {
+  rpc GetBook(BookRequest) returns (GetBookResponse);
+}`,
+		GoodExample: `service LibraryService {
+  rpc GetBook(GetBookRequest) returns (Book);
+}`,
+		Fix: `Name the request type after the method, return the resource from Get, Create and Update and google.protobuf.Empty from Delete, and add the name, resource, or update_mask fields that the method requires.`,
+	},
+	"COMMENT_ENUM": {
+		Rationale: `Leading comments are included in generated code and documentation. Enums without comments leave their meaning to be guessed from their name.`,
+		BadExample: `enum Status {
+  STATUS_UNSPECIFIED = 0;
+}`,
+		GoodExample: `// Status is the status of an order.
+enum Status {
+  STATUS_UNSPECIFIED = 0;
+}`,
+		Fix: `Add a non-empty leading comment to the enum. buf lint --fix adds a placeholder comment that you should then replace.`,
+	},
+	"COMMENT_ENUM_VALUE": {
+		Rationale: `Leading comments are included in generated code and documentation. Enum values without comments leave their meaning to be guessed from their name.`,
+		BadExample: `enum Status {
+  STATUS_UNSPECIFIED = 0;
+}`,
+		GoodExample: `enum Status {
+  // The status is not known.
+  STATUS_UNSPECIFIED = 0;
+}`,
+		Fix: `Add a non-empty leading comment to the enum value. buf lint --fix adds a placeholder comment that you should then replace.`,
+	},
+	"COMMENT_FIELD": {
+		Rationale: `Leading comments are included in generated code and documentation. Fields without comments leave their meaning, units, and constraints to be guessed from their name.`,
+		BadExample: `message Order {
+  int64 total = 1;
+}`,
+		GoodExample: `message Order {
+  // The total price of the order in cents.
+  int64 total = 1;
+}`,
+		Fix: `Add a non-empty leading comment to the field. buf lint --fix adds a placeholder comment that you should then replace.`,
+	},
+	"COMMENT_MESSAGE": {
+		Rationale:  `Leading comments are included in generated code and documentation. Messages without comments leave their purpose to be guessed from their name.`,
+		BadExample: `message Order {}`,
+		GoodExample: `// Order is an order placed by a customer.
+message Order {}`,
+		Fix: `Add a non-empty leading comment to the message. buf lint --fix adds a placeholder comment that you should then replace.`,
+	},
+	"COMMENT_ONEOF": {
+		Rationale: `Leading comments are included in generated code and documentation. Oneofs without comments leave the relationship between their fields to be guessed.`,
+		BadExample: `message Payment {
+  oneof method {
+    Card card = 1;
+    Transfer transfer = 2;
+  }
+}`,
+		GoodExample: `message Payment {
+  // The method of payment.
+  oneof method {
+    Card card = 1;
+    Transfer transfer = 2;
+  }
+}`,
+		Fix: `Add a non-empty leading comment to the oneof. buf lint --fix adds a placeholder comment that you should then replace.`,
+	},
+	"COMMENT_RPC": {
+		Rationale: `Leading comments are included in generated code and documentation. RPCs without comments leave their behavior and errors to be guessed from their name.`,
+		BadExample: `service OrderService {
+  rpc GetOrder(GetOrderRequest) returns (GetOrderResponse);
+}`,
+		GoodExample: `service OrderService {
+  // GetOrder returns the order with the given ID.
+  rpc GetOrder(GetOrderRequest) returns (GetOrderResponse);
+}`,
+		Fix: `Add a non-empty leading comment to the RPC. buf lint --fix adds a placeholder comment that you should then replace.`,
+	},
+	"COMMENT_SERVICE": {
+		Rationale:  `Leading comments are included in generated code and documentation. Services without comments leave their responsibilities to be guessed from their name.`,
+		BadExample: `service OrderService {}`,
+		GoodExample: `// OrderService manages orders.
+service OrderService {}`,
+		Fix: `Add a non-empty leading comment to the service. buf lint --fix adds a placeholder comment that you should then replace.`,
+	},
+	"DEPRECATION_COMMENT": {
+		Rationale: `Deprecating an element tells clients to stop using it, but not why, what to use instead, or when it will be removed. A leading comment gives clients the information they need to migrate.`,
+		BadExample: `message Order {
+  string customer = 1 [deprecated = true];
+}`,
+		GoodExample: `message Order {
+  // Deprecated: use customer_id instead. Will be removed in v2.
+  string customer = 1 [deprecated = true];
+}`,
+		Fix: `Add a leading comment to the deprecated element that explains why it is deprecated, what to use instead, or when it will be removed.`,
+	},
+	"DIRECTORY_SAME_PACKAGE": {
+		Rationale: `Many languages, such as Go, generate code for a directory as a single package. Files of different packages in the same directory generate code that does not compile, and make the layout of the module hard to follow.`,
+		BadExample: `// acme/v1/a.proto
+package acme.v1;
+// acme/v1/b.proto
+package acme.v2;`,
+		GoodExample: `// acme/v1/a.proto
+package acme.v1;
+// acme/v1/b.proto
+package acme.v1;`,
+		Fix: `Move the files so that each directory only contains files of a single package.`,
+	},
+	"ENUM_FIRST_VALUE_ZERO": {
+		Rationale: `In proto3, the first enum value must be 0, as it is the default value. Requiring this in proto2 as well keeps the default value of enums consistent, and makes moving to proto3 possible.`,
+		BadExample: `enum Status {
+  STATUS_ACTIVE = 1;
+}`,
+		GoodExample: `enum Status {
+  STATUS_UNSPECIFIED = 0;
+  STATUS_ACTIVE = 1;
+}`,
+		Fix: `Add a first value with the number 0, usually named ENUM_NAME_UNSPECIFIED.`,
+	},
+	"ENUM_NAMING": {
+		Rationale: `Naming rules enforce the naming conventions of your organization, beyond the PascalCase convention of ENUM_PASCAL_CASE.`,
+		BadExample: `# buf.yaml
+lint:
+  naming:
+    - kind: enum
+      pattern: "^[A-Z][A-Za-z]*$"
+
+enum Status2 {}`,
+		GoodExample: `enum Status {}`,
+		ConfigKeys: []string{
+			"lint.naming",
+		},
+		Fix: `Rename the enum so that it matches the patterns and styles of the naming rules of kind enum, or change the naming rules.`,
+	},
+	"ENUM_NO_ALLOW_ALIAS": {
+		Rationale: `Aliases give the same number more than one name. The JSON and text formats only use one of the names, and renaming or deleting an alias is easy to get wrong.`,
+		BadExample: `enum Status {
+  option allow_alias = true;
+  STATUS_UNSPECIFIED = 0;
+  STATUS_ACTIVE = 1;
+  STATUS_ENABLED = 1;
+}`,
+		GoodExample: `enum Status {
+  STATUS_UNSPECIFIED = 0;
+  STATUS_ACTIVE = 1;
+}`,
+		Fix: `Remove the allow_alias option and all but one name for each number.`,
+	},
+	"ENUM_PASCAL_CASE": {
+		Rationale:   `PascalCase is the convention of the Protobuf style guide for enums, and generates idiomatic type names in most languages.`,
+		BadExample:  `enum order_status {}`,
+		GoodExample: `enum OrderStatus {}`,
+		Fix:         `Rename the enum to PascalCase, and update the fields that use it. Renaming an enum changes the generated code, and is a breaking change for the JSON form of google.protobuf.Any.`,
+	},
+	"ENUM_USED": {
+		Rationale: `Enums that are not referenced by any RPC, field, or extension are dead code. They are still generated and documented, and are easily mistaken for part of the API.`,
+		BadExample: `enum LegacyStatus {
+  LEGACY_STATUS_UNSPECIFIED = 0;
+}
+
+message Order {
+  string id = 1;
+}`,
+		GoodExample: `message Order {
+  string id = 1;
+}`,
+		ConfigKeys: []string{
+			"lint.type_used_root_options",
+			"lint.type_used_root_types",
+		},
+		Fix: `Delete the enum, reference it from a field, or add it, its package, or an option set on it to the roots of the TYPE_USED rules if it is used outside of the module.`,
+	},
+	"ENUM_VALUE_NAMING": {
+		Rationale: `Naming rules enforce the naming conventions of your organization, beyond the UPPER_SNAKE_CASE convention of ENUM_VALUE_UPPER_SNAKE_CASE.`,
+		BadExample: `# buf.yaml
+lint:
+  naming:
+    - kind: enum_value
+      pattern: "^[A-Z_]+$"
+
+enum Status {
+  STATUS_V2 = 0;
+}`,
+		GoodExample: `enum Status {
+  STATUS_UNSPECIFIED = 0;
+}`,
+		ConfigKeys: []string{
+			"lint.naming",
+		},
+		Fix: `Rename the enum value so that it matches the patterns and styles of the naming rules of kind enum_value, or change the naming rules.`,
+	},
+	"ENUM_VALUE_NUMBER_CONTIGUOUS": {
+		Rationale: `Gaps in enum value numbers usually mean that values were deleted. Reserving the numbers of deleted values prevents them from being reused with a different meaning.`,
+		BadExample: `enum Status {
+  STATUS_UNSPECIFIED = 0;
+  STATUS_DONE = 3;
+}`,
+		GoodExample: `enum Status {
+  reserved 1, 2;
+  STATUS_UNSPECIFIED = 0;
+  STATUS_DONE = 3;
+}`,
+		Fix: `Reserve the numbers that are missing, or renumber the values if the enum has not been released yet.`,
+	},
+	"ENUM_VALUE_PREFIX": {
+		Rationale: `Enum values are scoped to the package in C++ and other languages, not the enum, so two enums of the same package cannot have values with the same name. Prefixing values with the name of the enum avoids these conflicts.`,
+		BadExample: `enum Status {
+  UNSPECIFIED = 0;
+}`,
+		GoodExample: `enum Status {
+  STATUS_UNSPECIFIED = 0;
+}`,
+		Fix: `Prefix the enum values with the name of the enum in UPPER_SNAKE_CASE. buf lint --fix renames the values. Renaming enum values is a breaking change for the JSON and text formats.`,
+	},
+	"ENUM_VALUE_UPPER_SNAKE_CASE": {
+		Rationale: `UPPER_SNAKE_CASE is the convention of the Protobuf style guide for enum values, and generates idiomatic constant names in most languages.`,
+		BadExample: `enum Status {
+  status_unspecified = 0;
+}`,
+		GoodExample: `enum Status {
+  STATUS_UNSPECIFIED = 0;
+}`,
+		Fix: `Rename the enum values to UPPER_SNAKE_CASE. Renaming enum values is a breaking change for the JSON and text formats.`,
+	},
+	"ENUM_ZERO_VALUE_SUFFIX": {
+		Rationale: `The zero value of an enum is the default value, and is indistinguishable from a value that was not set. Naming it ENUM_NAME_UNSPECIFIED makes clear that it has no meaning other than "not set".`,
+		BadExample: `enum Status {
+  STATUS_ACTIVE = 0;
+}`,
+		GoodExample: `enum Status {
+  STATUS_UNSPECIFIED = 0;
+  STATUS_ACTIVE = 1;
+}`,
+		ConfigKeys: []string{
+			"lint.enum_zero_value_suffix",
+		},
+		Fix: `Add a zero value with the suffix, or rename the zero value. buf lint --fix renames the zero value. Set lint.enum_zero_value_suffix to use a suffix other than _UNSPECIFIED.`,
+	},
+	"FIELD_LOWER_SNAKE_CASE": {
+		Rationale: `lower_snake_case is the convention of the Protobuf style guide for fields. Generators convert it to the idiomatic case of each language, and it is the basis of the default JSON name of the field.`,
+		BadExample: `message Order {
+  string customerId = 1;
+}`,
+		GoodExample: `message Order {
+  string customer_id = 1;
+}`,
+		Fix: `Rename the fields to lower_snake_case. buf lint --fix renames the fields. Renaming a field is a breaking change for the JSON and text formats unless json_name is set to the previous JSON name.`,
+	},
+	"FIELD_NAMING": {
+		Rationale: `Naming rules enforce the naming conventions of your organization, beyond the lower_snake_case convention of FIELD_LOWER_SNAKE_CASE.`,
+		BadExample: `# buf.yaml
+lint:
+  naming:
+    - kind: field
+      pattern: "^[a-z_]+$"
+
+message Order {
+  string address2 = 1;
+}`,
+		GoodExample: `message Order {
+  string billing_address = 1;
+}`,
+		ConfigKeys: []string{
+			"lint.naming",
+		},
+		Fix: `Rename the field so that it matches the patterns and styles of the naming rules of kind field, or change the naming rules.`,
+	},
+	"FIELD_NUMBER_CONTIGUOUS": {
+		Rationale: `Gaps in field numbers usually mean that fields were deleted. Reserving the numbers of deleted fields prevents them from being reused with a different type, which corrupts data written by older clients.`,
+		BadExample: `message Order {
+  string id = 1;
+  int64 total = 3;
+}`,
+		GoodExample: `message Order {
+  reserved 2;
+  string id = 1;
+  int64 total = 3;
+}`,
+		Fix: `Reserve the numbers that are missing, or renumber the fields if the message has not been released yet.`,
+	},
+	"FIELD_NUMBER_LOW_FIRST": {
+		Rationale: `Field numbers 1 to 15 are encoded with a one-byte tag, and higher numbers need at least two bytes. Using the low numbers first keeps the fields of messages that are sent often small on the wire. As this only matters for these messages, only the messages listed in field_number_low_first_messages are checked.`,
+		BadExample: `# buf.yaml
+lint:
+  field_number_low_first_messages:
+    - acme.v1.Order
+
+message Order {
+  string id = 1;
+  int64 total = 16;
+}`,
+		GoodExample: `# buf.yaml
+lint:
+  field_number_low_first_messages:
+    - acme.v1.Order
+
+message Order {
+  string id = 1;
+  int64 total = 2;
+}`,
+		ConfigKeys: []string{
+			"lint.field_number_low_first_messages",
+		},
+		Fix: `Use the unused numbers from 1 to 15 before higher numbers, or reserve them if they were used by deleted fields.`,
+	},
+	"FILE_LOWER_SNAKE_CASE": {
+		Rationale:   `lower_snake_case file names are the convention of the Protobuf style guide, and generate file names that are valid in every language and on every file system.`,
+		BadExample:  `acme/v1/OrderService.proto`,
+		GoodExample: `acme/v1/order_service.proto`,
+		Fix:         `Rename the file to lower_snake_case, and update the imports of the file.`,
+	},
+	"FILE_NAMING": {
+		Rationale: `Naming rules enforce the file layout conventions of your organization, beyond the lower_snake_case convention of FILE_LOWER_SNAKE_CASE.`,
+		BadExample: `# buf.yaml
+lint:
+  naming:
+    - kind: file
+      pattern: "_service\\.proto$|^[^/]+/v[0-9]+/[a-z_]+\\.proto$"
+
+acme/order.proto`,
+		GoodExample: `acme/v1/order.proto`,
+		ConfigKeys: []string{
+			"lint.naming",
+		},
+		Fix: `Move or rename the file so that its path matches the patterns and styles of the naming rules of kind file, or change the naming rules.`,
+	},
+	"IMPORT_NO_PUBLIC": {
+		Rationale:   `Public imports are not supported by all languages, and make it hard to tell where a type is defined.`,
+		BadExample:  `import public "acme/v1/order.proto";`,
+		GoodExample: `import "acme/v1/order.proto";`,
+		Fix:         `Replace the public import with regular imports in each file that uses the types of the imported file.`,
+	},
+	"IMPORT_NO_WEAK": {
+		Rationale:   `Weak imports are only supported by some languages, and have semantics that are not well defined.`,
+		BadExample:  `import weak "acme/v1/order.proto";`,
+		GoodExample: `import "acme/v1/order.proto";`,
+		Fix:         `Replace the weak import with a regular import.`,
+	},
+	"IMPORT_USED": {
+		Rationale: `Unused imports add dependencies between files and generated packages, slow down compilation, and are reported as warnings by protoc.`,
+		BadExample: `import "google/protobuf/timestamp.proto";
+
+message Order {
+  string id = 1;
+}`,
+		GoodExample: `message Order {
+  string id = 1;
+}`,
+		Fix: `Remove the unused import. buf lint --fix removes unused imports.`,
+	},
+	"MESSAGE_NAMING": {
+		Rationale: `Naming rules enforce the naming conventions of your organization, beyond the PascalCase convention of MESSAGE_PASCAL_CASE.`,
+		BadExample: `# buf.yaml
+lint:
+  naming:
+    - kind: message
+      pattern: "^[A-Z][A-Za-z]*$"
+
+message OrderV2 {}`,
+		GoodExample: `message Order {}`,
+		ConfigKeys: []string{
+			"lint.naming",
+		},
+		Fix: `Rename the message so that it matches the patterns and styles of the naming rules of kind message, or change the naming rules.`,
+	},
+	"MESSAGE_PASCAL_CASE": {
+		Rationale:   `PascalCase is the convention of the Protobuf style guide for messages, and generates idiomatic type names in most languages.`,
+		BadExample:  `message order_item {}`,
+		GoodExample: `message OrderItem {}`,
+		Fix:         `Rename the message to PascalCase, and update the fields and RPCs that use it. Renaming a message changes the generated code, and is a breaking change for the JSON form of google.protobuf.Any.`,
+	},
+	"MESSAGE_USED": {
+		Rationale: `Messages that are not referenced by any RPC, field, or extension are dead code. They are still generated and documented, and are easily mistaken for part of the API.`,
+		BadExample: `message LegacyOrder {
+  string id = 1;
+}
+
+service OrderService {
+  rpc GetOrder(GetOrderRequest) returns (GetOrderResponse);
+}`,
+		GoodExample: `service OrderService {
+  rpc GetOrder(GetOrderRequest) returns (GetOrderResponse);
+}`,
+		ConfigKeys: []string{
+			"lint.type_used_root_options",
+			"lint.type_used_root_types",
+		},
+		Fix: `Delete the message, reference it from an RPC or field, or add it, its package, or an option set on it to the roots of the TYPE_USED rules if it is used outside of the module, such as in google.protobuf.Any values or events.`,
+	},
+	"ONEOF_LOWER_SNAKE_CASE": {
+		Rationale: `lower_snake_case is the convention of the Protobuf style guide for oneofs, as oneofs are named like fields.`,
+		BadExample: `message Payment {
+  oneof paymentMethod {
+    Card card = 1;
+  }
+}`,
+		GoodExample: `message Payment {
+  oneof payment_method {
+    Card card = 1;
+  }
+}`,
+		Fix: `Rename the oneof to lower_snake_case. Renaming a oneof changes the generated code.`,
+	},
+	"ONEOF_NAMING": {
+		Rationale: `Naming rules enforce the naming conventions of your organization, beyond the lower_snake_case convention of ONEOF_LOWER_SNAKE_CASE.`,
+		BadExample: `# buf.yaml
+lint:
+  naming:
+    - kind: oneof
+      pattern: "^[a-z_]+$"
+
+message Payment {
+  oneof method2 {
+    Card card = 1;
+  }
+}`,
+		GoodExample: `message Payment {
+  oneof method {
+    Card card = 1;
+  }
+}`,
+		ConfigKeys: []string{
+			"lint.naming",
+		},
+		Fix: `Rename the oneof so that it matches the patterns and styles of the naming rules of kind oneof, or change the naming rules.`,
+	},
+	"PACKAGE_DEFINED": {
+		Rationale: `Files without a package put their types in the global namespace, where they can conflict with the types of any other file.`,
+		BadExample: `syntax = "proto3";
+
+message Order {}`,
+		GoodExample: `syntax = "proto3";
+
+package acme.v1;
+
+message Order {}`,
+		Fix: `Add a package declaration to the file, and move the file to the directory that matches the package.`,
+	},
+	"PACKAGE_DIRECTORY_MATCH": {
+		Rationale: `Putting each package in the directory that matches its name, relative to the root of the module, makes it easy to find the files of a package, and generates code with a predictable layout.`,
+		BadExample: `// order/order.proto
+package acme.v1;`,
+		GoodExample: `// acme/v1/order.proto
+package acme.v1;`,
+		Fix: `Move the file to the directory that matches its package, and update the imports of the file.`,
+	},
+	"PACKAGE_LOWER_SNAKE_CASE": {
+		Rationale:   `lower_snake.case is the convention of the Protobuf style guide for packages, and generates package names that are valid in every language.`,
+		BadExample:  `package Acme.OrderService.v1;`,
+		GoodExample: `package acme.order_service.v1;`,
+		Fix:         `Rename the package to lower_snake.case, and move the files to the matching directory. Renaming a package is a breaking change.`,
+	},
+	"PACKAGE_NAMING": {
+		Rationale: `Naming rules enforce the package conventions of your organization, such as a required prefix, beyond the lower_snake.case convention of PACKAGE_LOWER_SNAKE_CASE.`,
+		BadExample: `# buf.yaml
+lint:
+  naming:
+    - kind: package
+      pattern: "^acme\\."
+
+package orders.v1;`,
+		GoodExample: `package acme.orders.v1;`,
+		ConfigKeys: []string{
+			"lint.naming",
+		},
+		Fix: `Rename the package so that it matches the patterns and styles of the naming rules of kind package, or change the naming rules.`,
+	},
+	"PACKAGE_NO_IMPORT_CYCLE": {
+		Rationale: `Protobuf allows packages to import each other as long as files do not, but many languages, such as Go, do not allow cycles between the packages of the generated code.`,
+		BadExample: `// acme/a/v1/a.proto
+package acme.a.v1;
+import "acme/b/v1/b.proto";
+// acme/b/v1/b.proto
+package acme.b.v1;
+import "acme/a/v1/other.proto";`,
+		GoodExample: `// acme/a/v1/a.proto
+package acme.a.v1;
+import "acme/b/v1/b.proto";
+// acme/b/v1/b.proto
+package acme.b.v1;`,
+		Fix: `Move the types that both packages depend on into a third package, so that the imports between packages form no cycle.`,
+	},
+	"PACKAGE_SAME_CSHARP_NAMESPACE": {
+		Rationale: `Files of the same package with different csharp_namespace options split the package across C# namespaces.`,
+		BadExample: `// a.proto
+package acme.v1;
+option csharp_namespace = "Acme.V1";
+// b.proto
+package acme.v1;
+option csharp_namespace = "Acme.Orders";`,
+		GoodExample: `// a.proto
+package acme.v1;
+option csharp_namespace = "Acme.V1";
+// b.proto
+package acme.v1;
+option csharp_namespace = "Acme.V1";`,
+		Fix: `Set the same csharp_namespace option in all files of the package, or use managed mode in buf.gen.yaml.`,
+	},
+	"PACKAGE_SAME_DIRECTORY": {
+		Rationale: `Many languages generate code for a package as a single directory. Files of the same package in different directories generate code that does not compile, and make the layout of the module hard to follow.`,
+		BadExample: `// acme/v1/a.proto
+package acme.v1;
+// acme/other/b.proto
+package acme.v1;`,
+		GoodExample: `// acme/v1/a.proto
+package acme.v1;
+// acme/v1/b.proto
+package acme.v1;`,
+		Fix: `Move the files of the package into a single directory.`,
+	},
+	"PACKAGE_SAME_GO_PACKAGE": {
+		Rationale: `Files of the same package with different go_package options split the package across Go packages, which breaks the references between their types.`,
+		BadExample: `// a.proto
+package acme.v1;
+option go_package = "github.com/acme/gen/acmev1";
+// b.proto
+package acme.v1;
+option go_package = "github.com/acme/gen/orders";`,
+		GoodExample: `// a.proto
+package acme.v1;
+option go_package = "github.com/acme/gen/acmev1";
+// b.proto
+package acme.v1;
+option go_package = "github.com/acme/gen/acmev1";`,
+		Fix: `Set the same go_package option in all files of the package, or use managed mode in buf.gen.yaml.`,
+	},
+	"PACKAGE_SAME_JAVA_MULTIPLE_FILES": {
+		Rationale: `Files of the same package with different java_multiple_files options generate Java classes with inconsistent layouts for the same package.`,
+		BadExample: `// a.proto
+package acme.v1;
+option java_multiple_files = true;
+// b.proto
+package acme.v1;
+option java_multiple_files = false;`,
+		GoodExample: `// a.proto
+package acme.v1;
+option java_multiple_files = true;
+// b.proto
+package acme.v1;
+option java_multiple_files = true;`,
+		Fix: `Set the same java_multiple_files option in all files of the package, or use managed mode in buf.gen.yaml.`,
+	},
+	"PACKAGE_SAME_JAVA_PACKAGE": {
+		Rationale: `Files of the same package with different java_package options split the package across Java packages.`,
+		BadExample: `// a.proto
+package acme.v1;
+option java_package = "com.acme.v1";
+// b.proto
+package acme.v1;
+option java_package = "com.acme.orders";`,
+		GoodExample: `// a.proto
+package acme.v1;
+option java_package = "com.acme.v1";
+// b.proto
+package acme.v1;
+option java_package = "com.acme.v1";`,
+		Fix: `Set the same java_package option in all files of the package, or use managed mode in buf.gen.yaml.`,
+	},
+	"PACKAGE_SAME_PHP_NAMESPACE": {
+		Rationale: `Files of the same package with different php_namespace options split the package across PHP namespaces.`,
+		BadExample: `// a.proto
+package acme.v1;
+option php_namespace = "Acme\\V1";
+// b.proto
+package acme.v1;
+option php_namespace = "Acme\\Orders";`,
+		GoodExample: `// a.proto
+package acme.v1;
+option php_namespace = "Acme\\V1";
+// b.proto
+package acme.v1;
+option php_namespace = "Acme\\V1";`,
+		Fix: `Set the same php_namespace option in all files of the package, or use managed mode in buf.gen.yaml.`,
+	},
+	"PACKAGE_SAME_RUBY_PACKAGE": {
+		Rationale: `Files of the same package with different ruby_package options split the package across Ruby modules.`,
+		BadExample: `// a.proto
+package acme.v1;
+option ruby_package = "Acme::V1";
+// b.proto
+package acme.v1;
+option ruby_package = "Acme::Orders";`,
+		GoodExample: `// a.proto
+package acme.v1;
+option ruby_package = "Acme::V1";
+// b.proto
+package acme.v1;
+option ruby_package = "Acme::V1";`,
+		Fix: `Set the same ruby_package option in all files of the package, or use managed mode in buf.gen.yaml.`,
+	},
+	"PACKAGE_SAME_SWIFT_PREFIX": {
+		Rationale: `Files of the same package with different swift_prefix options generate Swift types with inconsistent prefixes for the same package.`,
+		BadExample: `// a.proto
+package acme.v1;
+option swift_prefix = "AV1";
+// b.proto
+package acme.v1;
+option swift_prefix = "AO";`,
+		GoodExample: `// a.proto
+package acme.v1;
+option swift_prefix = "AV1";
+// b.proto
+package acme.v1;
+option swift_prefix = "AV1";`,
+		Fix: `Set the same swift_prefix option in all files of the package, or use managed mode in buf.gen.yaml.`,
+	},
+	"PACKAGE_VERSION_SUFFIX": {
+		Rationale:   `Versioning packages lets you make breaking changes in a new version of a package while clients migrate from the previous version, and tells clients which packages are stable.`,
+		BadExample:  `package acme.orders;`,
+		GoodExample: `package acme.orders.v1;`,
+		Fix:         `Add a version such as v1, v1beta1, or v1test as the last component of the package, and move the files to the matching directory. Renaming a package is a breaking change.`,
+	},
+	"PROTOVALIDATE": {
+		Rationale: `protovalidate constraints are only checked at runtime. Constraints that do not apply to the type of the field, contradict each other, or have CEL expressions that do not compile fail every validation, or silently validate nothing.`,
+		BadExample: `message User {
+  string name = 1 [
+    (buf.validate.field).string.min_len = 10,
+    (buf.validate.field).string.max_len = 5
+  ];
+}`,
+		GoodExample: `message User {
+  string name = 1 [
+    (buf.validate.field).string.min_len = 5,
+    (buf.validate.field).string.max_len = 10
+  ];
+}`,
+		Fix: `Use constraints that match the type of the field, make sure that minimums are not greater than maximums, and fix the CEL expressions so that they compile and evaluate to a bool or string.`,
+	},
+	"PROTOVALIDATE_STRICT": {
+		Rationale: `Some protovalidate constraints are valid but rarely do what was intended. required on a field without presence also rejects the zero value of the field, such as 0 or an empty string, and duration and timestamp bounds with the lower bound after the upper bound require the value to be outside of the range.`,
+		BadExample: `message Job {
+  int32 priority = 1 [(buf.validate.field).required = true];
+  google.protobuf.Duration timeout = 2 [
+    (buf.validate.field).duration.gt = {seconds: 60},
+    (buf.validate.field).duration.lt = {seconds: 1}
+  ];
+}`,
+		GoodExample: `message Job {
+  optional int32 priority = 1 [(buf.validate.field).required = true];
+  google.protobuf.Duration timeout = 2 [
+    (buf.validate.field).duration.gt = {seconds: 1},
+    (buf.validate.field).duration.lt = {seconds: 60}
+  ];
+}`,
+		Fix: `Declare fields with required as optional so that only unset values are rejected, and swap inverted duration and timestamp bounds if the value should be within the range.`,
+	},
+	"RESERVED_NAME_WITH_NUMBER": {
+		Rationale: `Reserving the name of a deleted field or enum value protects the JSON and text formats, and reserving its number protects the binary format. A reserved name without a reserved number usually means that the number was forgotten.`,
+		BadExample: `message Order {
+  reserved "customer";
+  string id = 1;
+}`,
+		GoodExample: `message Order {
+  reserved 2;
+  reserved "customer";
+  string id = 1;
+}`,
+		Fix: `Reserve the number of the deleted field or enum value in addition to its name.`,
+	},
+	"RPC_NAMING": {
+		Rationale: `Naming rules enforce the naming conventions of your organization, such as verb prefixes, beyond the PascalCase convention of RPC_PASCAL_CASE.`,
+		BadExample: `# buf.yaml
+lint:
+  naming:
+    - kind: rpc
+      pattern: "^(Get|List|Create|Update|Delete)"
+
+rpc Orders(OrdersRequest) returns (OrdersResponse);`,
+		GoodExample: `rpc ListOrders(ListOrdersRequest) returns (ListOrdersResponse);`,
+		ConfigKeys: []string{
+			"lint.naming",
+		},
+		Fix: `Rename the RPC so that it matches the patterns and styles of the naming rules of kind rpc, or change the naming rules.`,
+	},
+	"RPC_NO_CLIENT_STREAMING": {
+		Rationale:   `Client streaming RPCs are not supported by all clients, such as browsers, and are harder to load balance, retry, and debug than unary RPCs.`,
+		BadExample:  `rpc UploadOrders(stream UploadOrdersRequest) returns (UploadOrdersResponse);`,
+		GoodExample: `rpc UploadOrders(UploadOrdersRequest) returns (UploadOrdersResponse);`,
+		Fix:         `Use a unary RPC that takes a batch of requests, or ignore the rule for RPCs that need client streaming.`,
+	},
+	"RPC_NO_SERVER_STREAMING": {
+		Rationale:   `Server streaming RPCs are harder to load balance, retry, and debug than unary RPCs.`,
+		BadExample:  `rpc ListOrders(ListOrdersRequest) returns (stream ListOrdersResponse);`,
+		GoodExample: `rpc ListOrders(ListOrdersRequest) returns (ListOrdersResponse);`,
+		Fix:         `Use a unary RPC with pagination, or ignore the rule for RPCs that need server streaming.`,
+	},
+	"RPC_PASCAL_CASE": {
+		Rationale:   `PascalCase is the convention of the Protobuf style guide for RPCs, and generates idiomatic method names in most languages.`,
+		BadExample:  `rpc get_order(GetOrderRequest) returns (GetOrderResponse);`,
+		GoodExample: `rpc GetOrder(GetOrderRequest) returns (GetOrderResponse);`,
+		Fix:         `Rename the RPC to PascalCase. Renaming an RPC is a breaking change.`,
+	},
+	"RPC_REQUEST_RESPONSE_UNIQUE": {
+		Rationale: `Sharing a request or response type between RPCs, or using the same type for both, means that a field cannot be added to one RPC without adding it to the others.`,
+		BadExample: `rpc GetOrder(OrderRequest) returns (Order);
+rpc DeleteOrder(OrderRequest) returns (Order);`,
+		GoodExample: `rpc GetOrder(GetOrderRequest) returns (GetOrderResponse);
+rpc DeleteOrder(DeleteOrderRequest) returns (DeleteOrderResponse);`,
+		ConfigKeys: []string{
+			"lint.rpc_allow_same_request_response",
+			"lint.rpc_allow_google_protobuf_empty_requests",
+			"lint.rpc_allow_google_protobuf_empty_responses",
+		},
+		Fix: `Give each RPC its own request and response types. Set the lint.rpc_allow_* keys to allow the same request and response type, or google.protobuf.Empty.`,
+	},
+	"RPC_REQUEST_STANDARD_NAME": {
+		Rationale:   `Naming request types after their RPC makes it clear which RPC a type belongs to, and leaves room to add fields to the request of one RPC without affecting others.`,
+		BadExample:  `rpc GetOrder(OrderQuery) returns (GetOrderResponse);`,
+		GoodExample: `rpc GetOrder(GetOrderRequest) returns (GetOrderResponse);`,
+		ConfigKeys: []string{
+			"lint.rpc_allow_google_protobuf_empty_requests",
+		},
+		Fix: `Name the request type RPCNameRequest or ServiceNameRPCNameRequest. buf lint --fix renames request types that are only used by one RPC.`,
+	},
+	"RPC_RESPONSE_STANDARD_NAME": {
+		Rationale:   `Naming response types after their RPC makes it clear which RPC a type belongs to, and leaves room to add fields to the response of one RPC without affecting others.`,
+		BadExample:  `rpc GetOrder(GetOrderRequest) returns (Order);`,
+		GoodExample: `rpc GetOrder(GetOrderRequest) returns (GetOrderResponse);`,
+		ConfigKeys: []string{
+			"lint.rpc_allow_google_protobuf_empty_responses",
+		},
+		Fix: `Name the response type RPCNameResponse or ServiceNameRPCNameResponse. buf lint --fix renames response types that are only used by one RPC.`,
+	},
+	"SERVICE_NAMING": {
+		Rationale: `Naming rules enforce the naming conventions of your organization, beyond the PascalCase convention of SERVICE_PASCAL_CASE.`,
+		BadExample: `# buf.yaml
+lint:
+  naming:
+    - kind: service
+      pattern: "^[A-Z][A-Za-z]*Service$"
+
+service OrderAPI {}`,
+		GoodExample: `service OrderService {}`,
+		ConfigKeys: []string{
+			"lint.naming",
+		},
+		Fix: `Rename the service so that it matches the patterns and styles of the naming rules of kind service, or change the naming rules.`,
+	},
+	"SERVICE_PASCAL_CASE": {
+		Rationale:   `PascalCase is the convention of the Protobuf style guide for services, and generates idiomatic type names in most languages.`,
+		BadExample:  `service order_service {}`,
+		GoodExample: `service OrderService {}`,
+		Fix:         `Rename the service to PascalCase. Renaming a service is a breaking change.`,
+	},
+	"SERVICE_SUFFIX": {
+		Rationale:   `A common suffix makes services easy to tell apart from messages, and avoids conflicts between the generated code of services and messages.`,
+		BadExample:  `service Orders {}`,
+		GoodExample: `service OrdersService {}`,
+		ConfigKeys: []string{
+			"lint.service_suffix",
+		},
+		Fix: `Add the suffix to the name of the service. buf lint --fix renames the service. Set lint.service_suffix to use a suffix other than Service. Renaming a service is a breaking change.`,
+	},
+	"SYNTAX_SPECIFIED": {
+		Rationale:  `Files without a syntax declaration default to proto2, which is rarely intended and is reported as a warning by protoc.`,
+		BadExample: `package acme.v1;`,
+		GoodExample: `syntax = "proto3";
+
+package acme.v1;`,
+		Fix: `Add syntax = "proto3"; or syntax = "proto2"; as the first statement of the file.`,
+	},
+}
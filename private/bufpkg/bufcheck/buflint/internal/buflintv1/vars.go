@@ -22,6 +22,10 @@ import (
 var (
 	// v1RuleBuilders are the rule builders.
 	v1RuleBuilders = []*internal.RuleBuilder{
+		buflintbuild.AIPFieldBehaviorRuleBuilder,
+		buflintbuild.AIPPaginationRuleBuilder,
+		buflintbuild.AIPResourceRuleBuilder,
+		buflintbuild.AIPStandardMethodRuleBuilder,
 		buflintbuild.CommentEnumRuleBuilder,
 		buflintbuild.CommentEnumValueRuleBuilder,
 		buflintbuild.CommentFieldRuleBuilder,
@@ -29,23 +33,36 @@ var (
 		buflintbuild.CommentOneofRuleBuilder,
 		buflintbuild.CommentRPCRuleBuilder,
 		buflintbuild.CommentServiceRuleBuilder,
+		buflintbuild.DeprecationCommentRuleBuilder,
 		buflintbuild.DirectorySamePackageRuleBuilder,
 		buflintbuild.EnumFirstValueZeroRuleBuilder,
+		buflintbuild.EnumNamingRuleBuilder,
 		buflintbuild.EnumNoAllowAliasRuleBuilder,
 		buflintbuild.EnumPascalCaseRuleBuilder,
+		buflintbuild.EnumUsedRuleBuilder,
+		buflintbuild.EnumValueNamingRuleBuilder,
+		buflintbuild.EnumValueNumberContiguousRuleBuilder,
 		buflintbuild.EnumValuePrefixRuleBuilder,
 		buflintbuild.EnumValueUpperSnakeCaseRuleBuilder,
 		buflintbuild.EnumZeroValueSuffixRuleBuilder,
 		buflintbuild.FieldLowerSnakeCaseRuleBuilder,
+		buflintbuild.FieldNamingRuleBuilder,
+		buflintbuild.FieldNumberContiguousRuleBuilder,
+		buflintbuild.FieldNumberLowFirstRuleBuilder,
 		buflintbuild.FileLowerSnakeCaseRuleBuilder,
+		buflintbuild.FileNamingRuleBuilder,
 		buflintbuild.ImportNoPublicRuleBuilder,
 		buflintbuild.ImportNoWeakRuleBuilder,
 		buflintbuild.ImportUsedRuleBuilder,
+		buflintbuild.MessageNamingRuleBuilder,
 		buflintbuild.MessagePascalCaseRuleBuilder,
+		buflintbuild.MessageUsedRuleBuilder,
 		buflintbuild.OneofLowerSnakeCaseRuleBuilder,
+		buflintbuild.OneofNamingRuleBuilder,
 		buflintbuild.PackageDefinedRuleBuilder,
 		buflintbuild.PackageDirectoryMatchRuleBuilder,
 		buflintbuild.PackageLowerSnakeCaseRuleBuilder,
+		buflintbuild.PackageNamingRuleBuilder,
 		buflintbuild.PackageNoImportCycleRuleBuilder,
 		buflintbuild.PackageSameCsharpNamespaceRuleBuilder,
 		buflintbuild.PackageSameDirectoryRuleBuilder,
@@ -57,12 +74,16 @@ var (
 		buflintbuild.PackageSameSwiftPrefixRuleBuilder,
 		buflintbuild.PackageVersionSuffixRuleBuilder,
 		buflintbuild.ProtovalidateRuleBuilder,
+		buflintbuild.ProtovalidateStrictRuleBuilder,
+		buflintbuild.ReservedNameWithNumberRuleBuilder,
+		buflintbuild.RPCNamingRuleBuilder,
 		buflintbuild.RPCNoClientStreamingRuleBuilder,
 		buflintbuild.RPCNoServerStreamingRuleBuilder,
 		buflintbuild.RPCPascalCaseRuleBuilder,
 		buflintbuild.RPCRequestResponseUniqueRuleBuilder,
 		buflintbuild.RPCRequestStandardNameRuleBuilder,
 		buflintbuild.RPCResponseStandardNameRuleBuilder,
+		buflintbuild.ServiceNamingRuleBuilder,
 		buflintbuild.ServicePascalCaseRuleBuilder,
 		buflintbuild.ServiceSuffixRuleBuilder,
 		buflintbuild.SyntaxSpecifiedRuleBuilder,
@@ -74,6 +95,18 @@ var (
 	}
 	// v1IDToCategories associates IDs to categories.
 	v1IDToCategories = map[string][]string{
+		"AIP_FIELD_BEHAVIOR": {
+			"AIP",
+		},
+		"AIP_PAGINATION": {
+			"AIP",
+		},
+		"AIP_RESOURCE": {
+			"AIP",
+		},
+		"AIP_STANDARD_METHOD": {
+			"AIP",
+		},
 		"COMMENT_ENUM": {
 			"COMMENTS",
 		},
@@ -95,6 +128,9 @@ var (
 		"COMMENT_SERVICE": {
 			"COMMENTS",
 		},
+		"DEPRECATION_COMMENT": {
+			"DEPRECATION",
+		},
 		"DIRECTORY_SAME_PACKAGE": {
 			"MINIMAL",
 			"BASIC",
@@ -104,6 +140,9 @@ var (
 			"BASIC",
 			"DEFAULT",
 		},
+		"ENUM_NAMING": {
+			"NAMING",
+		},
 		"ENUM_NO_ALLOW_ALIAS": {
 			"BASIC",
 			"DEFAULT",
@@ -112,6 +151,15 @@ var (
 			"BASIC",
 			"DEFAULT",
 		},
+		"ENUM_USED": {
+			"TYPE_USED",
+		},
+		"ENUM_VALUE_NAMING": {
+			"NAMING",
+		},
+		"ENUM_VALUE_NUMBER_CONTIGUOUS": {
+			"NUMBERING",
+		},
 		"ENUM_VALUE_PREFIX": {
 			"DEFAULT",
 		},
@@ -126,9 +174,21 @@ var (
 			"BASIC",
 			"DEFAULT",
 		},
+		"FIELD_NAMING": {
+			"NAMING",
+		},
+		"FIELD_NUMBER_CONTIGUOUS": {
+			"NUMBERING",
+		},
+		"FIELD_NUMBER_LOW_FIRST": {
+			"NUMBERING",
+		},
 		"FILE_LOWER_SNAKE_CASE": {
 			"DEFAULT",
 		},
+		"FILE_NAMING": {
+			"NAMING",
+		},
 		"IMPORT_NO_PUBLIC": {
 			"BASIC",
 			"DEFAULT",
@@ -141,14 +201,23 @@ var (
 			"BASIC",
 			"DEFAULT",
 		},
+		"MESSAGE_NAMING": {
+			"NAMING",
+		},
 		"MESSAGE_PASCAL_CASE": {
 			"BASIC",
 			"DEFAULT",
 		},
+		"MESSAGE_USED": {
+			"TYPE_USED",
+		},
 		"ONEOF_LOWER_SNAKE_CASE": {
 			"BASIC",
 			"DEFAULT",
 		},
+		"ONEOF_NAMING": {
+			"NAMING",
+		},
 		"PACKAGE_DEFINED": {
 			"MINIMAL",
 			"BASIC",
@@ -163,6 +232,9 @@ var (
 			"BASIC",
 			"DEFAULT",
 		},
+		"PACKAGE_NAMING": {
+			"NAMING",
+		},
 		"PACKAGE_NO_IMPORT_CYCLE": {},
 		"PACKAGE_SAME_CSHARP_NAMESPACE": {
 			"BASIC",
@@ -203,6 +275,13 @@ var (
 		"PROTOVALIDATE": {
 			"DEFAULT",
 		},
+		"PROTOVALIDATE_STRICT": {},
+		"RESERVED_NAME_WITH_NUMBER": {
+			"NUMBERING",
+		},
+		"RPC_NAMING": {
+			"NAMING",
+		},
 		"RPC_NO_CLIENT_STREAMING": {
 			"UNARY_RPC",
 		},
@@ -222,6 +301,9 @@ var (
 		"RPC_RESPONSE_STANDARD_NAME": {
 			"DEFAULT",
 		},
+		"SERVICE_NAMING": {
+			"NAMING",
+		},
 		"SERVICE_PASCAL_CASE": {
 			"BASIC",
 			"DEFAULT",
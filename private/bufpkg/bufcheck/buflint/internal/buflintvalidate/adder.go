@@ -43,14 +43,20 @@ import (
 type adder struct {
 	field               protosource.Field
 	fieldPrettyTypeName string
-	basePath            []int32
-	addFunc             func(protosource.Descriptor, protosource.Location, []protosource.Location, string, ...interface{})
+	// elementName is the name of the elements of the field that the rules apply to,
+	// such as "keys" for map key rules, or empty if the rules apply to the field itself.
+	//
+	// If set, fieldPrettyTypeName is the type of the elements.
+	elementName string
+	basePath    []int32
+	addFunc     func(protosource.Descriptor, protosource.Location, []protosource.Location, string, ...interface{})
 }
 
-func (a *adder) cloneWithNewBasePath(basePath ...int32) *adder {
+func (a *adder) cloneForElement(elementName string, elementPrettyTypeName string, basePath ...int32) *adder {
 	return &adder{
 		field:               a.field,
-		fieldPrettyTypeName: a.fieldPrettyTypeName,
+		fieldPrettyTypeName: elementPrettyTypeName,
+		elementName:         elementName,
 		basePath:            basePath,
 		addFunc:             a.addFunc,
 	}
@@ -34,11 +34,7 @@ func Check(
 	add func(protosource.Descriptor, protosource.Location, []protosource.Location, string, ...interface{}),
 	files []protosource.File,
 ) error {
-	fileDescriptors := make([]protodescriptor.FileDescriptor, 0, len(files))
-	for _, file := range files {
-		fileDescriptors = append(fileDescriptors, file.FileDescriptor())
-	}
-	descriptorResolver, err := protodesc.NewFiles(protodescriptor.FileDescriptorSetForFileDescriptors(fileDescriptors...))
+	descriptorResolver, err := newDescriptorResolver(files)
 	if err != nil {
 		return err
 	}
@@ -68,6 +64,14 @@ func Check(
 	return nil
 }
 
+func newDescriptorResolver(files []protosource.File) (protodesc.Resolver, error) {
+	fileDescriptors := make([]protodescriptor.FileDescriptor, 0, len(files))
+	for _, file := range files {
+		fileDescriptors = append(fileDescriptors, file.FileDescriptor())
+	}
+	return protodesc.NewFiles(protodescriptor.FileDescriptorSetForFileDescriptors(fileDescriptors...))
+}
+
 func checkForMessage(
 	add func(protosource.Descriptor, protosource.Location, []protosource.Location, string, ...interface{}),
 	descriptorResolver protodesc.Resolver,
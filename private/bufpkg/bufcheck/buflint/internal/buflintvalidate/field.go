@@ -298,6 +298,17 @@ func checkRulesTypeMatchFieldType(
 		isFieldDescriptorMessage(fieldDescriptor) && string(fieldDescriptor.Message().FullName()) == expectedFieldMessageName {
 		return true
 	}
+	if adder.elementName != "" {
+		adder.addForPathf(
+			[]int32{ruleFieldNumber},
+			"Field %q has %s of type %s, which do not match %s rules.",
+			adder.fieldName(),
+			adder.elementName,
+			adder.fieldPrettyTypeName,
+			adder.getFieldRuleName(ruleFieldNumber),
+		)
+		return false
+	}
 	adder.addForPathf(
 		[]int32{ruleFieldNumber},
 		"Field %q is of type %s but has %s rules.",
@@ -358,7 +369,7 @@ func checkRepeatedRules(
 	if repeatedRules.MinItems != nil && repeatedRules.MaxItems != nil && *repeatedRules.MinItems > *repeatedRules.MaxItems {
 		baseAdder.addForPathf(
 			[]int32{repeatedRulesFieldNumber, minItemsFieldNumberInRepeatedFieldRules},
-			"Field %q has value %d for %s, which must be higher than value %d for %s.",
+			"Field %q has value %d for %s, which must be lower than value %d for %s.",
 			baseAdder.fieldName(),
 			*repeatedRules.MinItems,
 			baseAdder.getFieldRuleName(repeatedRulesFieldNumber, minItemsFieldNumberInRepeatedFieldRules),
@@ -367,7 +378,7 @@ func checkRepeatedRules(
 		)
 		baseAdder.addForPathf(
 			[]int32{repeatedRulesFieldNumber, maxItemsFieldNumberInRepeatedFieldRules},
-			"Field %q has value %d for %s, which must be lower than value %d for %s.",
+			"Field %q has value %d for %s, which must be higher than value %d for %s.",
 			baseAdder.fieldName(),
 			*repeatedRules.MaxItems,
 			baseAdder.getFieldRuleName(repeatedRulesFieldNumber, maxItemsFieldNumberInRepeatedFieldRules),
@@ -375,7 +386,12 @@ func checkRepeatedRules(
 			baseAdder.getFieldRuleName(repeatedRulesFieldNumber, minItemsFieldNumberInRepeatedFieldRules),
 		)
 	}
-	itemAdder := baseAdder.cloneWithNewBasePath(repeatedRulesFieldNumber, itemsFieldNumberInRepeatedRules)
+	itemAdder := baseAdder.cloneForElement(
+		"items",
+		baseAdder.fieldPrettyTypeName,
+		repeatedRulesFieldNumber,
+		itemsFieldNumberInRepeatedRules,
+	)
 	return checkConstraintsForField(itemAdder, repeatedRules.Items, fieldDescriptor, false)
 }
 
@@ -405,7 +421,7 @@ func checkMapRules(
 		)
 		baseAdder.addForPathf(
 			[]int32{mapRulesFieldNumber, maxPairsFieldNumberInMapRules},
-			"Field %q has value %d for %s, which is lower than value %d for %s.",
+			"Field %q has value %d for %s, which must be higher than value %d for %s.",
 			baseAdder.fieldName(),
 			*mapRules.MaxPairs,
 			baseAdder.getFieldRuleName(mapRulesFieldNumber, maxPairsFieldNumberInMapRules),
@@ -413,12 +429,22 @@ func checkMapRules(
 			baseAdder.getFieldRuleName(mapRulesFieldNumber, minPairsFieldNumberInMapRules),
 		)
 	}
-	keyAdder := baseAdder.cloneWithNewBasePath(mapRulesFieldNumber, keysFieldNumberInMapRules)
+	keyAdder := baseAdder.cloneForElement(
+		"keys",
+		getFieldTypePrettyNameName(fieldDescriptor.MapKey()),
+		mapRulesFieldNumber,
+		keysFieldNumberInMapRules,
+	)
 	err := checkConstraintsForField(keyAdder, mapRules.Keys, fieldDescriptor.MapKey(), false)
 	if err != nil {
 		return err
 	}
-	valueAdder := baseAdder.cloneWithNewBasePath(mapRulesFieldNumber, valuesFieldNumberInMapRules)
+	valueAdder := baseAdder.cloneForElement(
+		"values",
+		getFieldTypePrettyNameName(fieldDescriptor.MapValue()),
+		mapRulesFieldNumber,
+		valuesFieldNumberInMapRules,
+	)
 	return checkConstraintsForField(valueAdder, mapRules.Values, fieldDescriptor.MapValue(), false)
 }
 
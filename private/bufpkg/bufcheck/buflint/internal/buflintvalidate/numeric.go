@@ -123,7 +123,8 @@ func checkNumericRules[
 		return nil
 	}
 	// We do not check which one is larger because in protovalidate, both
-	// {lt: 3, gt: 5} and {lt: 5, gt: 3} are valid.
+	// {lt: 3, gt: 5} and {lt: 5, gt: 3} are valid. Inverted duration and
+	// timestamp bounds are reported by CheckStrict.
 	if !equalFunc(upperBound, lowerBound) {
 		return nil
 	}
@@ -154,6 +155,59 @@ func checkNumericRules[
 	return nil
 }
 
+// checkBoundsOrder checks that the lower bound of the rules is not after the upper bound.
+//
+// protovalidate accepts inverted bounds and then requires the value to be outside of
+// the range, but durations and timestamps are almost always meant to be within a window,
+// so inverted bounds are reported for them by CheckStrict.
+func checkBoundsOrder[
+	T timestamppb.Timestamp | durationpb.Duration,
+](
+	adder *adder,
+	ruleFieldNumber int32,
+	ruleMessage protoreflect.Message,
+	convertFunc func(protoreflect.Value) (*T, string, error),
+	// lessFunc returns whether the first value is before the second value.
+	lessFunc func(*T, *T) bool,
+	// formatFunc returns the value suitable for printing with %v.
+	formatFunc func(*T) interface{},
+) error {
+	var lowerBound, upperBound *T
+	var lowerBoundFieldNumber, upperBoundFieldNumber int32
+	var err error
+	ruleMessage.Range(func(field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		// Invalid values are reported by checkNumericRules.
+		switch fieldName := string(field.Name()); fieldName {
+		case "gt", "gte":
+			lowerBound, _, err = convertFunc(value)
+			lowerBoundFieldNumber = int32(field.Number())
+		case "lt", "lte":
+			upperBound, _, err = convertFunc(value)
+			upperBoundFieldNumber = int32(field.Number())
+		}
+		return err == nil
+	})
+	if err != nil {
+		return err
+	}
+	if lowerBound == nil || upperBound == nil || !lessFunc(upperBound, lowerBound) {
+		return nil
+	}
+	adder.addForPathsf(
+		[][]int32{
+			{ruleFieldNumber, lowerBoundFieldNumber},
+			{ruleFieldNumber, upperBoundFieldNumber},
+		},
+		"Field %q has %s (%v) after %s (%v), which requires the value to be outside of this range. Swap the bounds if the value should be within the range.",
+		adder.fieldName(),
+		adder.getFieldRuleName(ruleFieldNumber, lowerBoundFieldNumber),
+		formatFunc(lowerBound),
+		adder.getFieldRuleName(ruleFieldNumber, upperBoundFieldNumber),
+		formatFunc(upperBound),
+	)
+	return nil
+}
+
 func getNumericPointerFromValue[
 	T int32 | int64 | uint32 | uint64 | float32 | float64,
 ](value protoreflect.Value) (*T, string, error) {
@@ -209,6 +263,14 @@ func compareDuration(d1 *durationpb.Duration, d2 *durationpb.Duration) bool {
 	return d1.Seconds == d2.Seconds && d1.Nanos == d2.Nanos
 }
 
+func lessTimestamp(t1 *timestamppb.Timestamp, t2 *timestamppb.Timestamp) bool {
+	return t1.Seconds < t2.Seconds || (t1.Seconds == t2.Seconds && t1.Nanos < t2.Nanos)
+}
+
+func lessDuration(d1 *durationpb.Duration, d2 *durationpb.Duration) bool {
+	return d1.AsDuration() < d2.AsDuration()
+}
+
 func checkDuration(duration *durationpb.Duration) string {
 	// This is slightly smaller than MaxInt64, 9,223,372,036,854,775,807,
 	// but 9,223,372,036,854,775,428 is the maximum value that does not cause a
@@ -0,0 +1,166 @@
+// Copyright 2020-2023 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package buflintvalidate
+
+import (
+	"time"
+
+	"buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	"github.com/bufbuild/buf/private/pkg/protosource"
+	"github.com/bufbuild/protovalidate-go/resolver"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// CheckStrict checks for rules on fields that are valid, but that usually do not
+// do what was intended:
+//  1. required on a field without presence, which rejects the zero value of the field.
+//  2. duration and timestamp rules with a lower bound after the upper bound, which
+//     require the value to be outside of the range.
+func CheckStrict(
+	add func(protosource.Descriptor, protosource.Location, []protosource.Location, string, ...interface{}),
+	files []protosource.File,
+) error {
+	descriptorResolver, err := newDescriptorResolver(files)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if file.IsImport() {
+			continue
+		}
+		if err := protosource.ForEachMessage(
+			func(message protosource.Message) error {
+				for _, field := range message.Fields() {
+					if err := checkStrictForField(add, descriptorResolver, field); err != nil {
+						return err
+					}
+				}
+				for _, extension := range message.Extensions() {
+					if err := checkStrictForField(add, descriptorResolver, extension); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+			file,
+		); err != nil {
+			return err
+		}
+		for _, extension := range file.Extensions() {
+			if err := checkStrictForField(add, descriptorResolver, extension); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func checkStrictForField(
+	add func(protosource.Descriptor, protosource.Location, []protosource.Location, string, ...interface{}),
+	descriptorResolver protodesc.Resolver,
+	field protosource.Field,
+) error {
+	fieldDescriptor, err := getReflectFieldDescriptor(descriptorResolver, field)
+	if err != nil {
+		return err
+	}
+	constraints := resolver.DefaultResolver{}.ResolveFieldConstraints(fieldDescriptor)
+	if constraints == nil {
+		return nil
+	}
+	adder := &adder{
+		field:               field,
+		fieldPrettyTypeName: getFieldTypePrettyNameName(fieldDescriptor),
+		addFunc:             add,
+	}
+	// Repeated and map fields cannot be set to a zero value, and extensions
+	// cannot have required.
+	if constraints.GetRequired() &&
+		!fieldDescriptor.HasPresence() &&
+		!fieldDescriptor.IsList() &&
+		!fieldDescriptor.IsMap() &&
+		!fieldDescriptor.IsExtension() {
+		adder.addForPathf(
+			[]int32{requiredFieldNumber},
+			"Field %q has %s but does not track presence, so its zero value is rejected as if the field was not set. Declare the field as optional to only reject unset values.",
+			adder.fieldName(),
+			adder.getFieldRuleName(requiredFieldNumber),
+		)
+	}
+	switch {
+	case constraints.GetRepeated() != nil:
+		return checkStrictBoundsOrder(
+			adder.cloneForElement(
+				"items",
+				adder.fieldPrettyTypeName,
+				repeatedRulesFieldNumber,
+				itemsFieldNumberInRepeatedRules,
+			),
+			constraints.GetRepeated().GetItems(),
+		)
+	case constraints.GetMap() != nil:
+		if err := checkStrictBoundsOrder(
+			adder.cloneForElement(
+				"keys",
+				getFieldTypePrettyNameName(fieldDescriptor.MapKey()),
+				mapRulesFieldNumber,
+				keysFieldNumberInMapRules,
+			),
+			constraints.GetMap().GetKeys(),
+		); err != nil {
+			return err
+		}
+		return checkStrictBoundsOrder(
+			adder.cloneForElement(
+				"values",
+				getFieldTypePrettyNameName(fieldDescriptor.MapValue()),
+				mapRulesFieldNumber,
+				valuesFieldNumberInMapRules,
+			),
+			constraints.GetMap().GetValues(),
+		)
+	default:
+		return checkStrictBoundsOrder(adder, constraints)
+	}
+}
+
+// checkStrictBoundsOrder checks the bounds of the duration and timestamp rules of the constraints.
+//
+// Rules that do not match the type of the field are reported by Check.
+func checkStrictBoundsOrder(adder *adder, constraints *validate.FieldConstraints) error {
+	if durationRules := constraints.GetDuration(); durationRules != nil {
+		return checkBoundsOrder[durationpb.Duration](
+			adder,
+			durationRulesFieldNumber,
+			durationRules.ProtoReflect(),
+			getDurationFromValue,
+			lessDuration,
+			func(d *durationpb.Duration) interface{} { return d.AsDuration() },
+		)
+	}
+	if timestampRules := constraints.GetTimestamp(); timestampRules != nil {
+		return checkBoundsOrder[timestamppb.Timestamp](
+			adder,
+			timestampRulesFieldNumber,
+			timestampRules.ProtoReflect(),
+			getTimestampFromValue,
+			lessTimestamp,
+			func(t *timestamppb.Timestamp) interface{} { return t.AsTime().Format(time.RFC3339Nano) },
+		)
+	}
+	return nil
+}
@@ -24,8 +24,10 @@ import (
 )
 
 const (
-	defaultEnumZeroValueSuffix = "_UNSPECIFIED"
-	defaultServiceSuffix       = "Service"
+	// DefaultEnumZeroValueSuffix is the suffix of enum zero values if none is configured.
+	DefaultEnumZeroValueSuffix = "_UNSPECIFIED"
+	// DefaultServiceSuffix is the suffix of services if none is configured.
+	DefaultServiceSuffix = "Service"
 )
 
 // Config is the check config.
@@ -59,6 +61,49 @@ type ConfigBuilder struct {
 	RPCAllowGoogleProtobufEmptyRequests  bool
 	RPCAllowGoogleProtobufEmptyResponses bool
 	ServiceSuffix                        string
+
+	// CustomOptions are the fully-qualified names of the custom options
+	// checked by the breaking change detector.
+	CustomOptions []string
+
+	// NamingRules are the user-supplied naming rules checked by the
+	// *_NAMING lint rules.
+	NamingRules []*NamingRule
+
+	// TypeUsedRootOptions are the fully-qualified names of the custom options
+	// that mark messages and enums as used for the *_USED type lint rules.
+	TypeUsedRootOptions []string
+	// TypeUsedRootTypes are the fully-qualified names of the messages, enums,
+	// and packages that are used for the *_USED type lint rules.
+	TypeUsedRootTypes []string
+
+	// FieldNumberLowFirstMessages are the fully-qualified names of the messages
+	// that are checked by the FIELD_NUMBER_LOW_FIRST lint rule.
+	FieldNumberLowFirstMessages []string
+
+	// PluginRuleBuilders are RuleBuilders for rules provided by plugins.
+	//
+	// These are added to the RuleBuilders of the VersionSpec, and are always
+	// used unless they are excluded with Except. Plugin rules have no categories.
+	PluginRuleBuilders []*RuleBuilder
+	// HasPluginsNotRun is true if the config has plugins that were not run, in
+	// which case PluginRuleBuilders does not contain the rules of the plugins.
+	//
+	// Plugins only declare their rules when they are run, so unknown IDs in Except
+	// and IgnoreIDOrCategoryToRootPaths are then assumed to be plugin rule IDs.
+	HasPluginsNotRun bool
+}
+
+// NamingRule is a user-supplied naming rule for a kind of element.
+type NamingRule struct {
+	// Kind is the kind of element that the rule applies to, such as "message".
+	Kind string
+	// Pattern is a regular expression that the names of elements must match.
+	Pattern string
+	// Style is the case style that the names of elements must be in, such as "PascalCase".
+	Style string
+	// Message is the message to use instead of the default message for violations.
+	Message string
 }
 
 // NewConfig returns a new Config.
@@ -74,15 +119,40 @@ func newConfig(configBuilder ConfigBuilder, versionSpec *VersionSpec) (*Config,
 		configBuilder.Use = versionSpec.DefaultCategories
 	}
 	if configBuilder.EnumZeroValueSuffix == "" {
-		configBuilder.EnumZeroValueSuffix = defaultEnumZeroValueSuffix
+		configBuilder.EnumZeroValueSuffix = DefaultEnumZeroValueSuffix
 	}
 	if configBuilder.ServiceSuffix == "" {
-		configBuilder.ServiceSuffix = defaultServiceSuffix
+		configBuilder.ServiceSuffix = DefaultServiceSuffix
+	}
+	ruleBuilders := versionSpec.RuleBuilders
+	idToCategories := versionSpec.IDToCategories
+	if len(configBuilder.PluginRuleBuilders) > 0 {
+		ruleBuilders = make([]*RuleBuilder, 0, len(versionSpec.RuleBuilders)+len(configBuilder.PluginRuleBuilders))
+		ruleBuilders = append(ruleBuilders, versionSpec.RuleBuilders...)
+		idToCategories = make(map[string][]string, len(versionSpec.IDToCategories)+len(configBuilder.PluginRuleBuilders))
+		for id, categories := range versionSpec.IDToCategories {
+			idToCategories[id] = categories
+		}
+		use := make([]string, 0, len(configBuilder.Use)+len(configBuilder.PluginRuleBuilders))
+		use = append(use, configBuilder.Use...)
+		categoryToIDs := getCategoryToIDs(versionSpec.IDToCategories)
+		for _, pluginRuleBuilder := range configBuilder.PluginRuleBuilders {
+			_, isID := idToCategories[pluginRuleBuilder.id]
+			_, isCategory := categoryToIDs[pluginRuleBuilder.id]
+			if isID || isCategory {
+				return nil, fmt.Errorf("plugin rule %q has the same ID as an existing rule or category", pluginRuleBuilder.id)
+			}
+			ruleBuilders = append(ruleBuilders, pluginRuleBuilder)
+			idToCategories[pluginRuleBuilder.id] = nil
+			use = append(use, pluginRuleBuilder.id)
+		}
+		configBuilder.Use = use
 	}
 	return newConfigForRuleBuilders(
 		configBuilder,
-		versionSpec.RuleBuilders,
-		versionSpec.IDToCategories,
+		ruleBuilders,
+		idToCategories,
+		versionSpec.IDToExplanation,
 	)
 }
 
@@ -90,6 +160,7 @@ func newConfigForRuleBuilders(
 	configBuilder ConfigBuilder,
 	ruleBuilders []*RuleBuilder,
 	idToCategories map[string][]string,
+	idToExplanation map[string]*Explanation,
 ) (*Config, error) {
 	// this checks that there are not duplicate IDs for a given revision
 	// which would be a system error
@@ -98,11 +169,11 @@ func newConfigForRuleBuilders(
 		return nil, err
 	}
 	categoryToIDs := getCategoryToIDs(idToCategories)
-	useIDMap, err := transformToIDMap(configBuilder.Use, idToCategories, categoryToIDs)
+	useIDMap, err := transformToIDMap(configBuilder.Use, idToCategories, categoryToIDs, false)
 	if err != nil {
 		return nil, err
 	}
-	exceptIDMap, err := transformToIDMap(configBuilder.Except, idToCategories, categoryToIDs)
+	exceptIDMap, err := transformToIDMap(configBuilder.Except, idToCategories, categoryToIDs, configBuilder.HasPluginsNotRun)
 	if err != nil {
 		return nil, err
 	}
@@ -120,6 +191,10 @@ func newConfigForRuleBuilders(
 	}
 	for id := range exceptIDMap {
 		if _, ok := idToRuleBuilder[id]; !ok {
+			if configBuilder.HasPluginsNotRun {
+				// assumed to be the ID of a plugin rule
+				continue
+			}
 			return nil, fmt.Errorf("%q is not a known id after verification", id)
 		}
 		delete(resultIDToRuleBuilder, id)
@@ -135,7 +210,7 @@ func newConfigForRuleBuilders(
 		if err != nil {
 			return nil, err
 		}
-		rule, err := ruleBuilder.NewRule(configBuilder, categories)
+		rule, err := ruleBuilder.NewRule(configBuilder, categories, idToExplanation[ruleBuilder.ID()])
 		if err != nil {
 			return nil, err
 		}
@@ -143,7 +218,7 @@ func newConfigForRuleBuilders(
 	}
 	sortRules(resultRules)
 
-	ignoreIDToRootPathsUnnormalized, err := transformToIDToListMap(configBuilder.IgnoreIDOrCategoryToRootPaths, idToCategories, categoryToIDs)
+	ignoreIDToRootPathsUnnormalized, err := transformToIDToListMap(configBuilder.IgnoreIDOrCategoryToRootPaths, idToCategories, categoryToIDs, configBuilder.HasPluginsNotRun)
 	if err != nil {
 		return nil, err
 	}
@@ -193,7 +268,11 @@ func newConfigForRuleBuilders(
 	}, nil
 }
 
-func transformToIDMap(idsOrCategories []string, idToCategories map[string][]string, categoryToIDs map[string][]string) (map[string]struct{}, error) {
+// transformToIDMap transforms the IDs and categories to a map of IDs.
+//
+// If allowUnknownIDs is true, values that are neither known IDs nor categories
+// are treated as IDs.
+func transformToIDMap(idsOrCategories []string, idToCategories map[string][]string, categoryToIDs map[string][]string, allowUnknownIDs bool) (map[string]struct{}, error) {
 	if len(idsOrCategories) == 0 {
 		return nil, nil
 	}
@@ -209,6 +288,9 @@ func transformToIDMap(idsOrCategories []string, idToCategories map[string][]stri
 			for _, id := range ids {
 				idMap[id] = struct{}{}
 			}
+		} else if allowUnknownIDs {
+			id := idOrCategory
+			idMap[id] = struct{}{}
 		} else {
 			return nil, fmt.Errorf("%q is not a known id or category", idOrCategory)
 		}
@@ -216,7 +298,11 @@ func transformToIDMap(idsOrCategories []string, idToCategories map[string][]stri
 	return idMap, nil
 }
 
-func transformToIDToListMap(idOrCategoryToList map[string][]string, idToCategories map[string][]string, categoryToIDs map[string][]string) (map[string]map[string]struct{}, error) {
+// transformToIDToListMap transforms the keys of the map from IDs and categories to IDs.
+//
+// If allowUnknownIDs is true, keys that are neither known IDs nor categories
+// are treated as IDs.
+func transformToIDToListMap(idOrCategoryToList map[string][]string, idToCategories map[string][]string, categoryToIDs map[string][]string, allowUnknownIDs bool) (map[string]map[string]struct{}, error) {
 	if len(idOrCategoryToList) == 0 {
 		return nil, nil
 	}
@@ -225,7 +311,9 @@ func transformToIDToListMap(idOrCategoryToList map[string][]string, idToCategori
 		if idOrCategory == "" {
 			continue
 		}
-		if _, ok := idToCategories[idOrCategory]; ok {
+		_, isID := idToCategories[idOrCategory]
+		ids, isCategory := categoryToIDs[idOrCategory]
+		if isID || (allowUnknownIDs && !isCategory) {
 			id := idOrCategory
 			if _, ok := idToListMap[id]; !ok {
 				idToListMap[id] = make(map[string]struct{})
@@ -233,7 +321,7 @@ func transformToIDToListMap(idOrCategoryToList map[string][]string, idToCategori
 			for _, elem := range list {
 				idToListMap[id][elem] = struct{}{}
 			}
-		} else if ids, ok := categoryToIDs[idOrCategory]; ok {
+		} else if isCategory {
 			for _, id := range ids {
 				if _, ok := idToListMap[id]; !ok {
 					idToListMap[id] = make(map[string]struct{})
@@ -0,0 +1,40 @@
+// Copyright 2020-2023 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+// Explanation is the long-form documentation of a rule.
+type Explanation struct {
+	// Rationale explains why the rule exists.
+	//
+	// Full sentences.
+	Rationale string
+	// BadExample is Protobuf source that violates the rule.
+	//
+	// For breaking rules, this shows the previous and the current source.
+	BadExample string
+	// GoodExample is Protobuf source that does not violate the rule.
+	//
+	// For breaking rules, this shows the previous and the current source.
+	GoodExample string
+	// ConfigKeys are the keys of buf.yaml that affect the rule, other than
+	// use, except, ignore, and ignore_only, which affect all rules.
+	//
+	// For example, "lint.enum_zero_value_suffix".
+	ConfigKeys []string
+	// Fix explains how to fix violations of the rule.
+	//
+	// Full sentences.
+	Fix string
+}
@@ -21,6 +21,23 @@ import (
 	"github.com/bufbuild/buf/private/pkg/protosource"
 )
 
+// PackageFileAnnotation is a FileAnnotation that has the package of the elements
+// it is for.
+//
+// All FileAnnotations added to a Helper are PackageFileAnnotations. Unlike the
+// FileInfo, the package is also set for FileAnnotations for elements that were
+// deleted, such as deleted files and packages.
+type PackageFileAnnotation interface {
+	bufanalysis.FileAnnotation
+
+	// Package returns the package of the elements that the FileAnnotation is for.
+	//
+	// This is the package of the file of the descriptor of the FileAnnotation, or
+	// of the first extra ignore descriptor if there is no descriptor, such as for
+	// deleted files and packages. This is empty if there are no descriptors.
+	Package() string
+}
+
 // Helper is a helper for rules.
 type Helper struct {
 	id              string
@@ -122,6 +139,7 @@ func (h *Helper) addFileAnnotationf(
 		newFileAnnotationf(
 			h.id,
 			descriptor,
+			extraIgnoreDescriptors,
 			location,
 			format,
 			args...,
@@ -141,10 +159,11 @@ func (h *Helper) FileAnnotations() []bufanalysis.FileAnnotation {
 func newFileAnnotationf(
 	id string,
 	descriptor protosource.Descriptor,
+	extraIgnoreDescriptors []protosource.Descriptor,
 	location protosource.Location,
 	format string,
 	args ...interface{},
-) bufanalysis.FileAnnotation {
+) PackageFileAnnotation {
 	startLine := 0
 	startColumn := 0
 	endLine := 0
@@ -155,17 +174,36 @@ func newFileAnnotationf(
 		endLine = location.EndLine()
 		endColumn = location.EndColumn()
 	}
-	var fileInfo bufanalysis.FileInfo
+	var (
+		fileInfo bufanalysis.FileInfo
+		pkg      string
+	)
 	if descriptor != nil {
 		fileInfo = descriptor.File()
+		pkg = descriptor.File().Package()
+	} else if len(extraIgnoreDescriptors) > 0 && extraIgnoreDescriptors[0] != nil {
+		pkg = extraIgnoreDescriptors[0].File().Package()
 	}
-	return bufanalysis.NewFileAnnotation(
-		fileInfo,
-		startLine,
-		startColumn,
-		endLine,
-		endColumn,
-		id,
-		fmt.Sprintf(format, args...),
-	)
+	return &packageFileAnnotation{
+		FileAnnotation: bufanalysis.NewFileAnnotation(
+			fileInfo,
+			startLine,
+			startColumn,
+			endLine,
+			endColumn,
+			id,
+			fmt.Sprintf(format, args...),
+		),
+		pkg: pkg,
+	}
+}
+
+type packageFileAnnotation struct {
+	bufanalysis.FileAnnotation
+
+	pkg string
+}
+
+func (p *packageFileAnnotation) Package() string {
+	return p.pkg
 }
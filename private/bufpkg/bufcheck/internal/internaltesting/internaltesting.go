@@ -54,4 +54,21 @@ func runTestRuleBuilders(t *testing.T, versionSpec *internal.VersionSpec) {
 		_, ok := idsMap[id]
 		assert.True(t, ok, "id %q configured in categories is not added to ruleBuilders", id)
 	}
+	if versionSpec.IDToExplanation == nil {
+		return
+	}
+	for id := range idsMap {
+		explanation, ok := versionSpec.IDToExplanation[id]
+		if !assert.True(t, ok, "id %q has no explanation", id) {
+			continue
+		}
+		assert.NotEmpty(t, explanation.Rationale, "id %q explanation has no rationale", id)
+		assert.NotEmpty(t, explanation.BadExample, "id %q explanation has no bad example", id)
+		assert.NotEmpty(t, explanation.GoodExample, "id %q explanation has no good example", id)
+		assert.NotEmpty(t, explanation.Fix, "id %q explanation has no fix", id)
+	}
+	for id := range versionSpec.IDToExplanation {
+		_, ok := idsMap[id]
+		assert.True(t, ok, "id %q configured in explanations is not added to ruleBuilders", id)
+	}
 }
@@ -40,10 +40,12 @@ type CheckFunc func(id string, ignoreFunc IgnoreFunc, previousFiles []protosourc
 
 // Rule provides a base embeddable rule.
 type Rule struct {
-	id         string
-	categories []string
-	purpose    string
-	checkFunc  CheckFunc
+	id          string
+	categories  []string
+	purpose     string
+	checkFunc   CheckFunc
+	explanation *Explanation
+	fileLocal   bool
 }
 
 // newRule returns a new Rule.
@@ -55,6 +57,8 @@ func newRule(
 	categories []string,
 	purpose string,
 	checkFunc CheckFunc,
+	explanation *Explanation,
+	fileLocal bool,
 ) *Rule {
 	c := make([]string, len(categories))
 	copy(c, categories)
@@ -65,10 +69,12 @@ func newRule(
 		},
 	)
 	return &Rule{
-		id:         id,
-		categories: c,
-		purpose:    "Checks that " + purpose + ".",
-		checkFunc:  checkFunc,
+		id:          id,
+		categories:  c,
+		purpose:     "Checks that " + purpose + ".",
+		checkFunc:   checkFunc,
+		explanation: explanation,
+		fileLocal:   fileLocal,
 	}
 }
 
@@ -87,6 +93,51 @@ func (c *Rule) Purpose() string {
 	return c.purpose
 }
 
+// Rationale implements Rule.
+func (c *Rule) Rationale() string {
+	if c.explanation == nil {
+		return ""
+	}
+	return c.explanation.Rationale
+}
+
+// BadExample implements Rule.
+func (c *Rule) BadExample() string {
+	if c.explanation == nil {
+		return ""
+	}
+	return c.explanation.BadExample
+}
+
+// GoodExample implements Rule.
+func (c *Rule) GoodExample() string {
+	if c.explanation == nil {
+		return ""
+	}
+	return c.explanation.GoodExample
+}
+
+// ConfigKeys implements Rule.
+func (c *Rule) ConfigKeys() []string {
+	if c.explanation == nil {
+		return nil
+	}
+	return c.explanation.ConfigKeys
+}
+
+// Fix implements Rule.
+func (c *Rule) Fix() string {
+	if c.explanation == nil {
+		return ""
+	}
+	return c.explanation.Fix
+}
+
+// FileLocal implements Rule.
+func (c *Rule) FileLocal() bool {
+	return c.fileLocal
+}
+
 // MarshalJSON implements Rule.
 func (c *Rule) MarshalJSON() ([]byte, error) {
 	return json.Marshal(ruleJSON{ID: c.id, Categories: c.categories, Purpose: c.purpose})
@@ -24,6 +24,7 @@ type RuleBuilder struct {
 	id         string
 	newPurpose func(ConfigBuilder) (string, error)
 	newCheck   func(ConfigBuilder) (CheckFunc, error)
+	fileLocal  bool
 }
 
 // NewRuleBuilder returns a new RuleBuilder.
@@ -53,13 +54,44 @@ func NewNopRuleBuilder(
 	)
 }
 
+// NewFileRuleBuilder returns a new RuleBuilder for a file-local rule.
+//
+// See Rule.FileLocal for the requirements of file-local rules.
+func NewFileRuleBuilder(
+	id string,
+	newPurpose func(ConfigBuilder) (string, error),
+	newCheck func(ConfigBuilder) (CheckFunc, error),
+) *RuleBuilder {
+	ruleBuilder := NewRuleBuilder(id, newPurpose, newCheck)
+	ruleBuilder.fileLocal = true
+	return ruleBuilder
+}
+
+// NewNopFileRuleBuilder returns a new RuleBuilder for a file-local rule
+// for the direct purpose and CheckFunc.
+//
+// See Rule.FileLocal for the requirements of file-local rules.
+func NewNopFileRuleBuilder(
+	id string,
+	purpose string,
+	checkFunc CheckFunc,
+) *RuleBuilder {
+	return NewFileRuleBuilder(
+		id,
+		newNopPurpose(purpose),
+		newNopCheckFunc(checkFunc),
+	)
+}
+
 // NewRule returns a new Rule.
 //
 // Categories will be sorted and Purpose will be prepended with "Checks that "
 // and appended with ".".
 //
 // Categories is an actual copy from the ruleBuilder.
-func (c *RuleBuilder) NewRule(configBuilder ConfigBuilder, categories []string) (*Rule, error) {
+//
+// The explanation may be nil.
+func (c *RuleBuilder) NewRule(configBuilder ConfigBuilder, categories []string, explanation *Explanation) (*Rule, error) {
 	purpose, err := c.newPurpose(configBuilder)
 	if err != nil {
 		return nil, err
@@ -73,6 +105,8 @@ func (c *RuleBuilder) NewRule(configBuilder ConfigBuilder, categories []string)
 		categories,
 		purpose,
 		check,
+		explanation,
+		c.fileLocal,
 	), nil
 }
 
@@ -37,9 +37,10 @@ const (
 
 // Runner is a runner.
 type Runner struct {
-	logger       *zap.Logger
-	ignorePrefix string
-	tracer       trace.Tracer
+	logger                *zap.Logger
+	ignorePrefix          string
+	justifiedIgnorePrefix string
+	tracer                trace.Tracer
 }
 
 // NewRunner returns a new Runner.
@@ -69,6 +70,21 @@ func RunnerWithIgnorePrefix(ignorePrefix string) RunnerOption {
 	}
 }
 
+// RunnerWithJustifiedIgnorePrefix returns a new RunnerOption that sets the justified comment
+// ignore prefix.
+//
+// This will result in failures where a descriptor from the current files has
+// "ignore_prefix id justification" in the leading comment of its location being ignored.
+// Unlike RunnerWithIgnorePrefix, the justification is required, and only the descriptors
+// of the current files are checked, not the locations.
+//
+// The default is to not enable justified comment ignores.
+func RunnerWithJustifiedIgnorePrefix(justifiedIgnorePrefix string) RunnerOption {
+	return func(runner *Runner) {
+		runner.justifiedIgnorePrefix = justifiedIgnorePrefix
+	}
+}
+
 // Check runs the Rules.
 func (r *Runner) Check(ctx context.Context, config *Config, previousFiles []protosource.File, files []protosource.File) ([]bufanalysis.FileAnnotation, error) {
 	rules := config.Rules
@@ -81,7 +97,7 @@ func (r *Runner) Check(ctx context.Context, config *Config, previousFiles []prot
 	))
 	defer span.End()
 
-	ignoreFunc := r.newIgnoreFunc(config)
+	ignoreFunc := r.newIgnoreFunc(config, files)
 	var fileAnnotations []bufanalysis.FileAnnotation
 	resultC := make(chan *result, len(rules))
 	for _, rule := range rules {
@@ -110,7 +126,14 @@ func (r *Runner) Check(ctx context.Context, config *Config, previousFiles []prot
 	return fileAnnotations, nil
 }
 
-func (r *Runner) newIgnoreFunc(config *Config) IgnoreFunc {
+func (r *Runner) newIgnoreFunc(config *Config, files []protosource.File) IgnoreFunc {
+	var currentFiles map[protosource.File]struct{}
+	if r.justifiedIgnorePrefix != "" && config.AllowCommentIgnores {
+		currentFiles = make(map[protosource.File]struct{}, len(files))
+		for _, file := range files {
+			currentFiles[file] = struct{}{}
+		}
+	}
 	return func(id string, descriptors []protosource.Descriptor, locations []protosource.Location) bool {
 		if idIsIgnored(id, descriptors, config) {
 			return true
@@ -121,6 +144,12 @@ func (r *Runner) newIgnoreFunc(config *Config) IgnoreFunc {
 			locationsAreIgnored(id, r.ignorePrefix, locations, config) {
 			return true
 		}
+		// if justifiedIgnorePrefix is empty, justified comment ignores are not enabled for the runner
+		// this is the case with linting
+		if r.justifiedIgnorePrefix != "" && config.AllowCommentIgnores &&
+			r.descriptorsAreIgnored(id, r.justifiedIgnorePrefix, descriptors, currentFiles) {
+			return true
+		}
 		if config.IgnoreUnstablePackages {
 			for _, descriptor := range descriptors {
 				if descriptorPackageIsUnstable(descriptor) {
@@ -180,6 +209,51 @@ func locationsAreIgnored(id string, ignorePrefix string, locations []protosource
 	return false
 }
 
+func (r *Runner) descriptorsAreIgnored(
+	id string,
+	justifiedIgnorePrefix string,
+	descriptors []protosource.Descriptor,
+	currentFiles map[protosource.File]struct{},
+) bool {
+	// we already check that justifiedIgnorePrefix is non-empty, but just doing here for safety
+	if id == "" || justifiedIgnorePrefix == "" {
+		return false
+	}
+	fullIgnorePrefix := justifiedIgnorePrefix + " " + id
+	for _, descriptor := range descriptors {
+		// previous descriptors are passed for some rules, but only comments
+		// on the current schema are considered
+		locationDescriptor, ok := descriptor.(protosource.LocationDescriptor)
+		if !ok {
+			continue
+		}
+		if _, ok := currentFiles[locationDescriptor.File()]; !ok {
+			continue
+		}
+		location := locationDescriptor.Location()
+		if location == nil {
+			continue
+		}
+		for _, line := range stringutil.SplitTrimLinesNoEmpty(location.LeadingComments()) {
+			if line != fullIgnorePrefix && !strings.HasPrefix(line, fullIgnorePrefix+" ") {
+				continue
+			}
+			if strings.TrimSpace(strings.TrimPrefix(line, fullIgnorePrefix)) == "" {
+				r.logger.Sugar().Warnf(
+					"%s:%d:%d: comment ignore for %s has no justification and was not applied",
+					locationDescriptor.File().Path(),
+					location.StartLine(),
+					location.StartColumn(),
+					id,
+				)
+				continue
+			}
+			return true
+		}
+	}
+	return false
+}
+
 func descriptorPackageIsUnstable(descriptor protosource.Descriptor) bool {
 	if descriptor == nil {
 		return false
@@ -27,6 +27,10 @@ type VersionSpec struct {
 	// May include IDs without any categories.
 	// To get all categories, use AllCategoriesForVersionSpec.
 	IDToCategories map[string][]string
+	// IDToExplanation are the explanations of the rules.
+	//
+	// May be nil if the rules of the version have no explanations.
+	IDToExplanation map[string]*Explanation
 }
 
 // AllCategoriesForVersionSpec returns all categories for the VersionSpec.
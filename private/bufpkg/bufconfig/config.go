@@ -17,6 +17,7 @@ package bufconfig
 import (
 	"github.com/bufbuild/buf/private/bufpkg/bufcheck/bufbreaking/bufbreakingconfig"
 	"github.com/bufbuild/buf/private/bufpkg/bufcheck/buflint/buflintconfig"
+	"github.com/bufbuild/buf/private/bufpkg/bufformatconfig"
 	"github.com/bufbuild/buf/private/bufpkg/bufmodule/bufmoduleconfig"
 	"github.com/bufbuild/buf/private/bufpkg/bufmodule/bufmoduleref"
 )
@@ -54,11 +55,16 @@ func newConfigV1(externalConfig ExternalConfigV1) (*Config, error) {
 			return nil, err
 		}
 	}
+	formatConfig, err := bufformatconfig.NewConfigV1(externalConfig.Format)
+	if err != nil {
+		return nil, err
+	}
 	return &Config{
 		Version:        V1Version,
 		ModuleIdentity: moduleIdentity,
 		Build:          buildConfig,
 		Breaking:       bufbreakingconfig.NewConfigV1(externalConfig.Breaking),
 		Lint:           buflintconfig.NewConfigV1(externalConfig.Lint),
+		Format:         formatConfig,
 	}, nil
 }
@@ -0,0 +1,89 @@
+// Copyright 2020-2023 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bufformatconfig
+
+import (
+	"errors"
+)
+
+// DefaultIndentWidth is the default number of spaces used for each level of indentation.
+const DefaultIndentWidth = 2
+
+// Config is the format config.
+//
+// The zero value of each field results in the default formatting behavior.
+type Config struct {
+	// IndentWidth is the number of spaces used for each level of indentation.
+	//
+	// If zero, DefaultIndentWidth is used.
+	IndentWidth int
+	// MaxLineLength is the maximum length of a line.
+	//
+	// If set, compact options are written on the same line as their field, enum value,
+	// or extension range if the line fits within this length and the options have no
+	// comments, and are wrapped with one option per line otherwise. Fields that do not
+	// fit within this length up to their options are wrapped after their type, and the
+	// name of the field is indented by two additional levels of indentation. Fields that
+	// are wrapped are not aligned by AlignFields.
+	//
+	// If zero, a single compact option is always written on the same line, and multiple
+	// compact options are always wrapped with one option per line.
+	MaxLineLength int
+	// AlignFields aligns the '=' signs, and therefore the field numbers, of consecutive
+	// fields within a message, oneof, extend block, or group.
+	//
+	// Fields are consecutive if they are not separated by a blank line or another
+	// element, such as a nested message or option.
+	AlignFields bool
+	// GroupImports separates imports into groups by the first component of their
+	// path, such as google or acme, with a blank line between the groups.
+	//
+	// Imports are always sorted.
+	GroupImports bool
+}
+
+// NewConfigV1 returns a new Config.
+func NewConfigV1(externalConfig ExternalConfigV1) (*Config, error) {
+	if externalConfig.IndentWidth < 0 {
+		return nil, errors.New("format.indent_width must not be negative")
+	}
+	if externalConfig.MaxLineLength < 0 {
+		return nil, errors.New("format.max_line_length must not be negative")
+	}
+	return &Config{
+		IndentWidth:   externalConfig.IndentWidth,
+		MaxLineLength: externalConfig.MaxLineLength,
+		AlignFields:   externalConfig.AlignFields,
+		GroupImports:  externalConfig.GroupImports,
+	}, nil
+}
+
+// ExternalConfigV1 is an external config.
+type ExternalConfigV1 struct {
+	IndentWidth   int  `json:"indent_width,omitempty" yaml:"indent_width,omitempty"`
+	MaxLineLength int  `json:"max_line_length,omitempty" yaml:"max_line_length,omitempty"`
+	AlignFields   bool `json:"align_fields,omitempty" yaml:"align_fields,omitempty"`
+	GroupImports  bool `json:"group_imports,omitempty" yaml:"group_imports,omitempty"`
+}
+
+// ExternalConfigV1ForConfig takes a *Config and returns the v1 externalconfig representation.
+func ExternalConfigV1ForConfig(config *Config) ExternalConfigV1 {
+	return ExternalConfigV1{
+		IndentWidth:   config.IndentWidth,
+		MaxLineLength: config.MaxLineLength,
+		AlignFields:   config.AlignFields,
+		GroupImports:  config.GroupImports,
+	}
+}
@@ -40,9 +40,16 @@ type ResponseWriter interface {
 		ctx context.Context,
 		response *pluginpb.CodeGeneratorResponse,
 		pluginOut string,
+		options ...AddResponseOption,
 	) error
 }
 
+// ManifestFileName is the name of the file written to an output directory that is
+// cleaned, listing the files generated to the directory, one path per line.
+//
+// Only the files listed in the manifest are ever removed from the directory.
+const ManifestFileName = ".buf.gen.manifest"
+
 // NewResponseWriter returns a new ResponseWriter.
 func NewResponseWriter(
 	logger *zap.Logger,
@@ -66,3 +73,33 @@ func ResponseWriterWithCreateOutDirIfNotExists() ResponseWriterOption {
 		responseWriterOptions.createOutDirIfNotExists = true
 	}
 }
+
+// AddResponseOption is an option for AddResponse.
+type AddResponseOption func(*addResponseOptions)
+
+// AddResponseWithClean returns a new AddResponseOption that cleans the output directory.
+//
+// When the responses are written, the files that were generated to the directory by a
+// previous clean generation, as listed in its ManifestFileName file, but are no longer
+// generated are removed. The manifest is then rewritten with the files that were generated.
+//
+// If the output is a .jar or .zip file, this has no effect, as the file is always
+// overwritten.
+func AddResponseWithClean() AddResponseOption {
+	return func(addResponseOptions *addResponseOptions) {
+		addResponseOptions.clean = true
+	}
+}
+
+// AddResponseWithPartial returns a new AddResponseOption that says the response was
+// generated from only part of the input, such as a subset of the files, so that the
+// files that are not in the response may still be generated from the rest of the input.
+//
+// If the output directory is cleaned, no files are removed from it, and the files
+// listed in its ManifestFileName file are kept in the manifest, so that they are
+// removed by the next clean generation from the entire input if no longer generated.
+func AddResponseWithPartial() AddResponseOption {
+	return func(addResponseOptions *addResponseOptions) {
+		addResponseOptions.partial = true
+	}
+}
@@ -19,10 +19,12 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 
 	"github.com/bufbuild/buf/private/pkg/app/appproto"
 	"github.com/bufbuild/buf/private/pkg/normalpath"
+	"github.com/bufbuild/buf/private/pkg/slicesext"
 	"github.com/bufbuild/buf/private/pkg/storage"
 	"github.com/bufbuild/buf/private/pkg/storage/storagearchive"
 	"github.com/bufbuild/buf/private/pkg/storage/storagemem"
@@ -41,6 +43,12 @@ Created-By: 1.6.0 (protoc)
 `)
 )
 
+// manifestHeader is the header of the ManifestFileName file.
+const manifestHeader = `# Code generated by buf generate. DO NOT EDIT.
+# The files generated to this directory. They are removed by the next clean
+# generation if they are no longer generated.
+`
+
 type responseWriter struct {
 	logger            *zap.Logger
 	storageosProvider storageos.Provider
@@ -63,6 +71,11 @@ type responseWriter struct {
 	// $ protoc example.proto --insertion-point-receiver_out=. --insertion-point-writer_out=$(pwd)
 	//
 	readWriteBuckets map[string]storage.ReadWriteBucket
+	// The output directory paths to clean when the responses are flushed.
+	cleanOutDirPaths map[string]struct{}
+	// The output directory paths with a response generated from only part
+	// of the input, from which no files are removed when cleaned.
+	partialOutDirPaths map[string]struct{}
 	// Cache the functions used to flush all of the responses to disk.
 	// This holds all of the buckets in-memory so that we only write
 	// the results to disk if all of the responses are successful.
@@ -85,6 +98,8 @@ func newResponseWriter(
 		responseWriter:          appproto.NewResponseWriter(logger),
 		createOutDirIfNotExists: responseWriterOptions.createOutDirIfNotExists,
 		readWriteBuckets:        make(map[string]storage.ReadWriteBucket),
+		cleanOutDirPaths:        make(map[string]struct{}),
+		partialOutDirPaths:      make(map[string]struct{}),
 	}
 }
 
@@ -92,7 +107,12 @@ func (w *responseWriter) AddResponse(
 	ctx context.Context,
 	response *pluginpb.CodeGeneratorResponse,
 	pluginOut string,
+	options ...AddResponseOption,
 ) error {
+	addResponseOptions := newAddResponseOptions()
+	for _, option := range options {
+		option(addResponseOptions)
+	}
 	// It's important that we get a consistent output path
 	// so that we use the same in-memory bucket for paths
 	// set to the same directory.
@@ -113,6 +133,8 @@ func (w *responseWriter) AddResponse(
 		response,
 		absPluginOut,
 		w.createOutDirIfNotExists,
+		addResponseOptions.clean,
+		addResponseOptions.partial,
 	)
 }
 
@@ -132,6 +154,8 @@ func (w *responseWriter) Close() error {
 	}
 	// Re-initialize the cached values to be safe.
 	w.readWriteBuckets = make(map[string]storage.ReadWriteBucket)
+	w.cleanOutDirPaths = make(map[string]struct{})
+	w.partialOutDirPaths = make(map[string]struct{})
 	w.closers = nil
 	return nil
 }
@@ -141,6 +165,8 @@ func (w *responseWriter) addResponse(
 	response *pluginpb.CodeGeneratorResponse,
 	pluginOut string,
 	createOutDirIfNotExists bool,
+	clean bool,
+	partial bool,
 ) error {
 	switch filepath.Ext(pluginOut) {
 	case ".jar":
@@ -160,6 +186,14 @@ func (w *responseWriter) addResponse(
 			createOutDirIfNotExists,
 		)
 	default:
+		if clean {
+			// The directory is cleaned if any of the responses written
+			// to it are to be cleaned.
+			w.cleanOutDirPaths[pluginOut] = struct{}{}
+		}
+		if partial {
+			w.partialOutDirPaths[pluginOut] = struct{}{}
+		}
 		return w.writeDirectory(
 			ctx,
 			response,
@@ -284,14 +318,113 @@ func (w *responseWriter) writeDirectory(
 		if err != nil {
 			return err
 		}
+		if _, ok := w.cleanOutDirPaths[outDirPath]; !ok {
+			_, err := storage.Copy(ctx, readWriteBucket, osReadWriteBucket)
+			return err
+		}
+		manifestFilePaths, err := readManifest(outDirPath)
+		if err != nil {
+			return err
+		}
+		generatedFilePaths, err := storage.AllPaths(ctx, readWriteBucket, "")
+		if err != nil {
+			return err
+		}
+		if _, ok := w.partialOutDirPaths[outDirPath]; ok {
+			// The files that were not generated may still be generated from the rest
+			// of the input, so they are kept, along with their entries in the manifest.
+			generatedFilePaths = append(generatedFilePaths, manifestFilePaths...)
+		} else if err := removeStaleFiles(outDirPath, manifestFilePaths, generatedFilePaths); err != nil {
+			return err
+		}
 		if _, err := storage.Copy(ctx, readWriteBucket, osReadWriteBucket); err != nil {
 			return err
 		}
-		return nil
+		return writeManifest(outDirPath, generatedFilePaths)
 	})
 	return nil
 }
 
+// removeStaleFiles removes the files listed in the manifest of the output directory
+// that are not in generatedFilePaths, along with any directories left empty.
+func removeStaleFiles(outDirPath string, manifestFilePaths []string, generatedFilePaths []string) error {
+	generatedFilePathMap := make(map[string]struct{}, len(generatedFilePaths))
+	for _, generatedFilePath := range generatedFilePaths {
+		generatedFilePathMap[generatedFilePath] = struct{}{}
+	}
+	for _, manifestFilePath := range manifestFilePaths {
+		if _, ok := generatedFilePathMap[manifestFilePath]; ok {
+			continue
+		}
+		if err := os.Remove(filepath.Join(outDirPath, normalpath.Unnormalize(manifestFilePath))); err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return err
+		}
+		// Remove the parent directories that are now empty, stopping at the
+		// first directory that is not. The output directory itself is kept.
+		for dirPath := normalpath.Dir(manifestFilePath); dirPath != "."; dirPath = normalpath.Dir(dirPath) {
+			if err := os.Remove(filepath.Join(outDirPath, normalpath.Unnormalize(dirPath))); err != nil {
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// readManifest returns the paths listed in the manifest of the output directory.
+//
+// Returns no paths if the manifest does not exist.
+func readManifest(outDirPath string) ([]string, error) {
+	manifestPath := filepath.Join(outDirPath, ManifestFileName)
+	data, err := os.ReadFile(manifestPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var paths []string
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		// The paths are validated so that files outside of the output
+		// directory are never removed.
+		path, err := normalpath.NormalizeAndValidate(line)
+		if err != nil {
+			return nil, fmt.Errorf("invalid path in %s: %w", manifestPath, err)
+		}
+		if path == "." || path == ManifestFileName {
+			return nil, fmt.Errorf("invalid path in %s: %q", manifestPath, line)
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+// writeManifest writes the manifest of the output directory with the given paths.
+func writeManifest(outDirPath string, paths []string) error {
+	var builder strings.Builder
+	builder.WriteString(manifestHeader)
+	for _, path := range slicesext.ToUniqueSorted(paths) {
+		builder.WriteString(path)
+		builder.WriteString("\n")
+	}
+	return os.WriteFile(filepath.Join(outDirPath, ManifestFileName), []byte(builder.String()), 0644)
+}
+
+type addResponseOptions struct {
+	clean   bool
+	partial bool
+}
+
+func newAddResponseOptions() *addResponseOptions {
+	return &addResponseOptions{}
+}
+
 type responseWriterOptions struct {
 	createOutDirIfNotExists bool
 }
@@ -0,0 +1,107 @@
+// Copyright 2020-2023 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appprotoos
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bufbuild/buf/private/pkg/storage/storageos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+func TestResponseWriterClean(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	outDirPath := t.TempDir()
+	writeResponse := func(clean bool, partial bool, fileNames ...string) {
+		t.Helper()
+		response := &pluginpb.CodeGeneratorResponse{}
+		for _, fileName := range fileNames {
+			response.File = append(
+				response.File,
+				&pluginpb.CodeGeneratorResponse_File{
+					Name:    proto.String(fileName),
+					Content: proto.String(fileName),
+				},
+			)
+		}
+		var options []AddResponseOption
+		if clean {
+			options = append(options, AddResponseWithClean())
+		}
+		if partial {
+			options = append(options, AddResponseWithPartial())
+		}
+		responseWriter := NewResponseWriter(zap.NewNop(), storageos.NewProvider())
+		require.NoError(t, responseWriter.AddResponse(ctx, response, outDirPath, options...))
+		require.NoError(t, responseWriter.Close())
+	}
+	assertFileExists := func(path string, expected bool) {
+		t.Helper()
+		_, err := os.Stat(filepath.Join(outDirPath, filepath.FromSlash(path)))
+		if expected {
+			assert.NoError(t, err)
+		} else {
+			assert.True(t, os.IsNotExist(err), "expected %s to not exist", path)
+		}
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(outDirPath, "handwritten.txt"), nil, 0600))
+
+	// Without clean, no manifest is written.
+	writeResponse(false, false, "a/v1/a.txt", "b/v1/b.txt")
+	assertFileExists(ManifestFileName, false)
+	// The first clean generation has no manifest to remove files from.
+	writeResponse(true, false, "a/v1/a.txt", "b/v1/b.txt", "c/c.txt")
+	assertFileExists(ManifestFileName, true)
+	data, err := os.ReadFile(filepath.Join(outDirPath, ManifestFileName))
+	require.NoError(t, err)
+	assert.Equal(t, manifestHeader+"a/v1/a.txt\nb/v1/b.txt\nc/c.txt\n", string(data))
+	// The files not generated from only part of the input are kept, and are kept in the manifest.
+	writeResponse(true, true, "a/v1/a.txt", "d/d.txt")
+	assertFileExists("b/v1/b.txt", true)
+	assertFileExists("c/c.txt", true)
+	assertFileExists("d/d.txt", true)
+	data, err = os.ReadFile(filepath.Join(outDirPath, ManifestFileName))
+	require.NoError(t, err)
+	assert.Equal(t, manifestHeader+"a/v1/a.txt\nb/v1/b.txt\nc/c.txt\nd/d.txt\n", string(data))
+	// The files no longer generated are removed, along with the directories left empty.
+	require.NoError(t, os.WriteFile(filepath.Join(outDirPath, "c", "handwritten.txt"), nil, 0600))
+	writeResponse(true, false, "a/v1/a.txt")
+	assertFileExists("d", false)
+	assertFileExists("a/v1/a.txt", true)
+	assertFileExists("b/v1/b.txt", false)
+	assertFileExists("b", false)
+	assertFileExists("c/c.txt", false)
+	assertFileExists("c/handwritten.txt", true)
+	assertFileExists("handwritten.txt", true)
+	data, err = os.ReadFile(filepath.Join(outDirPath, ManifestFileName))
+	require.NoError(t, err)
+	assert.Equal(t, manifestHeader+"a/v1/a.txt\n", string(data))
+
+	// Paths outside of the output directory are never removed.
+	require.NoError(t, os.WriteFile(filepath.Join(outDirPath, ManifestFileName), []byte("../outside.txt\n"), 0600))
+	responseWriter := NewResponseWriter(zap.NewNop(), storageos.NewProvider())
+	require.NoError(t, responseWriter.AddResponse(ctx, &pluginpb.CodeGeneratorResponse{}, outDirPath, AddResponseWithClean()))
+	err = responseWriter.Close()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid path")
+}
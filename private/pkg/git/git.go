@@ -276,6 +276,11 @@ type Repository interface {
 	DefaultBranch() string
 	// CheckedOutBranch returns the current checked out branch.
 	CheckedOutBranch(options ...CheckedOutBranchOption) (string, error)
+	// CheckedOutHash returns the hash of the commit that HEAD points to.
+	//
+	// Unlike CheckedOutBranch, this works when HEAD is detached and does not point
+	// to the HEAD of any branch, as is the case for many CI checkouts.
+	CheckedOutHash() (Hash, error)
 	// ForEachBranch ranges over branches in the repository in an undefined order.
 	ForEachBranch(f func(branch string, headHash Hash) error, options ...ForEachBranchOption) error
 	// ForEachCommit ranges over commits in reverse topological order, going backwards in time always
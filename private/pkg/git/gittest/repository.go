@@ -47,6 +47,9 @@ func (r *repository) Close() error {
 func (r *repository) CheckedOutBranch(options ...git.CheckedOutBranchOption) (string, error) {
 	return r.inner.CheckedOutBranch(options...)
 }
+func (r *repository) CheckedOutHash() (git.Hash, error) {
+	return r.inner.CheckedOutHash()
+}
 func (r *repository) DefaultBranch() string {
 	return r.inner.DefaultBranch()
 }
@@ -218,6 +218,28 @@ func (r *repository) CheckedOutBranch(options ...CheckedOutBranchOption) (string
 	return currentBranch, nil
 }
 
+func (r *repository) CheckedOutHash() (Hash, error) {
+	headBytes, err := os.ReadFile(filepath.Join(r.gitDirPath, "HEAD"))
+	if err != nil {
+		return nil, fmt.Errorf("read HEAD bytes: %w", err)
+	}
+	headBytes = bytes.TrimSuffix(headBytes, []byte{'\n'})
+	// .git/HEAD either points to a local branch, or is detached and is a git hash.
+	const localBranchRefPrefix = "ref: refs/heads/"
+	if !strings.HasPrefix(string(headBytes), localBranchRefPrefix) {
+		headHash, err := parseHashFromHex(string(headBytes))
+		if err != nil {
+			return nil, fmt.Errorf(".git/HEAD is not a local branch ref nor a git hash: %w", err)
+		}
+		return headHash, nil
+	}
+	commit, err := r.HEADCommit(HEADCommitWithBranch(strings.TrimPrefix(string(headBytes), localBranchRefPrefix)))
+	if err != nil {
+		return nil, err
+	}
+	return commit.Hash(), nil
+}
+
 func (r *repository) ForEachCommit(f func(Commit) error, options ...ForEachCommitOption) error {
 	var config forEachCommitOpts
 	for _, option := range options {
@@ -207,6 +207,34 @@ func TestCommits(t *testing.T) {
 	})
 }
 
+func TestCheckedOutHash(t *testing.T) {
+	t.Parallel()
+
+	repo := gittest.ScaffoldGitRepository(t)
+	writeModuleWithSampleCommits(t, context.Background(), repo)
+	currentBranch, err := repo.CheckedOutBranch()
+	require.NoError(t, err)
+	headCommit, err := repo.HEADCommit(git.HEADCommitWithBranch(currentBranch))
+	require.NoError(t, err)
+	headHash, err := repo.CheckedOutHash()
+	require.NoError(t, err)
+	assert.Equal(t, headCommit.Hash(), headHash)
+
+	// packed refs are resolved as well
+	repo.PackRefs(t)
+	headHash, err = repo.CheckedOutHash()
+	require.NoError(t, err)
+	assert.Equal(t, headCommit.Hash(), headHash)
+
+	// a detached HEAD that is not the HEAD of any branch
+	repo.Checkout(t, headCommit.Parents()[0].Hex())
+	_, err = repo.CheckedOutBranch()
+	require.Error(t, err)
+	headHash, err = repo.CheckedOutHash()
+	require.NoError(t, err)
+	assert.Equal(t, headCommit.Parents()[0], headHash)
+}
+
 func TestForEachBranch(t *testing.T) {
 	t.Parallel()
 	type testCase struct {
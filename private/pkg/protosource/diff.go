@@ -0,0 +1,358 @@
+// Copyright 2020-2023 Buf Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protosource
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+type change struct {
+	changeType   ChangeType
+	elementType  ElementType
+	fullName     string
+	pkg          string
+	previous     NamedDescriptor
+	current      NamedDescriptor
+	descriptions []string
+}
+
+func (c *change) Type() ChangeType {
+	return c.changeType
+}
+
+func (c *change) ElementType() ElementType {
+	return c.elementType
+}
+
+func (c *change) FullName() string {
+	return c.fullName
+}
+
+func (c *change) Package() string {
+	return c.pkg
+}
+
+func (c *change) Previous() NamedDescriptor {
+	return c.previous
+}
+
+func (c *change) Current() NamedDescriptor {
+	return c.current
+}
+
+func (c *change) Descriptions() []string {
+	return c.descriptions
+}
+
+type diffElement struct {
+	elementType ElementType
+	descriptor  NamedDescriptor
+	deprecated  bool
+	// fieldType is the type of the field, if the descriptor is a Field.
+	fieldType string
+}
+
+func diff(previousFiles []File, files []File) ([]Change, error) {
+	previousFullNameToElement, err := fullNameToDiffElement(previousFiles)
+	if err != nil {
+		return nil, err
+	}
+	fullNameToElement, err := fullNameToDiffElement(files)
+	if err != nil {
+		return nil, err
+	}
+	var changes []Change
+	for fullName, previousElement := range previousFullNameToElement {
+		element, ok := fullNameToElement[fullName]
+		if !ok || element.elementType != previousElement.elementType {
+			if diffElementParentIsMissing(fullName, previousFullNameToElement, fullNameToElement) {
+				continue
+			}
+			changes = append(changes, newChange(ChangeTypeRemoved, previousElement, nil))
+			continue
+		}
+		if !previousElement.deprecated && element.deprecated {
+			changes = append(changes, newChange(ChangeTypeDeprecated, previousElement, element))
+		}
+		if descriptions := diffElementDescriptions(previousElement, element); len(descriptions) > 0 {
+			change := newChange(ChangeTypeChanged, previousElement, element)
+			change.descriptions = descriptions
+			changes = append(changes, change)
+		}
+	}
+	for fullName, element := range fullNameToElement {
+		previousElement, ok := previousFullNameToElement[fullName]
+		if !ok || element.elementType != previousElement.elementType {
+			if diffElementParentIsMissing(fullName, fullNameToElement, previousFullNameToElement) {
+				continue
+			}
+			changes = append(changes, newChange(ChangeTypeAdded, nil, element))
+		}
+	}
+	sort.Slice(
+		changes,
+		func(i int, j int) bool {
+			if changes[i].Package() != changes[j].Package() {
+				return changes[i].Package() < changes[j].Package()
+			}
+			if changes[i].FullName() != changes[j].FullName() {
+				return changes[i].FullName() < changes[j].FullName()
+			}
+			return changes[i].Type() < changes[j].Type()
+		},
+	)
+	return changes, nil
+}
+
+func newChange(changeType ChangeType, previousElement *diffElement, element *diffElement) *change {
+	change := &change{
+		changeType: changeType,
+	}
+	if previousElement != nil {
+		change.elementType = previousElement.elementType
+		change.previous = previousElement.descriptor
+	}
+	if element != nil {
+		change.elementType = element.elementType
+		change.current = element.descriptor
+	}
+	descriptor := change.current
+	if descriptor == nil {
+		descriptor = change.previous
+	}
+	change.fullName = descriptor.FullName()
+	change.pkg = descriptor.File().Package()
+	return change
+}
+
+// diffElementParentIsMissing returns true if the parent of the element with the
+// given fullName exists within fullNameToElement but not otherFullNameToElement.
+func diffElementParentIsMissing(
+	fullName string,
+	fullNameToElement map[string]*diffElement,
+	otherFullNameToElement map[string]*diffElement,
+) bool {
+	lastDotIndex := strings.LastIndex(fullName, ".")
+	if lastDotIndex < 0 {
+		return false
+	}
+	parentFullName := fullName[:lastDotIndex]
+	parentElement, ok := fullNameToElement[parentFullName]
+	if !ok {
+		// The parent is a package.
+		return false
+	}
+	otherParentElement, ok := otherFullNameToElement[parentFullName]
+	return !ok || otherParentElement.elementType != parentElement.elementType
+}
+
+// diffElementDescriptions describes the changes between two elements of the same type.
+func diffElementDescriptions(previousElement *diffElement, element *diffElement) []string {
+	var descriptions []string
+	if previousElement.deprecated && !element.deprecated {
+		descriptions = append(descriptions, "no longer deprecated")
+	}
+	switch previousDescriptor := previousElement.descriptor.(type) {
+	case Field:
+		descriptor := element.descriptor.(Field)
+		if previousDescriptor.Number() != descriptor.Number() {
+			descriptions = append(descriptions, fmt.Sprintf(`number changed from "%d" to "%d"`, previousDescriptor.Number(), descriptor.Number()))
+		}
+		if previousLabel, label := fieldLabelString(previousDescriptor), fieldLabelString(descriptor); previousLabel != label {
+			descriptions = append(descriptions, fmt.Sprintf(`label changed from %q to %q`, previousLabel, label))
+		}
+		if previousElement.fieldType != element.fieldType {
+			descriptions = append(descriptions, fmt.Sprintf(`type changed from %q to %q`, previousElement.fieldType, element.fieldType))
+		}
+		if previousDescriptor.JSONName() != descriptor.JSONName() {
+			descriptions = append(descriptions, fmt.Sprintf(`JSON name changed from %q to %q`, previousDescriptor.JSONName(), descriptor.JSONName()))
+		}
+		if previousOneof, oneof := fieldOneofName(previousDescriptor), fieldOneofName(descriptor); previousOneof != oneof {
+			switch {
+			case previousOneof == "":
+				descriptions = append(descriptions, fmt.Sprintf(`moved into oneof %q`, oneof))
+			case oneof == "":
+				descriptions = append(descriptions, fmt.Sprintf(`moved out of oneof %q`, previousOneof))
+			default:
+				descriptions = append(descriptions, fmt.Sprintf(`oneof changed from %q to %q`, previousOneof, oneof))
+			}
+		}
+		if previousDescriptor.Extendee() != descriptor.Extendee() {
+			descriptions = append(descriptions, fmt.Sprintf(`extendee changed from %q to %q`, previousDescriptor.Extendee(), descriptor.Extendee()))
+		}
+	case EnumValue:
+		descriptor := element.descriptor.(EnumValue)
+		if previousDescriptor.Number() != descriptor.Number() {
+			descriptions = append(descriptions, fmt.Sprintf(`number changed from "%d" to "%d"`, previousDescriptor.Number(), descriptor.Number()))
+		}
+	case Method:
+		descriptor := element.descriptor.(Method)
+		if previousDescriptor.InputTypeName() != descriptor.InputTypeName() {
+			descriptions = append(descriptions, fmt.Sprintf(`request type changed from %q to %q`, previousDescriptor.InputTypeName(), descriptor.InputTypeName()))
+		}
+		if previousDescriptor.OutputTypeName() != descriptor.OutputTypeName() {
+			descriptions = append(descriptions, fmt.Sprintf(`response type changed from %q to %q`, previousDescriptor.OutputTypeName(), descriptor.OutputTypeName()))
+		}
+		if previousDescriptor.ClientStreaming() != descriptor.ClientStreaming() {
+			descriptions = append(descriptions, streamingDescription("client", descriptor.ClientStreaming()))
+		}
+		if previousDescriptor.ServerStreaming() != descriptor.ServerStreaming() {
+			descriptions = append(descriptions, streamingDescription("server", descriptor.ServerStreaming()))
+		}
+	}
+	// The previous Files generally do not have source code info, in which case
+	// the comments cannot be compared.
+	if previousLocation, location := previousElement.descriptor.Location(), element.descriptor.Location(); previousLocation != nil && location != nil {
+		if strings.TrimSpace(previousLocation.LeadingComments()) != strings.TrimSpace(location.LeadingComments()) ||
+			strings.TrimSpace(previousLocation.TrailingComments()) != strings.TrimSpace(location.TrailingComments()) {
+			descriptions = append(descriptions, "comment changed")
+		}
+	}
+	return descriptions
+}
+
+func fieldLabelString(field Field) string {
+	if field.Proto3Optional() {
+		return "optional"
+	}
+	return strings.ToLower(strings.TrimPrefix(field.Label().String(), "LABEL_"))
+}
+
+// fieldTypeString returns the type of the field, where map fields are described
+// by the types of the keys and values of their map entries.
+func fieldTypeString(field Field, fullNameToMapEntry map[string]Message) string {
+	switch field.Type() {
+	case descriptorpb.FieldDescriptorProto_TYPE_MESSAGE:
+		mapEntry, ok := fullNameToMapEntry[strings.TrimPrefix(field.TypeName(), ".")]
+		if !ok {
+			return field.TypeName()
+		}
+		var keyType, valueType string
+		for _, mapEntryField := range mapEntry.Fields() {
+			switch mapEntryField.Number() {
+			case 1:
+				keyType = fieldTypeString(mapEntryField, fullNameToMapEntry)
+			case 2:
+				valueType = fieldTypeString(mapEntryField, fullNameToMapEntry)
+			}
+		}
+		return fmt.Sprintf("map<%s, %s>", keyType, valueType)
+	case descriptorpb.FieldDescriptorProto_TYPE_ENUM:
+		return field.TypeName()
+	default:
+		return strings.ToLower(strings.TrimPrefix(field.Type().String(), "TYPE_"))
+	}
+}
+
+func fieldOneofName(field Field) string {
+	if oneof := field.Oneof(); oneof != nil && !field.Proto3Optional() {
+		return oneof.Name()
+	}
+	return ""
+}
+
+func streamingDescription(side string, streaming bool) string {
+	if streaming {
+		return side + " streaming added"
+	}
+	return side + " streaming removed"
+}
+
+func fullNameToDiffElement(files []File) (map[string]*diffElement, error) {
+	fullNameToElement := make(map[string]*diffElement)
+	fullNameToMapEntry := make(map[string]Message)
+	add := func(elementType ElementType, descriptor NamedDescriptor, deprecated bool) error {
+		fullName := descriptor.FullName()
+		if _, ok := fullNameToElement[fullName]; ok {
+			return fmt.Errorf("duplicate element: %q", fullName)
+		}
+		fullNameToElement[fullName] = &diffElement{
+			elementType: elementType,
+			descriptor:  descriptor,
+			deprecated:  deprecated,
+		}
+		return nil
+	}
+	for _, file := range files {
+		if err := ForEachMessage(
+			func(message Message) error {
+				if message.IsMapEntry() {
+					// Map entries are generated for map fields, so changes to
+					// them are changes to the map fields.
+					fullNameToMapEntry[message.FullName()] = message
+					return nil
+				}
+				if err := add(ElementTypeMessage, message, message.Deprecated()); err != nil {
+					return err
+				}
+				for _, field := range message.Fields() {
+					if err := add(ElementTypeField, field, field.Deprecated()); err != nil {
+						return err
+					}
+				}
+				for _, extension := range message.Extensions() {
+					if err := add(ElementTypeExtension, extension, extension.Deprecated()); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+			file,
+		); err != nil {
+			return nil, err
+		}
+		if err := ForEachEnum(
+			func(enum Enum) error {
+				if err := add(ElementTypeEnum, enum, enum.Deprecated()); err != nil {
+					return err
+				}
+				for _, enumValue := range enum.Values() {
+					if err := add(ElementTypeEnumValue, enumValue, enumValue.Deprecated()); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+			file,
+		); err != nil {
+			return nil, err
+		}
+		for _, extension := range file.Extensions() {
+			if err := add(ElementTypeExtension, extension, extension.Deprecated()); err != nil {
+				return nil, err
+			}
+		}
+		for _, service := range file.Services() {
+			if err := add(ElementTypeService, service, service.Deprecated()); err != nil {
+				return nil, err
+			}
+			for _, method := range service.Methods() {
+				if err := add(ElementTypeMethod, method, method.Deprecated()); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	for _, element := range fullNameToElement {
+		if field, ok := element.descriptor.(Field); ok {
+			element.fieldType = fieldTypeString(field, fullNameToMapEntry)
+		}
+	}
+	return fullNameToElement, nil
+}
@@ -42,6 +42,10 @@ func (f *file) FileDescriptor() protodescriptor.FileDescriptor {
 	return f.fileDescriptor
 }
 
+func (f *file) LocationForPath(path []int32) Location {
+	return f.getLocation(path)
+}
+
 func (f *file) Syntax() Syntax {
 	return f.syntax
 }
@@ -15,9 +15,12 @@
 package protosource
 
 import (
+	"fmt"
+
 	"google.golang.org/protobuf/encoding/protowire"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
 	"google.golang.org/protobuf/types/descriptorpb"
 )
 
@@ -35,14 +38,35 @@ func newOptionExtensionDescriptor(message proto.Message, optionsPath []int32, lo
 	}
 }
 
-func (o *optionExtensionDescriptor) OptionExtension(extensionType protoreflect.ExtensionType) (interface{}, bool) {
-	if extensionType.TypeDescriptor().ContainingMessage().FullName() != o.message.ProtoReflect().Descriptor().FullName() {
-		return nil, false
+func (o *optionExtensionDescriptor) OptionExtension(extensionType protoreflect.ExtensionType) (interface{}, bool, error) {
+	extensionTypeDescriptor := extensionType.TypeDescriptor()
+	if extensionTypeDescriptor.ContainingMessage().FullName() != o.message.ProtoReflect().Descriptor().FullName() {
+		return nil, false, nil
+	}
+	message := o.message
+	setExtensionType, isSet := getSetExtensionType(message, extensionTypeDescriptor.Number())
+	switch {
+	case isSet && setExtensionType == extensionType:
+	case isSet || hasUnknownField(message, extensionTypeDescriptor.Number()):
+		// The options were unmarshalled without the extension being known, in which case
+		// the extension is in the unknown fields, or with a different type for the same
+		// extension, such as a dynamic type from the compiler. Re-parse the options with
+		// the given extension type so that the value has the expected type.
+		var err error
+		message, err = reparseWithExtensionType(message, extensionType)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to parse option %s: %w", extensionTypeDescriptor.FullName(), err)
+		}
+	default:
+		return nil, false, nil
 	}
-	if !proto.HasExtension(o.message, extensionType) {
-		return nil, false
+	// We do not use proto.HasExtension and proto.GetExtension, as they require the containing
+	// message descriptor of the extension type to be the same as the descriptor of the message,
+	// which is not the case for extension types created from another set of descriptors.
+	if !message.ProtoReflect().Has(extensionTypeDescriptor) {
+		return nil, false, nil
 	}
-	return proto.GetExtension(o.message, extensionType), true
+	return extensionType.InterfaceOf(message.ProtoReflect().Get(extensionTypeDescriptor)), true, nil
 }
 
 func (o *optionExtensionDescriptor) OptionExtensionLocation(extensionType protoreflect.ExtensionType, extraPath ...int32) Location {
@@ -115,6 +139,60 @@ func (o *optionExtensionDescriptor) PresentExtensionNumbers() []int32 {
 	return fieldNumbers
 }
 
+// getSetExtensionType returns the type of the extension with the given number that is
+// set on the message.
+//
+// Returns false if no extension with the number is set.
+func getSetExtensionType(message proto.Message, number protoreflect.FieldNumber) (protoreflect.ExtensionType, bool) {
+	var extensionType protoreflect.ExtensionType
+	var found bool
+	message.ProtoReflect().Range(func(fieldDescriptor protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		if !fieldDescriptor.IsExtension() || fieldDescriptor.Number() != number {
+			return true
+		}
+		found = true
+		if extensionTypeDescriptor, ok := fieldDescriptor.(protoreflect.ExtensionTypeDescriptor); ok {
+			extensionType = extensionTypeDescriptor.Type()
+		}
+		return false
+	})
+	return extensionType, found
+}
+
+// hasUnknownField returns true if the unknown fields of the message contain
+// the given field number.
+func hasUnknownField(message proto.Message, number protoreflect.FieldNumber) bool {
+	for b := message.ProtoReflect().GetUnknown(); len(b) > 0; {
+		fieldNumber, _, n := protowire.ConsumeField(b)
+		if n < 0 {
+			return false
+		}
+		if fieldNumber == number {
+			return true
+		}
+		b = b[n:]
+	}
+	return false
+}
+
+// reparseWithExtensionType returns a copy of the message that was marshalled and
+// unmarshalled with only the given extension type known.
+func reparseWithExtensionType(message proto.Message, extensionType protoreflect.ExtensionType) (proto.Message, error) {
+	data, err := proto.Marshal(message)
+	if err != nil {
+		return nil, err
+	}
+	types := &protoregistry.Types{}
+	if err := types.RegisterExtension(extensionType); err != nil {
+		return nil, err
+	}
+	reparsedMessage := message.ProtoReflect().New().Interface()
+	if err := (proto.UnmarshalOptions{Resolver: types}).Unmarshal(data, reparsedMessage); err != nil {
+		return nil, err
+	}
+	return reparsedMessage, nil
+}
+
 func isDescendantPath(descendant, ancestor []int32) bool {
 	if len(descendant) < len(ancestor) {
 		return false
@@ -19,6 +19,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protodesc"
 	"google.golang.org/protobuf/reflect/protoreflect"
@@ -111,6 +112,55 @@ func TestOptionExtensionLocation(t *testing.T) {
 	checkLocation(t, loc, locations[4])
 }
 
+func TestOptionExtension(t *testing.T) {
+	t.Parallel()
+	customOption := makeCustomOption(t, 1079)
+	otherCustomOption := makeCustomOption(t, 1079)
+	getOptionExtension := func(options *descriptorpb.MessageOptions, extensionType protoreflect.ExtensionType) (interface{}, bool, error) {
+		descriptor := newOptionExtensionDescriptor(options, nil, nil)
+		return descriptor.OptionExtension(extensionType)
+	}
+
+	// Not set.
+	value, ok, err := getOptionExtension(&descriptorpb.MessageOptions{}, customOption)
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Nil(t, value)
+	// Set with the same type.
+	options := &descriptorpb.MessageOptions{}
+	options.ProtoReflect().Set(customOption.TypeDescriptor(), protoreflect.ValueOfString("foo"))
+	value, ok, err = getOptionExtension(options, customOption)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "foo", value)
+	// Set with a different type for the same extension.
+	value, ok, err = getOptionExtension(options, otherCustomOption)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "foo", value)
+	// Set in the unknown fields.
+	options = &descriptorpb.MessageOptions{}
+	unknown := protowire.AppendTag(nil, 1079, protowire.BytesType)
+	unknown = protowire.AppendString(unknown, "bar")
+	options.ProtoReflect().SetUnknown(unknown)
+	value, ok, err = getOptionExtension(options, customOption)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "bar", value)
+	// Another extension in the unknown fields.
+	value, ok, err = getOptionExtension(options, makeCustomOption(t, 1089))
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Nil(t, value)
+	// Set in the unknown fields with a value that cannot be parsed.
+	options = &descriptorpb.MessageOptions{}
+	unknown = protowire.AppendTag(nil, 1069, protowire.BytesType)
+	unknown = protowire.AppendBytes(unknown, nil)
+	options.ProtoReflect().SetUnknown(unknown)
+	_, _, err = getOptionExtension(options, makeRequiredMessageCustomOption(t, 1069))
+	require.Error(t, err)
+}
+
 func checkLocation(t *testing.T, loc Location, sourceCodeInfoLoc *descriptorpb.SourceCodeInfo_Location) {
 	t.Helper()
 	assert.Equal(t, sourceCodeInfoLoc.GetLeadingComments(), loc.LeadingComments())
@@ -142,3 +192,42 @@ func makeCustomOption(t *testing.T, tag int32) protoreflect.ExtensionType {
 	require.NoError(t, err)
 	return dynamicpb.NewExtensionType(fileDescriptor.Extensions().Get(0))
 }
+
+// makeRequiredMessageCustomOption returns a custom option with a message type that
+// has a required field.
+func makeRequiredMessageCustomOption(t *testing.T, tag int32) protoreflect.ExtensionType {
+	t.Helper()
+	fileDescriptorProto := &descriptorpb.FileDescriptorProto{
+		Name:       proto.String("test.proto"),
+		Package:    proto.String("test"),
+		Syntax:     proto.String("proto2"),
+		Dependency: []string{"google/protobuf/descriptor.proto"},
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: proto.String("Required"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					{
+						Name:     proto.String("name"),
+						Number:   proto.Int32(1),
+						Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+						Label:    descriptorpb.FieldDescriptorProto_LABEL_REQUIRED.Enum(),
+						JsonName: proto.String("name"),
+					},
+				},
+			},
+		},
+		Extension: []*descriptorpb.FieldDescriptorProto{
+			{
+				Name:     proto.String("required"),
+				Number:   proto.Int32(tag),
+				Type:     descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+				TypeName: proto.String(".test.Required"),
+				Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+				Extendee: proto.String(".google.protobuf.MessageOptions"),
+			},
+		},
+	}
+	fileDescriptor, err := protodesc.NewFile(fileDescriptorProto, protoregistry.GlobalFiles)
+	require.NoError(t, err)
+	return dynamicpb.NewExtensionType(fileDescriptor.Extensions().Get(0))
+}
@@ -127,9 +127,12 @@ type OptionExtensionDescriptor interface {
 	//
 	// Returns false if the extension is not set.
 	//
+	// Returns error if the extension is set, but its value cannot be parsed with the
+	// given extension type.
+	//
 	// See https://pkg.go.dev/google.golang.org/protobuf/proto#HasExtension
 	// See https://pkg.go.dev/google.golang.org/protobuf/proto#GetExtension
-	OptionExtension(extensionType protoreflect.ExtensionType) (interface{}, bool)
+	OptionExtension(extensionType protoreflect.ExtensionType) (interface{}, bool, error)
 
 	// OptionExtensionLocation returns the source location where the given extension
 	// field value is defined. The extra path can be additional path elements, for
@@ -269,6 +272,13 @@ type File interface {
 	PhpGenericServicesLocation() Location
 	CcEnableArenasLocation() Location
 
+	// LocationForPath returns the Location for the given SourceCodeInfo path.
+	//
+	// This is used when the path of an element is known but the element itself is not,
+	// for example when paths are returned by an external process.
+	// Returns nil if there is no Location for the path.
+	LocationForPath(path []int32) Location
+
 	// FileDescriptor returns the backing FileDescriptor for this File.
 	//
 	// Users should prefer to use the core protosource API to read properties of the File as opposed
@@ -1157,6 +1167,117 @@ func CheckTagRangeIsSubset(supersetRanges []TagRange, subsetRanges []TagRange) (
 	return true, nil
 }
 
+// ElementType is the type of a named element compared by Diff.
+type ElementType int
+
+const (
+	// ElementTypeMessage is a Message.
+	ElementTypeMessage ElementType = iota + 1
+	// ElementTypeField is a Field of a Message.
+	ElementTypeField
+	// ElementTypeExtension is a Field that is an extension.
+	ElementTypeExtension
+	// ElementTypeEnum is an Enum.
+	ElementTypeEnum
+	// ElementTypeEnumValue is an EnumValue.
+	ElementTypeEnumValue
+	// ElementTypeService is a Service.
+	ElementTypeService
+	// ElementTypeMethod is a Method.
+	ElementTypeMethod
+)
+
+// String implements fmt.Stringer.
+func (e ElementType) String() string {
+	switch e {
+	case ElementTypeMessage:
+		return "message"
+	case ElementTypeField:
+		return "field"
+	case ElementTypeExtension:
+		return "extension"
+	case ElementTypeEnum:
+		return "enum"
+	case ElementTypeEnumValue:
+		return "enum value"
+	case ElementTypeService:
+		return "service"
+	case ElementTypeMethod:
+		return "method"
+	default:
+		return strconv.Itoa(int(e))
+	}
+}
+
+// ChangeType is the type of a Change.
+type ChangeType int
+
+const (
+	// ChangeTypeAdded says that the element was added.
+	ChangeTypeAdded ChangeType = iota + 1
+	// ChangeTypeRemoved says that the element was removed.
+	ChangeTypeRemoved
+	// ChangeTypeDeprecated says that the element was deprecated.
+	ChangeTypeDeprecated
+	// ChangeTypeChanged says that the element was changed.
+	//
+	// Change.Descriptions describes what was changed.
+	ChangeTypeChanged
+)
+
+// String implements fmt.Stringer.
+func (c ChangeType) String() string {
+	switch c {
+	case ChangeTypeAdded:
+		return "added"
+	case ChangeTypeRemoved:
+		return "removed"
+	case ChangeTypeDeprecated:
+		return "deprecated"
+	case ChangeTypeChanged:
+		return "changed"
+	default:
+		return strconv.Itoa(int(c))
+	}
+}
+
+// Change is a change to a named element between two sets of Files.
+type Change interface {
+	// Type returns the type of the change.
+	Type() ChangeType
+	// ElementType returns the type of the changed element.
+	ElementType() ElementType
+	// FullName returns the fully-qualified name of the changed element.
+	FullName() string
+	// Package returns the package of the changed element.
+	Package() string
+	// Previous returns the element within the previous Files.
+	//
+	// Will be nil if the element was added.
+	Previous() NamedDescriptor
+	// Current returns the element within the current Files.
+	//
+	// Will be nil if the element was removed.
+	Current() NamedDescriptor
+	// Descriptions returns human-readable descriptions of what was changed,
+	// such as `type changed from "int32" to "string"`.
+	//
+	// Only set for ChangeTypeChanged.
+	Descriptions() []string
+}
+
+// Diff returns the Changes to the named elements from previousFiles to files.
+//
+// Elements are matched by their fully-qualified names. If a parent element was added
+// or removed, Changes are not returned for the elements nested within the parent.
+//
+// Comments are only compared if both elements have source code info.
+//
+// Changes are sorted by package, then fully-qualified name.
+func Diff(previousFiles []File, files []File) ([]Change, error) {
+	return diff(previousFiles, files)
+}
+
 // groupAdjacentTagRanges sorts and groups adjacent tag ranges.
 func groupAdjacentTagRanges(ranges []TagRange) []tagRangeGroup {
 	if len(ranges) == 0 {